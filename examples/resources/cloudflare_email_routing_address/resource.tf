@@ -0,0 +1,4 @@
+resource "cloudflare_email_routing_address" "example" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  email      = "owner@example.com"
+}
@@ -0,0 +1,9 @@
+resource "cloudflare_email_routing_catch_all" "example" {
+  zone_id = "0da42c8d2132a9ddaf714f9e7c920711"
+  name    = "catch-all"
+
+  action {
+    type  = "worker"
+    value = ["inbound-email"]
+  }
+}
@@ -0,0 +1,15 @@
+resource "cloudflare_email_routing_rule" "example" {
+  zone_id = "0da42c8d2132a9ddaf714f9e7c920711"
+  name    = "info"
+
+  matcher {
+    type  = "literal"
+    field = "to"
+    value = "info@example.com"
+  }
+
+  action {
+    type  = "forward"
+    value = [cloudflare_email_routing_address.example.email]
+  }
+}
@@ -0,0 +1 @@
+$ terraform import cloudflare_email_routing_settings.example <zone_id>
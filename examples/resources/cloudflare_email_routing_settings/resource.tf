@@ -0,0 +1,35 @@
+# Cloudflare adds the records routing needs.
+resource "cloudflare_email_routing_settings" "example" {
+  zone_id = "0da42c8d2132a9ddaf714f9e7c920711"
+  enabled = true
+}
+
+# Records managed by Terraform are used instead when they exist before
+# routing is enabled.
+resource "cloudflare_record" "mx" {
+  for_each = {
+    "route1.mx.cloudflare.net" = 13
+    "route2.mx.cloudflare.net" = 86
+    "route3.mx.cloudflare.net" = 24
+  }
+
+  zone_id  = "1d5fdc9e88c8a8c4518b068cd94331fe"
+  name     = "@"
+  type     = "MX"
+  value    = each.key
+  priority = each.value
+}
+
+resource "cloudflare_record" "spf" {
+  zone_id = "1d5fdc9e88c8a8c4518b068cd94331fe"
+  name    = "@"
+  type    = "TXT"
+  value   = "v=spf1 include:_spf.mx.cloudflare.net ~all"
+}
+
+resource "cloudflare_email_routing_settings" "managed_records" {
+  zone_id = "1d5fdc9e88c8a8c4518b068cd94331fe"
+  enabled = true
+
+  depends_on = [cloudflare_record.mx, cloudflare_record.spf]
+}
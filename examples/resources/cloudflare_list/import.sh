@@ -0,0 +1 @@
+$ terraform import cloudflare_list.example <account_id>/<list_id>
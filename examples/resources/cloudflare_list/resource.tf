@@ -0,0 +1,48 @@
+# Redirect list
+resource "cloudflare_list" "example" {
+  account_id  = "f037e56e89293a057740de681ac9abbe"
+  name        = "example_redirects"
+  description = "Redirects of the marketing site"
+  kind        = "redirect"
+
+  item {
+    comment = "Old product page"
+
+    redirect {
+      source_url            = "example.com/product"
+      target_url            = "https://example.com/products/new"
+      status_code           = 308
+      preserve_query_string = true
+    }
+  }
+
+  item {
+    redirect {
+      source_url         = "blog.example.com/"
+      target_url         = "https://example.com/blog"
+      include_subdomains = true
+      subpath_matching   = true
+    }
+  }
+}
+
+# ASN list
+resource "cloudflare_list" "blocked_asns" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  name       = "blocked_asns"
+  kind       = "asn"
+
+  item {
+    asn     = 64496
+    comment = "Abusive network"
+  }
+}
+
+# IP list with its items read from a file, with an item per line such as
+# `192.0.2.1,Scanner`
+resource "cloudflare_list" "threat_intel" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  name       = "threat_intel"
+  kind       = "ip"
+  items_file = "${path.module}/threat_intel.csv"
+}
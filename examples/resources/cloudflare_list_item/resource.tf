@@ -0,0 +1,14 @@
+# List whose items are managed by `cloudflare_list_item` resources, possibly
+# from other modules
+resource "cloudflare_list" "hostnames" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  name       = "allowed_hostnames"
+  kind       = "hostname"
+}
+
+resource "cloudflare_list_item" "app" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  list_id    = cloudflare_list.hostnames.id
+  hostname   = "app.example.com"
+  comment    = "Application"
+}
@@ -0,0 +1 @@
+$ terraform import cloudflare_magic_wan_site.example <account_id>/<site_id>
@@ -0,0 +1,11 @@
+resource "cloudflare_magic_wan_site" "example" {
+  account_id   = "c4a7362d577a6c3019a474fd6f485821"
+  name         = "London"
+  description  = "London branch office"
+  connector_id = "d7a7f9c1e5b2446b8a9ecbd5a3c2f1e0"
+
+  location {
+    lat = "51.5072"
+    lon = "-0.1276"
+  }
+}
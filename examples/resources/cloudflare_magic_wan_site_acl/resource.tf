@@ -0,0 +1,17 @@
+resource "cloudflare_magic_wan_site_acl" "example" {
+  account_id      = "c4a7362d577a6c3019a474fd6f485821"
+  site_id         = cloudflare_magic_wan_site.example.id
+  name            = "office to lab"
+  protocols       = ["tcp"]
+  forward_locally = true
+
+  lan_1 {
+    lan_id = cloudflare_magic_wan_site_lan.office.id
+  }
+
+  lan_2 {
+    lan_id  = cloudflare_magic_wan_site_lan.lab.id
+    ports   = [443]
+    subnets = ["192.168.2.0/24"]
+  }
+}
@@ -0,0 +1 @@
+$ terraform import cloudflare_magic_wan_site_lan.example <account_id>/<site_id>/<lan_id>
@@ -0,0 +1,26 @@
+resource "cloudflare_magic_wan_site_lan" "example" {
+  account_id = "c4a7362d577a6c3019a474fd6f485821"
+  site_id    = cloudflare_magic_wan_site.example.id
+  name       = "office"
+  physport   = 2
+  vlan_tag   = 10
+
+  static_addressing {
+    address = "192.168.1.1/24"
+
+    dhcp_server {
+      dhcp_pool_start = "192.168.1.100"
+      dhcp_pool_end   = "192.168.1.200"
+      dns_server      = "192.168.1.1"
+
+      reservations = {
+        "00:11:22:33:44:55" = "192.168.1.10"
+      }
+    }
+  }
+
+  routed_subnet {
+    prefix   = "10.100.0.0/24"
+    next_hop = "192.168.1.2"
+  }
+}
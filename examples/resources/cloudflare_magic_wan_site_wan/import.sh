@@ -0,0 +1 @@
+$ terraform import cloudflare_magic_wan_site_wan.example <account_id>/<site_id>/<wan_id>
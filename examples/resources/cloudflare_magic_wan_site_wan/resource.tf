@@ -0,0 +1,12 @@
+resource "cloudflare_magic_wan_site_wan" "example" {
+  account_id = "c4a7362d577a6c3019a474fd6f485821"
+  site_id    = cloudflare_magic_wan_site.example.id
+  name       = "ISP X"
+  physport   = 1
+  priority   = 1
+
+  static_addressing {
+    address         = "203.0.113.2/24"
+    gateway_address = "203.0.113.1"
+  }
+}
@@ -0,0 +1 @@
+$ terraform import cloudflare_pages_domain.example <account_id>/<project_name>/<domain>
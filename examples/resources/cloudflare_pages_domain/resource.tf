@@ -0,0 +1,5 @@
+resource "cloudflare_pages_domain" "example" {
+  account_id   = "f037e56e89293a057740de681ac9abbe"
+  project_name = "site"
+  domain       = "www.example.com"
+}
@@ -0,0 +1 @@
+$ terraform import cloudflare_pages_project.example <account_id>/<project_name>
@@ -0,0 +1,65 @@
+# Project deployed by uploading its assets
+resource "cloudflare_pages_project" "direct_upload" {
+  account_id        = "f037e56e89293a057740de681ac9abbe"
+  name              = "direct-upload"
+  production_branch = "main"
+}
+
+# Project built from a GitHub repository
+resource "cloudflare_pages_project" "site" {
+  account_id        = "f037e56e89293a057740de681ac9abbe"
+  name              = "site"
+  production_branch = "main"
+
+  source {
+    type = "github"
+    config {
+      owner                      = "cloudflare"
+      repo_name                  = "site"
+      pr_comments_enabled        = true
+      preview_deployment_setting = "custom"
+      preview_branch_includes    = ["dev", "feature/*"]
+    }
+  }
+
+  build_config {
+    build_command   = "npm run build"
+    destination_dir = "dist"
+    root_dir        = "/"
+  }
+
+  deployment_configs {
+    preview {
+      environment_variables = {
+        ENVIRONMENT = "preview"
+      }
+      kv_namespaces = {
+        CACHE = "5eb63bbbe01eeed093cb22bb8f5acdc3"
+      }
+      compatibility_date = "2022-08-15"
+    }
+
+    production {
+      environment_variables = {
+        ENVIRONMENT = "production"
+      }
+      secrets = {
+        API_KEY = var.api_key
+      }
+      kv_namespaces = {
+        CACHE = "8ea4f8f2fb8d4a5b9d4a2c1a0d5e6f7a"
+      }
+      d1_databases = {
+        DB = "445e2955-951a-43f8-a35b-a4d0c8138f63"
+      }
+      r2_buckets = {
+        ASSETS = "site-assets"
+      }
+      durable_object_namespaces = {
+        COUNTER = "5eb63bbbe01eeed093cb22bb8f5acdc3"
+      }
+      compatibility_date  = "2022-08-15"
+      compatibility_flags = ["nodejs_compat"]
+    }
+  }
+}
@@ -0,0 +1 @@
+$ terraform import cloudflare_r2_bucket_cors.example <account_id>/<bucket_name>
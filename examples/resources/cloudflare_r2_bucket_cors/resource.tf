@@ -0,0 +1,11 @@
+resource "cloudflare_r2_bucket_cors" "example" {
+  account_id  = "f037e56e89293a057740de681ac9abbe"
+  bucket_name = cloudflare_r2_bucket.example.name
+
+  rule {
+    allowed_origins = ["https://example.com"]
+    allowed_methods = ["GET", "HEAD"]
+    expose_headers  = ["ETag"]
+    max_age_seconds = 3600
+  }
+}
@@ -0,0 +1,15 @@
+resource "cloudflare_r2_bucket_lifecycle" "example" {
+  account_id  = "f037e56e89293a057740de681ac9abbe"
+  bucket_name = cloudflare_r2_bucket.example.name
+
+  rule {
+    id                        = "expire-logs"
+    prefix                    = "logs/"
+    delete_objects_after_days = 30
+  }
+
+  rule {
+    id                                 = "abort-uploads"
+    abort_multipart_uploads_after_days = 7
+  }
+}
@@ -0,0 +1,6 @@
+# Use the zone ID to import every record of the zone, or the zone ID and the
+# filter of the record set, as a name, comma separated types and a tag, any
+# of which may be empty.
+$ terraform import cloudflare_record_set.example <zone_id>
+$ terraform import cloudflare_record_set.example <zone_id>/<name>
+$ terraform import cloudflare_record_set.example <zone_id>/<name>/<types>/<tag>
@@ -0,0 +1,67 @@
+# Manage every record of the zone.
+resource "cloudflare_record_set" "example" {
+  zone_id = "0da42c8d2132a9ddaf714f9e7c920711"
+
+  record {
+    name    = "www"
+    type    = "A"
+    value   = "192.0.2.1"
+    proxied = true
+  }
+
+  record {
+    name     = "example.com"
+    type     = "MX"
+    value    = "mx.example.com"
+    priority = 10
+  }
+
+  record {
+    name = "_sip._tcp"
+    type = "SRV"
+    data {
+      service  = "_sip"
+      proto    = "_tcp"
+      name     = "example.com"
+      priority = 10
+      weight   = 5
+      port     = 5060
+      target   = "sip.example.com"
+    }
+  }
+}
+
+# Manage only the TXT records of a name, generated from a map.
+resource "cloudflare_record_set" "verification" {
+  zone_id = "0da42c8d2132a9ddaf714f9e7c920711"
+
+  filter {
+    name  = "_verification"
+    types = ["TXT"]
+  }
+
+  dynamic "record" {
+    for_each = var.verification_tokens
+    content {
+      name  = "_verification"
+      type  = "TXT"
+      value = record.value
+    }
+  }
+}
+
+# Manage only the records tagged by a team, which are given the tag when
+# created.
+resource "cloudflare_record_set" "edge" {
+  zone_id = "0da42c8d2132a9ddaf714f9e7c920711"
+
+  filter {
+    tag = "team:edge"
+  }
+
+  record {
+    name  = "edge"
+    type  = "CNAME"
+    value = "edge.example.net"
+  }
+}
@@ -230,3 +230,117 @@ resource "cloudflare_ruleset" "custom_fields_logging_example" {
     enabled     = true
   }
 }
+
+# Set cache settings for matching requests
+resource "cloudflare_ruleset" "cache_settings_example" {
+  zone_id     = "cb029e245cfdd66dc8d2e570d5dd3322"
+  name        = "set cache settings"
+  description = "set cache settings for the request"
+  kind        = "zone"
+  phase       = "http_request_cache_settings"
+
+  rules {
+    action = "set_cache_settings"
+    action_parameters {
+      cache = true
+      edge_ttl {
+        mode    = "override_origin"
+        default = 60
+        status_code_ttl {
+          status_code = 200
+          value       = 50
+        }
+        status_code_ttl {
+          status_code_range {
+            from = 201
+            to   = 300
+          }
+          value = 30
+        }
+      }
+      browser_ttl {
+        mode = "respect_origin"
+      }
+      serve_stale {
+        disable_stale_while_updating = true
+      }
+      respect_strong_etags = true
+      cache_key {
+        ignore_query_strings_order = false
+        cache_deception_armor      = true
+        custom_key {
+          query_string {
+            exclude = ["*"]
+          }
+          header {
+            include        = ["habc", "hdef"]
+            check_presence = ["habc_t", "hdef_t"]
+            exclude_origin = true
+          }
+          cookie {
+            include        = ["cabc", "cdef"]
+            check_presence = ["cabc_t", "cdef_t"]
+          }
+          user {
+            device_type = true
+            geo         = false
+          }
+          host {
+            resolved = true
+          }
+        }
+      }
+      origin_error_page_passthru = false
+    }
+    expression  = "(http.host eq \"example.host.com\")"
+    description = "set cache settings rule"
+    enabled     = true
+  }
+}
+
+# Redirect to a URL built from the request
+resource "cloudflare_ruleset" "dynamic_redirect_example" {
+  zone_id     = "cb029e245cfdd66dc8d2e570d5dd3322"
+  name        = "redirects"
+  description = "redirect to the new domain"
+  kind        = "zone"
+  phase       = "http_request_dynamic_redirect"
+
+  rules {
+    action = "redirect"
+    action_parameters {
+      from_value {
+        status_code = 301
+        target_url {
+          expression = "concat(\"https://example.com\", http.request.uri.path)"
+        }
+        preserve_query_string = true
+      }
+    }
+    expression  = "(http.host eq \"old.example.com\")"
+    description = "redirect to the new domain"
+    enabled     = true
+  }
+}
+
+# Bulk redirects from a list of redirects
+resource "cloudflare_ruleset" "bulk_redirect_example" {
+  account_id  = "d41d8cd98f00b204e9800998ecf8427e"
+  name        = "bulk redirects"
+  description = "redirect according to the list of redirects"
+  kind        = "root"
+  phase       = "http_request_redirect"
+
+  rules {
+    action = "redirect"
+    action_parameters {
+      from_list {
+        name = "redirect_list"
+        key  = "http.request.full_uri"
+      }
+    }
+    expression  = "http.request.full_uri in $redirect_list"
+    description = "apply the redirects of redirect_list"
+    enabled     = true
+  }
+}
@@ -0,0 +1,2 @@
+# Use the account ID and peer ID.
+$ terraform import cloudflare_secondary_dns_peer.example <account_id>/<peer_id>
@@ -0,0 +1,8 @@
+resource "cloudflare_secondary_dns_peer" "example" {
+  account_id  = "f037e56e89293a057740de681ac9abbe"
+  name        = "ns1.example.com"
+  ip          = "192.0.2.53"
+  port        = 53
+  ixfr_enable = true
+  tsig_id     = cloudflare_secondary_dns_tsig.example.id
+}
@@ -0,0 +1,2 @@
+# Use the account ID and TSIG ID.
+$ terraform import cloudflare_secondary_dns_tsig.example <account_id>/<tsig_id>
@@ -0,0 +1,2 @@
+# Use the zone ID.
+$ terraform import cloudflare_secondary_dns_zone.example <zone_id>
@@ -0,0 +1,6 @@
+resource "cloudflare_secondary_dns_zone" "example" {
+  zone_id              = "0da42c8d2132a9ddaf714f9e7c920711"
+  name                 = "example.com"
+  peers                = [cloudflare_secondary_dns_peer.example.id]
+  auto_refresh_seconds = 86400
+}
@@ -0,0 +1 @@
+$ terraform import cloudflare_tiered_cache.example <zone_id>
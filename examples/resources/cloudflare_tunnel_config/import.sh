@@ -0,0 +1,2 @@
+# Use the account ID and tunnel ID.
+$ terraform import cloudflare_tunnel_config.example <account_id>/<tunnel_id>
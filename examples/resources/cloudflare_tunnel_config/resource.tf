@@ -0,0 +1,39 @@
+resource "cloudflare_argo_tunnel" "example" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  name       = "example"
+  secret     = "AQIDBAUGBwgBAgMEBQYHCAECAwQFBgcIAQIDBAUGBwg="
+}
+
+resource "cloudflare_tunnel_config" "example" {
+  account_id = "f037e56e89293a057740de681ac9abbe"
+  tunnel_id  = cloudflare_argo_tunnel.example.id
+
+  config {
+    warp_routing {
+      enabled = true
+    }
+
+    origin_request {
+      connect_timeout = "30s"
+    }
+
+    ingress_rule {
+      hostname = "app.example.com"
+      service  = "http://localhost:8080"
+    }
+
+    ingress_rule {
+      hostname = "api.example.com"
+      path     = "^/v1/"
+      service  = "https://localhost:8443"
+
+      origin_request {
+        origin_server_name = "api.internal"
+      }
+    }
+
+    ingress_rule {
+      service = "http_status:404"
+    }
+  }
+}
@@ -0,0 +1 @@
+$ terraform import cloudflare_waiting_room_rules.example <zone_id>/<waiting_room_id>
@@ -0,0 +1,36 @@
+package mockapi
+
+func (s *Server) registerAccessRoutes() {
+	for _, root := range []string{"accounts", "zones"} {
+		s.crud("/"+root+"/{identifier}/access/apps", crudHooks{
+			kind:    "access application",
+			prepare: prepareAccessApplication,
+		})
+	}
+}
+
+// prepareAccessApplication populates the fields the API computes for Access
+// applications. The audience tag is generated once and kept across updates.
+func prepareAccessApplication(p params, existing, app object) *apiError {
+	if name, _ := app["name"].(string); name == "" {
+		return badRequest(12130, "access.api.error.invalid_request: name is required")
+	}
+
+	if existing != nil {
+		app["aud"] = existing["aud"]
+		app["created_at"] = existing["created_at"]
+	} else {
+		app["aud"] = newID() + newID()
+		app["created_at"] = timestamp()
+	}
+	app["updated_at"] = timestamp()
+
+	if _, ok := app["type"]; !ok {
+		app["type"] = "self_hosted"
+	}
+	if _, ok := app["session_duration"]; !ok {
+		app["session_duration"] = "24h"
+	}
+
+	return nil
+}
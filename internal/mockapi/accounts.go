@@ -0,0 +1,130 @@
+package mockapi
+
+import (
+	"net/http"
+	"time"
+)
+
+func (s *Server) registerAccountRoutes() {
+	// Members are invited with the IDs of their roles or with policies, and
+	// stay pending until they accept the invitation.
+	s.crud("/accounts/{account_id}/members", crudHooks{
+		kind: "member",
+		prepare: func(p params, existing, incoming object) *apiError {
+			if existing == nil {
+				email, _ := incoming["email"].(string)
+				if email == "" {
+					return badRequest(1001, "An invitation must have an email.")
+				}
+				delete(incoming, "email")
+				incoming["user"] = object{"id": newID(), "email": email}
+			} else {
+				incoming["user"] = existing["user"]
+			}
+
+			var roles []interface{}
+			incomingRoles, _ := incoming["roles"].([]interface{})
+			for _, role := range incomingRoles {
+				// Roles are invited by ID and updated as objects.
+				if r, ok := role.(map[string]interface{}); ok {
+					role = r["id"]
+				}
+				roles = append(roles, object{"id": role})
+			}
+			policies, _ := incoming["policies"].([]interface{})
+			if (len(roles) == 0) == (len(policies) == 0) {
+				return badRequest(1001, "A member must have either roles or policies.")
+			}
+			for _, policy := range policies {
+				policy := policy.(map[string]interface{})
+				if access, _ := policy["access"].(string); access != "allow" && access != "deny" {
+					return badRequest(1001, "The access of a policy must be allow or deny.")
+				}
+				if id, _ := policy["id"].(string); id == "" {
+					policy["id"] = newID()
+				}
+			}
+			incoming["roles"] = roles
+			incoming["policies"] = policies
+
+			switch status, _ := incoming["status"].(string); status {
+			case "accepted", "pending":
+			case "":
+				incoming["status"] = "pending"
+				if existing != nil {
+					incoming["status"] = existing["status"]
+				}
+			default:
+				return badRequest(1001, "The status of a member must be accepted or pending.")
+			}
+			return nil
+		},
+	})
+
+	for _, pattern := range []string{"/user/tokens", "/accounts/{account_id}/tokens"} {
+		pattern := pattern
+
+		// The value of tokens is only returned when they're created.
+		s.handle(http.MethodPost, pattern, func(w http.ResponseWriter, r *http.Request, p params) {
+			token, err := decodeObject(r)
+			if err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			if err := prepareToken(nil, token); err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			token["issued_on"] = timestamp()
+			token = s.collection(expandPattern(pattern, p)).create(token)
+			writeResult(w, http.StatusOK, mergeObjects(token, object{"value": tokenValue()}))
+		})
+
+		// Rolling a token only changes its value.
+		s.handle(http.MethodPut, pattern+"/{id}/value", func(w http.ResponseWriter, r *http.Request, p params) {
+			if _, ok := s.collection(expandPattern(pattern, p)).get(p["id"]); !ok {
+				writeAPIError(w, notFound("token"))
+				return
+			}
+			writeResult(w, http.StatusOK, tokenValue())
+		})
+
+		s.crud(pattern, crudHooks{
+			kind: "token",
+			prepare: func(p params, existing, incoming object) *apiError {
+				return prepareToken(existing, incoming)
+			},
+		})
+	}
+}
+
+// prepareToken checks a token has a name and policies and sets the fields
+// maintained by the API.
+func prepareToken(existing, incoming object) *apiError {
+	if name, _ := incoming["name"].(string); name == "" {
+		return badRequest(1001, "A token must have a name.")
+	}
+	if policies, _ := incoming["policies"].([]interface{}); len(policies) == 0 {
+		return badRequest(1001, "A token must have policies.")
+	}
+	incoming["status"] = "active"
+	if expiresOn, ok := incoming["expires_on"].(string); ok {
+		t, err := time.Parse(time.RFC3339, expiresOn)
+		if err != nil {
+			return badRequest(1001, "expires_on must be an RFC 3339 time.")
+		}
+		if t.Before(time.Now()) {
+			incoming["status"] = "expired"
+		}
+	}
+	if existing != nil {
+		incoming["issued_on"] = existing["issued_on"]
+	}
+	incoming["modified_on"] = timestamp()
+	return nil
+}
+
+// tokenValue returns a new random value of a token.
+func tokenValue() string {
+	return newID() + newID()[:8]
+}
@@ -0,0 +1,62 @@
+package mockapi
+
+import "net/http"
+
+// zoneFeatures are the on/off settings of zones served at their own
+// endpoint, along with the feature they can only be enabled on top of.
+var zoneFeatures = map[string]string{
+	"argo/smart_routing":                       "",
+	"argo/tiered_caching":                      "",
+	"cache/tiered_cache_smart_topology_enable": "argo/tiered_caching",
+	"cache/regional_tiered_cache":              "argo/tiered_caching",
+}
+
+func (s *Server) registerArgoRoutes() {
+	// Every feature is off until it's enabled.
+	feature := func(p params, name string) object {
+		c := s.collection(expandPattern("/zones/{zone_id}/features", p))
+		if f, ok := c.get(name); ok {
+			return f
+		}
+		return c.create(object{"id": name, "value": "off", "editable": true})
+	}
+
+	for name, requires := range zoneFeatures {
+		name, requires := name, requires
+		pattern := "/zones/{zone_id}/" + name
+
+		s.handle(http.MethodGet, pattern, func(w http.ResponseWriter, r *http.Request, p params) {
+			if _, ok := s.zone(p["zone_id"]); !ok {
+				writeAPIError(w, notFound("zone"))
+				return
+			}
+			writeResult(w, http.StatusOK, feature(p, name))
+		})
+
+		s.handle(http.MethodPatch, pattern, func(w http.ResponseWriter, r *http.Request, p params) {
+			if _, ok := s.zone(p["zone_id"]); !ok {
+				writeAPIError(w, notFound("zone"))
+				return
+			}
+			req, err := decodeObject(r)
+			if err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			value, _ := req["value"].(string)
+			if value != "on" && value != "off" {
+				writeAPIError(w, badRequest(1007, "Invalid value for zone setting "+name+"."))
+				return
+			}
+			if value == "on" && requires != "" && feature(p, requires)["value"] != "on" {
+				writeAPIError(w, badRequest(1142, "Tiered caching must be enabled before "+name+"."))
+				return
+			}
+
+			f := feature(p, name)
+			f["value"] = value
+			f["modified_on"] = timestamp()
+			writeResult(w, http.StatusOK, f)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package mockapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// customHostnameSSLStatuses are the statuses the certificate of a custom
+// hostname goes through before it's active.
+var customHostnameSSLStatuses = []string{"initializing", "pending_validation", "pending_issuance", "pending_deployment", "active"}
+
+func (s *Server) registerCustomHostnameRoutes() {
+	const pattern = "/zones/{zone_id}/custom_hostnames"
+
+	// Custom hostnames move on to the next status each time they're read, as
+	// if validation were in progress. Validation of hostnames under the
+	// reserved .invalid top level domain fails.
+	s.handle(http.MethodGet, pattern+"/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		c := s.collection(expandPattern(pattern, p))
+		hostname, ok := c.get(p["id"])
+		if !ok {
+			writeAPIError(w, notFound("custom hostname"))
+			return
+		}
+		writeResult(w, http.StatusOK, hostname)
+		advanceCustomHostname(hostname)
+	})
+
+	s.crud(pattern, crudHooks{
+		kind: "custom hostname",
+		prepare: func(p params, existing, incoming object) *apiError {
+			if _, ok := s.zone(p["zone_id"]); !ok {
+				return notFound("zone")
+			}
+			name, _ := incoming["hostname"].(string)
+			if name == "" {
+				return badRequest(1411, "hostname is required")
+			}
+
+			ssl, _ := incoming["ssl"].(map[string]interface{})
+			if ssl == nil {
+				ssl = map[string]interface{}{}
+				incoming["ssl"] = ssl
+			}
+
+			// Changes to the validation method of the certificate restart its
+			// validation.
+			if existing != nil {
+				existingSSL := existing["ssl"].(map[string]interface{})
+				if ssl["method"] == existingSSL["method"] {
+					for _, k := range []string{"status", "validation_records", "validation_errors"} {
+						if v, ok := existingSSL[k]; ok {
+							ssl[k] = v
+						}
+					}
+					for _, k := range []string{"status", "verification_errors", "ownership_verification"} {
+						if v, ok := existing[k]; ok {
+							incoming[k] = v
+						}
+					}
+					return nil
+				}
+			}
+			ssl["status"] = customHostnameSSLStatuses[0]
+			ssl["validation_records"] = []interface{}{map[string]interface{}{
+				"txt_name":  "_acme-challenge." + name,
+				"txt_value": newID(),
+			}}
+			incoming["status"] = "pending"
+			incoming["ownership_verification"] = map[string]interface{}{
+				"type":  "txt",
+				"name":  "_cf-custom-hostname." + name,
+				"value": newID(),
+			}
+			return nil
+		},
+	})
+}
+
+func advanceCustomHostname(hostname object) {
+	ssl := hostname["ssl"].(map[string]interface{})
+	status, _ := ssl["status"].(string)
+
+	if status == "pending_validation" && strings.HasSuffix(hostname["hostname"].(string), ".invalid") {
+		ssl["status"] = "validation_timed_out"
+		ssl["validation_errors"] = []interface{}{map[string]interface{}{
+			"message": "TXT record for " + hostname["hostname"].(string) + " not found",
+		}}
+		hostname["verification_errors"] = []interface{}{"custom hostname does not CNAME to this zone."}
+		return
+	}
+
+	for i, s := range customHostnameSSLStatuses[:len(customHostnameSSLStatuses)-1] {
+		if s == status {
+			ssl["status"] = customHostnameSSLStatuses[i+1]
+		}
+	}
+	if ssl["status"] == "active" {
+		hostname["status"] = "active"
+		delete(ssl, "validation_records")
+	}
+}
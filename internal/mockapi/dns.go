@@ -0,0 +1,145 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// proxiableRecordTypes are the DNS record types that may be proxied.
+var proxiableRecordTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"CNAME": true,
+}
+
+func (s *Server) registerDNSRoutes() {
+	const recordsPattern = "/zones/{zone_id}/dns_records"
+
+	// Records can also be listed by tag, which isn't a string field.
+	s.handle(http.MethodGet, recordsPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		query := r.URL.Query()
+		tag := query.Get("tag")
+		query.Del("tag")
+
+		records := []object{}
+		for _, rec := range s.collection(expandPattern(recordsPattern, p)).list(query) {
+			if tag == "" || hasTag(rec, tag) {
+				records = append(records, rec)
+			}
+		}
+		writeList(w, r, records)
+	})
+
+	s.crud(recordsPattern, crudHooks{
+		kind: "dns record",
+		prepare: func(p params, existing, incoming object) *apiError {
+			return s.prepareDNSRecord(p, existing, incoming)
+		},
+	})
+}
+
+// prepareDNSRecord normalises a DNS record the same way the API does: names
+// are fully qualified, computed fields are populated and duplicates are
+// rejected.
+func (s *Server) prepareDNSRecord(p params, existing, rec object) *apiError {
+	zone, ok := s.zone(p["zone_id"])
+	if !ok {
+		return notFound("zone")
+	}
+	zoneName := zone["name"].(string)
+
+	recordType, _ := rec["type"].(string)
+	if recordType == "" {
+		return badRequest(9000, "DNS record type is invalid.")
+	}
+	rec["type"] = strings.ToUpper(recordType)
+
+	data, _ := rec["data"].(map[string]interface{})
+
+	name, _ := rec["name"].(string)
+	if rec["type"] == "SRV" && data != nil {
+		if service, ok := data["service"].(string); ok {
+			proto, _ := data["proto"].(string)
+			target, _ := data["name"].(string)
+			name = strings.Join([]string{service, proto, target}, ".")
+		}
+	}
+	rec["name"] = qualifyName(name, zoneName)
+
+	if content, _ := rec["content"].(string); content == "" && data != nil {
+		rec["content"] = contentFromData(rec["type"].(string), data)
+	}
+
+	if ttl, ok := rec["ttl"].(float64); !ok || ttl == 0 {
+		rec["ttl"] = 1
+	}
+	if _, ok := rec["proxied"].(bool); !ok {
+		rec["proxied"] = false
+	}
+	if _, ok := rec["tags"].([]interface{}); !ok {
+		rec["tags"] = []interface{}{}
+	}
+
+	rec["zone_id"] = p["zone_id"]
+	rec["zone_name"] = zoneName
+	rec["proxiable"] = proxiableRecordTypes[rec["type"].(string)]
+	rec["locked"] = false
+	rec["meta"] = object{
+		"auto_added":             false,
+		"managed_by_apps":        false,
+		"managed_by_argo_tunnel": false,
+		"source":                 "primary",
+	}
+
+	for _, other := range s.collection(expandPattern("/zones/{zone_id}/dns_records", p)).all() {
+		if existing != nil && other["id"] == existing["id"] {
+			continue
+		}
+		if strings.EqualFold(other["name"].(string), rec["name"].(string)) &&
+			other["type"] == rec["type"] &&
+			other["content"] == rec["content"] {
+			return badRequest(81057, "The record already exists.")
+		}
+	}
+
+	return nil
+}
+
+// hasTag reports whether a DNS record has the given tag.
+func hasTag(rec object, tag string) bool {
+	tags, _ := rec["tags"].([]interface{})
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifyName returns the fully qualified form of a record name relative to
+// the zone apex.
+func qualifyName(name, zoneName string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	switch {
+	case name == "" || name == "@" || name == zoneName:
+		return zoneName
+	case strings.HasSuffix(name, "."+zoneName):
+		return name
+	default:
+		return name + "." + zoneName
+	}
+}
+
+// contentFromData renders the `content` of structured records the way the
+// API does when only `data` was provided.
+func contentFromData(recordType string, data map[string]interface{}) string {
+	switch recordType {
+	case "SRV":
+		return fmt.Sprintf("%v\t%v\t%v", data["weight"], data["port"], data["target"])
+	case "CAA":
+		return fmt.Sprintf("%v %v %q", data["flags"], data["tag"], data["value"])
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,303 @@
+package mockapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// emailRoutingMXRecords are the mail servers email routed by Cloudflare is
+// received by, with the priorities the API gives them.
+var emailRoutingMXRecords = []struct {
+	content  string
+	priority int
+}{
+	{"route1.mx.cloudflare.net", 13},
+	{"route2.mx.cloudflare.net", 86},
+	{"route3.mx.cloudflare.net", 24},
+}
+
+const emailRoutingSPFRecord = "v=spf1 include:_spf.mx.cloudflare.net ~all"
+
+var emailRoutingActionTypes = map[string]bool{"forward": true, "worker": true, "drop": true}
+
+func (s *Server) registerEmailRoutingRoutes() {
+	const routingPattern = "/zones/{zone_id}/email/routing"
+	rulesPattern := routingPattern + "/rules"
+	const addressesPattern = "/accounts/{account_id}/email/routing/addresses"
+
+	// Every zone has settings, disabled until routing is enabled, and a
+	// catch-all rule dropping email.
+	settings := func(w http.ResponseWriter, p params) (object, bool) {
+		zone, ok := s.zone(p["zone_id"])
+		if !ok {
+			writeAPIError(w, notFound("zone"))
+			return nil, false
+		}
+		c := s.collection(expandPattern(routingPattern, p))
+		if existing, ok := c.get("settings"); ok {
+			return existing, true
+		}
+		return c.create(object{
+			"id":          "settings",
+			"tag":         newID(),
+			"name":        zone["name"],
+			"enabled":     false,
+			"skip_wizard": false,
+			"status":      "unconfigured",
+		}), true
+	}
+	requiredRecords := func(p params) []object {
+		zone, _ := s.zone(p["zone_id"])
+		var out []object
+		for _, mx := range emailRoutingMXRecords {
+			out = append(out, object{"type": "MX", "name": zone["name"], "content": mx.content, "priority": mx.priority, "ttl": 1})
+		}
+		return append(out, object{"type": "TXT", "name": zone["name"], "content": emailRoutingSPFRecord, "ttl": 1})
+	}
+	settingsResponse := func(settings object) object {
+		return object{
+			"tag":         settings["tag"],
+			"name":        settings["name"],
+			"enabled":     settings["enabled"],
+			"skip_wizard": settings["skip_wizard"],
+			"status":      settings["status"],
+			"created":     settings["created_on"],
+			"modified":    settings["modified_on"],
+		}
+	}
+
+	s.handle(http.MethodGet, routingPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		settings, ok := settings(w, p)
+		if !ok {
+			return
+		}
+		if settings["enabled"] == true {
+			settings["status"] = "ready"
+			if len(s.missingEmailRoutingRecords(p, requiredRecords(p))) > 0 {
+				settings["status"] = "misconfigured"
+			}
+		}
+		writeResult(w, http.StatusOK, settingsResponse(settings))
+	})
+
+	s.handle(http.MethodGet, routingPattern+"/dns", func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := settings(w, p); ok {
+			writeResult(w, http.StatusOK, requiredRecords(p))
+		}
+	})
+
+	// Enabling routing adds the records it needs that the zone doesn't
+	// already have, and disabling it removes only the records it added.
+	s.handle(http.MethodPost, routingPattern+"/enable", func(w http.ResponseWriter, r *http.Request, p params) {
+		settings, ok := settings(w, p)
+		if !ok {
+			return
+		}
+		records := s.collection(expandPattern("/zones/{zone_id}/dns_records", p))
+		for _, rec := range s.missingEmailRoutingRecords(p, requiredRecords(p)) {
+			if err := s.prepareDNSRecord(p, nil, rec); err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			rec["locked"] = true
+			rec["meta"].(map[string]interface{})["email_routing"] = true
+			records.create(rec)
+		}
+		settings["enabled"] = true
+		settings["skip_wizard"] = true
+		settings["status"] = "ready"
+		writeResult(w, http.StatusOK, settingsResponse(settings))
+	})
+
+	s.handle(http.MethodPost, routingPattern+"/disable", func(w http.ResponseWriter, r *http.Request, p params) {
+		settings, ok := settings(w, p)
+		if !ok {
+			return
+		}
+		records := s.collection(expandPattern("/zones/{zone_id}/dns_records", p))
+		for _, rec := range records.all() {
+			if meta, _ := rec["meta"].(map[string]interface{}); meta["email_routing"] == true {
+				records.delete(rec["id"].(string))
+			}
+		}
+		settings["enabled"] = false
+		settings["status"] = "unconfigured"
+		writeResult(w, http.StatusOK, settingsResponse(settings))
+	})
+
+	catchAll := func(w http.ResponseWriter, p params) (object, bool) {
+		if _, ok := settings(w, p); !ok {
+			return nil, false
+		}
+		c := s.collection(expandPattern(rulesPattern, p) + "/catch_all")
+		if existing, ok := c.get("catch_all"); ok {
+			return existing, true
+		}
+		return c.create(object{
+			"id":       "catch_all",
+			"tag":      newID(),
+			"name":     "",
+			"enabled":  false,
+			"matchers": []interface{}{object{"type": "all"}},
+			"actions":  []interface{}{object{"type": "drop"}},
+		}), true
+	}
+
+	// The catch-all rule is registered before the other rules so that it
+	// isn't routed as a rule with the identifier catch_all.
+	s.handle(http.MethodGet, rulesPattern+"/catch_all", func(w http.ResponseWriter, r *http.Request, p params) {
+		if rule, ok := catchAll(w, p); ok {
+			writeResult(w, http.StatusOK, rule)
+		}
+	})
+
+	s.handle(http.MethodPut, rulesPattern+"/catch_all", func(w http.ResponseWriter, r *http.Request, p params) {
+		existing, ok := catchAll(w, p)
+		if !ok {
+			return
+		}
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		matchers, _ := req["matchers"].([]interface{})
+		if len(matchers) != 1 || matchers[0].(map[string]interface{})["type"] != "all" {
+			writeAPIError(w, badRequest(2020, "The catch-all rule must have a single matcher of type all."))
+			return
+		}
+		if err := validateEmailRoutingActions(req); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		req["tag"] = existing["tag"]
+		c := s.collection(expandPattern(rulesPattern, p) + "/catch_all")
+		writeResult(w, http.StatusOK, c.replace("catch_all", req))
+	})
+
+	s.crud(rulesPattern, crudHooks{
+		kind: "email routing rule",
+		prepare: func(p params, existing, incoming object) *apiError {
+			if _, ok := s.zone(p["zone_id"]); !ok {
+				return notFound("zone")
+			}
+			matchers, _ := incoming["matchers"].([]interface{})
+			if len(matchers) == 0 {
+				return badRequest(2020, "A rule must have at least one matcher.")
+			}
+			for _, m := range matchers {
+				m, _ := m.(map[string]interface{})
+				if m["type"] != "literal" || m["field"] != "to" {
+					return badRequest(2020, "Only literal matchers on the to field are supported.")
+				}
+				if value, _ := m["value"].(string); !strings.Contains(value, "@") {
+					return badRequest(2020, "The value of a matcher must be an email address.")
+				}
+			}
+			if err := validateEmailRoutingActions(incoming); err != nil {
+				return err
+			}
+			if _, ok := incoming["priority"].(float64); !ok {
+				incoming["priority"] = 0
+			}
+
+			// Rules are identified by their tag.
+			if existing == nil {
+				incoming["id"] = newID()
+			} else {
+				incoming["id"] = existing["id"]
+			}
+			incoming["tag"] = incoming["id"]
+			return nil
+		},
+	})
+
+	// Destination addresses are verified when they're read after being
+	// added, as if their owner followed the link sent to them. Addresses
+	// under the reserved .invalid top level domain are never verified.
+	s.handle(http.MethodGet, addressesPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		writeList(w, r, s.collection(expandPattern(addressesPattern, p)).all())
+	})
+
+	s.handle(http.MethodPost, addressesPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		email, _ := req["email"].(string)
+		if !strings.Contains(email, "@") {
+			writeAPIError(w, badRequest(2018, "The destination address is invalid."))
+			return
+		}
+		c := s.collection(expandPattern(addressesPattern, p))
+		for _, a := range c.all() {
+			if strings.EqualFold(a["email"].(string), email) {
+				writeAPIError(w, badRequest(2032, "The destination address already exists."))
+				return
+			}
+		}
+		a := c.create(object{"email": email, "verified": nil})
+		a["tag"] = a["id"]
+		a["created"] = a["created_on"]
+		a["modified"] = a["modified_on"]
+		writeResult(w, http.StatusOK, a)
+	})
+
+	s.handle(http.MethodGet, addressesPattern+"/{address_id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		a, ok := s.collection(expandPattern(addressesPattern, p)).get(p["address_id"])
+		if !ok {
+			writeAPIError(w, notFound("destination address"))
+			return
+		}
+		writeResult(w, http.StatusOK, a)
+		if a["verified"] == nil && !strings.HasSuffix(a["email"].(string), ".invalid") {
+			a["verified"] = timestamp()
+		}
+	})
+
+	s.handle(http.MethodDelete, addressesPattern+"/{address_id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		if !s.collection(expandPattern(addressesPattern, p)).delete(p["address_id"]) {
+			writeAPIError(w, notFound("destination address"))
+			return
+		}
+		writeResult(w, http.StatusOK, object{"tag": p["address_id"]})
+	})
+}
+
+// missingEmailRoutingRecords returns the records routing needs that the
+// zone doesn't have.
+func (s *Server) missingEmailRoutingRecords(p params, required []object) []object {
+	var missing []object
+	for _, req := range required {
+		found := false
+		for _, rec := range s.collection(expandPattern("/zones/{zone_id}/dns_records", p)).all() {
+			found = found || (rec["type"] == req["type"] &&
+				strings.EqualFold(rec["name"].(string), req["name"].(string)) &&
+				strings.Trim(rec["content"].(string), `"`) == req["content"])
+		}
+		if !found {
+			missing = append(missing, req)
+		}
+	}
+	return missing
+}
+
+func validateEmailRoutingActions(rule object) *apiError {
+	actions, _ := rule["actions"].([]interface{})
+	if len(actions) == 0 {
+		return badRequest(2020, "A rule must have at least one action.")
+	}
+	for _, a := range actions {
+		a, _ := a.(map[string]interface{})
+		actionType, _ := a["type"].(string)
+		if !emailRoutingActionTypes[actionType] {
+			return badRequest(2020, "The action type is invalid.")
+		}
+		values, _ := a["value"].([]interface{})
+		if actionType != "drop" && len(values) == 0 {
+			return badRequest(2020, "Forwarding and Worker actions must have a value.")
+		}
+	}
+	return nil
+}
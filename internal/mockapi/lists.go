@@ -0,0 +1,290 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// listKindFields are the fields items of each kind of list set.
+var listKindFields = map[string]string{
+	"ip":       "ip",
+	"redirect": "redirect",
+	"hostname": "hostname",
+	"asn":      "asn",
+}
+
+func (s *Server) registerListRoutes() {
+	const listsPattern = "/accounts/{account_id}/rules/lists"
+
+	// Operations on items complete straight away but, like the real API,
+	// still have to be polled for.
+	s.handle(http.MethodGet, listsPattern+"/bulk_operations/{operation_id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		operation, ok := s.collection(expandPattern(listsPattern+"/bulk_operations", p)).get(p["operation_id"])
+		if !ok {
+			writeAPIError(w, notFound("operation"))
+			return
+		}
+		writeResult(w, http.StatusOK, operation)
+	})
+
+	s.crud(listsPattern, crudHooks{
+		kind: "list",
+		prepare: func(p params, existing, incoming object) *apiError {
+			if existing != nil {
+				for _, field := range []string{"name", "kind", "num_items"} {
+					incoming[field] = existing[field]
+				}
+				return nil
+			}
+			if _, ok := listKindFields[incoming["kind"].(string)]; !ok {
+				return badRequest(10026, "invalid list kind")
+			}
+			incoming["num_items"] = 0
+			return nil
+		},
+	})
+
+	itemsPattern := listsPattern + "/{id}/items"
+
+	// list returns the list of the request, writing an error if it doesn't
+	// exist.
+	list := func(w http.ResponseWriter, p params) (object, bool) {
+		l, ok := s.collection(expandPattern(listsPattern, p)).get(p["id"])
+		if !ok {
+			writeAPIError(w, notFound("list"))
+		}
+		return l, ok
+	}
+
+	// decodeItems reads the items of a request, which must set the field of
+	// the kind of the list and no other.
+	decodeItems := func(w http.ResponseWriter, r *http.Request, l object) ([]object, bool) {
+		var items []object
+		if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+			writeAPIError(w, badRequest(6007, "Malformed JSON in request body"))
+			return nil, false
+		}
+		field := listKindFields[l["kind"].(string)]
+		for _, item := range items {
+			for _, other := range listKindFields {
+				if _, ok := item[other]; ok != (other == field) {
+					writeAPIError(w, badRequest(10021, "items of "+l["kind"].(string)+" lists must only set "+field))
+					return nil, false
+				}
+			}
+		}
+		return items, true
+	}
+
+	completeOperation := func(w http.ResponseWriter, p params, l object) {
+		l["num_items"] = len(s.collection(expandPattern(itemsPattern, p)).all())
+		operation := s.collection(expandPattern(listsPattern+"/bulk_operations", p)).create(object{"status": "completed", "completed": timestamp()})
+		writeResult(w, http.StatusOK, object{"operation_id": operation["id"]})
+	}
+
+	// Items are paginated with cursors rather than pages.
+	s.handle(http.MethodGet, itemsPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := list(w, p); !ok {
+			return
+		}
+
+		var items []object
+		search := strings.ToLower(r.URL.Query().Get("search"))
+		for _, item := range s.collection(expandPattern(itemsPattern, p)).all() {
+			if search == "" || strings.Contains(strings.ToLower(listItemValue(item)), search) {
+				items = append(items, item)
+			}
+		}
+
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		if perPage < 1 {
+			perPage = 25
+		}
+		start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		if start > len(items) {
+			start = len(items)
+		}
+		end := start + perPage
+		if end > len(items) {
+			end = len(items)
+		}
+
+		cursors := object{}
+		if end < len(items) {
+			cursors["after"] = strconv.Itoa(end)
+		}
+		writeJSON(w, http.StatusOK, object{
+			"success":     true,
+			"errors":      []messageInfo{},
+			"messages":    []messageInfo{},
+			"result":      append([]object{}, items[start:end]...),
+			"result_info": object{"cursors": cursors},
+		})
+	})
+
+	s.handle(http.MethodGet, itemsPattern+"/{item_id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := list(w, p); !ok {
+			return
+		}
+		item, ok := s.collection(expandPattern(itemsPattern, p)).get(p["item_id"])
+		if !ok {
+			writeAPIError(w, notFound("list item"))
+			return
+		}
+		writeResult(w, http.StatusOK, item)
+	})
+
+	s.handle(http.MethodPut, itemsPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		l, ok := list(w, p)
+		if !ok {
+			return
+		}
+		items, ok := decodeItems(w, r, l)
+		if !ok {
+			return
+		}
+
+		key := expandPattern(itemsPattern, p)
+		s.collections[key] = newCollection()
+		for _, item := range items {
+			s.collections[key].create(item)
+		}
+		completeOperation(w, p, l)
+	})
+
+	s.handle(http.MethodPost, itemsPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		l, ok := list(w, p)
+		if !ok {
+			return
+		}
+		items, ok := decodeItems(w, r, l)
+		if !ok {
+			return
+		}
+
+		c := s.collection(expandPattern(itemsPattern, p))
+		existing := make(map[string]bool)
+		for _, item := range c.all() {
+			existing[listItemValue(item)] = true
+		}
+		for _, item := range items {
+			if existing[listItemValue(item)] {
+				writeAPIError(w, badRequest(10034, fmt.Sprintf("duplicate list item %s", listItemValue(item))))
+				return
+			}
+		}
+		for _, item := range items {
+			c.create(item)
+		}
+		completeOperation(w, p, l)
+	})
+
+	s.handle(http.MethodDelete, itemsPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		l, ok := list(w, p)
+		if !ok {
+			return
+		}
+		var body struct {
+			Items []struct {
+				ID string `json:"id"`
+			} `json:"items"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeAPIError(w, badRequest(6007, "Malformed JSON in request body"))
+			return
+		}
+
+		c := s.collection(expandPattern(itemsPattern, p))
+		for _, item := range body.Items {
+			c.delete(item.ID)
+		}
+		completeOperation(w, p, l)
+	})
+}
+
+// listItemValue returns the value identifying an item within its list.
+func listItemValue(item object) string {
+	for _, field := range []string{"ip", "hostname", "asn"} {
+		if v, ok := item[field]; ok {
+			if hostname, ok := v.(map[string]interface{}); ok {
+				v = hostname["url_hostname"]
+			}
+			return fmt.Sprint(v)
+		}
+	}
+	if redirect, ok := item["redirect"].(map[string]interface{}); ok {
+		return fmt.Sprint(redirect["source_url"])
+	}
+	return ""
+}
+
+func (s *Server) registerGatewayListRoutes() {
+	const listsPattern = "/accounts/{account_id}/gateway/lists"
+	itemsPattern := listsPattern + "/{id}/items"
+
+	// Items are added and removed by patching the list, which takes
+	// precedence over the generic merge of the CRUD handlers.
+	s.handle(http.MethodPatch, listsPattern+"/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		l, ok := s.collection(expandPattern(listsPattern, p)).get(p["id"])
+		if !ok {
+			writeAPIError(w, notFound("list"))
+			return
+		}
+		var patch struct {
+			Append []object `json:"append"`
+			Remove []string `json:"remove"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			writeAPIError(w, badRequest(6007, "Malformed JSON in request body"))
+			return
+		}
+
+		c := s.collection(expandPattern(itemsPattern, p))
+		remove := make(map[string]bool)
+		for _, value := range patch.Remove {
+			remove[value] = true
+		}
+		for _, item := range c.all() {
+			if remove[item["value"].(string)] {
+				c.delete(item["id"].(string))
+			}
+		}
+		for _, item := range patch.Append {
+			c.create(object{"value": item["value"]})
+		}
+		l["count"] = len(c.all())
+
+		writeResult(w, http.StatusOK, l)
+	})
+
+	s.crud(listsPattern, crudHooks{
+		kind: "list",
+		prepare: func(p params, existing, incoming object) *apiError {
+			if existing != nil {
+				incoming["count"] = existing["count"]
+				return nil
+			}
+
+			items, _ := incoming["items"].([]interface{})
+			delete(incoming, "items")
+			incoming["id"] = newID()
+			c := s.collection(expandPattern(itemsPattern, params{"account_id": p["account_id"], "id": incoming["id"].(string)}))
+			for _, item := range items {
+				c.create(object{"value": item.(map[string]interface{})["value"]})
+			}
+			incoming["count"] = len(items)
+			return nil
+		},
+	})
+
+	s.handle(http.MethodGet, itemsPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := s.collection(expandPattern(listsPattern, p)).get(p["id"]); !ok {
+			writeAPIError(w, notFound("list"))
+			return
+		}
+		writeList(w, r, s.collection(expandPattern(itemsPattern, p)).all())
+	})
+}
@@ -0,0 +1,39 @@
+package mockapi
+
+func (s *Server) registerLoadBalancerRoutes() {
+	// Pools and monitors live under the user when the client is not
+	// configured with an account and under the account otherwise.
+	for _, root := range []string{"/user", "/accounts/{account_id}"} {
+		s.crud(root+"/load_balancers/pools", crudHooks{
+			kind:    "pool",
+			prepare: prepareLoadBalancerPool,
+		})
+		s.crud(root+"/load_balancers/monitors", crudHooks{
+			kind: "monitor",
+		})
+	}
+
+	s.crud("/zones/{zone_id}/load_balancers", crudHooks{
+		kind: "load balancer",
+		prepare: func(p params, existing, lb object) *apiError {
+			zone, ok := s.zone(p["zone_id"])
+			if !ok {
+				return notFound("zone")
+			}
+			if name, ok := lb["name"].(string); ok {
+				lb["name"] = qualifyName(name, zone["name"].(string))
+			}
+			return nil
+		},
+	})
+}
+
+// prepareLoadBalancerPool reports every pool as healthy since the fake does
+// not run any health checks.
+func prepareLoadBalancerPool(p params, existing, pool object) *apiError {
+	if origins, _ := pool["origins"].([]interface{}); len(origins) == 0 {
+		return badRequest(1002, "pool must contain at least one origin")
+	}
+	pool["healthy"] = true
+	return nil
+}
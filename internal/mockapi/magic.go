@@ -0,0 +1,275 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// magicTunnelHealthCheckDefaults are the health check settings of tunnels
+// created without them, besides the target which is the customer endpoint.
+var magicTunnelHealthCheckDefaults = object{
+	"enabled":   true,
+	"type":      "reply",
+	"direction": "unidirectional",
+	"rate":      "mid",
+}
+
+func (s *Server) registerMagicRoutes() {
+	const magicPattern = "/accounts/{account_id}/magic"
+	ipsecTunnelsPattern := magicPattern + "/ipsec_tunnels"
+	sitesPattern := magicPattern + "/sites"
+
+	// Like the API, the PSK of IPsec tunnels is never returned besides when
+	// it's generated.
+	s.handle(http.MethodPost, ipsecTunnelsPattern+"/{id}/psk_generate", func(w http.ResponseWriter, r *http.Request, p params) {
+		c := s.collection(expandPattern(ipsecTunnelsPattern, p))
+		tunnel, ok := c.get(p["id"])
+		if !ok {
+			writeAPIError(w, notFound("IPsec tunnel"))
+			return
+		}
+		tunnel["psk_metadata"] = object{"last_generated_on": timestamp()}
+		c.replace(p["id"], tunnel)
+		writeResult(w, http.StatusOK, object{
+			"ipsec_tunnel_id": p["id"],
+			"psk":             newID(),
+			"psk_metadata":    tunnel["psk_metadata"],
+		})
+	})
+
+	s.magicCrud(ipsecTunnelsPattern, "ipsec_tunnel", "ipsec_tunnels", crudHooks{
+		kind: "IPsec tunnel",
+		prepare: func(p params, existing, incoming object) *apiError {
+			for _, field := range []string{"name", "customer_endpoint", "cloudflare_endpoint", "interface_address"} {
+				if value, _ := incoming[field].(string); value == "" {
+					return badRequest(1001, "An IPsec tunnel must have a "+field+".")
+				}
+			}
+
+			healthCheck := mergeObjects(magicTunnelHealthCheckDefaults, object{"target": incoming["customer_endpoint"]})
+			if existing != nil {
+				healthCheck = existing["health_check"].(object)
+			}
+			if incomingHealthCheck, ok := incoming["health_check"].(map[string]interface{}); ok {
+				for k, v := range incomingHealthCheck {
+					if v != "" {
+						healthCheck = mergeObjects(healthCheck, object{k: v})
+					}
+				}
+			}
+			switch healthCheck["direction"] {
+			case "unidirectional", "bidirectional":
+			default:
+				return badRequest(1001, "The direction of a health check must be unidirectional or bidirectional.")
+			}
+			switch healthCheck["rate"] {
+			case "low", "mid", "high":
+			default:
+				return badRequest(1001, "The rate of a health check must be low, mid or high.")
+			}
+			incoming["health_check"] = healthCheck
+
+			if existing != nil {
+				incoming["remote_identities"] = existing["remote_identities"]
+				incoming["psk_metadata"] = existing["psk_metadata"]
+			} else {
+				id := newID()
+				incoming["id"] = id
+				incoming["remote_identities"] = object{
+					"hex_id":  id[:16],
+					"fqdn_id": id[:16] + ".ipsec.cloudflare.com",
+					"user_id": "ipsec@" + id[:16] + ".ipsec.cloudflare.com",
+				}
+			}
+			if psk, _ := incoming["psk"].(string); psk != "" {
+				incoming["psk_metadata"] = object{"last_generated_on": timestamp()}
+			}
+			delete(incoming, "psk")
+			return nil
+		},
+	})
+
+	s.magicCrud(sitesPattern, "site", "sites", crudHooks{
+		kind: "site",
+		prepare: func(p params, existing, incoming object) *apiError {
+			if name, _ := incoming["name"].(string); name == "" {
+				return badRequest(1001, "A site must have a name.")
+			}
+			if _, ok := incoming["ha_mode"].(bool); !ok {
+				incoming["ha_mode"] = false
+			}
+			if secondary, _ := incoming["secondary_connector_id"].(string); secondary != "" && incoming["ha_mode"] != true {
+				return badRequest(1001, "Only sites in high availability mode can have a secondary connector.")
+			}
+			if existing != nil && existing["ha_mode"] != incoming["ha_mode"] {
+				return badRequest(1001, "The high availability mode of a site can't be changed.")
+			}
+			return nil
+		},
+	})
+
+	// LANs, WANs and ACLs belong to a site and are checked against it.
+	site := func(p params) *apiError {
+		if _, ok := s.collection(expandPattern(sitesPattern, p)).get(p["site_id"]); !ok {
+			return notFound("site")
+		}
+		return nil
+	}
+
+	lansPattern := sitesPattern + "/{site_id}/lans"
+	s.magicCrud(lansPattern, "lan", "lans", crudHooks{
+		kind: "LAN",
+		prepare: func(p params, existing, incoming object) *apiError {
+			if err := site(p); err != nil {
+				return err
+			}
+			if err := prepareSiteInterface("LAN", incoming); err != nil {
+				return err
+			}
+			if addressing, ok := incoming["static_addressing"].(map[string]interface{}); ok {
+				if addressing["dhcp_relay"] != nil && addressing["dhcp_server"] != nil {
+					return badRequest(1001, "A LAN can't have both a DHCP relay and a DHCP server.")
+				}
+			}
+			incoming["site_id"] = p["site_id"]
+			return nil
+		},
+	})
+
+	s.magicCrud(sitesPattern+"/{site_id}/wans", "wan", "wans", crudHooks{
+		kind: "WAN",
+		prepare: func(p params, existing, incoming object) *apiError {
+			if err := site(p); err != nil {
+				return err
+			}
+			if err := prepareSiteInterface("WAN", incoming); err != nil {
+				return err
+			}
+			incoming["site_id"] = p["site_id"]
+			return nil
+		},
+	})
+
+	s.magicCrud(sitesPattern+"/{site_id}/acls", "acl", "acls", crudHooks{
+		kind: "ACL",
+		prepare: func(p params, existing, incoming object) *apiError {
+			if err := site(p); err != nil {
+				return err
+			}
+			if name, _ := incoming["name"].(string); name == "" {
+				return badRequest(1001, "An ACL must have a name.")
+			}
+			lans := s.collection(expandPattern(lansPattern, p))
+			var lanIDs []interface{}
+			for _, key := range []string{"lan_1", "lan_2"} {
+				configuration, _ := incoming[key].(map[string]interface{})
+				lanID, _ := configuration["lan_id"].(string)
+				lan, ok := lans.get(lanID)
+				if !ok {
+					return badRequest(1001, "The "+key+" of an ACL must be a LAN of its site.")
+				}
+				configuration["lan_name"] = lan["name"]
+				lanIDs = append(lanIDs, lan["id"])
+			}
+			if lanIDs[0] == lanIDs[1] {
+				return badRequest(1001, "An ACL must be between two different LANs.")
+			}
+			protocols, _ := incoming["protocols"].([]interface{})
+			for _, protocol := range protocols {
+				switch protocol {
+				case "tcp", "udp", "icmp":
+				default:
+					return badRequest(1001, "The protocols of an ACL must be tcp, udp or icmp.")
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// magicCrud registers list, create, read, replace and delete handlers for a
+// collection of Magic Transit objects rooted at pattern. Unlike other
+// collections, objects are created in bulk and results are wrapped in an
+// object keyed by the kind of object, singular or plural.
+func (s *Server) magicCrud(pattern, singular, plural string, hooks crudHooks) {
+	key := func(p params) string {
+		return expandPattern(pattern, p)
+	}
+
+	s.handle(http.MethodGet, pattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		writeResult(w, http.StatusOK, object{plural: s.collection(key(p)).all()})
+	})
+
+	s.handle(http.MethodPost, pattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		var req map[string][]object
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeAPIError(w, badRequest(6007, "Malformed JSON in request body"))
+			return
+		}
+		for _, obj := range req[plural] {
+			if err := hooks.prepare(p, nil, obj); err != nil {
+				writeAPIError(w, err)
+				return
+			}
+		}
+		created := []object{}
+		for _, obj := range req[plural] {
+			created = append(created, s.collection(key(p)).create(obj))
+		}
+		writeResult(w, http.StatusOK, object{plural: created})
+	})
+
+	s.handle(http.MethodGet, pattern+"/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		obj, ok := s.collection(key(p)).get(p["id"])
+		if !ok {
+			writeAPIError(w, notFound(hooks.kind))
+			return
+		}
+		writeResult(w, http.StatusOK, object{singular: obj})
+	})
+
+	s.handle(http.MethodPut, pattern+"/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		c := s.collection(key(p))
+		existing, ok := c.get(p["id"])
+		if !ok {
+			writeAPIError(w, notFound(hooks.kind))
+			return
+		}
+		obj, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		if err := hooks.prepare(p, existing, obj); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeResult(w, http.StatusOK, object{"modified": true, "modified_" + singular: c.replace(p["id"], obj)})
+	})
+
+	s.handle(http.MethodDelete, pattern+"/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		c := s.collection(key(p))
+		obj, ok := c.get(p["id"])
+		if !ok {
+			writeAPIError(w, notFound(hooks.kind))
+			return
+		}
+		c.delete(p["id"])
+		writeResult(w, http.StatusOK, object{"deleted": true, "deleted_" + singular: obj})
+	})
+}
+
+// prepareSiteInterface checks the LAN or WAN of a site has a name and a
+// port, and a VLAN tag in range.
+func prepareSiteInterface(kind string, incoming object) *apiError {
+	if name, _ := incoming["name"].(string); name == "" {
+		return badRequest(1001, "A "+kind+" must have a name.")
+	}
+	if physport, _ := incoming["physport"].(float64); physport < 1 {
+		return badRequest(1001, "A "+kind+" must have a port.")
+	}
+	if vlanTag, _ := incoming["vlan_tag"].(float64); vlanTag < 0 || vlanTag > 4094 {
+		return badRequest(1001, "The VLAN tag of a "+kind+" must be between 0 and 4094.")
+	}
+	return nil
+}
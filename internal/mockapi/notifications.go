@@ -0,0 +1,93 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// availableAlerts are the alert types notification policies can be created
+// for, grouped by product, along with the filters they support.
+var availableAlerts = map[string][]object{
+	"Billing": {
+		availableAlert("billing_usage_alert", "Usage Based Billing", "product", "limit"),
+	},
+	"Load Balancing": {
+		availableAlert("g6_pool_toggle_alert", "Pool Enablement", "pool_id", "enabled"),
+		availableAlert("load_balancing_health_alert", "Load Balancing Health Alert", "pool_id", "new_health", "event_source"),
+	},
+	"SSL/TLS": {
+		availableAlert("universal_ssl_event_type", "Universal SSL Alert"),
+	},
+	"Stream": {
+		availableAlert("stream_live_notifications", "Stream Live Input", "input_id", "event_type"),
+	},
+	"Traffic Monitoring": {
+		availableAlert("traffic_anomalies_alert", "Traffic Anomalies", "zones", "traffic_exclusions"),
+	},
+}
+
+func availableAlert(alertType, displayName string, filters ...string) object {
+	options := []interface{}{}
+	for _, filter := range filters {
+		options = append(options, object{"Key": filter, "ComparisonOperator": "==", "Optional": true})
+	}
+	return object{
+		"type":           alertType,
+		"display_name":   displayName,
+		"description":    displayName + " notifications.",
+		"filter_options": options,
+	}
+}
+
+func (s *Server) registerNotificationRoutes() {
+	const alertingPattern = "/accounts/{account_id}/alerting/v3"
+
+	s.handle(http.MethodGet, alertingPattern+"/available_alerts", func(w http.ResponseWriter, r *http.Request, p params) {
+		writeResult(w, http.StatusOK, availableAlerts)
+	})
+
+	// Like the API, policies are checked against the filters of their alert
+	// type.
+	s.crud(alertingPattern+"/policies", crudHooks{
+		kind: "policy",
+		prepare: func(p params, existing, incoming object) *apiError {
+			alertType, _ := incoming["alert_type"].(string)
+			filters, ok := availableAlertFilters(alertType)
+			if !ok {
+				return badRequest(17102, fmt.Sprintf("unsupported alert type %q", alertType))
+			}
+			policyFilters, _ := incoming["filters"].(map[string]interface{})
+			for key := range policyFilters {
+				if !filters[key] {
+					return badRequest(17103, fmt.Sprintf("filter %q is not supported by alert type %q", key, alertType))
+				}
+			}
+
+			now := timestamp()
+			incoming["created"] = now
+			if existing != nil {
+				incoming["created"] = existing["created"]
+			}
+			incoming["modified"] = now
+			return nil
+		},
+	})
+}
+
+// availableAlertFilters returns the filters supported by an alert type and
+// whether it exists.
+func availableAlertFilters(alertType string) (map[string]bool, bool) {
+	for _, alerts := range availableAlerts {
+		for _, alert := range alerts {
+			if alert["type"] != alertType {
+				continue
+			}
+			filters := make(map[string]bool)
+			for _, option := range alert["filter_options"].([]interface{}) {
+				filters[option.(object)["Key"].(string)] = true
+			}
+			return filters, true
+		}
+	}
+	return nil, false
+}
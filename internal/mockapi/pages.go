@@ -0,0 +1,257 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var pagesProjectNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,56}[a-z0-9]$`)
+
+// pagesDefaultCompatibilityDate is the compatibility date of deployment
+// configurations that don't set one.
+const pagesDefaultCompatibilityDate = "2022-08-15"
+
+// pagesDomainStatuses are the statuses a custom domain of a Pages project
+// goes through before it's active.
+var pagesDomainStatuses = []string{"initializing", "pending", "active"}
+
+func (s *Server) registerPagesRoutes() {
+	const projectsPattern = "/accounts/{account_id}/pages/projects"
+	projectPattern := projectsPattern + "/{project_name}"
+	domainsPattern := projectPattern + "/domains"
+
+	// Projects are identified by their name.
+	project := func(w http.ResponseWriter, p params) (object, bool) {
+		project, ok := s.collection(expandPattern(projectsPattern, p)).get(p["project_name"])
+		if !ok {
+			writeAPIError(w, &apiError{status: http.StatusNotFound, code: 8000007, message: "Project not found. The specified project name does not match any of your existing projects."})
+		}
+		return project, ok
+	}
+
+	s.handle(http.MethodGet, projectsPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		var out []object
+		for _, project := range s.collection(expandPattern(projectsPattern, p)).all() {
+			out = append(out, pagesProjectResponse(project))
+		}
+		writeList(w, r, out)
+	})
+
+	s.handle(http.MethodPost, projectsPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		name, _ := req["name"].(string)
+		if !pagesProjectNameRegexp.MatchString(name) {
+			writeAPIError(w, badRequest(8000011, "The project name is invalid."))
+			return
+		}
+		if branch, _ := req["production_branch"].(string); branch == "" {
+			writeAPIError(w, badRequest(8000012, "A production branch is required."))
+			return
+		}
+		c := s.collection(expandPattern(projectsPattern, p))
+		if _, exists := c.get(name); exists {
+			writeAPIError(w, &apiError{status: http.StatusConflict, code: 8000002, message: "A project with this name already exists."})
+			return
+		}
+
+		subdomain := name + ".pages.dev"
+		project := mergePatch(object{
+			"name":               name,
+			"subdomain":          subdomain,
+			"domains":            []interface{}{subdomain},
+			"build_config":       object{},
+			"deployment_configs": object{"preview": object{}, "production": object{}},
+		}, req)
+		project["id"] = name
+		setPagesDeploymentConfigDefaults(project)
+		writeResult(w, http.StatusOK, pagesProjectResponse(c.create(project)))
+	})
+
+	s.handle(http.MethodGet, projectPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		if project, ok := project(w, p); ok {
+			writeResult(w, http.StatusOK, pagesProjectResponse(project))
+		}
+	})
+
+	// Projects are edited with JSON merge patches, where null removes a
+	// setting such as an environment variable.
+	s.handle(http.MethodPatch, projectPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		existing, ok := project(w, p)
+		if !ok {
+			return
+		}
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		for _, k := range []string{"id", "name", "subdomain", "domains"} {
+			delete(req, k)
+		}
+		project := mergePatch(existing, req)
+		setPagesDeploymentConfigDefaults(project)
+		c := s.collection(expandPattern(projectsPattern, p))
+		writeResult(w, http.StatusOK, pagesProjectResponse(c.replace(p["project_name"], project)))
+	})
+
+	s.handle(http.MethodDelete, projectPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := project(w, p); !ok {
+			return
+		}
+		s.collection(expandPattern(projectsPattern, p)).delete(p["project_name"])
+		delete(s.collections, expandPattern(domainsPattern, p))
+		writeResult(w, http.StatusOK, nil)
+	})
+
+	// Custom domains move on to the next status each time they're read, as
+	// if verification were in progress. Verification of domains under the
+	// reserved .invalid top level domain fails.
+	domain := func(w http.ResponseWriter, p params) (object, bool) {
+		if _, ok := project(w, p); !ok {
+			return nil, false
+		}
+		d, ok := s.collection(expandPattern(domainsPattern, p)).get(p["domain_name"])
+		if !ok {
+			writeAPIError(w, &apiError{status: http.StatusNotFound, code: 8000018, message: "The specified domain was not found."})
+		}
+		return d, ok
+	}
+
+	s.handle(http.MethodPost, domainsPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		project, ok := project(w, p)
+		if !ok {
+			return
+		}
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		name, _ := req["name"].(string)
+		if name == "" {
+			writeAPIError(w, badRequest(8000015, "A domain name is required."))
+			return
+		}
+		c := s.collection(expandPattern(domainsPattern, p))
+		if _, exists := c.get(name); exists {
+			writeAPIError(w, &apiError{status: http.StatusConflict, code: 8000018, message: "The domain is already in use by this project."})
+			return
+		}
+
+		d := c.create(object{
+			"id":                name,
+			"name":              name,
+			"status":            pagesDomainStatuses[0],
+			"verification_data": object{"status": "pending"},
+			"validation_data":   object{"status": "initializing", "method": "http"},
+		})
+		domains, _ := project["domains"].([]interface{})
+		project["domains"] = append(domains, name)
+		writeResult(w, http.StatusOK, d)
+	})
+
+	s.handle(http.MethodGet, domainsPattern+"/{domain_name}", func(w http.ResponseWriter, r *http.Request, p params) {
+		d, ok := domain(w, p)
+		if !ok {
+			return
+		}
+		writeResult(w, http.StatusOK, d)
+		advancePagesDomain(d)
+	})
+
+	s.handle(http.MethodDelete, domainsPattern+"/{domain_name}", func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := domain(w, p); !ok {
+			return
+		}
+		s.collection(expandPattern(domainsPattern, p)).delete(p["domain_name"])
+		project, _ := s.collection(expandPattern(projectsPattern, p)).get(p["project_name"])
+		var domains []interface{}
+		for _, d := range project["domains"].([]interface{}) {
+			if d != p["domain_name"] {
+				domains = append(domains, d)
+			}
+		}
+		project["domains"] = domains
+		writeResult(w, http.StatusOK, nil)
+	})
+}
+
+// setPagesDeploymentConfigDefaults sets the compatibility date of deployment
+// configurations that don't have one.
+func setPagesDeploymentConfigDefaults(project object) {
+	configs, _ := project["deployment_configs"].(map[string]interface{})
+	for _, env := range []string{"preview", "production"} {
+		config, _ := configs[env].(map[string]interface{})
+		if config == nil {
+			continue
+		}
+		if date, _ := config["compatibility_date"].(string); date == "" {
+			config["compatibility_date"] = pagesDefaultCompatibilityDate
+		}
+	}
+}
+
+// pagesProjectResponse returns a copy of project without the values of its
+// secrets, which are write only.
+func pagesProjectResponse(project object) object {
+	var out object
+	b, _ := json.Marshal(project)
+	json.Unmarshal(b, &out) //nolint:errcheck
+
+	configs, _ := out["deployment_configs"].(map[string]interface{})
+	for _, config := range configs {
+		config, _ := config.(map[string]interface{})
+		vars, _ := config["env_vars"].(map[string]interface{})
+		for _, v := range vars {
+			if v, ok := v.(map[string]interface{}); ok && v["type"] == "secret_text" {
+				v["value"] = ""
+			}
+		}
+	}
+
+	return out
+}
+
+func advancePagesDomain(d object) {
+	status, _ := d["status"].(string)
+
+	if status == "pending" && strings.HasSuffix(d["name"].(string), ".invalid") {
+		d["status"] = "error"
+		d["verification_data"] = object{"status": "error", "error_message": "CNAME record for " + d["name"].(string) + " does not point to the project"}
+		return
+	}
+
+	for i, s := range pagesDomainStatuses[:len(pagesDomainStatuses)-1] {
+		if s == status {
+			d["status"] = pagesDomainStatuses[i+1]
+		}
+	}
+	if d["status"] == "active" {
+		d["verification_data"] = object{"status": "active"}
+		d["validation_data"] = object{"status": "active", "method": "http"}
+	}
+}
+
+// mergePatch applies a JSON merge patch to base, returning the result. Nested
+// objects are merged and null values remove what they replace.
+func mergePatch(base, patch object) object {
+	out := mergeObjects(base, object{})
+	for k, v := range patch {
+		switch v := v.(type) {
+		case nil:
+			delete(out, k)
+		case map[string]interface{}:
+			existing, _ := out[k].(map[string]interface{})
+			out[k] = mergePatch(existing, v)
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
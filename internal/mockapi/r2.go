@@ -0,0 +1,209 @@
+package mockapi
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var (
+	r2BucketNameRegexp    = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,61}[a-z0-9]$`)
+	r2BucketLocationHints = []string{"apac", "eeur", "enam", "weur", "wnam"}
+)
+
+func (s *Server) registerR2Routes() {
+	const bucketsPattern = "/accounts/{account_id}/r2/buckets"
+	bucketPattern := bucketsPattern + "/{bucket_name}"
+
+	// Buckets are identified by their name, and so are the collections of
+	// their settings.
+	bucket := func(w http.ResponseWriter, p params) (object, bool) {
+		b, ok := s.collection(expandPattern(bucketsPattern, p)).get(p["bucket_name"])
+		if !ok {
+			writeAPIError(w, &apiError{status: http.StatusNotFound, code: 10006, message: "The specified bucket does not exist."})
+		}
+		return b, ok
+	}
+	settings := func(p params, name string) *collection {
+		return s.collection(expandPattern(bucketPattern, p) + "/" + name)
+	}
+
+	s.handle(http.MethodPost, bucketsPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		name, _ := req["name"].(string)
+		if !r2BucketNameRegexp.MatchString(name) {
+			writeAPIError(w, badRequest(10005, "The specified bucket name is not valid."))
+			return
+		}
+		c := s.collection(expandPattern(bucketsPattern, p))
+		if _, exists := c.get(name); exists {
+			writeAPIError(w, &apiError{status: http.StatusConflict, code: 10004, message: "The bucket you tried to create already exists, and you own it."})
+			return
+		}
+
+		location := "enam"
+		if hint, ok := req["locationHint"].(string); ok && hint != "" {
+			valid := false
+			for _, h := range r2BucketLocationHints {
+				valid = valid || h == hint
+			}
+			if !valid {
+				writeAPIError(w, badRequest(10085, "The specified location hint is not valid."))
+				return
+			}
+			location = hint
+		}
+
+		b := c.create(object{"id": name, "name": name, "location": strings.ToUpper(location)})
+		writeResult(w, http.StatusOK, object{"name": b["name"], "location": b["location"], "creation_date": b["created_on"]})
+	})
+
+	s.handle(http.MethodGet, bucketPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		b, ok := bucket(w, p)
+		if !ok {
+			return
+		}
+		writeResult(w, http.StatusOK, object{"name": b["name"], "location": b["location"], "creation_date": b["created_on"]})
+	})
+
+	s.handle(http.MethodDelete, bucketPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := bucket(w, p); !ok {
+			return
+		}
+		s.collection(expandPattern(bucketsPattern, p)).delete(p["bucket_name"])
+		for _, name := range []string{"lifecycle", "cors", "domains/custom"} {
+			delete(s.collections, expandPattern(bucketPattern, p)+"/"+name)
+		}
+		writeResult(w, http.StatusOK, object{})
+	})
+
+	// Lifecycle and CORS rules are each replaced as a whole.
+	for _, name := range []string{"lifecycle", "cors"} {
+		name := name
+		s.handle(http.MethodGet, bucketPattern+"/"+name, func(w http.ResponseWriter, r *http.Request, p params) {
+			if _, ok := bucket(w, p); !ok {
+				return
+			}
+			rules, ok := settings(p, name).get(name)
+			if !ok {
+				if name == "cors" {
+					writeAPIError(w, &apiError{status: http.StatusNotFound, code: 10059, message: "The CORS configuration does not exist."})
+					return
+				}
+				rules = object{"rules": []interface{}{}}
+			}
+			writeResult(w, http.StatusOK, object{"rules": rules["rules"]})
+		})
+
+		s.handle(http.MethodPut, bucketPattern+"/"+name, func(w http.ResponseWriter, r *http.Request, p params) {
+			if _, ok := bucket(w, p); !ok {
+				return
+			}
+			req, err := decodeObject(r)
+			if err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			if _, ok := req["rules"].([]interface{}); !ok {
+				writeAPIError(w, badRequest(10040, "rules are required"))
+				return
+			}
+			c := settings(p, name)
+			c.delete(name)
+			c.create(object{"id": name, "rules": req["rules"]})
+			writeResult(w, http.StatusOK, object{})
+		})
+	}
+
+	s.handle(http.MethodDelete, bucketPattern+"/cors", func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := bucket(w, p); !ok {
+			return
+		}
+		settings(p, "cors").delete("cors")
+		writeResult(w, http.StatusOK, object{})
+	})
+
+	customDomain := func(w http.ResponseWriter, p params) (object, bool) {
+		if _, ok := bucket(w, p); !ok {
+			return nil, false
+		}
+		d, ok := settings(p, "domains/custom").get(p["domain"])
+		if !ok {
+			writeAPIError(w, &apiError{status: http.StatusNotFound, code: 10092, message: "The specified custom domain does not exist."})
+		}
+		return d, ok
+	}
+	writeCustomDomain := func(w http.ResponseWriter, d object) {
+		out := mergeObjects(d, object{})
+		for _, k := range []string{"id", "created_on", "modified_on"} {
+			delete(out, k)
+		}
+		writeResult(w, http.StatusOK, out)
+	}
+
+	s.handle(http.MethodPost, bucketPattern+"/domains/custom", func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := bucket(w, p); !ok {
+			return
+		}
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		domain, _ := req["domain"].(string)
+		zoneID, _ := req["zoneId"].(string)
+		zone, ok := s.zone(zoneID)
+		if !ok || !(domain == zone["name"] || strings.HasSuffix(domain, "."+zone["name"].(string))) {
+			writeAPIError(w, badRequest(10093, "The domain must belong to the specified zone."))
+			return
+		}
+		c := settings(p, "domains/custom")
+		if _, exists := c.get(domain); exists {
+			writeAPIError(w, &apiError{status: http.StatusConflict, code: 10094, message: "The custom domain is already connected to a bucket."})
+			return
+		}
+		if _, ok := req["minTLS"]; !ok {
+			req["minTLS"] = "1.0"
+		}
+		req["id"] = domain
+		req["zoneName"] = zone["name"]
+		req["status"] = object{"ownership": "active", "ssl": "active"}
+		writeCustomDomain(w, c.create(req))
+	})
+
+	s.handle(http.MethodGet, bucketPattern+"/domains/custom/{domain}", func(w http.ResponseWriter, r *http.Request, p params) {
+		if d, ok := customDomain(w, p); ok {
+			writeCustomDomain(w, d)
+		}
+	})
+
+	s.handle(http.MethodPut, bucketPattern+"/domains/custom/{domain}", func(w http.ResponseWriter, r *http.Request, p params) {
+		d, ok := customDomain(w, p)
+		if !ok {
+			return
+		}
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		for _, k := range []string{"enabled", "minTLS"} {
+			if v, ok := req[k]; ok {
+				d[k] = v
+			}
+		}
+		writeCustomDomain(w, d)
+	})
+
+	s.handle(http.MethodDelete, bucketPattern+"/domains/custom/{domain}", func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := customDomain(w, p); !ok {
+			return
+		}
+		settings(p, "domains/custom").delete(p["domain"])
+		writeResult(w, http.StatusOK, object{"domain": p["domain"]})
+	})
+}
@@ -0,0 +1,184 @@
+package mockapi
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// entrypointKinds maps the route root to the ruleset kind used for phase
+// entrypoints at that level.
+var entrypointKinds = map[string]string{
+	"zones":    "zone",
+	"accounts": "root",
+}
+
+func (s *Server) registerRulesetRoutes() {
+	for root, entrypointKind := range entrypointKinds {
+		s.registerRulesetRoutesFor(root, entrypointKind)
+	}
+}
+
+func (s *Server) registerRulesetRoutesFor(root, entrypointKind string) {
+	pattern := "/" + root + "/{identifier}/rulesets"
+	rulesets := func(p params) *collection {
+		return s.collection(expandPattern(pattern, p))
+	}
+
+	s.handle(http.MethodGet, pattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		var out []object
+		for _, rs := range rulesets(p).all() {
+			summary := mergeObjects(rs, object{})
+			delete(summary, "rules")
+			out = append(out, summary)
+		}
+		writeList(w, r, out)
+	})
+
+	s.handle(http.MethodPost, pattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		rs, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		if rs["kind"] == entrypointKind {
+			if _, ok := findEntrypoint(rulesets(p), entrypointKind, rs["phase"]); ok {
+				writeAPIError(w, badRequest(20217, "A similar configuration with rules already exists and overwriting will have unintended consequences"))
+				return
+			}
+		}
+		rs["version"] = "1"
+		prepareRulesetRules(rs, nil)
+		writeResult(w, http.StatusOK, rulesets(p).create(rs))
+	})
+
+	s.handle(http.MethodGet, pattern+"/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		rs, ok := rulesets(p).get(p["id"])
+		if !ok {
+			writeAPIError(w, notFound("ruleset"))
+			return
+		}
+		writeResult(w, http.StatusOK, rs)
+	})
+
+	s.handle(http.MethodPut, pattern+"/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		existing, ok := rulesets(p).get(p["id"])
+		if !ok {
+			writeAPIError(w, notFound("ruleset"))
+			return
+		}
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeResult(w, http.StatusOK, updateRuleset(rulesets(p), existing, req))
+	})
+
+	s.handle(http.MethodDelete, pattern+"/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		if !rulesets(p).delete(p["id"]) {
+			writeAPIError(w, notFound("ruleset"))
+			return
+		}
+		// The API responds to ruleset deletion with an empty 204 rather
+		// than the usual envelope.
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	s.handle(http.MethodGet, pattern+"/phases/{phase}/entrypoint", func(w http.ResponseWriter, r *http.Request, p params) {
+		rs, ok := findEntrypoint(rulesets(p), entrypointKind, p["phase"])
+		if !ok {
+			writeAPIError(w, notFound("ruleset"))
+			return
+		}
+		writeResult(w, http.StatusOK, rs)
+	})
+
+	s.handle(http.MethodPut, pattern+"/phases/{phase}/entrypoint", func(w http.ResponseWriter, r *http.Request, p params) {
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		existing, ok := findEntrypoint(rulesets(p), entrypointKind, p["phase"])
+		if !ok {
+			rs := object{
+				"name":        "default",
+				"description": req["description"],
+				"kind":        entrypointKind,
+				"phase":       p["phase"],
+				"rules":       req["rules"],
+				"version":     "1",
+			}
+			prepareRulesetRules(rs, nil)
+			writeResult(w, http.StatusOK, rulesets(p).create(rs))
+			return
+		}
+
+		writeResult(w, http.StatusOK, updateRuleset(rulesets(p), existing, req))
+	})
+}
+
+func findEntrypoint(c *collection, kind string, phase interface{}) (object, bool) {
+	for _, rs := range c.all() {
+		if rs["kind"] == kind && rs["phase"] == phase {
+			return rs, true
+		}
+	}
+	return nil, false
+}
+
+// updateRuleset replaces the description and rules of a ruleset and bumps
+// its version, as the API does for every successful update.
+func updateRuleset(c *collection, existing, req object) object {
+	rs := mergeObjects(existing, object{
+		"description": req["description"],
+		"rules":       req["rules"],
+	})
+
+	version, _ := strconv.Atoi(existing["version"].(string))
+	rs["version"] = strconv.Itoa(version + 1)
+	prepareRulesetRules(rs, existing)
+
+	return c.replace(existing["id"].(string), rs)
+}
+
+// prepareRulesetRules assigns identifiers, versions and timestamps to the
+// rules of a ruleset. Rules that reuse the identifier of a rule in previous
+// keep it.
+func prepareRulesetRules(rs, previous object) {
+	known := map[string]bool{}
+	if previous != nil {
+		rules, _ := previous["rules"].([]interface{})
+		for _, r := range rules {
+			if id, ok := r.(map[string]interface{})["id"].(string); ok {
+				known[id] = true
+			}
+		}
+	}
+
+	rules, _ := rs["rules"].([]interface{})
+	if rules == nil {
+		rules = []interface{}{}
+	}
+
+	now := timestamp()
+	for _, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, _ := rule["id"].(string); !known[id] {
+			rule["id"] = newID()
+		}
+		if _, ok := rule["enabled"]; !ok {
+			rule["enabled"] = true
+		}
+		rule["ref"] = rule["id"]
+		rule["version"] = rs["version"]
+		rule["last_updated"] = now
+	}
+
+	rs["rules"] = rules
+	rs["last_updated"] = now
+}
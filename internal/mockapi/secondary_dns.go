@@ -0,0 +1,95 @@
+package mockapi
+
+import (
+	"net/http"
+	"time"
+)
+
+func (s *Server) registerSecondaryDNSRoutes() {
+	const pattern = "/zones/{zone_id}/secondary_dns"
+
+	// Each zone has at most one secondary DNS configuration, which is kept in
+	// a collection of its own keyed by the zone identifier.
+	configs := func(p params) *collection {
+		return s.collection(expandPattern(pattern, p))
+	}
+
+	s.handle(http.MethodGet, pattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		cfg, ok := configs(p).get(p["zone_id"])
+		if !ok {
+			writeAPIError(w, notFound("secondary zone configuration"))
+			return
+		}
+		writeResult(w, http.StatusOK, cfg)
+	})
+
+	upsert := func(create bool) handlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, p params) {
+			if _, ok := s.zone(p["zone_id"]); !ok {
+				writeAPIError(w, notFound("zone"))
+				return
+			}
+			existing, exists := configs(p).get(p["zone_id"])
+			if create && exists {
+				writeAPIError(w, badRequest(1000, "secondary zone configuration already exists"))
+				return
+			}
+			if !create && !exists {
+				writeAPIError(w, notFound("secondary zone configuration"))
+				return
+			}
+
+			cfg, err := decodeObject(r)
+			if err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			for _, k := range []string{"primaries", "peers"} {
+				if peers, ok := cfg[k].([]interface{}); ok && len(peers) == 0 {
+					writeAPIError(w, badRequest(1000, "at least one peer is required"))
+					return
+				}
+			}
+
+			cfg["id"] = p["zone_id"]
+			cfg["soa_serial"] = 0
+			cfg["checked_time"] = time.Time{}.Format(time.RFC3339)
+			cfg["modified_time"] = timestamp()
+			if create {
+				cfg["created_time"] = timestamp()
+				writeResult(w, http.StatusOK, configs(p).create(cfg))
+				return
+			}
+			cfg["created_time"] = existing["created_time"]
+			cfg["soa_serial"] = existing["soa_serial"]
+			writeResult(w, http.StatusOK, configs(p).replace(p["zone_id"], cfg))
+		}
+	}
+	s.handle(http.MethodPost, pattern, upsert(true))
+	s.handle(http.MethodPut, pattern, upsert(false))
+
+	s.handle(http.MethodDelete, pattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		if !configs(p).delete(p["zone_id"]) {
+			writeAPIError(w, notFound("secondary zone configuration"))
+			return
+		}
+		writeResult(w, http.StatusOK, object{"id": p["zone_id"]})
+	})
+
+	// A forced transfer bumps the SOA serial so callers can observe that it
+	// happened.
+	s.handle(http.MethodPost, pattern+"/force_axfr", func(w http.ResponseWriter, r *http.Request, p params) {
+		cfg, ok := configs(p).get(p["zone_id"])
+		if !ok {
+			writeAPIError(w, notFound("secondary zone configuration"))
+			return
+		}
+		serial, _ := cfg["soa_serial"].(int)
+		cfg["soa_serial"] = serial + 1
+		cfg["checked_time"] = timestamp()
+		writeResult(w, http.StatusOK, "OK")
+	})
+
+	s.crud("/accounts/{account_id}/secondary_dns/tsigs", crudHooks{kind: "tsig"})
+	s.crud("/accounts/{account_id}/secondary_dns/primaries", crudHooks{kind: "peer"})
+}
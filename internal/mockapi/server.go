@@ -0,0 +1,536 @@
+// Package mockapi provides an in-process, stateful fake of the Cloudflare v4
+// API. It is intended for running the provider acceptance tests without a
+// live account: the provider is pointed at the fake using the existing
+// `api_hostname` and `api_base_path` settings and every request is served
+// from memory.
+//
+// The fake only implements the subset of the API needed by the resources
+// that are exercised against it (zones, DNS records, rulesets, Access
+// applications, load balancers, Workers, lists, Teams lists, custom hostnames,
+// R2 buckets, Pages projects, Email Routing, Argo and tiered cache, waiting
+// rooms, notification policies, account members, API tokens, IPsec tunnels
+// and Magic WAN sites). Unknown routes respond the same way the real API does
+// for an unroutable request.
+package mockapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BasePath is the API base path that the fake serves requests from. It
+// mirrors the default value of the provider `api_base_path` setting.
+const BasePath = "/client/v4"
+
+// object is the in-memory representation of any API object. Objects are
+// stored exactly as they are sent on the wire so that the fake does not need
+// to know about every field the API supports.
+type object = map[string]interface{}
+
+// Server is a fake Cloudflare API backed by an httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	routes      []route
+	collections map[string]*collection
+	zones       *collection
+	scripts     map[string]*workerScript
+}
+
+// NewServer starts and returns a new fake API server. Callers should call
+// Close when finished to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		collections: make(map[string]*collection),
+		zones:       newCollection(),
+		scripts:     make(map[string]*workerScript),
+	}
+
+	s.registerZoneRoutes()
+	s.registerDNSRoutes()
+	s.registerSecondaryDNSRoutes()
+	s.registerRulesetRoutes()
+	s.registerAccessRoutes()
+	s.registerLoadBalancerRoutes()
+	s.registerWorkerRoutes()
+	s.registerListRoutes()
+	s.registerGatewayListRoutes()
+	s.registerCustomHostnameRoutes()
+	s.registerR2Routes()
+	s.registerPagesRoutes()
+	s.registerEmailRoutingRoutes()
+	s.registerArgoRoutes()
+	s.registerWaitingRoomRoutes()
+	s.registerNotificationRoutes()
+	s.registerAccountRoutes()
+	s.registerMagicRoutes()
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Hostname returns the host and port the fake is listening on, suitable for
+// use as the provider `api_hostname` setting.
+func (s *Server) Hostname() string {
+	u, _ := url.Parse(s.URL)
+	return u.Host
+}
+
+// BaseURL returns the full base URL of the fake API.
+func (s *Server) BaseURL() string {
+	return s.URL + BasePath
+}
+
+// params holds the named path segments matched for a route.
+type params map[string]string
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, p params)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+}
+
+// handle registers a handler for the given method and path pattern. Pattern
+// segments wrapped in braces (`{zone_id}`) match any single path segment and
+// are made available to the handler by name.
+func (s *Server) handle(method, pattern string, h handlerFunc) {
+	s.routes = append(s.routes, route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  h,
+	})
+}
+
+func (rt route) match(method string, segments []string) (params, bool) {
+	if rt.method != method || len(rt.segments) != len(segments) {
+		return nil, false
+	}
+
+	p := params{}
+	for i, seg := range rt.segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			p[strings.Trim(seg, "{}")] = segments[i]
+			continue
+		}
+		if seg != segments[i] {
+			return nil, false
+		}
+	}
+
+	return p, true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, BasePath+"/") {
+		writeError(w, http.StatusNotFound, 7000, "No route for that URI")
+		return
+	}
+
+	if r.Header.Get("Authorization") == "" && (r.Header.Get("X-Auth-Key") == "" || r.Header.Get("X-Auth-Email") == "") {
+		writeError(w, http.StatusBadRequest, 9106, "Missing X-Auth-Key, X-Auth-Email or Authorization headers")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, BasePath)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rt := range s.routes {
+		if p, ok := rt.match(r.Method, segments); ok {
+			rt.handler(w, r, p)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, 7003, fmt.Sprintf("Could not route to %s, perhaps your object identifier is invalid?", path))
+}
+
+// collection returns the collection stored at key, creating it when it does
+// not yet exist. Keys are the request path without the object identifier so
+// that zone and account scoped objects never collide.
+func (s *Server) collection(key string) *collection {
+	c, ok := s.collections[key]
+	if !ok {
+		c = newCollection()
+		s.collections[key] = c
+	}
+	return c
+}
+
+// crudHooks customise the behaviour of the generic CRUD handlers.
+type crudHooks struct {
+	// kind is the human readable name used in error messages.
+	kind string
+
+	// prepare is called with the stored object (nil on create) and the
+	// incoming object before it is persisted. It may mutate the incoming
+	// object or return an error response.
+	prepare func(p params, existing, incoming object) *apiError
+}
+
+// crud registers list, create, read, replace, edit and delete handlers for a
+// collection rooted at pattern.
+func (s *Server) crud(pattern string, hooks crudHooks) {
+	key := func(p params) string {
+		return expandPattern(pattern, p)
+	}
+
+	s.handle(http.MethodGet, pattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		writeList(w, r, s.collection(key(p)).list(r.URL.Query()))
+	})
+
+	s.handle(http.MethodPost, pattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		obj, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		if hooks.prepare != nil {
+			if err := hooks.prepare(p, nil, obj); err != nil {
+				writeAPIError(w, err)
+				return
+			}
+		}
+		writeResult(w, http.StatusOK, s.collection(key(p)).create(obj))
+	})
+
+	s.handle(http.MethodGet, pattern+"/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		obj, ok := s.collection(key(p)).get(p["id"])
+		if !ok {
+			writeAPIError(w, notFound(hooks.kind))
+			return
+		}
+		writeResult(w, http.StatusOK, obj)
+	})
+
+	update := func(merge bool) handlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, p params) {
+			c := s.collection(key(p))
+			existing, ok := c.get(p["id"])
+			if !ok {
+				writeAPIError(w, notFound(hooks.kind))
+				return
+			}
+			obj, err := decodeObject(r)
+			if err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			if merge {
+				obj = mergeObjects(existing, obj)
+			}
+			if hooks.prepare != nil {
+				if err := hooks.prepare(p, existing, obj); err != nil {
+					writeAPIError(w, err)
+					return
+				}
+			}
+			writeResult(w, http.StatusOK, c.replace(p["id"], obj))
+		}
+	}
+	s.handle(http.MethodPut, pattern+"/{id}", update(false))
+	s.handle(http.MethodPatch, pattern+"/{id}", update(true))
+
+	s.handle(http.MethodDelete, pattern+"/{id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		if !s.collection(key(p)).delete(p["id"]) {
+			writeAPIError(w, notFound(hooks.kind))
+			return
+		}
+		writeResult(w, http.StatusOK, object{"id": p["id"]})
+	})
+}
+
+// expandPattern substitutes matched params back into a route pattern.
+func expandPattern(pattern string, p params) string {
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			segments[i] = p[strings.Trim(seg, "{}")]
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// collection is an ordered set of objects keyed by their `id`.
+type collection struct {
+	items map[string]object
+	order []string
+}
+
+func newCollection() *collection {
+	return &collection{items: make(map[string]object)}
+}
+
+func (c *collection) create(obj object) object {
+	id, _ := obj["id"].(string)
+	if id == "" {
+		id = newID()
+	}
+	now := timestamp()
+	obj["id"] = id
+	obj["created_on"] = now
+	obj["modified_on"] = now
+
+	c.items[id] = obj
+	c.order = append(c.order, id)
+
+	return obj
+}
+
+func (c *collection) get(id string) (object, bool) {
+	obj, ok := c.items[id]
+	return obj, ok
+}
+
+func (c *collection) replace(id string, obj object) object {
+	existing := c.items[id]
+	obj["id"] = id
+	obj["created_on"] = existing["created_on"]
+	obj["modified_on"] = timestamp()
+	c.items[id] = obj
+	return obj
+}
+
+func (c *collection) delete(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+	delete(c.items, id)
+	for i, v := range c.order {
+		if v == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// all returns every object in insertion order.
+func (c *collection) all() []object {
+	out := make([]object, 0, len(c.order))
+	for _, id := range c.order {
+		out = append(out, c.items[id])
+	}
+	return out
+}
+
+// list returns the objects that match the filters in query. Any query
+// parameter that names a top level string field of an object is treated as
+// an exact match filter; pagination and ordering parameters are ignored here
+// and handled by writeList.
+func (c *collection) list(query url.Values) []object {
+	var out []object
+	for _, obj := range c.all() {
+		if matchesQuery(obj, query) {
+			out = append(out, obj)
+		}
+	}
+	return out
+}
+
+var nonFilterParams = map[string]bool{
+	"page":      true,
+	"per_page":  true,
+	"order":     true,
+	"direction": true,
+	"match":     true,
+}
+
+func matchesQuery(obj object, query url.Values) bool {
+	for k := range query {
+		if nonFilterParams[k] {
+			continue
+		}
+		v, ok := obj[k]
+		if !ok {
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if !strings.EqualFold(s, query.Get(k)) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeObjects returns a shallow copy of base with the keys of patch applied
+// on top, matching the semantics of the API PATCH endpoints.
+func mergeObjects(base, patch object) object {
+	out := make(object, len(base)+len(patch))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range patch {
+		out[k] = v
+	}
+	return out
+}
+
+// apiError is an error response in the API envelope format.
+type apiError struct {
+	status  int
+	code    int
+	message string
+}
+
+// notFound returns the error used for unknown object identifiers. The
+// message intentionally includes both the HTTP status and the "could not
+// find" phrasing as resources match on either to detect remote deletion.
+func notFound(kind string) *apiError {
+	return &apiError{
+		status:  http.StatusNotFound,
+		code:    10000 + http.StatusNotFound,
+		message: fmt.Sprintf("HTTP status 404: could not find %s", kind),
+	}
+}
+
+func badRequest(code int, message string) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: code, message: message}
+}
+
+type envelope struct {
+	Success    bool          `json:"success"`
+	Errors     []messageInfo `json:"errors"`
+	Messages   []messageInfo `json:"messages"`
+	Result     interface{}   `json:"result"`
+	ResultInfo *resultInfo   `json:"result_info,omitempty"`
+}
+
+type messageInfo struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type resultInfo struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	TotalPages int `json:"total_pages"`
+	Count      int `json:"count"`
+	Total      int `json:"total_count"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
+
+func writeResult(w http.ResponseWriter, status int, result interface{}) {
+	writeJSON(w, status, envelope{
+		Success:  true,
+		Errors:   []messageInfo{},
+		Messages: []messageInfo{},
+		Result:   result,
+	})
+}
+
+// writeList writes a paginated list response honouring the `page` and
+// `per_page` query parameters.
+func writeList(w http.ResponseWriter, r *http.Request, items []object) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage < 1 {
+		perPage = 100
+	}
+
+	total := len(items)
+	totalPages := (total + perPage - 1) / perPage
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	result := items[start:end]
+	if result == nil {
+		result = []object{}
+	}
+
+	writeJSON(w, http.StatusOK, envelope{
+		Success:  true,
+		Errors:   []messageInfo{},
+		Messages: []messageInfo{},
+		Result:   result,
+		ResultInfo: &resultInfo{
+			Page:       page,
+			PerPage:    perPage,
+			TotalPages: totalPages,
+			Count:      len(result),
+			Total:      total,
+		},
+	})
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	writeJSON(w, status, envelope{
+		Success:  false,
+		Errors:   []messageInfo{{Code: code, Message: message}},
+		Messages: []messageInfo{},
+	})
+}
+
+func writeAPIError(w http.ResponseWriter, err *apiError) {
+	writeError(w, err.status, err.code, err.message)
+}
+
+func decodeObject(r *http.Request) (object, *apiError) {
+	obj := object{}
+	if r.Body == nil || r.ContentLength == 0 {
+		return obj, nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+		return nil, badRequest(6007, fmt.Sprintf("Malformed JSON in request body: %s", err))
+	}
+	return obj, nil
+}
+
+// newID returns a random 32 character hex identifier in the same format as
+// the API uses for most objects.
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b) //nolint:errcheck
+	return hex.EncodeToString(b)
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+// sortedKeys returns the keys of m in lexical order.
+func sortedKeys(m map[string]*workerScript) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,790 @@
+package mockapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAccountID = "f037e56e89293a057740de681ac9abbe"
+	testZoneID    = "0da42c8d2132a9ddaf714f9e7c920711"
+	testZoneName  = "terraform.cfapi.net"
+)
+
+func newTestClient(t *testing.T) (*Server, *cloudflare.API) {
+	s := NewServer()
+	t.Cleanup(s.Close)
+	s.AddZone(testZoneID, testZoneName, testAccountID)
+
+	client, err := cloudflare.New(
+		"0123456789abcdef0123456789abcdef01234",
+		"terraform@example.com",
+		cloudflare.BaseURL(s.BaseURL()),
+		cloudflare.UsingAccount(testAccountID),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s, client
+}
+
+func TestRejectsMissingCredentials(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client, _ := cloudflare.NewWithAPIToken("x", cloudflare.BaseURL(s.BaseURL()))
+	client.APIToken = ""
+
+	_, err := client.ZoneDetails(context.Background(), testZoneID)
+	assert.Error(t, err)
+}
+
+func TestZones(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	id, err := client.ZoneIDByName(testZoneName)
+	assert.NoError(t, err)
+	assert.Equal(t, testZoneID, id)
+
+	zone, err := client.CreateZone(ctx, "example.com", false, cloudflare.Account{ID: testAccountID}, "full")
+	assert.NoError(t, err)
+	assert.Equal(t, "pending", zone.Status)
+
+	_, err = client.CreateZone(ctx, "example.com", false, cloudflare.Account{ID: testAccountID}, "full")
+	assert.Error(t, err)
+
+	assert.NoError(t, client.ZoneSetPlan(ctx, zone.ID, "CF_BIZ"))
+	zone, err = client.ZoneSetPaused(ctx, zone.ID, true)
+	assert.NoError(t, err)
+	assert.True(t, zone.Paused)
+	assert.Equal(t, "business", zone.Plan.LegacyID)
+
+	_, err = client.DeleteZone(ctx, zone.ID)
+	assert.NoError(t, err)
+
+	_, err = client.ZoneDetails(ctx, zone.ID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP status 404")
+}
+
+func TestZonesPagination(t *testing.T) {
+	s, client := newTestClient(t)
+	for i := 0; i < 120; i++ {
+		s.AddZone(newID(), newID()+".example.com", testAccountID)
+	}
+
+	zones, err := client.ListZonesContext(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, zones.Result, 121)
+}
+
+func TestDNSRecords(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateDNSRecord(ctx, testZoneID, cloudflare.DNSRecord{
+		Type:    "A",
+		Name:    "www",
+		Content: "192.0.2.1",
+		TTL:     3600,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "www."+testZoneName, created.Result.Name)
+	assert.True(t, created.Result.Proxiable)
+	assert.Len(t, created.Result.Meta, 4)
+
+	_, err = client.CreateDNSRecord(ctx, testZoneID, cloudflare.DNSRecord{
+		Type:    "A",
+		Name:    "www." + testZoneName,
+		Content: "192.0.2.1",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already exist")
+
+	err = client.UpdateDNSRecord(ctx, testZoneID, created.Result.ID, cloudflare.DNSRecord{Content: "192.0.2.2"})
+	assert.NoError(t, err)
+
+	records, err := client.DNSRecords(ctx, testZoneID, cloudflare.DNSRecord{Name: "www." + testZoneName})
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "192.0.2.2", records[0].Content)
+		assert.Equal(t, 3600, records[0].TTL)
+	}
+
+	// Records are listed by tag with the raw client, as cloudflare-go
+	// doesn't support tags.
+	_, err = client.Raw(http.MethodPost, "/zones/"+testZoneID+"/dns_records", map[string]interface{}{
+		"type": "TXT", "name": "www", "content": "tagged", "tags": []string{"env:production"},
+	})
+	assert.NoError(t, err)
+	result, err := client.Raw(http.MethodGet, "/zones/"+testZoneID+"/dns_records?tag=env:production", nil)
+	assert.NoError(t, err)
+	var tagged []cloudflare.DNSRecord
+	assert.NoError(t, json.Unmarshal(result, &tagged))
+	if assert.Len(t, tagged, 1) {
+		assert.Equal(t, "tagged", tagged[0].Content)
+	}
+
+	assert.NoError(t, client.DeleteDNSRecord(ctx, testZoneID, created.Result.ID))
+	_, err = client.DNSRecord(ctx, testZoneID, created.Result.ID)
+	assert.Error(t, err)
+}
+
+func TestRulesets(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, err := client.GetZoneRulesetPhase(ctx, testZoneID, "http_request_firewall_custom")
+	assert.Error(t, err)
+
+	rs, err := client.CreateZoneRuleset(ctx, testZoneID, cloudflare.Ruleset{
+		Name:  "custom",
+		Kind:  "zone",
+		Phase: "http_request_firewall_custom",
+		Rules: []cloudflare.RulesetRule{{
+			Action:     "block",
+			Expression: `http.request.uri.path eq "/admin"`,
+			Enabled:    true,
+		}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", rs.Version)
+	if assert.Len(t, rs.Rules, 1) {
+		assert.Len(t, rs.Rules[0].ID, 32)
+	}
+
+	entrypoint, err := client.UpdateZoneRulesetPhase(ctx, testZoneID, "http_request_firewall_custom", cloudflare.Ruleset{
+		Description: "updated",
+		Rules:       rs.Rules,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, rs.ID, entrypoint.ID)
+	assert.Equal(t, "2", entrypoint.Version)
+	assert.Equal(t, rs.Rules[0].ID, entrypoint.Rules[0].ID)
+
+	assert.NoError(t, client.DeleteZoneRuleset(ctx, testZoneID, rs.ID))
+	_, err = client.GetZoneRuleset(ctx, testZoneID, rs.ID)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not find ruleset")
+	}
+}
+
+func TestAccessApplications(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	app, err := client.CreateAccessApplication(ctx, testAccountID, cloudflare.AccessApplication{
+		Name:   "app",
+		Domain: "app." + testZoneName,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, app.AUD, 64)
+
+	app.Name = "renamed"
+	updated, err := client.UpdateAccessApplication(ctx, testAccountID, app)
+	assert.NoError(t, err)
+	assert.Equal(t, app.AUD, updated.AUD)
+	assert.Equal(t, "renamed", updated.Name)
+
+	_, err = client.ZoneLevelAccessApplication(ctx, testZoneID, app.ID)
+	assert.Error(t, err)
+}
+
+func TestLoadBalancers(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, err := client.CreateLoadBalancerPool(ctx, cloudflare.LoadBalancerPool{Name: "empty"})
+	assert.Error(t, err)
+
+	pool, err := client.CreateLoadBalancerPool(ctx, cloudflare.LoadBalancerPool{
+		Name:    "pool",
+		Origins: []cloudflare.LoadBalancerOrigin{{Name: "origin", Address: "192.0.2.1", Enabled: true}},
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pool.ID)
+
+	lb, err := client.CreateLoadBalancer(ctx, testZoneID, cloudflare.LoadBalancer{
+		Name:         "lb",
+		DefaultPools: []string{pool.ID},
+		FallbackPool: pool.ID,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "lb."+testZoneName, lb.Name)
+
+	found, err := client.LoadBalancerDetails(ctx, testZoneID, lb.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, pool.ID, found.FallbackPool)
+}
+
+func TestWorkers(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+	params := &cloudflare.WorkerRequestParams{ScriptName: "worker"}
+
+	_, err := client.UploadWorkerWithBindings(ctx, params, &cloudflare.WorkerScriptParams{
+		Script: "addEventListener('fetch', () => {})",
+		Bindings: map[string]cloudflare.WorkerBinding{
+			"TEXT":   cloudflare.WorkerPlainTextBinding{Text: "plain"},
+			"SECRET": cloudflare.WorkerSecretTextBinding{Text: "secret"},
+			"WASM":   cloudflare.WorkerWebAssemblyBinding{Module: strings.NewReader("wasm")},
+		},
+	})
+	assert.NoError(t, err)
+
+	script, err := client.DownloadWorker(ctx, params)
+	assert.NoError(t, err)
+	assert.Equal(t, "addEventListener('fetch', () => {})", script.Script)
+
+	bindings, err := client.ListWorkerBindings(ctx, params)
+	assert.NoError(t, err)
+	assert.Len(t, bindings.BindingList, 3)
+	for _, b := range bindings.BindingList {
+		switch binding := b.Binding.(type) {
+		case cloudflare.WorkerPlainTextBinding:
+			assert.Equal(t, "plain", binding.Text)
+		case cloudflare.WorkerSecretTextBinding:
+			assert.Empty(t, binding.Text)
+		}
+	}
+
+	raw, err := client.Raw(http.MethodGet, "/accounts/"+testAccountID+"/workers/scripts/worker/settings", nil)
+	assert.NoError(t, err)
+	var settings struct {
+		Bindings []map[string]interface{} `json:"bindings"`
+	}
+	assert.NoError(t, json.Unmarshal(raw, &settings))
+	assert.Len(t, settings.Bindings, 3)
+
+	route, err := client.CreateWorkerRoute(ctx, testZoneID, cloudflare.WorkerRoute{Pattern: testZoneName + "/*", Script: "worker"})
+	assert.NoError(t, err)
+	routes, err := client.ListWorkerRoutes(ctx, testZoneID)
+	assert.NoError(t, err)
+	if assert.Len(t, routes.Routes, 1) {
+		assert.Equal(t, route.ID, routes.Routes[0].ID)
+	}
+
+	_, err = client.DeleteWorker(ctx, params)
+	assert.NoError(t, err)
+	_, err = client.DownloadWorker(ctx, params)
+	assert.Error(t, err)
+}
+
+func TestWorkerMigrations(t *testing.T) {
+	var migrations workerMigrations
+	assert.NoError(t, json.Unmarshal([]byte(`{"new_tag":"v1","steps":[{"new_classes":["Counter"]}]}`), &migrations))
+	binding := object{"name": "COUNTER", "type": "durable_object_namespace", "class_name": "Counter"}
+
+	v1 := &workerScript{migrations: &migrations, bindings: []object{binding}}
+	assert.Nil(t, applyWorkerMigrations(v1, nil))
+	assert.Equal(t, "v1", v1.migrationTag)
+
+	// Migrations apply on top of the tag of the existing script.
+	assert.NotNil(t, applyWorkerMigrations(&workerScript{migrations: &migrations}, v1))
+
+	// Classes must exist to be bound to.
+	assert.Nil(t, applyWorkerMigrations(&workerScript{bindings: []object{binding}}, v1))
+	assert.NotNil(t, applyWorkerMigrations(&workerScript{bindings: []object{binding}}, nil))
+}
+
+func TestLists(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	list, err := client.CreateIPList(ctx, testAccountID, "blocked", "", "ip")
+	assert.NoError(t, err)
+
+	var items []cloudflare.IPListItemCreateRequest
+	for i := 0; i < 30; i++ {
+		items = append(items, cloudflare.IPListItemCreateRequest{IP: fmt.Sprintf("192.0.2.%d", i)})
+	}
+	resp, err := client.ReplaceIPListItemsAsync(ctx, testAccountID, list.ID, items)
+	if !assert.NoError(t, err) {
+		return
+	}
+	operation, err := client.GetIPListBulkOperation(ctx, testAccountID, resp.Result.OperationID)
+	assert.NoError(t, err)
+	assert.Equal(t, "completed", operation.Status)
+
+	// Items span two pages of the default page size.
+	listItems, err := client.ListIPListItems(ctx, testAccountID, list.ID)
+	assert.NoError(t, err)
+	assert.Len(t, listItems, 30)
+
+	_, err = client.DeleteIPList(ctx, testAccountID, list.ID)
+	assert.NoError(t, err)
+	_, err = client.GetIPList(ctx, testAccountID, list.ID)
+	assert.Error(t, err)
+}
+
+func TestTeamsLists(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	list, err := client.CreateTeamsList(ctx, testAccountID, cloudflare.TeamsList{
+		Name:  "serials",
+		Type:  "SERIAL",
+		Items: []cloudflare.TeamsListItem{{Value: "a"}, {Value: "b"}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, uint64(2), list.Count)
+
+	list, err = client.PatchTeamsList(ctx, testAccountID, cloudflare.PatchTeamsList{
+		ID:     list.ID,
+		Append: []cloudflare.TeamsListItem{{Value: "c"}},
+		Remove: []string{"a"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), list.Count)
+
+	items, resultInfo, err := client.TeamsListItems(ctx, cloudflare.TeamsListItemsParams{
+		AccountID:         testAccountID,
+		ListID:            list.ID,
+		PaginationOptions: cloudflare.PaginationOptions{PerPage: 1},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, "b", items[0].Value)
+	}
+	assert.Equal(t, 2, resultInfo.TotalPages)
+}
+
+func TestCustomHostnames(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	resp, err := client.CreateCustomHostname(ctx, testZoneID, cloudflare.CustomHostname{
+		Hostname: "shop.example.com",
+		SSL:      &cloudflare.CustomHostnameSSL{Method: "txt"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, cloudflare.CustomHostnameStatus("pending"), resp.Result.Status)
+
+	// Validation progresses each time the hostname is read.
+	var statuses []string
+	for i := 0; i < 5; i++ {
+		hostname, err := client.CustomHostname(ctx, testZoneID, resp.Result.ID)
+		assert.NoError(t, err)
+		statuses = append(statuses, hostname.SSL.Status)
+	}
+	assert.Equal(t, customHostnameSSLStatuses, statuses)
+
+	hostname, err := client.CustomHostname(ctx, testZoneID, resp.Result.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, cloudflare.ACTIVE, hostname.Status)
+}
+
+func TestR2(t *testing.T) {
+	_, client := newTestClient(t)
+	bucketsURI := "/accounts/" + testAccountID + "/r2/buckets"
+
+	bucket, err := client.Raw(http.MethodPost, bucketsURI, map[string]interface{}{"name": "assets", "locationHint": "weur"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(bucket), `"location":"WEUR"`)
+
+	_, err = client.Raw(http.MethodPost, bucketsURI, map[string]interface{}{"name": "assets"})
+	assert.Error(t, err, "bucket names are unique")
+
+	// Buckets without CORS rules have no configuration.
+	_, err = client.Raw(http.MethodGet, bucketsURI+"/assets/cors", nil)
+	var notFound *cloudflare.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	lifecycle, err := client.Raw(http.MethodGet, bucketsURI+"/assets/lifecycle", nil)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"rules":[]}`, string(lifecycle))
+
+	_, err = client.Raw(http.MethodPost, bucketsURI+"/assets/domains/custom", map[string]interface{}{"domain": "assets.example.com", "zoneId": testZoneID})
+	assert.Error(t, err, "custom domains must belong to their zone")
+	domain, err := client.Raw(http.MethodPost, bucketsURI+"/assets/domains/custom", map[string]interface{}{"domain": "assets." + testZoneName, "zoneId": testZoneID, "enabled": true})
+	assert.NoError(t, err)
+	assert.Contains(t, string(domain), `"minTLS":"1.0"`)
+
+	_, err = client.Raw(http.MethodDelete, bucketsURI+"/assets", nil)
+	assert.NoError(t, err)
+	_, err = client.Raw(http.MethodGet, bucketsURI+"/assets/domains/custom/assets."+testZoneName, nil)
+	assert.ErrorAs(t, err, &notFound)
+}
+
+func TestPagesProjects(t *testing.T) {
+	_, client := newTestClient(t)
+	projectsURI := "/accounts/" + testAccountID + "/pages/projects"
+
+	_, err := client.Raw(http.MethodPost, projectsURI, map[string]interface{}{
+		"name":              "site",
+		"production_branch": "main",
+		"deployment_configs": map[string]interface{}{"production": map[string]interface{}{"env_vars": map[string]interface{}{
+			"PLAIN":  map[string]interface{}{"type": "plain_text", "value": "a"},
+			"SECRET": map[string]interface{}{"type": "secret_text", "value": "b"},
+		}}},
+	})
+	assert.NoError(t, err)
+
+	// Null removes settings, and secrets are never read back.
+	project, err := client.Raw(http.MethodPatch, projectsURI+"/site", map[string]interface{}{
+		"deployment_configs": map[string]interface{}{"production": map[string]interface{}{"env_vars": map[string]interface{}{"PLAIN": nil}}},
+	})
+	assert.NoError(t, err)
+	var result struct {
+		DeploymentConfigs map[string]struct {
+			CompatibilityDate string            `json:"compatibility_date"`
+			EnvVars           map[string]object `json:"env_vars"`
+		} `json:"deployment_configs"`
+	}
+	assert.NoError(t, json.Unmarshal(project, &result))
+	assert.Equal(t, map[string]object{"SECRET": {"type": "secret_text", "value": ""}}, result.DeploymentConfigs["production"].EnvVars)
+	assert.Equal(t, pagesDefaultCompatibilityDate, result.DeploymentConfigs["preview"].CompatibilityDate)
+
+	// Domains are active after being read a few times.
+	_, err = client.Raw(http.MethodPost, projectsURI+"/site/domains", map[string]interface{}{"name": "www.example.com"})
+	assert.NoError(t, err)
+	for i := 0; i < len(pagesDomainStatuses); i++ {
+		_, err = client.Raw(http.MethodGet, projectsURI+"/site/domains/www.example.com", nil)
+		assert.NoError(t, err)
+	}
+	domain, err := client.Raw(http.MethodGet, projectsURI+"/site/domains/www.example.com", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(domain), `"status":"active"`)
+}
+
+func TestEmailRouting(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+	routingURI := "/zones/" + testZoneID + "/email/routing"
+
+	// Routing adds the records it needs next to the existing ones, and
+	// only removes its own.
+	priority := uint16(13)
+	existing, err := client.CreateDNSRecord(ctx, testZoneID, cloudflare.DNSRecord{Type: "MX", Name: "@", Content: "route1.mx.cloudflare.net", Priority: &priority})
+	assert.NoError(t, err)
+	_, err = client.Raw(http.MethodPost, routingURI+"/enable", struct{}{})
+	assert.NoError(t, err)
+	records, err := client.DNSRecords(ctx, testZoneID, cloudflare.DNSRecord{})
+	assert.NoError(t, err)
+	assert.Len(t, records, len(emailRoutingMXRecords)+1)
+
+	err = client.DeleteDNSRecord(ctx, testZoneID, records[1].ID)
+	assert.NoError(t, err)
+	settings, err := client.Raw(http.MethodGet, routingURI, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(settings), `"status":"misconfigured"`)
+
+	_, err = client.Raw(http.MethodPost, routingURI+"/disable", struct{}{})
+	assert.NoError(t, err)
+	records, err = client.DNSRecords(ctx, testZoneID, cloudflare.DNSRecord{})
+	assert.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, existing.Result.ID, records[0].ID)
+	}
+
+	_, err = client.Raw(http.MethodPost, routingURI+"/rules", map[string]interface{}{
+		"matchers": []interface{}{map[string]interface{}{"type": "literal", "field": "to", "value": "info@" + testZoneName}},
+		"actions":  []interface{}{map[string]interface{}{"type": "forward"}},
+	})
+	assert.Error(t, err, "forwarding needs destination addresses")
+	catchAll, err := client.Raw(http.MethodGet, routingURI+"/rules/catch_all", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(catchAll), `"actions":[{"type":"drop"}]`)
+
+	// Addresses are verified when they're read after being added.
+	addressesURI := "/accounts/" + testAccountID + "/email/routing/addresses"
+	address, err := client.Raw(http.MethodPost, addressesURI, map[string]interface{}{"email": "owner@example.com"})
+	assert.NoError(t, err)
+	var result struct {
+		Tag      string  `json:"tag"`
+		Verified *string `json:"verified"`
+	}
+	assert.NoError(t, json.Unmarshal(address, &result))
+	assert.Nil(t, result.Verified)
+	_, err = client.Raw(http.MethodGet, addressesURI+"/"+result.Tag, nil)
+	assert.NoError(t, err)
+	address, err = client.Raw(http.MethodGet, addressesURI+"/"+result.Tag, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(address, &result))
+	assert.NotNil(t, result.Verified)
+}
+
+func TestArgo(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+	smartTopologyURI := "/zones/" + testZoneID + "/cache/tiered_cache_smart_topology_enable"
+
+	_, err := client.Raw(http.MethodPatch, smartTopologyURI, map[string]interface{}{"value": "on"})
+	assert.Error(t, err, "smart topology requires tiered caching")
+
+	_, err = client.UpdateArgoTieredCaching(ctx, testZoneID, "on")
+	assert.NoError(t, err)
+	setting, err := client.Raw(http.MethodPatch, smartTopologyURI, map[string]interface{}{"value": "on"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(setting), `"value":"on"`)
+
+	smartRouting, err := client.ArgoSmartRouting(ctx, testZoneID)
+	assert.NoError(t, err)
+	assert.Equal(t, "off", smartRouting.Value)
+}
+
+func TestWaitingRooms(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	room, err := client.CreateWaitingRoom(ctx, testZoneID, cloudflare.WaitingRoom{
+		Name:              "launch",
+		Host:              "www." + testZoneName,
+		TotalActiveUsers:  200,
+		NewUsersPerMinute: 200,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "/", room.Path)
+	assert.Equal(t, 5, room.SessionDuration)
+
+	raw, err := client.Raw(http.MethodGet, "/zones/"+testZoneID+"/waiting_rooms/"+room.ID, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), `"queueing_method":"fifo"`)
+	assert.Contains(t, string(raw), `"cookie_attributes":{"samesite":"auto","secure":"auto"}`)
+
+	rulesURI := "/zones/" + testZoneID + "/waiting_rooms/" + room.ID + "/rules"
+	_, err = client.Raw(http.MethodPut, rulesURI, []map[string]interface{}{{"action": "block", "expression": "true"}})
+	assert.Error(t, err, "only bypass rules are supported")
+	rules, err := client.Raw(http.MethodPut, rulesURI, []map[string]interface{}{{"action": "bypass_waiting_room", "expression": "ip.src eq 192.0.2.1"}})
+	assert.NoError(t, err)
+	assert.Contains(t, string(rules), `"enabled":true`)
+
+	settingsURI := "/zones/" + testZoneID + "/waiting_rooms/settings"
+	settings, err := client.Raw(http.MethodPatch, settingsURI, map[string]interface{}{"search_engine_crawler_bypass": true})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"search_engine_crawler_bypass":true}`, string(settings))
+
+	assert.NoError(t, client.DeleteWaitingRoom(ctx, testZoneID, room.ID))
+	_, err = client.Raw(http.MethodGet, rulesURI, nil)
+	assert.Error(t, err, "rules of deleted waiting rooms are gone")
+}
+
+func TestNotificationPolicies(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	alerts, err := client.GetAvailableNotificationTypes(ctx, testAccountID)
+	assert.NoError(t, err)
+	assert.Equal(t, "billing_usage_alert", alerts.Result["Billing"][0].Type)
+
+	_, err = client.CreateNotificationPolicy(ctx, testAccountID, cloudflare.NotificationPolicy{
+		Name:      "usage",
+		AlertType: "billing_usage_alert",
+		Filters:   map[string][]string{"pool_id": {"17b5962d775c646f3f9725cbc7a53df4"}},
+	})
+	assert.Error(t, err, "billing alerts can't be filtered by pool")
+
+	created, err := client.CreateNotificationPolicy(ctx, testAccountID, cloudflare.NotificationPolicy{
+		Name:      "usage",
+		AlertType: "billing_usage_alert",
+		Filters:   map[string][]string{"product": {"worker_requests"}, "limit": {"100"}},
+		Mechanisms: map[string]cloudflare.NotificationMechanismIntegrations{
+			"email": {{ID: "test@example.com"}},
+		},
+	})
+	assert.NoError(t, err)
+
+	policy, err := client.GetNotificationPolicy(ctx, testAccountID, created.Result.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"100"}, policy.Result.Filters["limit"])
+	assert.False(t, policy.Result.Created.IsZero())
+
+	_, err = client.DeleteNotificationPolicy(ctx, testAccountID, created.Result.ID)
+	assert.NoError(t, err)
+}
+
+func TestAccountMembers(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, err := client.Raw(http.MethodPost, "/accounts/"+testAccountID+"/members", map[string]interface{}{"email": "user@example.com"})
+	assert.Error(t, err, "members need roles or policies")
+
+	member, err := client.CreateAccountMember(ctx, testAccountID, "user@example.com", []string{"05784afa30c1afe1440e79d9351c7430"})
+	assert.NoError(t, err)
+	assert.Equal(t, "pending", member.Status)
+	assert.Equal(t, "user@example.com", member.User.Email)
+
+	member, err = client.UpdateAccountMember(ctx, testAccountID, member.ID, cloudflare.AccountMember{
+		Roles: []cloudflare.AccountRole{{ID: "33666b9c79b9a5273fc7344ff42f953d"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "33666b9c79b9a5273fc7344ff42f953d", member.Roles[0].ID)
+	assert.Equal(t, "pending", member.Status)
+
+	assert.NoError(t, client.DeleteAccountMember(ctx, testAccountID, member.ID))
+	_, err = client.AccountMember(ctx, testAccountID, member.ID)
+	assert.Error(t, err)
+}
+
+func TestAPITokens(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	token := cloudflare.APIToken{
+		Name: "dns",
+		Policies: []cloudflare.APITokenPolicies{{
+			Effect:           "allow",
+			Resources:        map[string]interface{}{"com.cloudflare.api.account.zone.*": "*"},
+			PermissionGroups: []cloudflare.APITokenPermissionGroups{{ID: "82e64a83756745bbbb1c9c2701bf816b"}},
+		}},
+	}
+	created, err := client.CreateAPIToken(ctx, token)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, created.Value)
+	assert.Equal(t, "active", created.Status)
+
+	read, err := client.GetAPIToken(ctx, created.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, read.Value, "values are only returned on creation")
+
+	raw, err := client.Raw(http.MethodPost, "/accounts/"+testAccountID+"/tokens", token)
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), `"value"`)
+	tokens, err := client.APITokens(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1, "user and account tokens are separate")
+
+	value, err := client.RollAPIToken(ctx, created.ID)
+	assert.NoError(t, err)
+	assert.NotEqual(t, created.Value, value)
+
+	assert.NoError(t, client.DeleteAPIToken(ctx, created.ID))
+}
+
+func TestIPsecTunnels(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	tunnels, err := client.CreateMagicTransitIPsecTunnels(ctx, testAccountID, []cloudflare.MagicTransitIPsecTunnel{{
+		Name:               "branch",
+		CustomerEndpoint:   "203.0.113.1",
+		CloudflareEndpoint: "162.159.64.41",
+		InterfaceAddress:   "10.212.0.9/31",
+	}})
+	assert.NoError(t, err)
+	assert.Len(t, tunnels, 1)
+	tunnel := tunnels[0]
+	assert.Equal(t, "203.0.113.1", tunnel.HealthCheck.Target, "health checks target the customer endpoint")
+	assert.True(t, tunnel.HealthCheck.Enabled)
+	assert.NotEmpty(t, tunnel.RemoteIdentities.FQDNID)
+
+	psk, metadata, err := client.GenerateMagicTransitIPsecTunnelPSK(ctx, testAccountID, tunnel.ID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, psk)
+	assert.NotNil(t, metadata.LastGeneratedOn)
+
+	tunnel.Description = "Branch office"
+	tunnel.HealthCheck.Type = "request"
+	_, err = client.UpdateMagicTransitIPsecTunnel(ctx, testAccountID, tunnel.ID, tunnel)
+	assert.NoError(t, err)
+	tunnel, err = client.GetMagicTransitIPsecTunnel(ctx, testAccountID, tunnel.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Branch office", tunnel.Description)
+	assert.Equal(t, "request", tunnel.HealthCheck.Type)
+	assert.Empty(t, tunnel.Psk, "PSKs are never returned")
+
+	_, err = client.DeleteMagicTransitIPsecTunnel(ctx, testAccountID, tunnel.ID)
+	assert.NoError(t, err)
+	_, err = client.GetMagicTransitIPsecTunnel(ctx, testAccountID, tunnel.ID)
+	assert.Error(t, err)
+}
+
+func TestMagicWANSites(t *testing.T) {
+	_, client := newTestClient(t)
+	sitesURI := "/accounts/" + testAccountID + "/magic/sites"
+
+	create := func(uri, plural string, obj map[string]interface{}) (string, error) {
+		res, err := client.Raw(http.MethodPost, uri, map[string]interface{}{plural: []interface{}{obj}})
+		if err != nil {
+			return "", err
+		}
+		var created map[string][]map[string]interface{}
+		assert.NoError(t, json.Unmarshal(res, &created))
+		return created[plural][0]["id"].(string), nil
+	}
+
+	_, err := create(sitesURI, "sites", map[string]interface{}{"name": "branch", "secondary_connector_id": "c2"})
+	assert.Error(t, err, "secondary connectors need high availability mode")
+	siteID, err := create(sitesURI, "sites", map[string]interface{}{"name": "branch", "connector_id": "c1"})
+	assert.NoError(t, err)
+
+	_, err = create(sitesURI+"/"+newID()+"/lans", "lans", map[string]interface{}{"name": "office", "physport": 2})
+	assert.Error(t, err, "LANs belong to an existing site")
+	_, err = create(sitesURI+"/"+siteID+"/lans", "lans", map[string]interface{}{"name": "office"})
+	assert.Error(t, err, "LANs need a port")
+	officeID, err := create(sitesURI+"/"+siteID+"/lans", "lans", map[string]interface{}{"name": "office", "physport": 2})
+	assert.NoError(t, err)
+	labID, err := create(sitesURI+"/"+siteID+"/lans", "lans", map[string]interface{}{"name": "lab", "physport": 3, "vlan_tag": 10})
+	assert.NoError(t, err)
+
+	_, err = create(sitesURI+"/"+siteID+"/acls", "acls", map[string]interface{}{
+		"name":  "office to office",
+		"lan_1": map[string]interface{}{"lan_id": officeID},
+		"lan_2": map[string]interface{}{"lan_id": officeID},
+	})
+	assert.Error(t, err, "ACLs are between two different LANs")
+	aclID, err := create(sitesURI+"/"+siteID+"/acls", "acls", map[string]interface{}{
+		"name":  "office to lab",
+		"lan_1": map[string]interface{}{"lan_id": officeID},
+		"lan_2": map[string]interface{}{"lan_id": labID},
+	})
+	assert.NoError(t, err)
+
+	res, err := client.Raw(http.MethodGet, sitesURI+"/"+siteID+"/acls/"+aclID, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(res), `"lan_name":"lab"`)
+
+	res, err = client.Raw(http.MethodPut, sitesURI+"/"+siteID, map[string]interface{}{"name": "branch office", "ha_mode": false})
+	assert.NoError(t, err)
+	assert.Contains(t, string(res), `"modified_site"`)
+	_, err = client.Raw(http.MethodPut, sitesURI+"/"+siteID, map[string]interface{}{"name": "branch office", "ha_mode": true})
+	assert.Error(t, err, "the high availability mode can't be changed")
+
+	_, err = client.Raw(http.MethodDelete, sitesURI+"/"+siteID+"/acls/"+aclID, nil)
+	assert.NoError(t, err)
+	_, err = client.Raw(http.MethodGet, sitesURI+"/"+siteID+"/acls/"+aclID, nil)
+	assert.Error(t, err)
+}
+
+func TestSecondaryDNS(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	peer, err := client.CreateSecondaryDNSPrimary(ctx, testAccountID, cloudflare.SecondaryDNSPrimary{
+		Name: "primary",
+		IP:   "192.0.2.53",
+		Port: 53,
+	})
+	assert.NoError(t, err)
+
+	_, err = client.GetSecondaryDNSZone(ctx, testZoneID)
+	assert.Error(t, err)
+
+	zone, err := client.CreateSecondaryDNSZone(ctx, testZoneID, cloudflare.SecondaryDNSZone{
+		Name:               testZoneName,
+		AutoRefreshSeconds: 86400,
+		Primaries:          []string{peer.ID},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testZoneID, zone.ID)
+
+	assert.NoError(t, client.ForceSecondaryDNSZoneAXFR(ctx, testZoneID))
+	zone, err = client.GetSecondaryDNSZone(ctx, testZoneID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, zone.SoaSerial)
+
+	assert.NoError(t, client.DeleteSecondaryDNSZone(ctx, testZoneID))
+}
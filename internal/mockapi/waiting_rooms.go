@@ -0,0 +1,143 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// waitingRoomDefaults are the settings of waiting rooms created without them.
+var waitingRoomDefaults = object{
+	"path":                      "/",
+	"queueing_method":           "fifo",
+	"session_duration":          5,
+	"default_template_language": "en-US",
+	"cookie_attributes":         object{"samesite": "auto", "secure": "auto"},
+	"additional_routes":         []interface{}{},
+}
+
+func (s *Server) registerWaitingRoomRoutes() {
+	const waitingRoomsPattern = "/zones/{zone_id}/waiting_rooms"
+	settingsPattern := waitingRoomsPattern + "/settings"
+	rulesPattern := waitingRoomsPattern + "/{waiting_room_id}/rules"
+
+	// Settings are registered before waiting rooms so that they aren't read
+	// as a waiting room with the ID "settings".
+	settings := func(w http.ResponseWriter, p params) (object, bool) {
+		if _, ok := s.zone(p["zone_id"]); !ok {
+			writeAPIError(w, notFound("zone"))
+			return nil, false
+		}
+		c := s.collection(expandPattern(settingsPattern, p))
+		if settings, ok := c.get("settings"); ok {
+			return settings, true
+		}
+		return c.create(object{"id": "settings", "search_engine_crawler_bypass": false}), true
+	}
+
+	s.handle(http.MethodGet, settingsPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		if settings, ok := settings(w, p); ok {
+			writeResult(w, http.StatusOK, object{"search_engine_crawler_bypass": settings["search_engine_crawler_bypass"]})
+		}
+	})
+
+	updateSettings := func(merge bool) handlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, p params) {
+			existing, ok := settings(w, p)
+			if !ok {
+				return
+			}
+			req, err := decodeObject(r)
+			if err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			if merge {
+				req = mergeObjects(existing, req)
+			}
+			bypass, _ := req["search_engine_crawler_bypass"].(bool)
+			c := s.collection(expandPattern(settingsPattern, p))
+			c.replace("settings", object{"search_engine_crawler_bypass": bypass})
+			writeResult(w, http.StatusOK, object{"search_engine_crawler_bypass": bypass})
+		}
+	}
+	s.handle(http.MethodPut, settingsPattern, updateSettings(false))
+	s.handle(http.MethodPatch, settingsPattern, updateSettings(true))
+
+	// Rules are replaced as a whole and get new identifiers every time.
+	waitingRoom := func(w http.ResponseWriter, p params) bool {
+		if _, ok := s.collection(expandPattern(waitingRoomsPattern, p)).get(p["waiting_room_id"]); !ok {
+			writeAPIError(w, notFound("waiting room"))
+			return false
+		}
+		return true
+	}
+
+	s.handle(http.MethodGet, rulesPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		if waitingRoom(w, p) {
+			writeResult(w, http.StatusOK, s.collection(expandPattern(rulesPattern, p)).all())
+		}
+	})
+
+	s.handle(http.MethodPut, rulesPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		if !waitingRoom(w, p) {
+			return
+		}
+		var rules []object
+		if err := json.NewDecoder(r.Body).Decode(&rules); err != nil {
+			writeAPIError(w, badRequest(6007, "Malformed JSON in request body"))
+			return
+		}
+		for _, rule := range rules {
+			if rule["action"] != "bypass_waiting_room" {
+				writeAPIError(w, badRequest(1001, "Waiting room rules only support the bypass_waiting_room action."))
+				return
+			}
+			if expression, _ := rule["expression"].(string); expression == "" {
+				writeAPIError(w, badRequest(1001, "Waiting room rules must have an expression."))
+				return
+			}
+		}
+
+		delete(s.collections, expandPattern(rulesPattern, p))
+		c := s.collection(expandPattern(rulesPattern, p))
+		for _, rule := range rules {
+			delete(rule, "id")
+			rule["version"] = "1"
+			rule["last_updated"] = timestamp()
+			if _, ok := rule["enabled"].(bool); !ok {
+				rule["enabled"] = true
+			}
+			c.create(rule)
+		}
+		writeResult(w, http.StatusOK, c.all())
+	})
+
+	s.crud(waitingRoomsPattern, crudHooks{
+		kind: "waiting room",
+		prepare: func(p params, existing, incoming object) *apiError {
+			if _, ok := s.zone(p["zone_id"]); !ok {
+				return notFound("zone")
+			}
+			for _, field := range []string{"name", "host"} {
+				if value, _ := incoming[field].(string); value == "" {
+					return badRequest(1001, "A waiting room must have a "+field+".")
+				}
+			}
+			for k, v := range waitingRoomDefaults {
+				if incoming[k] == nil || incoming[k] == "" || incoming[k] == float64(0) {
+					incoming[k] = v
+				}
+			}
+			if attributes, ok := incoming["cookie_attributes"].(map[string]interface{}); ok {
+				incoming["cookie_attributes"] = mergeObjects(waitingRoomDefaults["cookie_attributes"].(object), attributes)
+			}
+			for _, route := range incoming["additional_routes"].([]interface{}) {
+				route, _ := route.(map[string]interface{})
+				if host, _ := route["host"].(string); host == "" {
+					return badRequest(1001, "An additional route must have a host.")
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,344 @@
+package mockapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// workerScript is an uploaded Worker script along with every part of the
+// multipart form it was uploaded with.
+type workerScript struct {
+	meta       object
+	bodyPart   string
+	mainModule bool
+	parts      map[string][]byte
+	partTypes  map[string]string
+	partOrder  []string
+	bindings   []object
+	migrations *workerMigrations
+	// classes are the Durable Object classes created by the migrations
+	// applied to the script, tagged migrationTag.
+	classes      map[string]bool
+	migrationTag string
+
+	compatibilityDate  string
+	compatibilityFlags []string
+}
+
+type workerMigrations struct {
+	OldTag string `json:"old_tag"`
+	NewTag string `json:"new_tag"`
+	Steps  []struct {
+		NewClasses     []string `json:"new_classes"`
+		RenamedClasses []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"renamed_classes"`
+		DeletedClasses []string `json:"deleted_classes"`
+	} `json:"steps"`
+}
+
+func (s *Server) registerWorkerRoutes() {
+	const scriptPattern = "/accounts/{account_id}/workers/scripts/{script_name}"
+
+	s.handle(http.MethodGet, "/accounts/{account_id}/workers/scripts", func(w http.ResponseWriter, r *http.Request, p params) {
+		out := []object{}
+		for _, name := range sortedKeys(s.scripts) {
+			out = append(out, s.scripts[name].meta)
+		}
+		writeResult(w, http.StatusOK, out)
+	})
+
+	s.handle(http.MethodPut, scriptPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		script, err := parseWorkerUpload(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		name := p["script_name"]
+		if err := applyWorkerMigrations(script, s.scripts[name]); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		now := timestamp()
+		sum := sha256.Sum256(script.parts[script.bodyPart])
+		script.meta = object{
+			"id":          name,
+			"etag":        hex.EncodeToString(sum[:]),
+			"size":        len(script.parts[script.bodyPart]),
+			"created_on":  now,
+			"modified_on": now,
+		}
+		if existing, ok := s.scripts[name]; ok {
+			script.meta["created_on"] = existing.meta["created_on"]
+		}
+		if script.migrationTag != "" {
+			script.meta["migration_tag"] = script.migrationTag
+		}
+		s.scripts[name] = script
+
+		writeResult(w, http.StatusOK, script.meta)
+	})
+
+	// Downloading a script returns the raw script content rather than the
+	// usual JSON envelope.
+	s.handle(http.MethodGet, scriptPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		script, ok := s.scripts[p["script_name"]]
+		if !ok {
+			writeAPIError(w, notFound("worker script"))
+			return
+		}
+		if !script.mainModule {
+			w.Header().Set("Content-Type", "application/javascript")
+			w.Write(script.parts[script.bodyPart]) //nolint:errcheck
+			return
+		}
+
+		// Scripts in the ES module format are downloaded as a multipart
+		// form of their modules, starting with the main module.
+		bindingParts := make(map[string]bool)
+		for _, b := range script.bindings {
+			if part, ok := b["part"].(string); ok {
+				bindingParts[part] = true
+			}
+		}
+		modules := []string{script.bodyPart}
+		for _, name := range script.partOrder {
+			if name != "metadata" && name != script.bodyPart && !bindingParts[name] {
+				modules = append(modules, name)
+			}
+		}
+
+		mw := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", mw.FormDataContentType())
+		for _, name := range modules {
+			h := make(textproto.MIMEHeader)
+			h.Set("Content-Disposition", `form-data; name="`+name+`"; filename="`+name+`"`)
+			h.Set("Content-Type", script.partTypes[name])
+			part, err := mw.CreatePart(h)
+			if err != nil {
+				return
+			}
+			part.Write(script.parts[name]) //nolint:errcheck
+		}
+		mw.Close() //nolint:errcheck
+	})
+
+	s.handle(http.MethodDelete, scriptPattern, func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := s.scripts[p["script_name"]]; !ok {
+			writeAPIError(w, notFound("worker script"))
+			return
+		}
+		delete(s.scripts, p["script_name"])
+		writeResult(w, http.StatusOK, object{"id": p["script_name"]})
+	})
+
+	s.handle(http.MethodGet, scriptPattern+"/bindings", func(w http.ResponseWriter, r *http.Request, p params) {
+		script, ok := s.scripts[p["script_name"]]
+		if !ok {
+			writeAPIError(w, notFound("worker script"))
+			return
+		}
+		writeResult(w, http.StatusOK, script.readBindings())
+	})
+
+	s.handle(http.MethodGet, scriptPattern+"/settings", func(w http.ResponseWriter, r *http.Request, p params) {
+		script, ok := s.scripts[p["script_name"]]
+		if !ok {
+			writeAPIError(w, notFound("worker script"))
+			return
+		}
+		settings := object{
+			"bindings":            script.readBindings(),
+			"compatibility_date":  script.compatibilityDate,
+			"compatibility_flags": script.compatibilityFlags,
+		}
+		if script.migrationTag != "" {
+			settings["migration_tag"] = script.migrationTag
+		}
+		writeResult(w, http.StatusOK, settings)
+	})
+
+	s.handle(http.MethodGet, scriptPattern+"/bindings/{binding_name}/content", func(w http.ResponseWriter, r *http.Request, p params) {
+		script, ok := s.scripts[p["script_name"]]
+		if !ok {
+			writeAPIError(w, notFound("worker script"))
+			return
+		}
+		for _, b := range script.bindings {
+			if b["name"] != p["binding_name"] {
+				continue
+			}
+			part, _ := b["part"].(string)
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write(script.parts[part]) //nolint:errcheck
+			return
+		}
+		writeAPIError(w, notFound("binding"))
+	})
+
+	s.crud("/zones/{zone_id}/workers/routes", crudHooks{kind: "route"})
+	s.crud("/accounts/{account_id}/storage/kv/namespaces", crudHooks{kind: "namespace"})
+}
+
+// readBindings returns the bindings of script as they are read back.
+func (script *workerScript) readBindings() []object {
+	out := make([]object, 0, len(script.bindings))
+	for _, b := range script.bindings {
+		binding := mergeObjects(b, object{})
+		delete(binding, "part")
+		// Secret values are write only.
+		if binding["type"] == "secret_text" {
+			delete(binding, "text")
+		}
+		out = append(out, binding)
+	}
+	return out
+}
+
+// parseWorkerUpload reads a Worker upload request. Scripts may be uploaded
+// either as a bare JavaScript body or as a multipart form with a `metadata`
+// part describing the bindings and which part holds the script.
+func parseWorkerUpload(r *http.Request) (*workerScript, *apiError) {
+	script := &workerScript{
+		bodyPart:           "script",
+		parts:              make(map[string][]byte),
+		partTypes:          make(map[string]string),
+		compatibilityFlags: []string{},
+	}
+
+	mediaType, mediaParams, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, badRequest(10001, "could not read request body")
+		}
+		script.parts[script.bodyPart] = body
+		return script, nil
+	}
+
+	reader := multipart.NewReader(r.Body, mediaParams["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, badRequest(10001, "malformed multipart body")
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, badRequest(10001, "malformed multipart body")
+		}
+		script.parts[part.FormName()] = content
+		script.partTypes[part.FormName()] = part.Header.Get("Content-Type")
+		script.partOrder = append(script.partOrder, part.FormName())
+	}
+
+	metadata, ok := script.parts["metadata"]
+	if !ok {
+		return nil, badRequest(10021, "missing metadata part")
+	}
+	var meta struct {
+		BodyPart   string            `json:"body_part"`
+		MainModule string            `json:"main_module"`
+		Bindings   []object          `json:"bindings"`
+		Migrations *workerMigrations `json:"migrations"`
+
+		CompatibilityDate  string   `json:"compatibility_date"`
+		CompatibilityFlags []string `json:"compatibility_flags"`
+	}
+	if err := json.Unmarshal(metadata, &meta); err != nil {
+		return nil, badRequest(10021, "malformed metadata part")
+	}
+
+	switch {
+	case meta.MainModule != "":
+		script.bodyPart = meta.MainModule
+		script.mainModule = true
+	case meta.BodyPart != "":
+		script.bodyPart = meta.BodyPart
+	}
+	if _, ok := script.parts[script.bodyPart]; !ok {
+		return nil, badRequest(10021, "script part "+script.bodyPart+" is missing")
+	}
+	script.bindings = meta.Bindings
+	for _, b := range script.bindings {
+		// Services are bound to their production environment by default.
+		if _, ok := b["environment"]; b["type"] == "service" && !ok {
+			b["environment"] = "production"
+		}
+	}
+	script.migrations = meta.Migrations
+	script.compatibilityDate = meta.CompatibilityDate
+	if meta.CompatibilityFlags != nil {
+		script.compatibilityFlags = meta.CompatibilityFlags
+	}
+
+	return script, nil
+}
+
+// applyWorkerMigrations applies the migrations of an upload on top of those
+// applied to the existing script, if any, and checks that Durable Object
+// bindings to classes of the script name classes that exist.
+func applyWorkerMigrations(script, existing *workerScript) *apiError {
+	script.classes = make(map[string]bool)
+	if existing != nil {
+		script.migrationTag = existing.migrationTag
+		for class := range existing.classes {
+			script.classes[class] = true
+		}
+	}
+
+	if m := script.migrations; m != nil {
+		if m.OldTag != script.migrationTag {
+			return badRequest(10079, "migration tag precondition failed; current tag is "+script.migrationTag)
+		}
+		for _, step := range m.Steps {
+			for _, class := range step.NewClasses {
+				if script.classes[class] {
+					return badRequest(10074, "Cannot apply new-class migration to class "+class+" that is already depended on")
+				}
+				script.classes[class] = true
+			}
+			for _, rename := range step.RenamedClasses {
+				if !script.classes[rename.From] {
+					return badRequest(10074, "Cannot apply rename migration to class "+rename.From+" that doesn't exist")
+				}
+				delete(script.classes, rename.From)
+				script.classes[rename.To] = true
+			}
+			for _, class := range step.DeletedClasses {
+				if !script.classes[class] {
+					return badRequest(10074, "Cannot apply delete migration to class "+class+" that doesn't exist")
+				}
+				delete(script.classes, class)
+			}
+		}
+		script.migrationTag = m.NewTag
+	}
+
+	for _, b := range script.bindings {
+		if b["type"] != "durable_object_namespace" {
+			continue
+		}
+		if _, ok := b["script_name"]; ok {
+			continue
+		}
+		if class, _ := b["class_name"].(string); !script.classes[class] {
+			return badRequest(10061, "Cannot create binding for class "+class+" that is not exported by the script")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package mockapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ratePlanLegacyIDs maps the subscription rate plan identifiers sent by the
+// client to the `legacy_id` the API reports on the zone.
+var ratePlanLegacyIDs = map[string]string{
+	"CF_FREE":       "free",
+	"CF_PRO":        "pro",
+	"CF_PRO_20_20":  "pro",
+	"CF_BIZ":        "business",
+	"CF_ENT":        "enterprise",
+	"PARTNERS_FREE": "partners_free",
+	"PARTNERS_PRO":  "partners_pro",
+	"PARTNERS_BIZ":  "partners_business",
+	"PARTNERS_ENT":  "partners_enterprise",
+}
+
+// AddZone seeds an active zone with the given identifier and name. It is
+// used to provide the fixed zones the acceptance tests expect to exist.
+func (s *Server) AddZone(id, name, accountID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	z := newZone(name, accountID, "full")
+	z["id"] = id
+	z["status"] = "active"
+	s.zones.create(z)
+}
+
+func newZone(name, accountID, zoneType string) object {
+	return object{
+		"name":                  strings.ToLower(strings.TrimSuffix(name, ".")),
+		"status":                "pending",
+		"paused":                false,
+		"type":                  zoneType,
+		"development_mode":      0,
+		"name_servers":          []interface{}{"ns1.mockapi.cloudflare.com", "ns2.mockapi.cloudflare.com"},
+		"original_name_servers": []interface{}{},
+		"vanity_name_servers":   []interface{}{},
+		"verification_key":      "",
+		"account":               object{"id": accountID, "name": "Mock Account"},
+		"owner":                 object{"id": accountID, "type": "organization"},
+		"permissions":           []interface{}{"#zone:read", "#zone:edit", "#dns_records:read", "#dns_records:edit"},
+		"plan":                  zonePlan("free"),
+		"plan_pending":          object{},
+		"meta": object{
+			"page_rule_quota":          3,
+			"wildcard_proxiable":       false,
+			"phishing_detected":        false,
+			"custom_certificate_quota": 0,
+		},
+	}
+}
+
+func zonePlan(legacyID string) object {
+	return object{
+		"id":                 legacyID,
+		"name":               legacyID,
+		"legacy_id":          legacyID,
+		"is_subscribed":      true,
+		"can_subscribe":      true,
+		"externally_managed": false,
+	}
+}
+
+// zone returns the zone with the given identifier, if it exists.
+func (s *Server) zone(id string) (object, bool) {
+	return s.zones.get(id)
+}
+
+func (s *Server) registerZoneRoutes() {
+	s.handle(http.MethodGet, "/zones", func(w http.ResponseWriter, r *http.Request, p params) {
+		query := r.URL.Query()
+		var out []object
+		for _, z := range s.zones.all() {
+			if name := query.Get("name"); name != "" && !strings.EqualFold(name, z["name"].(string)) {
+				continue
+			}
+			if status := query.Get("status"); status != "" && status != z["status"] {
+				continue
+			}
+			if accountID := query.Get("account.id"); accountID != "" && accountID != z["account"].(object)["id"] {
+				continue
+			}
+			out = append(out, z)
+		}
+		writeList(w, r, out)
+	})
+
+	s.handle(http.MethodPost, "/zones", func(w http.ResponseWriter, r *http.Request, p params) {
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		name, _ := req["name"].(string)
+		if name == "" {
+			writeAPIError(w, badRequest(1001, "Invalid or missing zone name."))
+			return
+		}
+		for _, z := range s.zones.all() {
+			if strings.EqualFold(z["name"].(string), name) {
+				writeAPIError(w, badRequest(1061, name+" already exists"))
+				return
+			}
+		}
+
+		var accountID string
+		if account, ok := req["account"].(map[string]interface{}); ok {
+			accountID, _ = account["id"].(string)
+		}
+		zoneType, _ := req["type"].(string)
+		if zoneType == "" {
+			zoneType = "full"
+		}
+
+		writeResult(w, http.StatusOK, s.zones.create(newZone(name, accountID, zoneType)))
+	})
+
+	s.handle(http.MethodGet, "/zones/{zone_id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		z, ok := s.zone(p["zone_id"])
+		if !ok {
+			writeAPIError(w, notFound("zone"))
+			return
+		}
+		writeResult(w, http.StatusOK, z)
+	})
+
+	s.handle(http.MethodPatch, "/zones/{zone_id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		z, ok := s.zone(p["zone_id"])
+		if !ok {
+			writeAPIError(w, notFound("zone"))
+			return
+		}
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		for _, k := range []string{"paused", "type", "vanity_name_servers"} {
+			if v, ok := req[k]; ok && v != nil {
+				z[k] = v
+			}
+		}
+		if plan, ok := req["plan"].(map[string]interface{}); ok {
+			if id, ok := plan["id"].(string); ok && id != "" {
+				z["plan"] = zonePlan(id)
+			}
+		}
+		z["modified_on"] = timestamp()
+		writeResult(w, http.StatusOK, z)
+	})
+
+	s.handle(http.MethodDelete, "/zones/{zone_id}", func(w http.ResponseWriter, r *http.Request, p params) {
+		if !s.zones.delete(p["zone_id"]) {
+			writeAPIError(w, notFound("zone"))
+			return
+		}
+		writeResult(w, http.StatusOK, object{"id": p["zone_id"]})
+	})
+
+	subscription := func(w http.ResponseWriter, r *http.Request, p params) {
+		z, ok := s.zone(p["zone_id"])
+		if !ok {
+			writeAPIError(w, notFound("zone"))
+			return
+		}
+		req, err := decodeObject(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		var planID string
+		if ratePlan, ok := req["rate_plan"].(map[string]interface{}); ok {
+			planID, _ = ratePlan["id"].(string)
+		}
+		legacyID, ok := ratePlanLegacyIDs[planID]
+		if !ok {
+			writeAPIError(w, badRequest(1004, "Invalid rate plan "+planID))
+			return
+		}
+		z["plan"] = zonePlan(legacyID)
+		writeResult(w, http.StatusOK, req)
+	}
+	s.handle(http.MethodPost, "/zones/{zone_id}/subscription", subscription)
+	s.handle(http.MethodPut, "/zones/{zone_id}/subscription", subscription)
+
+	s.handle(http.MethodPut, "/zones/{zone_id}/activation_check", func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := s.zone(p["zone_id"]); !ok {
+			writeAPIError(w, notFound("zone"))
+			return
+		}
+		writeResult(w, http.StatusOK, object{"id": p["zone_id"]})
+	})
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiRequestError is an error response of a request made with apiRequest.
+type apiRequestError struct {
+	StatusCode int
+	Errors     []cloudflare.ResponseInfo
+}
+
+func (e *apiRequestError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, info := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s (%d)", info.Message, info.Code))
+	}
+	return fmt.Sprintf("HTTP status %d: %s", e.StatusCode, strings.Join(messages, ", "))
+}
+
+// apiRequest sends a request the way cloudflare-go would, with the HTTP
+// client of the provider and the credentials of client, and returns the body
+// and headers of the response. Unlike requests made with cloudflare-go, the
+// body may have any content type and responses don't have to be JSON.
+// Requests are rate limited and retried with the settings of the provider.
+func apiRequest(ctx context.Context, meta interface{}, client *cloudflare.API, method, uri, contentType string, body []byte) ([]byte, http.Header, error) {
+	m := meta.(*providerMeta)
+
+	var resp *http.Response
+	var respBody []byte
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			delay := m.retryPolicy.MinRetryDelay * time.Duration(1<<(attempt-1))
+			if delay > m.retryPolicy.MaxRetryDelay {
+				delay = m.retryPolicy.MaxRetryDelay
+			}
+			tflog.Debug(ctx, fmt.Sprintf("Sleeping %s before retry attempt number %d for request %s %s", delay, attempt, method, uri))
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, nil, fmt.Errorf("operation aborted during backoff: %w", ctx.Err())
+			}
+		}
+
+		if err := m.requestLimiter.Wait(ctx); err != nil {
+			return nil, nil, fmt.Errorf("error caused by request rate limiting: %w", err)
+		}
+
+		var err error
+		resp, respBody, err = doAPIRequest(ctx, m.httpClient, client, method, uri, contentType, body)
+
+		// Requests are retried when the API is rate limiting them or fails,
+		// assuming that failed operations are rolled back.
+		retry := err != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		if !retry || attempt >= m.retryPolicy.MaxRetries {
+			if err != nil {
+				return nil, nil, err
+			}
+			break
+		}
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &apiRequestError{StatusCode: resp.StatusCode}
+		var errResp cloudflare.Response
+		if json.Unmarshal(respBody, &errResp) == nil {
+			apiErr.Errors = errResp.Errors
+		}
+		return nil, nil, apiErr
+	}
+
+	return respBody, resp.Header, nil
+}
+
+func doAPIRequest(ctx context.Context, httpClient *http.Client, client *cloudflare.API, method, uri, contentType string, body []byte) (*http.Response, []byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, client.BaseURL+uri, reqBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("HTTP request creation failed: %w", err)
+	}
+
+	if client.APIToken != "" {
+		req.Header.Set("Authorization", "Bearer "+client.APIToken)
+	} else {
+		req.Header.Set("X-Auth-Key", client.APIKey)
+		req.Header.Set("X-Auth-Email", client.APIEmail)
+	}
+	if client.UserAgent != "" {
+		req.Header.Set("User-Agent", client.UserAgent)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read response body: %w", err)
+	}
+
+	return resp, respBody, nil
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRequestRetries(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case r.URL.Path == "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"success":false,"errors":[{"code":7003,"message":"Could not route"}]}`)
+		case calls == 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case calls == 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			assert.Equal(t, "Bearer api-token", r.Header.Get("Authorization"))
+			fmt.Fprint(w, `{"success":true,"result":{}}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := cloudflare.NewWithAPIToken("api-token", cloudflare.BaseURL(server.URL))
+	require.NoError(t, err)
+	retryPolicy := cloudflare.RetryPolicy{MaxRetries: 2, MinRetryDelay: time.Millisecond, MaxRetryDelay: time.Millisecond}
+	m := newProviderMeta("default", nil, server.Client(), 1000, retryPolicy)
+	ctx := context.Background()
+
+	// Rate limited and failed requests are retried.
+	body, _, err := apiRequest(ctx, m, client, http.MethodGet, "/ok", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"success":true,"result":{}}`, string(body))
+	assert.Equal(t, 3, calls)
+
+	// Other errors aren't.
+	calls = 0
+	_, _, err = apiRequest(ctx, m, client, http.MethodGet, "/missing", "", nil)
+	assert.EqualError(t, err, "HTTP status 404: Could not route (7003)")
+	assert.Equal(t, 1, calls)
+
+	// Retries stop once the policy is exhausted.
+	m.retryPolicy.MaxRetries = 1
+	calls = 0
+	_, _, err = apiRequest(ctx, m, client, http.MethodGet, "/ok", "", nil)
+	assert.EqualError(t, err, "HTTP status 502: ")
+	assert.Equal(t, 2, calls)
+}
@@ -9,13 +9,22 @@ import (
 )
 
 func TestMain(m *testing.M) {
+	if os.Getenv("CLOUDFLARE_MOCK_API") != "" {
+		testAccStartMockAPI()
+	}
+
 	resource.TestMain(m)
 }
 
 // sharedClient returns a common Cloudflare client setup needed for the
 // sweeper functions.
 func sharedClient() (*cloudflare.API, error) {
-	client, err := cloudflare.New(os.Getenv("CLOUDFLARE_API_KEY"), os.Getenv("CLOUDFLARE_EMAIL"))
+	var options []cloudflare.Option
+	if testAccMockAPI != nil {
+		options = append(options, cloudflare.BaseURL(testAccMockAPI.BaseURL()))
+	}
+
+	client, err := cloudflare.New(os.Getenv("CLOUDFLARE_API_KEY"), os.Getenv("CLOUDFLARE_EMAIL"), options...)
 
 	if err != nil {
 		return client, err
@@ -3,9 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
 )
 
 type Config struct {
@@ -13,7 +18,13 @@ type Config struct {
 	APIKey            string
 	APIUserServiceKey string
 	APIToken          string
-	Options           []cloudflare.Option
+	// AccountID is the account of clients of this configuration, used by
+	// resources that don't set their own.
+	AccountID string
+	// AccountIDs are the accounts that resources are managed with this
+	// configuration for, unless they select another profile.
+	AccountIDs []string
+	Options    []cloudflare.Option
 }
 
 // Client returns a new client for accessing cloudflare.
@@ -38,3 +49,185 @@ func (c *Config) Client() (*cloudflare.API, error) {
 	tflog.Info(ctx, fmt.Sprintf("cloudflare Client configured for user: %s", c.Email))
 	return client, nil
 }
+
+// defaultAccountID returns the account of clients of c: its AccountID, or
+// else the first of its AccountIDs.
+func (c *Config) defaultAccountID() string {
+	if c.AccountID != "" {
+		return c.AccountID
+	}
+	if len(c.AccountIDs) > 0 {
+		return c.AccountIDs[0]
+	}
+	return ""
+}
+
+// hasCredentials reports whether c holds either an API token or an API key
+// along with its email.
+func (c *Config) hasCredentials() bool {
+	return c.APIToken != "" || (c.APIKey != "" && c.Email != "")
+}
+
+// providerMeta is the meta value of the provider. It holds the configuration
+// of every credential profile and lazily creates a client for each of them
+// the first time it is used, so that configuring many profiles doesn't slow
+// down runs that only use a few.
+type providerMeta struct {
+	defaultProfile string
+	profiles       map[string]*Config
+
+	// httpClient is the client every API client is configured with, for the
+	// few requests cloudflare-go can't make itself.
+	httpClient *http.Client
+	// requestLimiter and retryPolicy apply the rate limit and retry policy of
+	// cloudflare-go clients to those requests.
+	requestLimiter *rate.Limiter
+	retryPolicy    cloudflare.RetryPolicy
+
+	mu      sync.Mutex
+	clients map[string]*cloudflare.API
+
+	zonesMu sync.Mutex
+	zones   map[zoneNameKey]*zoneLookup
+}
+
+// zoneNameKey identifies a zone name looked up with a client, as each
+// profile may have access to different zones.
+type zoneNameKey struct {
+	client *cloudflare.API
+	name   string
+}
+
+// zoneLookup holds the identifier of a zone name once it has been looked up.
+// Its lock is held during the lookup, so that resources in the same zone
+// being planned in parallel wait for it rather than each looking it up, while
+// other zones are looked up at the same time.
+type zoneLookup struct {
+	mu     sync.Mutex
+	zoneID string
+}
+
+func newProviderMeta(defaultProfile string, profiles map[string]*Config, httpClient *http.Client, rps float64, retryPolicy cloudflare.RetryPolicy) *providerMeta {
+	return &providerMeta{
+		defaultProfile: defaultProfile,
+		profiles:       profiles,
+		httpClient:     httpClient,
+		requestLimiter: rate.NewLimiter(rate.Limit(rps), 1),
+		retryPolicy:    retryPolicy,
+		clients:        make(map[string]*cloudflare.API),
+		zones:          make(map[zoneNameKey]*zoneLookup),
+	}
+}
+
+// client returns the client of the named profile, creating it if needed.
+func (m *providerMeta) client(profile string) (*cloudflare.API, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if client, ok := m.clients[profile]; ok {
+		return client, nil
+	}
+
+	config, ok := m.profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("credential profile %q is not configured, available profiles are: %s", profile, strings.Join(m.profileNames(), ", "))
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		return nil, fmt.Errorf("error creating client for credential profile %q: %w", profile, err)
+	}
+	m.clients[profile] = client
+
+	return client, nil
+}
+
+// zoneIDByName returns the identifier of the zone with the given name. Results
+// are cached for the lifetime of the provider so that resolving the zone of
+// many resources only looks it up once.
+func (m *providerMeta) zoneIDByName(client *cloudflare.API, name string) (string, error) {
+	key := zoneNameKey{client: client, name: strings.ToLower(strings.TrimSuffix(name, "."))}
+
+	m.zonesMu.Lock()
+	lookup, ok := m.zones[key]
+	if !ok {
+		lookup = &zoneLookup{}
+		m.zones[key] = lookup
+	}
+	m.zonesMu.Unlock()
+
+	lookup.mu.Lock()
+	defer lookup.mu.Unlock()
+
+	if lookup.zoneID != "" {
+		return lookup.zoneID, nil
+	}
+
+	zoneID, err := client.ZoneIDByName(key.name)
+	if err != nil {
+		return "", err
+	}
+	lookup.zoneID = zoneID
+
+	return zoneID, nil
+}
+
+// defaultClient returns the client of the default profile, which is created
+// when the provider is configured.
+func (m *providerMeta) defaultClient() *cloudflare.API {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.clients[m.defaultProfile]
+}
+
+// profileForAccount returns the name of the profile configured for
+// accountID, or the default profile if there isn't one.
+func (m *providerMeta) profileForAccount(accountID string) string {
+	if accountID == "" {
+		return m.defaultProfile
+	}
+
+	if config, ok := m.profiles[m.defaultProfile]; ok && contains(config.AccountIDs, accountID) {
+		return m.defaultProfile
+	}
+	for _, name := range m.profileNames() {
+		if contains(m.profiles[name].AccountIDs, accountID) {
+			return name
+		}
+	}
+
+	return m.defaultProfile
+}
+
+func (m *providerMeta) profileNames() []string {
+	names := make([]string, 0, len(m.profiles))
+	for name := range m.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resourceAttributeGetter is implemented by both schema.ResourceData and
+// schema.ResourceDiff.
+type resourceAttributeGetter interface {
+	GetOk(key string) (interface{}, bool)
+}
+
+// apiClient returns the client to manage a resource with. This is the client
+// of the profile selected by the `profile` attribute, falling back to the
+// profile configured for the `account_id` of the resource and then to the
+// default profile.
+func apiClient(meta interface{}, d resourceAttributeGetter) (*cloudflare.API, error) {
+	m := meta.(*providerMeta)
+
+	if profile, ok := d.GetOk("profile"); ok {
+		return m.client(profile.(string))
+	}
+
+	accountID, _ := d.GetOk("account_id")
+	accountIDStr, _ := accountID.(string)
+
+	return m.client(m.profileForAccount(accountIDStr))
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultCredentialsFile is where credential profiles are loaded from when
+// `credentials_file` isn't set. A missing file at this location is ignored.
+const defaultCredentialsFile = "~/.cloudflare/credentials"
+
+// parseCredentialsFile parses credential profiles from an INI style file
+// where each section is a profile:
+//
+//	[default]
+//	api_token = ...
+//
+//	[acme]
+//	api_key     = ...
+//	email       = ...
+//	account_id  = 023e105f4ecef8ad9ca31a8372d0c353
+//	account_ids = 023e105f4ecef8ad9ca31a8372d0c353, 372e67954025e0ba6aaa6d586b9e0b59
+func parseCredentialsFile(content string) (map[string]*Config, error) {
+	profiles := make(map[string]*Config)
+	var current *Config
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			if !strings.HasSuffix(text, "]") {
+				return nil, fmt.Errorf("line %d: invalid profile header %q", line, text)
+			}
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: profile name must not be empty", line)
+			}
+			if _, ok := profiles[name]; ok {
+				return nil, fmt.Errorf("line %d: duplicate profile %q", line, name)
+			}
+			current = &Config{}
+			profiles[name] = current
+			continue
+		}
+
+		separator := strings.Index(text, "=")
+		if separator == -1 {
+			return nil, fmt.Errorf("line %d: expected key = value", line)
+		}
+		key, value := text[:separator], text[separator+1:]
+		if current == nil {
+			return nil, fmt.Errorf("line %d: %q is set outside of a profile", line, strings.TrimSpace(key))
+		}
+
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		switch key = strings.TrimSpace(key); key {
+		case "api_token":
+			current.APIToken = value
+		case "api_key":
+			current.APIKey = value
+		case "email":
+			current.Email = value
+		case "api_user_service_key":
+			current.APIUserServiceKey = value
+		case "account_id":
+			current.AccountID = value
+		case "account_ids":
+			for _, id := range strings.Split(value, ",") {
+				if id = strings.TrimSpace(id); id != "" {
+					current.AccountIDs = append(current.AccountIDs, id)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("line %d: unknown setting %q", line, key)
+		}
+	}
+
+	return profiles, scanner.Err()
+}
+
+// loadCredentialsFile reads the credential profiles from path. Unless
+// required, a missing file results in no profiles rather than an error.
+func loadCredentialsFile(path string, required bool) (map[string]*Config, error) {
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			if required {
+				return nil, fmt.Errorf("error finding home directory for credentials file %q: %w", path, err)
+			}
+			return nil, nil
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading credentials file %q: %w", path, err)
+	}
+
+	profiles, err := parseCredentialsFile(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing credentials file %q: %w", path, err)
+	}
+
+	return profiles, nil
+}
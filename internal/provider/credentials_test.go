@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCredentialsFile(t *testing.T) {
+	profiles, err := parseCredentialsFile(`
+# Shared credentials
+[default]
+api_token = "0123456789abcdefghijklmnopqrstuvwxyzABCD"
+
+[acme]
+api_key     = 0123456789abcdef0123456789abcdef01234
+email       = ops@example.com
+account_id  = 372e67954025e0ba6aaa6d586b9e0b59
+account_ids = f037e56e89293a057740de681ac9abbe, 372e67954025e0ba6aaa6d586b9e0b59
+`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*Config{
+		"default": {APIToken: "0123456789abcdefghijklmnopqrstuvwxyzABCD"},
+		"acme": {
+			APIKey:     "0123456789abcdef0123456789abcdef01234",
+			Email:      "ops@example.com",
+			AccountID:  "372e67954025e0ba6aaa6d586b9e0b59",
+			AccountIDs: []string{"f037e56e89293a057740de681ac9abbe", "372e67954025e0ba6aaa6d586b9e0b59"},
+		},
+	}, profiles)
+
+	errors := map[string]string{
+		"api_token = x":     `line 1: "api_token" is set outside of a profile`,
+		"[a]\napi_token":    "line 2: expected key = value",
+		"[a]\nregion = eu":  `line 2: unknown setting "region"`,
+		"[a]\n[a]":          `line 2: duplicate profile "a"`,
+		"[]":                "line 1: profile name must not be empty",
+		"[a\napi_token = x": `line 1: invalid profile header "[a"`,
+	}
+	for content, expected := range errors {
+		_, err := parseCredentialsFile(content)
+		assert.EqualError(t, err, expected, content)
+	}
+}
+
+func TestLoadCredentialsFile(t *testing.T) {
+	profiles, err := loadCredentialsFile(filepath.Join(t.TempDir(), "missing"), false)
+	assert.NoError(t, err)
+	assert.Empty(t, profiles)
+
+	_, err = loadCredentialsFile(filepath.Join(t.TempDir(), "missing"), true)
+	assert.Error(t, err)
+}
+
+func TestProviderCredentialProfiles(t *testing.T) {
+	for _, env := range []string{"CLOUDFLARE_API_TOKEN", "CLOUDFLARE_API_KEY", "CLOUDFLARE_EMAIL", "CLOUDFLARE_ACCOUNT_ID", "CLOUDFLARE_PROFILE", "CLOUDFLARE_REQUEST_RECORDING_PATH"} {
+		t.Setenv(env, "")
+	}
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(path, []byte("[default]\napi_token = default-token\n\n[acme]\napi_token = file-token\n"), 0o600))
+
+	p := New("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"credentials_file": path,
+		"profiles": []interface{}{
+			map[string]interface{}{
+				"name":        "acme",
+				"api_token":   "acme-token",
+				"account_ids": []interface{}{"f037e56e89293a057740de681ac9abbe"},
+			},
+			map[string]interface{}{
+				"name":       "beta",
+				"api_token":  "beta-token",
+				"account_id": "023e105f4ecef8ad9ca31a8372d0c353",
+			},
+		},
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+
+	m := p.Meta().(*providerMeta)
+	assert.Equal(t, "default-token", m.defaultClient().APIToken)
+	assert.Len(t, m.clients, 1, "only the default client is created upfront")
+
+	clientFor := func(r *schema.Resource, raw map[string]interface{}) string {
+		client, err := apiClient(m, schema.TestResourceDataRaw(t, r.Schema, raw))
+		require.NoError(t, err)
+		return client.APIToken
+	}
+
+	record := p.ResourcesMap["cloudflare_record"]
+	assert.Equal(t, "default-token", clientFor(record, map[string]interface{}{"zone_id": "z"}))
+	assert.Equal(t, "acme-token", clientFor(record, map[string]interface{}{"zone_id": "z", "profile": "acme"}))
+
+	account := p.ResourcesMap["cloudflare_access_group"]
+	assert.Equal(t, "acme-token", clientFor(account, map[string]interface{}{"account_id": "f037e56e89293a057740de681ac9abbe"}))
+	assert.Equal(t, "default-token", clientFor(account, map[string]interface{}{"account_id": "372e67954025e0ba6aaa6d586b9e0b59"}))
+	assert.Equal(t, "default-token", clientFor(account, map[string]interface{}{"account_id": "f037e56e89293a057740de681ac9abbe", "profile": "default"}))
+
+	_, err := apiClient(m, schema.TestResourceDataRaw(t, record.Schema, map[string]interface{}{"profile": "missing"}))
+	assert.EqualError(t, err, `credential profile "missing" is not configured, available profiles are: acme, beta, default`)
+
+	// Clients use the account of their profile for resources without one.
+	accountFor := func(profile string) string {
+		client, err := m.client(profile)
+		require.NoError(t, err)
+		return client.AccountID
+	}
+	assert.Equal(t, "", accountFor("default"))
+	assert.Equal(t, "f037e56e89293a057740de681ac9abbe", accountFor("acme"))
+	assert.Equal(t, "023e105f4ecef8ad9ca31a8372d0c353", accountFor("beta"))
+}
+
+func TestProviderDefaultCredentialsFile(t *testing.T) {
+	for _, env := range []string{"CLOUDFLARE_API_KEY", "CLOUDFLARE_EMAIL", "CLOUDFLARE_ACCOUNT_ID", "CLOUDFLARE_PROFILE", "CLOUDFLARE_CREDENTIALS_FILE", "CLOUDFLARE_REQUEST_RECORDING_PATH"} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("CLOUDFLARE_API_TOKEN", "0123456789abcdefghijklmnopqrstuvwxyzABCD")
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".cloudflare", "credentials")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte("region = eu\n"), 0o600))
+
+	// A malformed default file is ignored as it may not be meant for the
+	// provider, unlike one it's configured with.
+	p := New("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	assert.False(t, diags.HasError(), "%v", diags)
+
+	p = New("dev")()
+	diags = p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{"credentials_file": path}))
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "error parsing credentials file")
+}
@@ -17,7 +17,10 @@ func dataSourceCloudflareAccessIdentityProvider() *schema.Resource {
 }
 
 func dataSourceCloudflareAccessIdentityProviderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	identifier, err := initIdentifier(d)
 	name := d.Get("name").(string)
 	if err != nil {
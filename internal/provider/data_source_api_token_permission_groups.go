@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cloudflare/cloudflare-go"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -25,7 +24,10 @@ func dataSourceCloudflareApiTokenPermissionGroups() *schema.Resource {
 
 func dataSourceCloudflareApiTokenPermissionGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	tflog.Debug(ctx, fmt.Sprintf("Reading API Token Permission Groups"))
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	permissions, err := client.ListAPITokensPermissionGroups(ctx)
 	if err != nil {
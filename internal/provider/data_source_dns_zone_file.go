@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudflareDNSZoneFile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCloudflareDNSZoneFileRead,
+		Description: "Use this data source to parse an RFC 1035 zone file into a list of records that can be used to manage them with `cloudflare_record`.",
+
+		Schema: map[string]*schema.Schema{
+			"content": {
+				Description: "The content of the zone file.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"origin": {
+				Description: "The origin that relative names are resolved against until a `$ORIGIN` directive is encountered. Also used to identify the zone apex when the zone file has no `$ORIGIN` directive.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"default_ttl": {
+				Description: "The TTL to use for records without one when no `$TTL` directive applies. Defaults to `1`, which Cloudflare treats as automatic.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+			},
+			"records": {
+				Description: "The records in the zone file. SOA records and NS records at the zone apex are omitted as Cloudflare manages them.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Description: "The value of the record, for record types that are configured with `value` in `cloudflare_record`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"data": {
+							Description: "The structured value of the record, for record types that are configured with `data` in `cloudflare_record`.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: dataSourceCloudflareDNSZoneFileRecordDataSchema(),
+							},
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"proxied": {
+							Description: "Whether the record is tagged as proxied in the zone file, as done by zone files exported from Cloudflare.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceCloudflareDNSZoneFileRecordDataSchema returns the `data` block of
+// `cloudflare_record` with every attribute computed so the two always share
+// the same shape.
+func dataSourceCloudflareDNSZoneFileRecordDataSchema() map[string]*schema.Schema {
+	recordData := resourceCloudflareRecordSchema()["data"].Elem.(*schema.Resource).Schema
+
+	s := make(map[string]*schema.Schema, len(recordData))
+	for k, v := range recordData {
+		s[k] = &schema.Schema{
+			Type:     v.Type,
+			Computed: true,
+		}
+	}
+
+	return s
+}
+
+func dataSourceCloudflareDNSZoneFileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	content := d.Get("content").(string)
+
+	records, err := parseZoneFile(content, d.Get("origin").(string), d.Get("default_ttl").(int))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error parsing zone file: %w", err))
+	}
+
+	out := make([]map[string]interface{}, 0, len(records))
+	for _, r := range records {
+		record := map[string]interface{}{
+			"name":    r.Name,
+			"type":    r.Type,
+			"value":   r.Value,
+			"ttl":     r.TTL,
+			"proxied": r.Proxied,
+			"data":    []interface{}{},
+		}
+		if r.Priority != nil {
+			record["priority"] = *r.Priority
+		}
+		if r.Data != nil {
+			record["data"] = []interface{}{r.Data}
+		}
+		out = append(out, record)
+	}
+
+	if err := d.Set("records", out); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting records: %w", err))
+	}
+
+	d.SetId(stringChecksum(content))
+
+	return nil
+}
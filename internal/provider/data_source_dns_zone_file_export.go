@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudflareDNSZoneFileExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCloudflareDNSZoneFileExportRead,
+		Description: "Use this data source to render the current DNS records of a zone as an RFC 1035 zone file.",
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Description: "The zone identifier to target for the resource.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"content": {
+				Description: "The DNS records of the zone in zone file format. Proxied records are tagged with a `cf_tags=cf-proxied:true` comment.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"record_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCloudflareDNSZoneFileExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	zone, err := client.ZoneDetails(ctx, zoneID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error finding zone %q: %w", zoneID, err))
+	}
+
+	records, err := client.DNSRecords(ctx, zoneID, cloudflare.DNSRecord{})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing DNS records for zone %q: %w", zoneID, err))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Exporting %d DNS records for zone %s", len(records), zone.Name))
+
+	content := renderZoneFile(zone.Name, records)
+
+	d.SetId(zoneID)
+	d.Set("content", content)
+	d.Set("record_count", len(records))
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCloudflareDNSZoneFileExport(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("data.cloudflare_dns_zone_file_export.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareDNSZoneFileExportConfig(zoneID, rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "zone_id", zoneID),
+					resource.TestMatchResourceAttr(name, "content", regexp.MustCompile(fmt.Sprintf(`(?m)^\$ORIGIN %s\.$`, regexp.QuoteMeta(domain)))),
+					resource.TestMatchResourceAttr(name, "content", regexp.MustCompile(fmt.Sprintf(`(?m)^%s\.%s\.\t300\tIN\tA\t192\.0\.2\.1$`, rnd, regexp.QuoteMeta(domain)))),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloudflareDNSZoneFileExportConfig(zoneID, name string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_record" "%[2]s" {
+  zone_id = "%[1]s"
+  name    = "%[2]s"
+  value   = "192.0.2.1"
+  type    = "A"
+  ttl     = 300
+}
+
+data "cloudflare_dns_zone_file_export" "%[2]s" {
+  zone_id    = "%[1]s"
+  depends_on = [cloudflare_record.%[2]s]
+}`, zoneID, name)
+}
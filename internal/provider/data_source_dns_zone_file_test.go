@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCloudflareDNSZoneFile(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("data.cloudflare_dns_zone_file.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareDNSZoneFileConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "id"),
+					resource.TestCheckResourceAttr(name, "records.#", "3"),
+					resource.TestCheckResourceAttr(name, "records.0.name", "www.example.com"),
+					resource.TestCheckResourceAttr(name, "records.0.type", "A"),
+					resource.TestCheckResourceAttr(name, "records.0.value", "192.0.2.1"),
+					resource.TestCheckResourceAttr(name, "records.0.ttl", "3600"),
+					resource.TestCheckResourceAttr(name, "records.0.proxied", "true"),
+					resource.TestCheckResourceAttr(name, "records.1.type", "TXT"),
+					resource.TestCheckResourceAttr(name, "records.1.value", "hello world"),
+					resource.TestCheckResourceAttr(name, "records.1.ttl", "1"),
+					resource.TestCheckResourceAttr(name, "records.2.data.0.service", "_sip"),
+					resource.TestCheckResourceAttr(name, "records.2.data.0.port", "5060"),
+					resource.TestCheckResourceAttr(name, "records.2.data.0.target", "sip.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloudflareDNSZoneFileConfig(name string) string {
+	return fmt.Sprintf(`
+data "cloudflare_dns_zone_file" "%[1]s" {
+  origin  = "example.com"
+  content = <<-EOT
+    www       3600 IN A 192.0.2.1 ; cf_tags=cf-proxied:true
+    txt       IN TXT "hello" " world"
+    _sip._tcp IN SRV 10 60 5060 sip
+  EOT
+}`, name)
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// cloudflare-go doesn't expose the filter options of the available alerts,
+// so they're read with a raw request.
+type notificationAvailableAlert struct {
+	cloudflare.NotificationAlertWithDescription
+	FilterOptions []notificationAvailableAlertFilterOption `json:"filter_options"`
+}
+
+type notificationAvailableAlertFilterOption struct {
+	Key      string `json:"key"`
+	Optional bool   `json:"optional"`
+}
+
+func dataSourceCloudflareNotificationPolicyAlertTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCloudflareNotificationPolicyAlertTypesRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Description: "The account identifier to target for the resource.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+
+			"alert_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"product": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"filters": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudflareNotificationPolicyAlertTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading available notification alert types of account %s", accountID))
+	alerts, err := notificationAvailableAlertsOf(client, accountID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing available notification alert types: %w", err))
+	}
+
+	products := make([]string, 0, len(alerts))
+	for product := range alerts {
+		products = append(products, product)
+	}
+	sort.Strings(products)
+
+	alertTypes := make([]interface{}, 0)
+	alertTypeIDs := make([]string, 0)
+	for _, product := range products {
+		for _, alert := range alerts[product] {
+			filters := make([]string, 0, len(alert.FilterOptions))
+			for _, option := range alert.FilterOptions {
+				filters = append(filters, option.Key)
+			}
+			alertTypes = append(alertTypes, map[string]interface{}{
+				"type":         alert.Type,
+				"product":      product,
+				"display_name": alert.DisplayName,
+				"description":  alert.Description,
+				"filters":      filters,
+			})
+			alertTypeIDs = append(alertTypeIDs, alert.Type)
+		}
+	}
+
+	if err := d.Set("alert_types", alertTypes); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting alert types: %w", err))
+	}
+
+	d.SetId(stringListChecksum(alertTypeIDs))
+	return nil
+}
+
+func notificationAvailableAlertsOf(client *cloudflare.API, accountID string) (map[string][]notificationAvailableAlert, error) {
+	var alerts map[string][]notificationAvailableAlert
+	result, err := client.Raw(http.MethodGet, fmt.Sprintf("/accounts/%s/alerting/v3/available_alerts", accountID), nil)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(result, &alerts)
+	return alerts, err
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareNotificationPolicyAlertTypes(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("data.cloudflare_notification_policy_alert_types.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareNotificationPolicyAlertTypesConfig(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "id"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "alert_types.*", map[string]string{
+						"type":      "billing_usage_alert",
+						"product":   "Billing",
+						"filters.#": "2",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloudflareNotificationPolicyAlertTypesConfig(name, accountID string) string {
+	return fmt.Sprintf(`data "cloudflare_notification_policy_alert_types" "%[1]s" {
+		account_id = "%[2]s"
+	}`, name, accountID)
+}
+
+func TestNotificationPolicyAlertTypesRead(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+
+	dataSource := p.DataSourcesMap["cloudflare_notification_policy_alert_types"]
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{"account_id": testAccCloudflareAccountID})
+	diags := dataSource.ReadContext(context.Background(), d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.NotEmpty(t, d.Id())
+
+	// Alert types are sorted by product.
+	alertTypes := d.Get("alert_types").([]interface{})
+	require.NotEmpty(t, alertTypes)
+	billing := alertTypes[0].(map[string]interface{})
+	assert.Equal(t, "billing_usage_alert", billing["type"])
+	assert.Equal(t, "Billing", billing["product"])
+	assert.Equal(t, []interface{}{"product", "limit"}, billing["filters"])
+
+	// The filters of every alert type are known to the provider.
+	for _, alertType := range alertTypes {
+		alertType := alertType.(map[string]interface{})
+		allowed, ok := notificationPolicyAlertTypeFilters[alertType["type"].(string)]
+		if assert.True(t, ok, alertType["type"]) {
+			assert.ElementsMatch(t, allowed, alertType["filters"], alertType["type"])
+		}
+	}
+}
@@ -88,7 +88,10 @@ func dataSourceCloudflareWAFGroups() *schema.Resource {
 }
 
 func dataSourceCloudflareWAFGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	// Prepare the filters to be applied to the search
@@ -76,7 +76,10 @@ func dataSourceCloudflareZones() *schema.Resource {
 
 func dataSourceCloudflareZonesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	tflog.Debug(ctx, fmt.Sprintf("Reading Zones"))
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	filter, err := expandFilter(d.Get("filter"))
 	if err != nil {
 		return diag.FromErr(err)
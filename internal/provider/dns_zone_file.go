@@ -0,0 +1,727 @@
+package provider
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// zoneFileProxiedTag is the comment Cloudflare adds to records in exported
+// zone files to indicate that they are proxied.
+const zoneFileProxiedTag = "cf_tags=cf-proxied:true"
+
+// zoneFileRecord is a single resource record parsed from an RFC 1035 master
+// file, normalised into the same `value`/`data` shape that `cloudflare_record`
+// accepts.
+type zoneFileRecord struct {
+	Name     string
+	Type     string
+	TTL      int
+	Value    string
+	Priority *int
+	Proxied  bool
+	Data     map[string]interface{}
+}
+
+type zoneFileToken struct {
+	value  string
+	quoted bool
+}
+
+// zoneFileLine is a logical line of a master file. Parentheses allow a
+// logical line to span several physical lines; number is the physical line
+// the logical one starts on.
+type zoneFileLine struct {
+	number     int
+	blankOwner bool
+	tokens     []zoneFileToken
+	comment    string
+}
+
+var zoneFileTTLRegexp = regexp.MustCompile(`^([0-9]+[smhdwSMHDW]?)+$`)
+
+var zoneFileTTLUnits = map[byte]int{
+	's': 1,
+	'm': 60,
+	'h': 60 * 60,
+	'd': 24 * 60 * 60,
+	'w': 7 * 24 * 60 * 60,
+}
+
+var zoneFileCertTypes = map[string]int{
+	"PKIX":    1,
+	"SPKI":    2,
+	"PGP":     3,
+	"IPKIX":   4,
+	"ISPKI":   5,
+	"IPGP":    6,
+	"ACPKIX":  7,
+	"IACPKIX": 8,
+	"URI":     253,
+	"OID":     254,
+}
+
+// parseZoneFile parses the content of an RFC 1035 master file. origin is used
+// for relative names until a `$ORIGIN` directive is seen and defaultTTL for
+// records without a TTL when no `$TTL` directive or earlier TTL applies.
+//
+// SOA records and NS records at the zone apex are skipped as Cloudflare
+// manages both itself.
+func parseZoneFile(content, origin string, defaultTTL int) ([]zoneFileRecord, error) {
+	lines, err := splitZoneFileLines(content)
+	if err != nil {
+		return nil, err
+	}
+
+	origin = strings.ToLower(strings.TrimSuffix(origin, "."))
+	apex := origin
+	dollarTTL, lastTTL := -1, -1
+	lastOwner := ""
+	records := []zoneFileRecord{}
+
+	for _, line := range lines {
+		tokens := line.tokens
+		first := tokens[0]
+
+		if !first.quoted && strings.HasPrefix(first.value, "$") {
+			switch directive := strings.ToUpper(first.value); directive {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN expects exactly one domain name", line.number)
+				}
+				origin, err = qualifyZoneFileName(tokens[1].value, origin)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line.number, err)
+				}
+				if apex == "" {
+					apex = origin
+				}
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $TTL expects exactly one TTL", line.number)
+				}
+				dollarTTL, err = parseZoneFileTTL(tokens[1].value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line.number, err)
+				}
+			default:
+				return nil, fmt.Errorf("line %d: %s directives are not supported", line.number, directive)
+			}
+			continue
+		}
+
+		var owner string
+		if line.blankOwner {
+			if lastOwner == "" {
+				return nil, fmt.Errorf("line %d: record has no owner name and there is no previous record to inherit it from", line.number)
+			}
+			owner = lastOwner
+		} else {
+			owner, err = qualifyZoneFileName(first.value, origin)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line.number, err)
+			}
+			tokens = tokens[1:]
+		}
+		lastOwner = owner
+
+		ttl := -1
+		for len(tokens) > 0 && !tokens[0].quoted {
+			value := tokens[0].value
+			if ttl == -1 && zoneFileTTLRegexp.MatchString(value) {
+				if ttl, err = parseZoneFileTTL(value); err != nil {
+					return nil, fmt.Errorf("line %d: %w", line.number, err)
+				}
+			} else if class := strings.ToUpper(value); class == "IN" || class == "CH" || class == "HS" || class == "CS" {
+				if class != "IN" {
+					return nil, fmt.Errorf("line %d: only the IN class is supported, got %s", line.number, class)
+				}
+			} else {
+				break
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: missing record type", line.number)
+		}
+
+		switch {
+		case ttl != -1:
+			lastTTL = ttl
+		case dollarTTL != -1:
+			ttl = dollarTTL
+		case lastTTL != -1:
+			ttl = lastTTL
+		default:
+			ttl = defaultTTL
+		}
+
+		recordType := strings.ToUpper(tokens[0].value)
+		if recordType == "SOA" || (recordType == "NS" && owner == apex) {
+			continue
+		}
+
+		record, err := buildZoneFileRecord(recordType, owner, origin, tokens[1:])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s record %q: %w", line.number, recordType, owner, err)
+		}
+		record.TTL = ttl
+		record.Proxied = strings.Contains(line.comment, zoneFileProxiedTag)
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// splitZoneFileLines tokenises a master file into logical lines, handling
+// comments, quoted character strings, escapes and parentheses.
+func splitZoneFileLines(content string) ([]zoneFileLine, error) {
+	lines := []zoneFileLine{}
+	lineNumber := 1
+	current := zoneFileLine{number: lineNumber}
+	depth := 0
+	atLineStart := true
+
+	flush := func() {
+		if len(current.tokens) > 0 {
+			lines = append(lines, current)
+		}
+		current = zoneFileLine{number: lineNumber}
+		atLineStart = true
+	}
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '\n':
+			lineNumber++
+			if depth == 0 {
+				flush()
+			}
+			i++
+			continue
+		case c == ';':
+			end := strings.IndexByte(content[i:], '\n')
+			if end == -1 {
+				end = len(content) - i
+			}
+			current.comment += content[i+1 : i+end]
+			i += end
+		case c == ' ' || c == '\t' || c == '\r':
+			if atLineStart && depth == 0 && len(current.tokens) == 0 {
+				current.blankOwner = true
+			}
+			i++
+		case c == '(':
+			depth++
+			i++
+		case c == ')':
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNumber)
+			}
+			depth--
+			i++
+		case c == '"':
+			value, n, err := readZoneFileString(content[i+1:], '"')
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			current.tokens = append(current.tokens, zoneFileToken{value: value, quoted: true})
+			i += n + 1
+		default:
+			value, n, err := readZoneFileString(content[i:], 0)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			current.tokens = append(current.tokens, zoneFileToken{value: value})
+			i += n
+		}
+		atLineStart = false
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", current.number)
+	}
+	flush()
+
+	return lines, nil
+}
+
+// readZoneFileString reads a character string from s, decoding `\X` and
+// `\DDD` escapes. When quote is set the string ends at the matching quote,
+// which is consumed; otherwise it ends at the first delimiter. The number of
+// bytes consumed is returned alongside the decoded value.
+func readZoneFileString(s string, quote byte) (string, int, error) {
+	var b strings.Builder
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		if quote != 0 {
+			if c == quote {
+				return b.String(), i + 1, nil
+			}
+			if c == '\n' {
+				return "", 0, fmt.Errorf("unterminated quoted string")
+			}
+		} else if strings.IndexByte(" \t\r\n;()\"", c) != -1 {
+			break
+		}
+
+		if c == '\\' && i+1 < len(s) {
+			if i+3 < len(s) && isDigits(s[i+1:i+4]) {
+				n, _ := strconv.Atoi(s[i+1 : i+4])
+				if n > 255 {
+					return "", 0, fmt.Errorf("invalid escape \\%s", s[i+1:i+4])
+				}
+				b.WriteByte(byte(n))
+				i += 4
+				continue
+			}
+			b.WriteByte(s[i+1])
+			i += 2
+			continue
+		}
+
+		b.WriteByte(c)
+		i++
+	}
+
+	if quote != 0 {
+		return "", 0, fmt.Errorf("unterminated quoted string")
+	}
+	return b.String(), i, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// qualifyZoneFileName returns the fully qualified form of name, without the
+// trailing dot, resolving `@` and relative names against origin.
+func qualifyZoneFileName(name, origin string) (string, error) {
+	name = strings.ToLower(name)
+	switch {
+	case name == "@":
+		if origin == "" {
+			return "", fmt.Errorf("%q used without an origin", name)
+		}
+		return origin, nil
+	case name == ".":
+		return ".", nil
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, "."), nil
+	case origin == "":
+		return "", fmt.Errorf("relative name %q used without an origin", name)
+	default:
+		return name + "." + origin, nil
+	}
+}
+
+// parseZoneFileTTL parses a TTL either as a number of seconds or using the
+// BIND unit syntax, such as `1h30m`.
+func parseZoneFileTTL(s string) (int, error) {
+	if !zoneFileTTLRegexp.MatchString(s) {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+
+	total, current := 0, 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			current = current*10 + int(c-'0')
+			continue
+		}
+		total += current * zoneFileTTLUnits[strings.ToLower(string(c))[0]]
+		current = 0
+	}
+
+	return total + current, nil
+}
+
+// buildZoneFileRecord converts the RDATA of a record into either a `value`
+// or a `data` map, mirroring how `cloudflare_record` expects each type to be
+// configured.
+func buildZoneFileRecord(recordType, owner, origin string, rdata []zoneFileToken) (zoneFileRecord, error) {
+	record := zoneFileRecord{Name: owner, Type: recordType}
+
+	fields := make([]string, len(rdata))
+	for i, t := range rdata {
+		fields[i] = t.value
+	}
+
+	want := func(n int) error {
+		if len(fields) < n {
+			return fmt.Errorf("expected at least %d fields, got %d", n, len(fields))
+		}
+		return nil
+	}
+	ints := func(names []string, values []string) (map[string]interface{}, error) {
+		data := make(map[string]interface{})
+		for i, name := range names {
+			v, err := strconv.Atoi(values[i])
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q", name, values[i])
+			}
+			data[name] = v
+		}
+		return data, nil
+	}
+	target := func(name string) (string, error) {
+		return qualifyZoneFileName(name, origin)
+	}
+
+	switch recordType {
+	case "A", "AAAA":
+		if err := want(1); err != nil {
+			return record, err
+		}
+		ip := net.ParseIP(fields[0])
+		if ip == nil || (recordType == "A") != (ip.To4() != nil) {
+			return record, fmt.Errorf("invalid address %q", fields[0])
+		}
+		record.Value = fields[0]
+
+	case "CNAME", "NS", "PTR":
+		if err := want(1); err != nil {
+			return record, err
+		}
+		value, err := target(fields[0])
+		if err != nil {
+			return record, err
+		}
+		record.Value = value
+
+	case "MX":
+		if err := want(2); err != nil {
+			return record, err
+		}
+		priority, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return record, fmt.Errorf("invalid preference %q", fields[0])
+		}
+		value, err := target(fields[1])
+		if err != nil {
+			return record, err
+		}
+		record.Priority = &priority
+		record.Value = value
+
+	case "TXT", "SPF":
+		if err := want(1); err != nil {
+			return record, err
+		}
+		record.Value = strings.Join(fields, "")
+
+	case "SRV":
+		if err := want(4); err != nil {
+			return record, err
+		}
+		labels := strings.SplitN(owner, ".", 3)
+		if len(labels) != 3 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+			return record, fmt.Errorf("owner must be in the form _service._proto.name")
+		}
+		data, err := ints([]string{"priority", "weight", "port"}, fields[:3])
+		if err != nil {
+			return record, err
+		}
+		if data["target"], err = target(fields[3]); err != nil {
+			return record, err
+		}
+		data["service"] = labels[0]
+		data["proto"] = labels[1]
+		data["name"] = labels[2]
+		record.Data = data
+
+	case "URI":
+		if err := want(3); err != nil {
+			return record, err
+		}
+		priority, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return record, fmt.Errorf("invalid priority %q", fields[0])
+		}
+		data, err := ints([]string{"weight"}, fields[1:2])
+		if err != nil {
+			return record, err
+		}
+		data["content"] = fields[2]
+		record.Priority = &priority
+		record.Data = data
+
+	case "CAA":
+		if err := want(3); err != nil {
+			return record, err
+		}
+		record.Data = map[string]interface{}{
+			"flags": fields[0],
+			"tag":   fields[1],
+			"value": strings.Join(fields[2:], ""),
+		}
+
+	case "DS":
+		if err := want(4); err != nil {
+			return record, err
+		}
+		data, err := ints([]string{"key_tag", "algorithm", "digest_type"}, fields[:3])
+		if err != nil {
+			return record, err
+		}
+		data["digest"] = strings.Join(fields[3:], "")
+		record.Data = data
+
+	case "DNSKEY":
+		if err := want(4); err != nil {
+			return record, err
+		}
+		data, err := ints([]string{"protocol", "algorithm"}, fields[1:3])
+		if err != nil {
+			return record, err
+		}
+		data["flags"] = fields[0]
+		data["public_key"] = strings.Join(fields[3:], "")
+		record.Data = data
+
+	case "CERT":
+		if err := want(4); err != nil {
+			return record, err
+		}
+		if certType, ok := zoneFileCertTypes[strings.ToUpper(fields[0])]; ok {
+			fields[0] = strconv.Itoa(certType)
+		}
+		data, err := ints([]string{"type", "key_tag", "algorithm"}, fields[:3])
+		if err != nil {
+			return record, err
+		}
+		data["certificate"] = strings.Join(fields[3:], "")
+		record.Data = data
+
+	case "SSHFP":
+		if err := want(3); err != nil {
+			return record, err
+		}
+		data, err := ints([]string{"algorithm", "type"}, fields[:2])
+		if err != nil {
+			return record, err
+		}
+		data["fingerprint"] = strings.Join(fields[2:], "")
+		record.Data = data
+
+	case "TLSA", "SMIMEA":
+		if err := want(4); err != nil {
+			return record, err
+		}
+		data, err := ints([]string{"usage", "selector", "matching_type"}, fields[:3])
+		if err != nil {
+			return record, err
+		}
+		data["certificate"] = strings.Join(fields[3:], "")
+		record.Data = data
+
+	case "NAPTR":
+		if err := want(6); err != nil {
+			return record, err
+		}
+		data, err := ints([]string{"order", "preference"}, fields[:2])
+		if err != nil {
+			return record, err
+		}
+		data["flags"] = fields[2]
+		data["service"] = fields[3]
+		data["regex"] = fields[4]
+		if data["replacement"], err = target(fields[5]); err != nil {
+			return record, err
+		}
+		record.Data = data
+
+	case "LOC":
+		data, err := parseZoneFileLOC(fields)
+		if err != nil {
+			return record, err
+		}
+		record.Data = data
+
+	default:
+		return record, fmt.Errorf("unsupported record type")
+	}
+
+	return record, nil
+}
+
+// parseZoneFileLOC parses the RDATA of a LOC record as described in RFC 1876:
+//
+//	d1 [m1 [s1]] {"N"|"S"} d2 [m2 [s2]] {"E"|"W"} alt["m"] [siz["m"] [hp["m"] [vp["m"]]]]
+func parseZoneFileLOC(fields []string) (map[string]interface{}, error) {
+	data := map[string]interface{}{
+		"lat_minutes":    0,
+		"lat_seconds":    0.0,
+		"long_minutes":   0,
+		"long_seconds":   0.0,
+		"size":           1.0,
+		"precision_horz": 10000.0,
+		"precision_vert": 10.0,
+	}
+
+	i := 0
+	coordinate := func(prefix string, directions string) error {
+		if i >= len(fields) {
+			return fmt.Errorf("missing %s", prefix)
+		}
+		degrees, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return fmt.Errorf("invalid %s degrees %q", prefix, fields[i])
+		}
+		data[prefix+"_degrees"] = degrees
+		i++
+
+		for n, name := range []string{"_minutes", "_seconds"} {
+			if i >= len(fields) || strings.Contains(directions, strings.ToUpper(fields[i])) {
+				break
+			}
+			if n == 0 {
+				minutes, err := strconv.Atoi(fields[i])
+				if err != nil {
+					return fmt.Errorf("invalid %s minutes %q", prefix, fields[i])
+				}
+				data[prefix+name] = minutes
+			} else {
+				seconds, err := strconv.ParseFloat(fields[i], 64)
+				if err != nil {
+					return fmt.Errorf("invalid %s seconds %q", prefix, fields[i])
+				}
+				data[prefix+name] = seconds
+			}
+			i++
+		}
+
+		if i >= len(fields) || len(fields[i]) != 1 || !strings.Contains(directions, strings.ToUpper(fields[i])) {
+			return fmt.Errorf("missing %s direction", prefix)
+		}
+		data[prefix+"_direction"] = strings.ToUpper(fields[i])
+		i++
+
+		return nil
+	}
+
+	if err := coordinate("lat", "NS"); err != nil {
+		return nil, err
+	}
+	if err := coordinate("long", "EW"); err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{"altitude", "size", "precision_horz", "precision_vert"} {
+		if i >= len(fields) {
+			if name == "altitude" {
+				return nil, fmt.Errorf("missing altitude")
+			}
+			break
+		}
+		value, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(fields[i]), "m"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", name, fields[i])
+		}
+		data[name] = value
+		i++
+	}
+
+	if i != len(fields) {
+		return nil, fmt.Errorf("unexpected trailing fields %q", strings.Join(fields[i:], " "))
+	}
+
+	return data, nil
+}
+
+// renderZoneFile renders records as an RFC 1035 master file for the zone
+// named zoneName. Proxied records are tagged with the same comment the
+// Cloudflare dashboard adds to its exports so that they survive a round trip
+// through parseZoneFile.
+func renderZoneFile(zoneName string, records []cloudflare.DNSRecord) string {
+	sorted := make([]cloudflare.DNSRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
+		}
+		return sorted[i].Content < sorted[j].Content
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", zoneName)
+
+	for _, r := range sorted {
+		fmt.Fprintf(&b, "%s.\t%d\tIN\t%s\t%s", r.Name, r.TTL, r.Type, renderZoneFileRData(r))
+		if r.Proxied != nil && *r.Proxied {
+			fmt.Fprintf(&b, " ; %s", zoneFileProxiedTag)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func renderZoneFileRData(r cloudflare.DNSRecord) string {
+	priority := ""
+	if r.Priority != nil {
+		priority = fmt.Sprintf("%d ", *r.Priority)
+	}
+
+	switch r.Type {
+	case "CNAME", "NS", "PTR":
+		return fqdn(r.Content)
+	case "MX":
+		return priority + fqdn(r.Content)
+	case "SRV":
+		// The content of SRV records is "weight port target" with the
+		// priority held separately.
+		fields := strings.Fields(r.Content)
+		if len(fields) == 3 {
+			fields[2] = fqdn(fields[2])
+		}
+		return priority + strings.Join(fields, " ")
+	case "URI":
+		return priority + r.Content
+	case "TXT", "SPF":
+		return quoteZoneFileTXT(r.Content)
+	default:
+		return r.Content
+	}
+}
+
+func fqdn(name string) string {
+	if name == "" || strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// quoteZoneFileTXT quotes the content of a TXT record, splitting it into
+// multiple character strings as each may be at most 255 bytes long.
+func quoteZoneFileTXT(content string) string {
+	if strings.HasPrefix(content, `"`) && strings.HasSuffix(content, `"`) && len(content) > 1 {
+		return content
+	}
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	parts := []string{}
+	for len(content) > 255 {
+		parts = append(parts, `"`+escaper.Replace(content[:255])+`"`)
+		content = content[255:]
+	}
+	parts = append(parts, `"`+escaper.Replace(content)+`"`)
+
+	return strings.Join(parts, " ")
+}
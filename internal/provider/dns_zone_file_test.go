@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+)
+
+const testZoneFile = `
+$ORIGIN example.com.
+$TTL 1h
+@       IN SOA ns1.example.com. hostmaster.example.com. (
+            2022060101 ; serial
+            7200       ; refresh
+            3600       ; retry
+            1209600    ; expire
+            3600 )     ; minimum
+        IN NS   ns1.example.com.
+        IN MX   10 mail
+www     300 IN A 192.0.2.1 ; cf_tags=cf-proxied:true
+        IN AAAA 2001:db8::1
+alias   IN 600 CNAME www
+txt     IN TXT "v=spf1 include:_spf.example.com" " ~all"
+_sip._tcp IN SRV 10 60 5060 sip.example.com.
+        IN CAA 0 issue "letsencrypt.org"
+loc     IN LOC 51 30 12.748 N 0 7 39.612 W 0.00m 1m 10000m 10m
+
+$ORIGIN sub.example.com.
+host    A 192.0.2.2
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, err := parseZoneFile(testZoneFile, "", 1)
+	if !assert.NoError(t, err) || !assert.Len(t, records, 9) {
+		return
+	}
+
+	mx := records[0]
+	assert.Equal(t, "example.com", mx.Name)
+	assert.Equal(t, "MX", mx.Type)
+	assert.Equal(t, "mail.example.com", mx.Value)
+	assert.Equal(t, 10, *mx.Priority)
+	assert.Equal(t, 3600, mx.TTL)
+
+	www := records[1]
+	assert.Equal(t, "www.example.com", www.Name)
+	assert.Equal(t, 300, www.TTL)
+	assert.True(t, www.Proxied)
+
+	aaaa := records[2]
+	assert.Equal(t, "www.example.com", aaaa.Name)
+	assert.Equal(t, "2001:db8::1", aaaa.Value)
+	assert.False(t, aaaa.Proxied)
+
+	cname := records[3]
+	assert.Equal(t, 600, cname.TTL)
+	assert.Equal(t, "www.example.com", cname.Value)
+
+	assert.Equal(t, "v=spf1 include:_spf.example.com ~all", records[4].Value)
+
+	srv := records[5]
+	assert.Equal(t, "_sip._tcp.example.com", srv.Name)
+	assert.Equal(t, map[string]interface{}{
+		"service":  "_sip",
+		"proto":    "_tcp",
+		"name":     "example.com",
+		"priority": 10,
+		"weight":   60,
+		"port":     5060,
+		"target":   "sip.example.com",
+	}, srv.Data)
+
+	caa := records[6]
+	assert.Equal(t, "_sip._tcp.example.com", caa.Name)
+	assert.Equal(t, map[string]interface{}{"flags": "0", "tag": "issue", "value": "letsencrypt.org"}, caa.Data)
+
+	loc := records[7]
+	assert.Equal(t, 51, loc.Data["lat_degrees"])
+	assert.Equal(t, 12.748, loc.Data["lat_seconds"])
+	assert.Equal(t, "W", loc.Data["long_direction"])
+	assert.Equal(t, 10000.0, loc.Data["precision_horz"])
+
+	assert.Equal(t, "host.sub.example.com", records[8].Name)
+}
+
+func TestParseZoneFileTTLs(t *testing.T) {
+	records, err := parseZoneFile("a 120 A 192.0.2.1\nb A 192.0.2.2\n", "example.com", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 120, records[1].TTL, "records without a TTL inherit the previous one")
+
+	records, err = parseZoneFile("a A 192.0.2.1\n", "example.com.", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, records[0].TTL)
+	assert.Equal(t, "a.example.com", records[0].Name)
+
+	ttl, err := parseZoneFileTTL("1h30m")
+	assert.NoError(t, err)
+	assert.Equal(t, 5400, ttl)
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	cases := map[string]string{
+		"www A 192.0.2.1\n":                         "line 1: relative name \"www\" used without an origin",
+		"$ORIGIN example.com.\n\nwww A 192.0.2.300": "line 3: A record \"www.example.com\": invalid address \"192.0.2.300\"",
+		"$ORIGIN example.com.\n@ HINFO a b":         "line 2: HINFO record \"example.com\": unsupported record type",
+		"$INCLUDE other.zone":                       "line 1: $INCLUDE directives are not supported",
+		"$ORIGIN example.com.\n@ CH TXT \"a\"":      "line 2: only the IN class is supported, got CH",
+		"$ORIGIN example.com.\n@ TXT (\"a\"":        "line 2: unbalanced parentheses",
+		"$ORIGIN example.com.\n@ TXT \"a":           "line 2: unterminated quoted string",
+		" A 192.0.2.1":                              "line 1: record has no owner name and there is no previous record to inherit it from",
+	}
+
+	for content, expected := range cases {
+		_, err := parseZoneFile(content, "", 1)
+		if assert.Error(t, err, content) {
+			assert.Equal(t, expected, err.Error())
+		}
+	}
+}
+
+func TestRenderZoneFileRoundTrip(t *testing.T) {
+	priority := uint16(10)
+	records := []cloudflare.DNSRecord{
+		{Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 1, Proxied: cloudflare.BoolPtr(true)},
+		{Name: "example.com", Type: "MX", Content: "mail.example.com", TTL: 3600, Priority: &priority},
+		{Name: "example.com", Type: "TXT", Content: `v=spf1 "quoted" -all`, TTL: 300},
+		{Name: "_sip._tcp.example.com", Type: "SRV", Content: "60 5060 sip.example.com", TTL: 300, Priority: &priority},
+	}
+
+	content := renderZoneFile("example.com", records)
+	assert.Contains(t, content, "www.example.com.\t1\tIN\tA\t192.0.2.1 ; cf_tags=cf-proxied:true\n")
+
+	parsed, err := parseZoneFile(content, "", 1)
+	if !assert.NoError(t, err) || !assert.Len(t, parsed, 4) {
+		return
+	}
+
+	assert.Equal(t, "_sip._tcp.example.com", parsed[0].Name)
+	assert.Equal(t, "sip.example.com", parsed[0].Data["target"])
+	assert.Equal(t, 10, *parsed[1].Priority)
+	assert.Equal(t, `v=spf1 "quoted" -all`, parsed[2].Value)
+	assert.True(t, parsed[3].Proxied)
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// cloudflare-go doesn't support Email Routing, so its settings, rules and
+// destination addresses are managed with raw requests.
+
+var (
+	emailRoutingRuleMatcherTypes  = []string{"literal"}
+	emailRoutingRuleMatcherFields = []string{"to"}
+	emailRoutingRuleActionTypes   = []string{"forward", "worker", "drop"}
+)
+
+type emailRoutingSettings struct {
+	Tag        string `json:"tag"`
+	Name       string `json:"name"`
+	Enabled    bool   `json:"enabled"`
+	SkipWizard bool   `json:"skip_wizard"`
+	Status     string `json:"status"`
+	Created    string `json:"created"`
+	Modified   string `json:"modified"`
+}
+
+// emailRoutingDNSRecord is a DNS record a zone needs for its email to be
+// routed.
+type emailRoutingDNSRecord struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Content  string `json:"content"`
+	Priority int    `json:"priority,omitempty"`
+	TTL      int    `json:"ttl"`
+}
+
+type emailRoutingRule struct {
+	Tag      string                    `json:"tag,omitempty"`
+	Name     string                    `json:"name"`
+	Enabled  bool                      `json:"enabled"`
+	Priority int                       `json:"priority"`
+	Matchers []emailRoutingRuleMatcher `json:"matchers"`
+	Actions  []emailRoutingRuleAction  `json:"actions"`
+}
+
+type emailRoutingRuleMatcher struct {
+	Type  string `json:"type"`
+	Field string `json:"field,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+type emailRoutingRuleAction struct {
+	Type  string   `json:"type"`
+	Value []string `json:"value,omitempty"`
+}
+
+// emailRoutingAddress is a destination address of an account. Verified is
+// when the owner of the address confirmed it, and empty until then.
+type emailRoutingAddress struct {
+	Tag      string `json:"tag,omitempty"`
+	Email    string `json:"email"`
+	Verified string `json:"verified,omitempty"`
+	Created  string `json:"created,omitempty"`
+	Modified string `json:"modified,omitempty"`
+}
+
+// emailRoutingURI returns the Email Routing endpoint of the zone, or of the
+// given collection of it.
+func emailRoutingURI(zoneID string, collection ...string) string {
+	uri := fmt.Sprintf("/zones/%s/email/routing", zoneID)
+	for _, c := range collection {
+		uri += "/" + url.PathEscape(c)
+	}
+	return uri
+}
+
+// emailRoutingAddressURI returns the endpoint of the destination addresses
+// of the account, or of the given one.
+func emailRoutingAddressURI(accountID string, tag ...string) string {
+	uri := fmt.Sprintf("/accounts/%s/email/routing/addresses", accountID)
+	for _, t := range tag {
+		uri += "/" + url.PathEscape(t)
+	}
+	return uri
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Lists can hold tens of thousands of items, so rather than being replaced as
+// a whole their items are added and removed in chunks, and can be loaded from
+// a file of which only a hash is kept in state.
+
+// listItemsChunkSize is the most items added or removed by a single request.
+const listItemsChunkSize = 1000
+
+// listItemsFileEntry is an item of a file of list items.
+type listItemsFileEntry struct {
+	Value   string
+	Comment string
+}
+
+// readListItemsFile reads the items of a file with an item per line. Lines
+// are comma separated, holding the value of the item followed by an optional
+// comment. Blank lines and lines starting with # are ignored.
+func readListItemsFile(path string) ([]listItemsFileEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading list items file: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var entries []listItemsFileEntry
+	// Lists hold each value once, so a value repeated in the file would never
+	// match the list.
+	lines := make(map[string]int)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading list items file %q: %w", path, err)
+		}
+
+		line, _ := r.FieldPos(0)
+		if len(record) > 2 {
+			return nil, fmt.Errorf("line %d of list items file %q has %d fields, but items only have a value and a comment", line, path, len(record))
+		}
+
+		entry := listItemsFileEntry{Value: strings.TrimSpace(record[0])}
+		if len(record) == 2 {
+			entry.Comment = strings.TrimSpace(record[1])
+		}
+		if entry.Value == "" {
+			return nil, fmt.Errorf("line %d of list items file %q has no value", line, path)
+		}
+		if first, ok := lines[entry.Value]; ok {
+			return nil, fmt.Errorf("line %d of list items file %q repeats %q from line %d", line, path, entry.Value, first)
+		}
+		lines[entry.Value] = line
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// hashListItemsFileEntries returns the hash of a set of items, regardless of
+// their order.
+func hashListItemsFileEntries(entries []listItemsFileEntry) string {
+	lines := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		lines = append(lines, entry.Value+"\t"+entry.Comment+"\n")
+	}
+	sort.Strings(lines)
+
+	h := sha256.New()
+	for _, line := range lines {
+		h.Write([]byte(line))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// cloudflare-go doesn't support Magic WAN sites nor the direction and rate of
+// tunnel health checks, so they're sent and read with raw requests using the
+// types below. Like the other Magic Transit endpoints, requests and results
+// are wrapped in an object keyed by the kind of object, e.g. `{"sites": [...]}`
+// on create, `{"site": {...}}` on read and `{"modified_site": {...}}` on
+// update.
+type magicWANSite struct {
+	ID                   string                `json:"id,omitempty"`
+	Name                 string                `json:"name"`
+	Description          string                `json:"description,omitempty"`
+	ConnectorID          string                `json:"connector_id,omitempty"`
+	SecondaryConnectorID string                `json:"secondary_connector_id,omitempty"`
+	HAMode               bool                  `json:"ha_mode"`
+	Location             *magicWANSiteLocation `json:"location,omitempty"`
+}
+
+type magicWANSiteLocation struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
+
+type magicWANSiteLAN struct {
+	ID               string                           `json:"id,omitempty"`
+	SiteID           string                           `json:"site_id,omitempty"`
+	Name             string                           `json:"name"`
+	Physport         int                              `json:"physport"`
+	VlanTag          int                              `json:"vlan_tag"`
+	HALink           bool                             `json:"ha_link"`
+	Nat              *magicWANSiteNat                 `json:"nat,omitempty"`
+	StaticAddressing *magicWANSiteLANStaticAddressing `json:"static_addressing,omitempty"`
+	RoutedSubnets    []magicWANSiteLANRoutedSubnet    `json:"routed_subnets,omitempty"`
+}
+
+type magicWANSiteNat struct {
+	StaticPrefix string `json:"static_prefix,omitempty"`
+}
+
+type magicWANSiteLANStaticAddressing struct {
+	Address          string                     `json:"address"`
+	SecondaryAddress string                     `json:"secondary_address,omitempty"`
+	VirtualAddress   string                     `json:"virtual_address,omitempty"`
+	DHCPRelay        *magicWANSiteLANDHCPRelay  `json:"dhcp_relay,omitempty"`
+	DHCPServer       *magicWANSiteLANDHCPServer `json:"dhcp_server,omitempty"`
+}
+
+// magicWANSiteLANDHCPRelay forwards the DHCP requests of the LAN to the
+// given servers.
+type magicWANSiteLANDHCPRelay struct {
+	ServerAddresses []string `json:"server_addresses"`
+}
+
+// magicWANSiteLANDHCPServer leases the addresses of the pool to the LAN,
+// reserving those of the given MAC addresses.
+type magicWANSiteLANDHCPServer struct {
+	DHCPPoolStart string            `json:"dhcp_pool_start"`
+	DHCPPoolEnd   string            `json:"dhcp_pool_end"`
+	DNSServer     string            `json:"dns_server,omitempty"`
+	Reservations  map[string]string `json:"reservations,omitempty"`
+}
+
+type magicWANSiteLANRoutedSubnet struct {
+	Prefix  string           `json:"prefix"`
+	NextHop string           `json:"next_hop"`
+	Nat     *magicWANSiteNat `json:"nat,omitempty"`
+}
+
+// magicWANSiteWAN gets its address with DHCP unless it has static
+// addressing.
+type magicWANSiteWAN struct {
+	ID               string                           `json:"id,omitempty"`
+	SiteID           string                           `json:"site_id,omitempty"`
+	Name             string                           `json:"name"`
+	Physport         int                              `json:"physport"`
+	VlanTag          int                              `json:"vlan_tag"`
+	Priority         int                              `json:"priority"`
+	StaticAddressing *magicWANSiteWANStaticAddressing `json:"static_addressing,omitempty"`
+}
+
+type magicWANSiteWANStaticAddressing struct {
+	Address          string `json:"address"`
+	GatewayAddress   string `json:"gateway_address"`
+	SecondaryAddress string `json:"secondary_address,omitempty"`
+}
+
+// magicWANSiteACL allows traffic between two LANs of a site.
+type magicWANSiteACL struct {
+	ID             string                       `json:"id,omitempty"`
+	Name           string                       `json:"name"`
+	Description    string                       `json:"description,omitempty"`
+	LAN1           magicWANSiteACLConfiguration `json:"lan_1"`
+	LAN2           magicWANSiteACLConfiguration `json:"lan_2"`
+	Protocols      []string                     `json:"protocols,omitempty"`
+	ForwardLocally bool                         `json:"forward_locally"`
+}
+
+type magicWANSiteACLConfiguration struct {
+	LANID   string   `json:"lan_id"`
+	LANName string   `json:"lan_name,omitempty"`
+	Ports   []int    `json:"ports,omitempty"`
+	Subnets []string `json:"subnets,omitempty"`
+}
+
+type ipsecTunnel struct {
+	cloudflare.MagicTransitIPsecTunnel
+	HealthCheck *magicTransitTunnelHealthCheck `json:"health_check,omitempty"`
+}
+
+type magicTransitTunnelHealthCheck struct {
+	cloudflare.MagicTransitTunnelHealthcheck
+	Direction string `json:"direction,omitempty"`
+	Rate      string `json:"rate,omitempty"`
+}
+
+// magicWANSiteACLProtocols are the protocols ACLs can allow.
+var magicWANSiteACLProtocols = []string{"tcp", "udp", "icmp"}
+
+// magicTransitTunnelHealthCheckDirections are the directions of tunnel health
+// checks.
+var magicTransitTunnelHealthCheckDirections = []string{"unidirectional", "bidirectional"}
+
+// magicTransitTunnelHealthCheckRates are the rates of tunnel health checks.
+var magicTransitTunnelHealthCheckRates = []string{"low", "mid", "high"}
+
+// magicTransitURI is the URI of the Magic Transit objects of the account at
+// the given path.
+func magicTransitURI(accountID string, path ...string) string {
+	uri := fmt.Sprintf("/accounts/%s/magic", accountID)
+	for _, p := range path {
+		uri += "/" + p
+	}
+	return uri
+}
+
+// magicTransitRequest sends a request and reads the value of the given key
+// of its result into v.
+func magicTransitRequest(client *cloudflare.API, method, uri string, body interface{}, resultKey string, v interface{}) error {
+	result, err := client.Raw(method, uri, body)
+	if err != nil {
+		return err
+	}
+	var wrapped map[string]json.RawMessage
+	if err := json.Unmarshal(result, &wrapped); err != nil {
+		return err
+	}
+	value, ok := wrapped[resultKey]
+	if !ok {
+		return fmt.Errorf("%s is missing from the response", resultKey)
+	}
+	return json.Unmarshal(value, v)
+}
+
+// createMagicTransitObject creates a single object in a collection of Magic
+// Transit objects, like the sites of an account, where plural is the key of
+// the collection.
+func createMagicTransitObject(client *cloudflare.API, uri, plural string, body, v interface{}) error {
+	var created []json.RawMessage
+	req := map[string]interface{}{plural: []interface{}{body}}
+	if err := magicTransitRequest(client, http.MethodPost, uri, req, plural, &created); err != nil {
+		return err
+	}
+	if len(created) != 1 {
+		return fmt.Errorf("expected a single created object, got %d", len(created))
+	}
+	return json.Unmarshal(created[0], v)
+}
+
+func magicTransitObjectOf(client *cloudflare.API, uri, singular string, v interface{}) error {
+	return magicTransitRequest(client, http.MethodGet, uri, nil, singular, v)
+}
+
+func updateMagicTransitObject(client *cloudflare.API, uri, singular string, body, v interface{}) error {
+	return magicTransitRequest(client, http.MethodPut, uri, body, "modified_"+singular, v)
+}
+
+func deleteMagicTransitObject(client *cloudflare.API, uri string) error {
+	_, err := client.Raw(http.MethodDelete, uri, nil)
+	return err
+}
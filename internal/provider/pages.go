@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// cloudflare-go's Pages types can neither configure bindings nor remove
+// environment variables, which takes an explicit null in a merge patch, so
+// projects are managed with raw requests.
+
+var (
+	pagesSourceTypes              = []string{"github", "gitlab"}
+	pagesPreviewDeploymentSetting = []string{"all", "none", "custom"}
+	pagesDeploymentEnvironments   = []string{"preview", "production"}
+)
+
+type pagesProject struct {
+	Name              string                  `json:"name,omitempty"`
+	Subdomain         string                  `json:"subdomain,omitempty"`
+	Domains           []string                `json:"domains,omitempty"`
+	ProductionBranch  string                  `json:"production_branch,omitempty"`
+	Source            *pagesProjectSource     `json:"source,omitempty"`
+	BuildConfig       *pagesBuildConfig       `json:"build_config,omitempty"`
+	DeploymentConfigs *pagesDeploymentConfigs `json:"deployment_configs,omitempty"`
+	CreatedOn         string                  `json:"created_on,omitempty"`
+}
+
+type pagesProjectSource struct {
+	Type   string                    `json:"type"`
+	Config *pagesProjectSourceConfig `json:"config"`
+}
+
+type pagesProjectSourceConfig struct {
+	Owner                        string   `json:"owner"`
+	RepoName                     string   `json:"repo_name"`
+	ProductionBranch             string   `json:"production_branch"`
+	PRCommentsEnabled            bool     `json:"pr_comments_enabled"`
+	DeploymentsEnabled           bool     `json:"deployments_enabled"`
+	ProductionDeploymentsEnabled bool     `json:"production_deployments_enabled"`
+	PreviewDeploymentSetting     string   `json:"preview_deployment_setting,omitempty"`
+	PreviewBranchIncludes        []string `json:"preview_branch_includes"`
+	PreviewBranchExcludes        []string `json:"preview_branch_excludes"`
+}
+
+type pagesBuildConfig struct {
+	BuildCommand      string `json:"build_command"`
+	DestinationDir    string `json:"destination_dir"`
+	RootDir           string `json:"root_dir"`
+	WebAnalyticsTag   string `json:"web_analytics_tag"`
+	WebAnalyticsToken string `json:"web_analytics_token"`
+}
+
+type pagesDeploymentConfigs struct {
+	Preview    *pagesDeploymentConfig `json:"preview,omitempty"`
+	Production *pagesDeploymentConfig `json:"production,omitempty"`
+}
+
+// pagesDeploymentConfig is the configuration of the deployments of an
+// environment. Settings keyed by name are removed by setting them to nil.
+type pagesDeploymentConfig struct {
+	EnvVars                 map[string]*pagesEnvVar        `json:"env_vars,omitempty"`
+	CompatibilityDate       string                         `json:"compatibility_date,omitempty"`
+	CompatibilityFlags      []string                       `json:"compatibility_flags"`
+	KVNamespaces            map[string]*pagesNamespaceRef  `json:"kv_namespaces,omitempty"`
+	DurableObjectNamespaces map[string]*pagesNamespaceRef  `json:"durable_object_namespaces,omitempty"`
+	D1Databases             map[string]*pagesD1DatabaseRef `json:"d1_databases,omitempty"`
+	R2Buckets               map[string]*pagesR2BucketRef   `json:"r2_buckets,omitempty"`
+}
+
+type pagesEnvVar struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type pagesNamespaceRef struct {
+	NamespaceID string `json:"namespace_id"`
+}
+
+type pagesD1DatabaseRef struct {
+	ID string `json:"id"`
+}
+
+type pagesR2BucketRef struct {
+	Name string `json:"name"`
+}
+
+type pagesDomain struct {
+	Name             string                 `json:"name"`
+	Status           string                 `json:"status,omitempty"`
+	VerificationData *pagesDomainValidation `json:"verification_data,omitempty"`
+	ValidationData   *pagesDomainValidation `json:"validation_data,omitempty"`
+}
+
+type pagesDomainValidation struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// pagesProjectURI returns the endpoint of the named project, or of the given
+// collection of it.
+func pagesProjectURI(accountID, projectName string, collection ...string) string {
+	uri := fmt.Sprintf("/accounts/%s/pages/projects/%s", accountID, url.PathEscape(projectName))
+	for _, c := range collection {
+		uri += "/" + url.PathEscape(c)
+	}
+	return uri
+}
@@ -9,15 +9,18 @@ import (
 	"regexp"
 
 	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/recorder"
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 
 	"strings"
+	"time"
 )
 
 func init() {
@@ -137,21 +140,102 @@ func New(version string) func() *schema.Provider {
 					DefaultFunc: schema.EnvDefaultFunc("CLOUDFLARE_API_BASE_PATH", "/client/v4"),
 					Description: "Configure the base path used by the API client. Alternatively, can be configured using the `CLOUDFLARE_API_BASE_PATH` environment variable.",
 				},
+
+				"profile": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("CLOUDFLARE_PROFILE", "default"),
+					Description: "Name of the credential profile to use for resources that don't select one. Credentials set on the provider take precedence over those of the profile. Alternatively, can be configured using the `CLOUDFLARE_PROFILE` environment variable.",
+				},
+
+				"credentials_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("CLOUDFLARE_CREDENTIALS_FILE", nil),
+					Description: fmt.Sprintf("Path of an INI file to load credential profiles from, with a section per profile setting any of `api_token`, `api_key`, `email`, `api_user_service_key`, `account_id` and `account_ids`. Profiles are loaded from `%s` if it exists when this isn't set. Alternatively, can be configured using the `CLOUDFLARE_CREDENTIALS_FILE` environment variable.", defaultCredentialsFile),
+				},
+
+				"profiles": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Credential profiles that resources can select with their `profile` attribute. Resources that don't select a profile use the profile listing their `account_id` in `account_ids`, or the default profile. Profiles replace those of the same name loaded from `credentials_file`.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Name of the profile.",
+							},
+							"api_token": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Sensitive:   true,
+								Description: "The API Token for operations.",
+							},
+							"api_key": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Sensitive:   true,
+								Description: "The API key for operations.",
+							},
+							"email": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "A registered Cloudflare email address.",
+							},
+							"api_user_service_key": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Sensitive:   true,
+								Description: "A special Cloudflare API key good for a restricted set of endpoints.",
+							},
+							"account_id": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "Account that clients of this profile use for resources without an `account_id`. Defaults to the first of `account_ids`.",
+							},
+							"account_ids": {
+								Type:        schema.TypeSet,
+								Optional:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+								Description: "Accounts whose resources are managed with this profile unless they select another one.",
+							},
+						},
+					},
+				},
+
+				"request_recording_path": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("CLOUDFLARE_REQUEST_RECORDING_PATH", nil),
+					Description: "Path of a file to record every API request and response to, with credentials redacted. Files ending in `.har` are written as an HTTP Archive, any other file as JSON Lines. Interactions are appended to an existing file. Alternatively, can be configured using the `CLOUDFLARE_REQUEST_RECORDING_PATH` environment variable.",
+				},
+
+				"request_recording_mode": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("CLOUDFLARE_REQUEST_RECORDING_MODE", recorder.ModeRecord),
+					ValidateFunc: validation.StringInSlice(recorder.Modes, false),
+					Description:  fmt.Sprintf("Whether to record API requests to `request_recording_path` or to replay the responses recorded there without contacting the API. Credentials aren't required when replaying. %s. Alternatively, can be configured using the `CLOUDFLARE_REQUEST_RECORDING_MODE` environment variable.", renderAvailableDocumentationValuesStringSlice(recorder.Modes)),
+				},
 			},
 
 			DataSourcesMap: map[string]*schema.Resource{
-				"cloudflare_access_identity_provider":    dataSourceCloudflareAccessIdentityProvider(),
-				"cloudflare_account_roles":               dataSourceCloudflareAccountRoles(),
-				"cloudflare_api_token_permission_groups": dataSourceCloudflareApiTokenPermissionGroups(),
-				"cloudflare_devices":                     dataSourceCloudflareDevices(),
-				"cloudflare_ip_ranges":                   dataSourceCloudflareIPRanges(),
-				"cloudflare_origin_ca_root_certificate":  dataSourceCloudflareOriginCARootCertificate(),
-				"cloudflare_waf_groups":                  dataSourceCloudflareWAFGroups(),
-				"cloudflare_waf_packages":                dataSourceCloudflareWAFPackages(),
-				"cloudflare_waf_rules":                   dataSourceCloudflareWAFRules(),
-				"cloudflare_zone_dnssec":                 dataSourceCloudflareZoneDNSSEC(),
-				"cloudflare_zone":                        dataSourceCloudflareZone(),
-				"cloudflare_zones":                       dataSourceCloudflareZones(),
+				"cloudflare_access_identity_provider":        dataSourceCloudflareAccessIdentityProvider(),
+				"cloudflare_account_roles":                   dataSourceCloudflareAccountRoles(),
+				"cloudflare_api_token_permission_groups":     dataSourceCloudflareApiTokenPermissionGroups(),
+				"cloudflare_devices":                         dataSourceCloudflareDevices(),
+				"cloudflare_dns_zone_file":                   dataSourceCloudflareDNSZoneFile(),
+				"cloudflare_dns_zone_file_export":            dataSourceCloudflareDNSZoneFileExport(),
+				"cloudflare_ip_ranges":                       dataSourceCloudflareIPRanges(),
+				"cloudflare_notification_policy_alert_types": dataSourceCloudflareNotificationPolicyAlertTypes(),
+				"cloudflare_origin_ca_root_certificate":      dataSourceCloudflareOriginCARootCertificate(),
+				"cloudflare_waf_groups":                      dataSourceCloudflareWAFGroups(),
+				"cloudflare_waf_packages":                    dataSourceCloudflareWAFPackages(),
+				"cloudflare_waf_rules":                       dataSourceCloudflareWAFRules(),
+				"cloudflare_zone_dnssec":                     dataSourceCloudflareZoneDNSSEC(),
+				"cloudflare_zone":                            dataSourceCloudflareZone(),
+				"cloudflare_zones":                           dataSourceCloudflareZones(),
 			},
 
 			ResourcesMap: map[string]*schema.Resource{
@@ -180,6 +264,10 @@ func New(version string) func() *schema.Provider {
 				"cloudflare_device_posture_rule":                    resourceCloudflareDevicePostureRule(),
 				"cloudflare_device_policy_certificates":             resourceCloudflareDevicePolicyCertificates(),
 				"cloudflare_device_posture_integration":             resourceCloudflareDevicePostureIntegration(),
+				"cloudflare_email_routing_address":                  resourceCloudflareEmailRoutingAddress(),
+				"cloudflare_email_routing_catch_all":                resourceCloudflareEmailRoutingCatchAll(),
+				"cloudflare_email_routing_rule":                     resourceCloudflareEmailRoutingRule(),
+				"cloudflare_email_routing_settings":                 resourceCloudflareEmailRoutingSettings(),
 				"cloudflare_fallback_domain":                        resourceCloudflareFallbackDomain(),
 				"cloudflare_filter":                                 resourceCloudflareFilter(),
 				"cloudflare_firewall_rule":                          resourceCloudflareFirewallRule(),
@@ -187,6 +275,8 @@ func New(version string) func() *schema.Provider {
 				"cloudflare_healthcheck":                            resourceCloudflareHealthcheck(),
 				"cloudflare_ip_list":                                resourceCloudflareIPList(),
 				"cloudflare_ipsec_tunnel":                           resourceCloudflareIPsecTunnel(),
+				"cloudflare_list":                                   resourceCloudflareList(),
+				"cloudflare_list_item":                              resourceCloudflareListItem(),
 				"cloudflare_load_balancer_monitor":                  resourceCloudflareLoadBalancerMonitor(),
 				"cloudflare_load_balancer_pool":                     resourceCloudflareLoadBalancerPool(),
 				"cloudflare_load_balancer":                          resourceCloudflareLoadBalancer(),
@@ -194,13 +284,27 @@ func New(version string) func() *schema.Provider {
 				"cloudflare_logpush_job":                            resourceCloudflareLogpushJob(),
 				"cloudflare_logpush_ownership_challenge":            resourceCloudflareLogpushOwnershipChallenge(),
 				"cloudflare_magic_firewall_ruleset":                 resourceCloudflareMagicFirewallRuleset(),
+				"cloudflare_magic_wan_site":                         resourceCloudflareMagicWANSite(),
+				"cloudflare_magic_wan_site_acl":                     resourceCloudflareMagicWANSiteACL(),
+				"cloudflare_magic_wan_site_lan":                     resourceCloudflareMagicWANSiteLAN(),
+				"cloudflare_magic_wan_site_wan":                     resourceCloudflareMagicWANSiteWAN(),
 				"cloudflare_notification_policy_webhooks":           resourceCloudflareNotificationPolicyWebhooks(),
 				"cloudflare_notification_policy":                    resourceCloudflareNotificationPolicy(),
 				"cloudflare_origin_ca_certificate":                  resourceCloudflareOriginCACertificate(),
 				"cloudflare_page_rule":                              resourceCloudflarePageRule(),
+				"cloudflare_pages_domain":                           resourceCloudflarePagesDomain(),
+				"cloudflare_pages_project":                          resourceCloudflarePagesProject(),
+				"cloudflare_r2_bucket":                              resourceCloudflareR2Bucket(),
+				"cloudflare_r2_bucket_cors":                         resourceCloudflareR2BucketCORS(),
+				"cloudflare_r2_bucket_lifecycle":                    resourceCloudflareR2BucketLifecycle(),
+				"cloudflare_r2_custom_domain":                       resourceCloudflareR2CustomDomain(),
 				"cloudflare_rate_limit":                             resourceCloudflareRateLimit(),
 				"cloudflare_record":                                 resourceCloudflareRecord(),
+				"cloudflare_record_set":                             resourceCloudflareRecordSet(),
 				"cloudflare_ruleset":                                resourceCloudflareRuleset(),
+				"cloudflare_secondary_dns_peer":                     resourceCloudflareSecondaryDNSPeer(),
+				"cloudflare_secondary_dns_tsig":                     resourceCloudflareSecondaryDNSTSIG(),
+				"cloudflare_secondary_dns_zone":                     resourceCloudflareSecondaryDNSZone(),
 				"cloudflare_spectrum_application":                   resourceCloudflareSpectrumApplication(),
 				"cloudflare_split_tunnel":                           resourceCloudflareSplitTunnel(),
 				"cloudflare_static_route":                           resourceCloudflareStaticRoute(),
@@ -209,6 +313,8 @@ func New(version string) func() *schema.Provider {
 				"cloudflare_teams_location":                         resourceCloudflareTeamsLocation(),
 				"cloudflare_teams_rule":                             resourceCloudflareTeamsRule(),
 				"cloudflare_teams_proxy_endpoint":                   resourceCloudflareTeamsProxyEndpoint(),
+				"cloudflare_tiered_cache":                           resourceCloudflareTieredCache(),
+				"cloudflare_tunnel_config":                          resourceCloudflareTunnelConfig(),
 				"cloudflare_tunnel_route":                           resourceCloudflareTunnelRoute(),
 				"cloudflare_tunnel_virtual_network":                 resourceCloudflareTunnelVirtualNetwork(),
 				"cloudflare_waf_group":                              resourceCloudflareWAFGroup(),
@@ -217,6 +323,8 @@ func New(version string) func() *schema.Provider {
 				"cloudflare_waf_rule":                               resourceCloudflareWAFRule(),
 				"cloudflare_waiting_room":                           resourceCloudflareWaitingRoom(),
 				"cloudflare_waiting_room_event":                     resourceCloudflareWaitingRoomEvent(),
+				"cloudflare_waiting_room_rules":                     resourceCloudflareWaitingRoomRules(),
+				"cloudflare_waiting_room_settings":                  resourceCloudflareWaitingRoomSettings(),
 				"cloudflare_worker_cron_trigger":                    resourceCloudflareWorkerCronTrigger(),
 				"cloudflare_worker_route":                           resourceCloudflareWorkerRoute(),
 				"cloudflare_worker_script":                          resourceCloudflareWorkerScript(),
@@ -232,6 +340,18 @@ func New(version string) func() *schema.Provider {
 
 		p.ConfigureContextFunc = configure(version, p)
 
+		// Profiles are added first, as whether changing them replaces a
+		// resource depends on it lacking an update of its own, which zone
+		// names provide.
+		for _, r := range p.ResourcesMap {
+			addProfileSchema(r)
+			addZoneNameSchema(r)
+		}
+		for _, r := range p.DataSourcesMap {
+			addProfileSchema(r)
+			addZoneNameSchema(r)
+		}
+
 		return p
 	}
 }
@@ -244,7 +364,13 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 		baseURL := cloudflare.BaseURL(
 			"https://" + d.Get("api_hostname").(string) + d.Get("api_base_path").(string),
 		)
-		limitOpt := cloudflare.UsingRateLimit(float64(d.Get("rps").(int)))
+		rps := float64(d.Get("rps").(int))
+		retryPolicy := cloudflare.RetryPolicy{
+			MaxRetries:    d.Get("retries").(int),
+			MinRetryDelay: time.Duration(d.Get("min_backoff").(int)) * time.Second,
+			MaxRetryDelay: time.Duration(d.Get("max_backoff").(int)) * time.Second,
+		}
+		limitOpt := cloudflare.UsingRateLimit(rps)
 		retryOpt := cloudflare.UsingRetryPolicy(d.Get("retries").(int), d.Get("min_backoff").(int), d.Get("max_backoff").(int))
 		options := []cloudflare.Option{limitOpt, retryOpt, baseURL}
 
@@ -253,18 +379,64 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 		}
 
 		c := cleanhttp.DefaultClient()
+
+		replaying := false
+		if path, ok := d.GetOk("request_recording_path"); ok {
+			switch mode := d.Get("request_recording_mode").(string); mode {
+			case recorder.ModeReplay:
+				replayer, err := recorder.NewReplayer(path.(string))
+				if err != nil {
+					return nil, diag.FromErr(err)
+				}
+				tflog.Info(ctx, fmt.Sprintf("replaying API requests from %s", path.(string)))
+				c.Transport = replayer
+				replaying = true
+			default:
+				rec, err := recorder.NewRecorder(path.(string), c.Transport)
+				if err != nil {
+					return nil, diag.FromErr(err)
+				}
+				tflog.Info(ctx, fmt.Sprintf("recording API requests to %s", path.(string)))
+				c.Transport = rec
+			}
+		}
+
 		c.Transport = logging.NewTransport("Cloudflare", c.Transport)
 		options = append(options, cloudflare.HTTPClient(c))
 
 		ua := fmt.Sprintf("terraform/%s terraform-plugin-sdk/%s terraform-provider-cloudflare/%s", p.TerraformVersion, meta.SDKVersionString(), version)
 		options = append(options, cloudflare.UserAgent(ua))
 
-		config := Config{Options: options}
+		profiles, err := loadCredentialsFiles(ctx, d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		for _, v := range d.Get("profiles").([]interface{}) {
+			profile := v.(map[string]interface{})
+			profiles[profile["name"].(string)] = &Config{
+				APIToken:          profile["api_token"].(string),
+				APIKey:            profile["api_key"].(string),
+				Email:             profile["email"].(string),
+				APIUserServiceKey: profile["api_user_service_key"].(string),
+				AccountID:         profile["account_id"].(string),
+				AccountIDs:        expandInterfaceToStringList(profile["account_ids"].(*schema.Set).List()),
+			}
+		}
+
+		defaultProfile := d.Get("profile").(string)
+		config := profiles[defaultProfile]
+		if config == nil {
+			config = &Config{}
+			profiles[defaultProfile] = config
+		}
 
+		// Credentials set on the provider itself take precedence over those of
+		// the default profile.
 		if v, ok := d.GetOk("api_token"); ok {
-			config.APIToken = v.(string)
+			config.APIToken, config.APIKey, config.Email = v.(string), "", ""
 		} else if v, ok := d.GetOk("api_key"); ok {
-			config.APIKey = v.(string)
+			config.APIToken, config.APIKey = "", v.(string)
 			if v, ok = d.GetOk("email"); ok {
 				config.Email = v.(string)
 			} else {
@@ -275,37 +447,112 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 
 				return nil, diags
 			}
-		} else {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "credentials are not set correctly",
-			})
-			return nil, diags
 		}
 
 		if v, ok := d.GetOk("api_user_service_key"); ok {
 			config.APIUserServiceKey = v.(string)
 		}
 
-		client, err := config.Client()
-		if err != nil {
-			return nil, diag.FromErr(err)
+		for name, profile := range profiles {
+			if !profile.hasCredentials() {
+				if replaying {
+					// Recordings don't contain credentials so any value will do.
+					profile.APIToken = recorder.Redacted
+				} else if name == defaultProfile {
+					diags = append(diags, diag.Diagnostic{
+						Severity: diag.Error,
+						Summary:  "credentials are not set correctly",
+					})
+					return nil, diags
+				} else {
+					return nil, diag.Errorf("credential profile %q must set either api_token or api_key and email", name)
+				}
+			}
 		}
 
 		if accountID, ok := d.GetOk("account_id"); ok {
 			tflog.Info(ctx, fmt.Sprintf("using specified account id %s in Cloudflare provider", accountID.(string)))
-			options = append(options, cloudflare.UsingAccount(accountID.(string)))
-		} else {
-			return client, diag.FromErr(err)
+			config.AccountID = accountID.(string)
 		}
 
-		config.Options = options
+		for _, profile := range profiles {
+			// Each profile gets its own slice as the account differs.
+			profile.Options = append([]cloudflare.Option{}, options...)
+			if accountID := profile.defaultAccountID(); accountID != "" {
+				profile.Options = append(profile.Options, cloudflare.UsingAccount(accountID))
+			}
+		}
 
-		client, err = config.Client()
-		if err != nil {
+		// Only the client of the default profile is created upfront, so that
+		// invalid provider credentials are still reported straight away.
+		m := newProviderMeta(defaultProfile, profiles, c, rps, retryPolicy)
+		if _, err := m.client(defaultProfile); err != nil {
 			return nil, diag.FromErr(err)
 		}
 
-		return client, nil
+		return m, nil
+	}
+}
+
+// addProfileSchema adds the `profile` attribute to a resource or data source
+// and validates its value during plan.
+func addProfileSchema(r *schema.Resource) {
+	description := "Name of the credential profile to manage the resource with. Defaults to the profile configured for the `account_id` of the resource, if any, or the default profile of the provider."
+	if r.CreateContext == nil {
+		description = "Name of the credential profile to read the data source with. Defaults to the profile configured for the `account_id` of the data source, if any, or the default profile of the provider."
+	}
+
+	r.Schema["profile"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: description,
+		// Resources that can't be updated in place have to be replaced.
+		ForceNew: r.CreateContext != nil && r.UpdateContext == nil,
 	}
+
+	// Data sources don't support CustomizeDiff.
+	switch {
+	case r.CreateContext == nil:
+	case r.CustomizeDiff == nil:
+		r.CustomizeDiff = validateResourceProfile
+	default:
+		r.CustomizeDiff = customdiff.All(validateResourceProfile, r.CustomizeDiff)
+	}
+}
+
+func validateResourceProfile(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	m, ok := meta.(*providerMeta)
+	if !ok || !d.NewValueKnown("profile") {
+		return nil
+	}
+
+	if profile, ok := d.GetOk("profile"); ok {
+		if _, exists := m.profiles[profile.(string)]; !exists {
+			return fmt.Errorf("profile: credential profile %q is not configured, available profiles are: %s", profile, strings.Join(m.profileNames(), ", "))
+		}
+	}
+
+	return nil
+}
+
+// loadCredentialsFiles loads the credential profiles of the configured
+// credentials file, or of the default one if it exists. Only the configured
+// file failing to load is an error, as the default one may not even be meant
+// for the provider.
+func loadCredentialsFiles(ctx context.Context, d *schema.ResourceData) (map[string]*Config, error) {
+	path, required := defaultCredentialsFile, false
+	if v, ok := d.GetOk("credentials_file"); ok {
+		path, required = v.(string), true
+	}
+
+	profiles, err := loadCredentialsFile(path, required)
+	if err != nil && !required {
+		tflog.Warn(ctx, fmt.Sprintf("ignoring default credentials file: %s", err))
+		profiles, err = nil, nil
+	}
+	if profiles == nil {
+		profiles = make(map[string]*Config)
+	}
+
+	return profiles, err
 }
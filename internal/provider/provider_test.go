@@ -2,12 +2,21 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -42,16 +51,144 @@ var (
 	testAccCloudflareAltZoneID string = "b72110c08e3382597095c29ba7e661ea"
 	// Integration test account alternate zone name.
 	testAccCloudflareAltZoneName string = "terraform2.cfapi.net"
+
+	// testAccMockAPI is the in-process fake of the Cloudflare API that the
+	// acceptance tests run against when `CLOUDFLARE_MOCK_API` is set. It is
+	// nil when running against the real API.
+	testAccMockAPI *mockapi.Server
 )
 
 func init() {
-	testAccProvider = New("dev")()
+	testAccProvider = testAccNewProvider()
 	providerFactories = map[string]func() (*schema.Provider, error){
 		"cloudflare": func() (*schema.Provider, error) {
-			return New("dev")(), nil
+			return testAccNewProvider(), nil
 		},
 	}
 }
+
+// testAccNewProvider returns a provider instance for acceptance testing. When
+// the mock API is running, the configured client is switched to plain HTTP
+// as the mock does not serve TLS.
+func testAccNewProvider() *schema.Provider {
+	p := New("dev")()
+	configure := p.ConfigureContextFunc
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		meta, diags := configure(ctx, d)
+		if m, ok := meta.(*providerMeta); ok && testAccMockAPI != nil {
+			client := m.defaultClient()
+			client.BaseURL = strings.Replace(client.BaseURL, "https://", "http://", 1)
+		}
+		return meta, diags
+	}
+
+	return p
+}
+
+// testAccStartMockAPI starts the mock API, seeds it with the zones the
+// acceptance tests expect and points the provider at it using the same
+// environment variables used for a live account.
+func testAccStartMockAPI() {
+	testAccMockAPI = mockapi.NewServer()
+	testAccMockAPI.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	testAccMockAPI.AddZone(testAccCloudflareAltZoneID, testAccCloudflareAltZoneName, testAccCloudflareAccountID)
+
+	env := map[string]string{
+		"CLOUDFLARE_API_HOSTNAME":  testAccMockAPI.Hostname(),
+		"CLOUDFLARE_API_BASE_PATH": mockapi.BasePath,
+		"CLOUDFLARE_EMAIL":         "terraform-acceptance-test@cfapi.net",
+		"CLOUDFLARE_API_KEY":       "0123456789abcdef0123456789abcdef01234",
+		"CLOUDFLARE_ACCOUNT_ID":    testAccCloudflareAccountID,
+		"CLOUDFLARE_ZONE_ID":       testAccCloudflareZoneID,
+		"CLOUDFLARE_DOMAIN":        testAccCloudflareZoneName,
+		"CLOUDFLARE_ALT_ZONE_ID":   testAccCloudflareAltZoneID,
+		"CLOUDFLARE_ALT_DOMAIN":    testAccCloudflareAltZoneName,
+	}
+	for k, v := range env {
+		os.Setenv(k, v)
+	}
+	os.Unsetenv("CLOUDFLARE_API_TOKEN")
+}
+
+// testMockAPIProvider returns a provider configured against the mock API,
+// along with its meta.
+func testMockAPIProvider(t *testing.T, server *mockapi.Server) (*schema.Provider, *providerMeta) {
+	for _, env := range []string{"CLOUDFLARE_API_TOKEN", "CLOUDFLARE_API_KEY", "CLOUDFLARE_EMAIL", "CLOUDFLARE_ACCOUNT_ID", "CLOUDFLARE_PROFILE", "CLOUDFLARE_REQUEST_RECORDING_PATH"} {
+		t.Setenv(env, "")
+	}
+
+	p := New("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_token":    "0123456789abcdefghijklmnopqrstuvwxyzABCD",
+		"api_hostname": server.Hostname(),
+		"rps":          1000,
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+	m := p.Meta().(*providerMeta)
+	client := m.defaultClient()
+	client.BaseURL = strings.Replace(client.BaseURL, "https://", "http://", 1)
+
+	return p, m
+}
+
+// testMockAPIConfig returns a copy of a resource's raw configuration with the
+// given attributes set.
+func testMockAPIConfig(raw map[string]interface{}, attributes map[string]interface{}) map[string]interface{} {
+	config := make(map[string]interface{}, len(raw)+len(attributes))
+	for k, v := range raw {
+		config[k] = v
+	}
+	for k, v := range attributes {
+		config[k] = v
+	}
+	return config
+}
+
+// testMockAPIDiff plans a resource's configuration against its state the way
+// Terraform does, passing the configuration on as the raw config.
+func testMockAPIDiff(t *testing.T, r *schema.Resource, m *providerMeta, state *terraform.InstanceState, raw map[string]interface{}) (*terraform.InstanceDiff, error) {
+	b, err := json.Marshal(raw)
+	require.NoError(t, err)
+	rawConfig, err := ctyjson.Unmarshal(b, r.CoreConfigSchema().ImpliedType())
+	require.NoError(t, err)
+
+	if state == nil {
+		state = &terraform.InstanceState{}
+	} else {
+		state = state.DeepCopy()
+	}
+	state.RawConfig = rawConfig
+
+	return r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(raw), m)
+}
+
+// testMockAPIApplyE plans and applies a resource's configuration against the
+// mock API, returning the first error.
+func testMockAPIApplyE(t *testing.T, r *schema.Resource, m *providerMeta, state *terraform.InstanceState, raw map[string]interface{}) (*terraform.InstanceState, error) {
+	diff, err := testMockAPIDiff(t, r, m, state, raw)
+	if err != nil {
+		return state, err
+	}
+	state, diags := r.Apply(context.Background(), state, diff, m)
+	if diags.HasError() {
+		return state, fmt.Errorf("%v", diags[0].Summary)
+	}
+	return state, nil
+}
+
+// testMockAPIApply applies a resource's configuration against the mock API
+// and checks that planning it again is empty.
+func testMockAPIApply(t *testing.T, r *schema.Resource, m *providerMeta, state *terraform.InstanceState, raw map[string]interface{}) *terraform.InstanceState {
+	state, err := testMockAPIApplyE(t, r, m, state, raw)
+	require.NoError(t, err)
+
+	diff, err := testMockAPIDiff(t, r, m, state, raw)
+	require.NoError(t, err)
+	require.True(t, diff.Empty(), "%v", diff)
+
+	return state
+}
+
 func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = New("dev")()
 }
@@ -62,6 +199,55 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestProviderRequestRecording(t *testing.T) {
+	for _, env := range []string{"CLOUDFLARE_API_TOKEN", "CLOUDFLARE_API_KEY", "CLOUDFLARE_EMAIL", "CLOUDFLARE_ACCOUNT_ID", "CLOUDFLARE_REQUEST_RECORDING_PATH", "CLOUDFLARE_REQUEST_RECORDING_MODE"} {
+		t.Setenv(env, "")
+	}
+
+	server := mockapi.NewServer()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	path := filepath.Join(t.TempDir(), "recording.har")
+
+	zoneDetails := func(config map[string]interface{}) (cloudflare.Zone, error) {
+		p := New("dev")()
+		if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config)); diags.HasError() {
+			t.Fatalf("failed to configure provider: %v", diags)
+		}
+		client := p.Meta().(*providerMeta).defaultClient()
+		client.BaseURL = strings.Replace(client.BaseURL, "https://", "http://", 1)
+		return client.ZoneDetails(context.Background(), testAccCloudflareZoneID)
+	}
+
+	zone, err := zoneDetails(map[string]interface{}{
+		"api_token":              "0123456789abcdefghijklmnopqrstuvwxyzABCD",
+		"api_hostname":           server.Hostname(),
+		"request_recording_path": path,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "0123456789abcdefghijklmnopqrstuvwxyzABCD") {
+		t.Error("expected the API token to be redacted from the recording")
+	}
+
+	replayed, err := zoneDetails(map[string]interface{}{
+		"request_recording_path": path,
+		"request_recording_mode": "replay",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.Name != zone.Name || replayed.Name != testAccCloudflareZoneName {
+		t.Errorf("expected replayed zone %q, got %q", zone.Name, replayed.Name)
+	}
+}
+
 type preCheckFunc = func(*testing.T)
 
 func testAccPreCheck(t *testing.T) {
@@ -189,3 +375,12 @@ func skipV1WAFTestForNonConfiguredDefaultZone(t *testing.T) {
 		t.Skipf("Skipping acceptance test as %s is using WAF v2 and cannot assert v1 resource configurations", testAccCloudflareZoneID)
 	}
 }
+
+// skipSecondaryDNSTestForNonConfiguredDefaultZone will force an acceptance
+// test to skip instead of running and failing due to the default zone being a
+// full zone rather than a secondary one. The mock API has no such restriction.
+func skipSecondaryDNSTestForNonConfiguredDefaultZone(t *testing.T) {
+	if os.Getenv("CLOUDFLARE_ZONE_ID") == testAccCloudflareZoneID && testAccMockAPI == nil {
+		t.Skipf("Skipping acceptance test as %s is not configured as a secondary zone", testAccCloudflareZoneID)
+	}
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// cloudflare-go doesn't support R2, so its buckets and their settings are
+// managed with raw requests.
+
+// r2BucketLocationHints are the locations buckets can be hinted to be created
+// in.
+var r2BucketLocationHints = []string{"apac", "eeur", "enam", "weur", "wnam"}
+
+type r2Bucket struct {
+	Name         string `json:"name"`
+	Location     string `json:"location,omitempty"`
+	LocationHint string `json:"locationHint,omitempty"`
+	CreationDate string `json:"creation_date,omitempty"`
+}
+
+type r2LifecycleRules struct {
+	Rules []r2LifecycleRule `json:"rules"`
+}
+
+type r2LifecycleRule struct {
+	ID                              string                 `json:"id"`
+	Enabled                         bool                   `json:"enabled"`
+	Conditions                      r2LifecycleConditions  `json:"conditions"`
+	DeleteObjectsTransition         *r2LifecycleTransition `json:"deleteObjectsTransition,omitempty"`
+	AbortMultipartUploadsTransition *r2LifecycleTransition `json:"abortMultipartUploadsTransition,omitempty"`
+}
+
+type r2LifecycleConditions struct {
+	Prefix string `json:"prefix"`
+}
+
+type r2LifecycleTransition struct {
+	Condition r2LifecycleCondition `json:"condition"`
+}
+
+// r2LifecycleCondition is either the age of objects in seconds, or the date
+// after which objects are transitioned.
+type r2LifecycleCondition struct {
+	Type   string `json:"type"`
+	MaxAge int    `json:"maxAge,omitempty"`
+	Date   string `json:"date,omitempty"`
+}
+
+type r2CORSRules struct {
+	Rules []r2CORSRule `json:"rules"`
+}
+
+type r2CORSRule struct {
+	ID            string        `json:"id,omitempty"`
+	Allowed       r2CORSAllowed `json:"allowed"`
+	ExposeHeaders []string      `json:"exposeHeaders,omitempty"`
+	MaxAgeSeconds int           `json:"maxAgeSeconds,omitempty"`
+}
+
+type r2CORSAllowed struct {
+	Origins []string `json:"origins"`
+	Methods []string `json:"methods"`
+	Headers []string `json:"headers,omitempty"`
+}
+
+type r2CustomDomain struct {
+	Domain   string                `json:"domain,omitempty"`
+	ZoneID   string                `json:"zoneId,omitempty"`
+	ZoneName string                `json:"zoneName,omitempty"`
+	Enabled  bool                  `json:"enabled"`
+	MinTLS   string                `json:"minTLS,omitempty"`
+	Status   *r2CustomDomainStatus `json:"status,omitempty"`
+}
+
+type r2CustomDomainStatus struct {
+	Ownership string `json:"ownership"`
+	SSL       string `json:"ssl"`
+}
+
+// r2BucketURI returns the endpoint of the named bucket, or of the given
+// setting of it.
+func r2BucketURI(accountID, bucketName string, setting ...string) string {
+	uri := fmt.Sprintf("/accounts/%s/r2/buckets/%s", accountID, url.PathEscape(bucketName))
+	for _, s := range setting {
+		uri += "/" + s
+	}
+	return uri
+}
+
+func isR2NotFound(err error) bool {
+	var notFoundError *cloudflare.NotFoundError
+	return errors.As(err, &notFoundError)
+}
@@ -535,7 +535,7 @@ resource "cloudflare_access_application" "%[1]s" {
 }
 
 func testAccCheckCloudflareAccessApplicationDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*cloudflare.API)
+	client := testAccProvider.Meta().(*providerMeta).defaultClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "cloudflare_access_application" {
@@ -26,7 +26,10 @@ func resourceCloudflareAccessBookmark() *schema.Resource {
 }
 
 func resourceCloudflareAccessBookmarkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	newAccessBookmark := cloudflare.AccessBookmark{
 		Name:               d.Get("name").(string),
@@ -58,7 +61,10 @@ func resourceCloudflareAccessBookmarkCreate(ctx context.Context, d *schema.Resou
 }
 
 func resourceCloudflareAccessBookmarkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	identifier, err := initIdentifier(d)
 	if err != nil {
@@ -90,7 +96,10 @@ func resourceCloudflareAccessBookmarkRead(ctx context.Context, d *schema.Resourc
 }
 
 func resourceCloudflareAccessBookmarkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	updatedAccessBookmark := cloudflare.AccessBookmark{
 		ID:                 d.Id(),
@@ -125,7 +134,10 @@ func resourceCloudflareAccessBookmarkUpdate(ctx context.Context, d *schema.Resou
 }
 
 func resourceCloudflareAccessBookmarkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	bookmarkID := d.Id()
 
 	tflog.Debug(ctx, fmt.Sprintf("Deleting Cloudflare Access Bookmark using ID: %s", bookmarkID))
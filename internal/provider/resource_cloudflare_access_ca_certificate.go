@@ -26,7 +26,10 @@ func resourceCloudflareAccessCACertificate() *schema.Resource {
 }
 
 func resourceCloudflareAccessCACertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	identifier, err := initIdentifier(d)
 	if err != nil {
@@ -49,7 +52,10 @@ func resourceCloudflareAccessCACertificateCreate(ctx context.Context, d *schema.
 }
 
 func resourceCloudflareAccessCACertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	applicationID := d.Get("application_id").(string)
 	identifier, err := initIdentifier(d)
 	if err != nil {
@@ -83,7 +89,10 @@ func resourceCloudflareAccessCACertificateUpdate(ctx context.Context, d *schema.
 }
 
 func resourceCloudflareAccessCACertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	applicationID := d.Get("application_id").(string)
 
 	tflog.Debug(ctx, fmt.Sprintf("Deleting Cloudflare CA Certificate using ID: %s", d.Id()))
@@ -6,7 +6,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/cloudflare/cloudflare-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -80,7 +79,7 @@ resource "cloudflare_access_ca_certificate" "%[1]s" {
 }
 
 func testAccCheckCloudflareAccessCACertificateDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*cloudflare.API)
+	client := testAccProvider.Meta().(*providerMeta).defaultClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "cloudflare_access_ca_certificate" {
@@ -25,7 +25,10 @@ func resourceCloudflareAccessGroup() *schema.Resource {
 }
 
 func resourceCloudflareAccessGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	identifier, err := initIdentifier(d)
 	if err != nil {
@@ -66,7 +69,10 @@ func resourceCloudflareAccessGroupRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCloudflareAccessGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	newAccessGroup := cloudflare.AccessGroup{
 		Name: d.Get("name").(string),
 	}
@@ -95,7 +101,10 @@ func resourceCloudflareAccessGroupCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareAccessGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	updatedAccessGroup := cloudflare.AccessGroup{
 		Name: d.Get("name").(string),
 		ID:   d.Id(),
@@ -128,7 +137,10 @@ func resourceCloudflareAccessGroupUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareAccessGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Deleting Cloudflare Access Group using ID: %s", d.Id()))
 
@@ -405,7 +405,7 @@ func testAccCheckCloudflareAccessGroupExists(n string, accessIdentifier AccessId
 			return fmt.Errorf("No AccessGroup ID is set")
 		}
 
-		client := testAccProvider.Meta().(*cloudflare.API)
+		client := testAccProvider.Meta().(*providerMeta).defaultClient()
 		var foundAccessGroup cloudflare.AccessGroup
 		var err error
 
@@ -433,7 +433,7 @@ func testAccCheckCloudflareAccessGroupExists(n string, accessIdentifier AccessId
 }
 
 func testAccCheckCloudflareAccessGroupDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*cloudflare.API)
+	client := testAccProvider.Meta().(*providerMeta).defaultClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "cloudflare_access_group" {
@@ -470,7 +470,7 @@ func testAccManuallyDeleteAccessGroup(name string, initialID *string) resource.T
 			return fmt.Errorf("not found: %s", name)
 		}
 
-		client := testAccProvider.Meta().(*cloudflare.API)
+		client := testAccProvider.Meta().(*providerMeta).defaultClient()
 		*initialID = rs.Primary.ID
 		err := client.DeleteAccessGroup(context.Background(), rs.Primary.Attributes["account_id"], rs.Primary.ID)
 		if err != nil {
@@ -27,7 +27,10 @@ func resourceCloudflareAccessIdentityProvider() *schema.Resource {
 }
 
 func resourceCloudflareAccessIdentityProviderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	identifier, err := initIdentifier(d)
 	if err != nil {
@@ -62,7 +65,10 @@ func resourceCloudflareAccessIdentityProviderRead(ctx context.Context, d *schema
 }
 
 func resourceCloudflareAccessIdentityProviderCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	IDPConfig, _ := convertSchemaToStruct(d)
 
@@ -95,7 +101,10 @@ func resourceCloudflareAccessIdentityProviderCreate(ctx context.Context, d *sche
 }
 
 func resourceCloudflareAccessIdentityProviderUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	IDPConfig, conversionErr := convertSchemaToStruct(d)
 	if conversionErr != nil {
@@ -134,7 +143,10 @@ func resourceCloudflareAccessIdentityProviderUpdate(ctx context.Context, d *sche
 }
 
 func resourceCloudflareAccessIdentityProviderDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Deleting Cloudflare Access Identity Provider using ID: %s", d.Id()))
 
@@ -26,7 +26,10 @@ func resourceCloudflareAccessKeysConfiguration() *schema.Resource {
 }
 
 func resourceCloudflareAccessKeysConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	keysConfig, err := client.AccessKeysConfig(ctx, accountID)
@@ -60,7 +63,10 @@ func resourceCloudflareAccessKeysConfigurationCreate(ctx context.Context, d *sch
 }
 
 func resourceCloudflareAccessKeysConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	keysConfigUpdateReq := cloudflare.AccessKeysConfigUpdateRequest{
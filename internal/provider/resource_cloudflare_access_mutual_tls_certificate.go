@@ -26,7 +26,10 @@ func resourceCloudflareAccessMutualTLSCertificate() *schema.Resource {
 }
 
 func resourceCloudflareAccessMutualTLSCertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	newAccessMutualTLSCertificate := cloudflare.AccessMutualTLSCertificate{
 		Name:        d.Get("name").(string),
@@ -57,7 +60,10 @@ func resourceCloudflareAccessMutualTLSCertificateCreate(ctx context.Context, d *
 }
 
 func resourceCloudflareAccessMutualTLSCertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	identifier, err := initIdentifier(d)
 	if err != nil {
@@ -88,7 +94,10 @@ func resourceCloudflareAccessMutualTLSCertificateRead(ctx context.Context, d *sc
 }
 
 func resourceCloudflareAccessMutualTLSCertificateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	updatedAccessMutualTLSCert := cloudflare.AccessMutualTLSCertificate{
 		ID:   d.Id(),
@@ -116,7 +125,10 @@ func resourceCloudflareAccessMutualTLSCertificateUpdate(ctx context.Context, d *
 }
 
 func resourceCloudflareAccessMutualTLSCertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	certID := d.Id()
 
 	tflog.Debug(ctx, fmt.Sprintf("Deleting Cloudflare Access Mutual TLS Certificate using ID: %s", certID))
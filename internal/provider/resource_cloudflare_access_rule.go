@@ -36,7 +36,10 @@ func resourceCloudflareAccessRule() *schema.Resource {
 }
 
 func resourceCloudflareAccessRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	newRule := cloudflare.AccessRule{
@@ -80,7 +83,10 @@ func resourceCloudflareAccessRuleCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareAccessRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	var accessRuleResponse *cloudflare.AccessRuleResponse
@@ -127,7 +133,10 @@ func resourceCloudflareAccessRuleRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceCloudflareAccessRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	updatedRule := cloudflare.AccessRule{
@@ -165,7 +174,10 @@ func resourceCloudflareAccessRuleUpdate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareAccessRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	tflog.Info(ctx, fmt.Sprintf("Deleting Cloudflare Access Rule: id %s for zone_id %s", d.Id(), zoneID))
@@ -190,7 +202,10 @@ func resourceCloudflareAccessRuleDelete(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareAccessRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return nil, clientErr
+	}
 	attributes := strings.Split(d.Id(), "/")
 
 	var (
@@ -51,7 +51,10 @@ func resourceCloudflareAccessServiceTokenExpireDiff(ctx context.Context, d *sche
 }
 
 func resourceCloudflareAccessServiceTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	identifier, err := initIdentifier(d)
 	if err != nil {
@@ -82,7 +85,10 @@ func resourceCloudflareAccessServiceTokenRead(ctx context.Context, d *schema.Res
 }
 
 func resourceCloudflareAccessServiceTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	tokenName := d.Get("name").(string)
 
 	identifier, err := initIdentifier(d)
@@ -112,7 +118,10 @@ func resourceCloudflareAccessServiceTokenCreate(ctx context.Context, d *schema.R
 }
 
 func resourceCloudflareAccessServiceTokenUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	tokenName := d.Get("name").(string)
 
 	identifier, err := initIdentifier(d)
@@ -136,7 +145,10 @@ func resourceCloudflareAccessServiceTokenUpdate(ctx context.Context, d *schema.R
 }
 
 func resourceCloudflareAccessServiceTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	identifier, err := initIdentifier(d)
 	if err != nil {
@@ -2,7 +2,10 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	cloudflare "github.com/cloudflare/cloudflare-go"
@@ -11,6 +14,38 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// accountMemberStatuses are the statuses of account members, which are
+// pending until they accept their invitation.
+var accountMemberStatuses = []string{"accepted", "pending"}
+
+// cloudflare-go doesn't support the policies of account members, so members
+// are sent and read with raw requests.
+type accountMember struct {
+	cloudflare.AccountMember
+	Roles    []cloudflare.AccountRole `json:"roles,omitempty"`
+	Policies []accountMemberPolicy    `json:"policies,omitempty"`
+}
+
+type accountMemberInvitation struct {
+	Email    string                `json:"email"`
+	Roles    []string              `json:"roles,omitempty"`
+	Policies []accountMemberPolicy `json:"policies,omitempty"`
+	Status   string                `json:"status,omitempty"`
+}
+
+// accountMemberPolicy grants the permission groups of API tokens on the
+// resources of the resource groups.
+type accountMemberPolicy struct {
+	ID               string                                `json:"id,omitempty"`
+	Access           string                                `json:"access"`
+	PermissionGroups []cloudflare.APITokenPermissionGroups `json:"permission_groups"`
+	ResourceGroups   []accountMemberResourceGroup          `json:"resource_groups"`
+}
+
+type accountMemberResourceGroup struct {
+	ID string `json:"id"`
+}
+
 func resourceCloudflareAccountMember() *schema.Resource {
 	return &schema.Resource{
 		Schema:        resourceCloudflareAccountMemberSchema(),
@@ -25,12 +60,16 @@ func resourceCloudflareAccountMember() *schema.Resource {
 }
 
 func resourceCloudflareAccountMemberRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := accountMemberAccountID(d, client)
 
-	member, err := client.AccountMember(ctx, client.AccountID, d.Id())
+	member, err := accountMemberOf(client, accountID, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "Member not found") ||
-			strings.Contains(err.Error(), "HTTP status 404") {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) || strings.Contains(err.Error(), "Member not found") {
 			tflog.Warn(ctx, fmt.Sprintf("Removing account member from state because it's not present in API"))
 			d.SetId("")
 			return nil
@@ -38,24 +77,36 @@ func resourceCloudflareAccountMemberRead(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
+	// Members granted policies are only managed with their policies, whatever
+	// roles the API reports for them.
 	var memberIDs []string
-	for _, role := range member.Roles {
-		memberIDs = append(memberIDs, role.ID)
+	if len(member.Policies) == 0 {
+		for _, role := range member.Roles {
+			memberIDs = append(memberIDs, role.ID)
+		}
 	}
 
+	d.Set("account_id", accountID)
 	d.Set("email_address", member.User.Email)
 	d.Set("role_ids", memberIDs)
+	d.Set("status", member.Status)
+	if err := d.Set("policy", flattenAccountMemberPolicies(member.Policies)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting policies of account member: %w", err))
+	}
 	d.SetId(d.Id())
 
 	return nil
 }
 
 func resourceCloudflareAccountMemberDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	tflog.Info(ctx, fmt.Sprintf("Deleting Cloudflare account member ID: %s", d.Id()))
 
-	err := client.DeleteAccountMember(ctx, client.AccountID, d.Id())
+	err := client.DeleteAccountMember(ctx, accountMemberAccountID(d, client), d.Id())
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error deleting Cloudflare account member: %w", err))
 	}
@@ -67,14 +118,23 @@ func resourceCloudflareAccountMemberCreate(ctx context.Context, d *schema.Resour
 	memberEmailAddress := d.Get("email_address").(string)
 	requestedMemberRoles := d.Get("role_ids").(*schema.Set).List()
 
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	var accountMemberRoleIDs []string
 	for _, roleID := range requestedMemberRoles {
 		accountMemberRoleIDs = append(accountMemberRoleIDs, roleID.(string))
 	}
 
-	r, err := client.CreateAccountMember(ctx, client.AccountID, memberEmailAddress, accountMemberRoleIDs)
+	invitation := accountMemberInvitation{
+		Email:    memberEmailAddress,
+		Roles:    accountMemberRoleIDs,
+		Policies: expandAccountMemberPolicies(d.Get("policy").(*schema.Set)),
+		Status:   d.Get("status").(string),
+	}
+	r, err := accountMemberRequest(client, http.MethodPost, accountMembersURI(accountMemberAccountID(d, client)), invitation)
 
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error creating Cloudflare account member: %w", err))
@@ -90,17 +150,23 @@ func resourceCloudflareAccountMemberCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceCloudflareAccountMemberUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountRoles := []cloudflare.AccountRole{}
 	memberRoles := d.Get("role_ids").(*schema.Set).List()
 
 	for _, r := range memberRoles {
-		accountRole, _ := client.AccountRole(ctx, client.AccountID, r.(string))
-		accountRoles = append(accountRoles, accountRole)
+		accountRoles = append(accountRoles, cloudflare.AccountRole{ID: r.(string)})
 	}
 
-	updatedAccountMember := cloudflare.AccountMember{Roles: accountRoles}
-	_, err := client.UpdateAccountMember(ctx, client.AccountID, d.Id(), updatedAccountMember)
+	updatedAccountMember := accountMember{
+		AccountMember: cloudflare.AccountMember{Status: d.Get("status").(string)},
+		Roles:         accountRoles,
+		Policies:      expandAccountMemberPolicies(d.Get("policy").(*schema.Set)),
+	}
+	_, err := accountMemberRequest(client, http.MethodPut, accountMembersURI(accountMemberAccountID(d, client), d.Id()), updatedAccountMember)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to update Cloudflare account member: %w", err))
 	}
@@ -109,7 +175,10 @@ func resourceCloudflareAccountMemberUpdate(ctx context.Context, d *schema.Resour
 }
 
 func resourceCloudflareAccountMemberImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return nil, clientErr
+	}
 
 	// split the id so we can lookup the account member
 	idAttr := strings.SplitN(d.Id(), "/", 2)
@@ -122,21 +191,84 @@ func resourceCloudflareAccountMemberImport(ctx context.Context, d *schema.Resour
 		return nil, fmt.Errorf("invalid id %q specified, should be in format \"accountID/accountMemberID\" for import", d.Id())
 	}
 
-	member, err := client.AccountMember(ctx, accountID, accountMemberID)
+	member, err := accountMemberOf(client, accountID, accountMemberID)
 	if err != nil {
 		return nil, fmt.Errorf("unable to find account member with ID %q: %w", accountMemberID, err)
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Found account member: %s", member.User.Email))
 
-	var memberIDs []string
-	for _, role := range member.Roles {
-		memberIDs = append(memberIDs, role.ID)
-	}
-
-	d.Set("email_address", member.User.Email)
-	d.Set("role_ids", memberIDs)
+	d.Set("account_id", accountID)
 	d.SetId(accountMemberID)
 
+	resourceCloudflareAccountMemberRead(ctx, d, meta)
+
 	return []*schema.ResourceData{d}, nil
 }
+
+// accountMemberAccountID returns the account of the member, which defaults
+// to the account of the provider.
+func accountMemberAccountID(d *schema.ResourceData, client *cloudflare.API) string {
+	if accountID, ok := d.GetOk("account_id"); ok {
+		return accountID.(string)
+	}
+	return client.AccountID
+}
+
+func expandAccountMemberPolicies(set *schema.Set) []accountMemberPolicy {
+	var policies []accountMemberPolicy
+	for _, p := range set.List() {
+		policy := p.(map[string]interface{})
+
+		var resourceGroups []accountMemberResourceGroup
+		for _, id := range expandInterfaceToStringList(policy["resource_groups"].(*schema.Set).List()) {
+			resourceGroups = append(resourceGroups, accountMemberResourceGroup{ID: id})
+		}
+
+		policies = append(policies, accountMemberPolicy{
+			Access:           policy["access"].(string),
+			PermissionGroups: expandAPITokenPermissionGroups(policy["permission_groups"].(*schema.Set)),
+			ResourceGroups:   resourceGroups,
+		})
+	}
+	return policies
+}
+
+func flattenAccountMemberPolicies(policies []accountMemberPolicy) []map[string]interface{} {
+	var flattened []map[string]interface{}
+	for _, policy := range policies {
+		var resourceGroups []string
+		for _, group := range policy.ResourceGroups {
+			resourceGroups = append(resourceGroups, group.ID)
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"access":            policy.Access,
+			"permission_groups": flattenAPITokenPermissionGroups(policy.PermissionGroups),
+			"resource_groups":   resourceGroups,
+		})
+	}
+	return flattened
+}
+
+func accountMembersURI(accountID string, path ...string) string {
+	uri := fmt.Sprintf("/accounts/%s/members", accountID)
+	for _, p := range path {
+		uri += "/" + p
+	}
+	return uri
+}
+
+func accountMemberOf(client *cloudflare.API, accountID, memberID string) (accountMember, error) {
+	return accountMemberRequest(client, http.MethodGet, accountMembersURI(accountID, memberID), nil)
+}
+
+func accountMemberRequest(client *cloudflare.API, method, uri string, body interface{}) (accountMember, error) {
+	var member accountMember
+	result, err := client.Raw(method, uri, body)
+	if err != nil {
+		return member, err
+	}
+	err = json.Unmarshal(result, &member)
+	return member, err
+}
@@ -1,11 +1,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccCloudflareAccountMemberBasic(t *testing.T) {
@@ -48,3 +53,91 @@ func testCloudflareAccountMemberBasicConfig(resourceID, emailAddress string) str
     role_ids = [ "05784afa30c1afe1440e79d9351c7430" ]
   }`, resourceID, emailAddress)
 }
+
+func TestAccCloudflareAccountMemberPolicies(t *testing.T) {
+	// Temporarily unset CLOUDFLARE_API_TOKEN as the API token won't have
+	// permission to manage account members.
+	if os.Getenv("CLOUDFLARE_API_TOKEN") != "" {
+		defer func(apiToken string) {
+			os.Setenv("CLOUDFLARE_API_TOKEN", apiToken)
+		}(os.Getenv("CLOUDFLARE_API_TOKEN"))
+		os.Setenv("CLOUDFLARE_API_TOKEN", "")
+	}
+
+	rnd := generateRandomResourceName()
+	name := "cloudflare_account_member." + rnd
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckAccount(t)
+			testAccPreCheckEmail(t)
+			testAccPreCheckApiKey(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testCloudflareAccountMemberPoliciesConfig(rnd, accountID, fmt.Sprintf("%s@example.com", rnd)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "account_id", accountID),
+					resource.TestCheckResourceAttr(name, "status", "pending"),
+					resource.TestCheckResourceAttr(name, "role_ids.#", "0"),
+					resource.TestCheckResourceAttr(name, "policy.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testCloudflareAccountMemberPoliciesConfig(resourceID, accountID, emailAddress string) string {
+	return fmt.Sprintf(`
+  resource "cloudflare_account_member" "%[1]s" {
+    account_id    = "%[2]s"
+    email_address = "%[3]s"
+
+    policy {
+      permission_groups = [ "05784afa30c1afe1440e79d9351c7430" ]
+      resource_groups   = [ "%[2]s" ]
+    }
+  }`, resourceID, accountID, emailAddress)
+}
+
+func TestAccountMemberApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_account_member"]
+	config := map[string]interface{}{
+		"account_id":    testAccCloudflareAccountID,
+		"email_address": "user@example.com",
+	}
+
+	diags := r.Validate(terraform.NewResourceConfigRaw(config))
+	assert.True(t, diags.HasError(), "members need roles or policies")
+
+	state := testMockAPIApply(t, r, m, nil, testMockAPIConfig(config, map[string]interface{}{"role_ids": []interface{}{"05784afa30c1afe1440e79d9351c7430"}}))
+	assert.Equal(t, "pending", state.Attributes["status"])
+	assert.Equal(t, "1", state.Attributes["role_ids.#"])
+
+	state = testMockAPIApply(t, r, m, state, testMockAPIConfig(config, map[string]interface{}{
+		"policy": []interface{}{map[string]interface{}{
+			"permission_groups": []interface{}{"05784afa30c1afe1440e79d9351c7430"},
+			"resource_groups":   []interface{}{"7b5a1e8f2c3d4e5f6a7b8c9d0e1f2a3b"},
+		}},
+	}))
+	member, err := accountMemberOf(m.defaultClient(), testAccCloudflareAccountID, state.ID)
+	require.NoError(t, err)
+	assert.Empty(t, member.Roles)
+	require.Len(t, member.Policies, 1)
+	assert.Equal(t, "allow", member.Policies[0].Access)
+	assert.Equal(t, []accountMemberResourceGroup{{ID: "7b5a1e8f2c3d4e5f6a7b8c9d0e1f2a3b"}}, member.Policies[0].ResourceGroups)
+	assert.Equal(t, "0", state.Attributes["role_ids.#"])
+
+	_, diags = r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state, "deleted members are removed from state")
+}
@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -21,17 +23,26 @@ func resourceCloudflareApiToken() *schema.Resource {
 		UpdateContext: resourceCloudflareApiTokenUpdate,
 		DeleteContext: resourceCloudflareApiTokenDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceCloudflareApiTokenImport,
 		},
+		CustomizeDiff: resourceCloudflareApiTokenCustomizeDiff,
 	}
 }
 
-func buildAPIToken(d *schema.ResourceData) cloudflare.APIToken {
+func buildAPIToken(d *schema.ResourceData) (cloudflare.APIToken, error) {
 	token := cloudflare.APIToken{}
 
 	token.Name = d.Get("name").(string)
 	token.Policies = resourceDataToApiTokenPolices(d)
 
+	var err error
+	if token.NotBefore, err = apiTokenTime(d, "not_before"); err != nil {
+		return token, err
+	}
+	if token.ExpiresOn, err = apiTokenTime(d, "expires_on"); err != nil {
+		return token, err
+	}
+
 	ipsIn := []string{}
 	ipsNotIn := []string{}
 	if ips, ok := d.GetOk("condition.0.request_ip.0.in"); ok {
@@ -56,18 +67,38 @@ func buildAPIToken(d *schema.ResourceData) cloudflare.APIToken {
 		}
 	}
 
-	return token
+	return token, nil
+}
+
+// apiTokenTime returns the time of the key, if it's set.
+func apiTokenTime(d *schema.ResourceData, key string) (*time.Time, error) {
+	value, ok := d.GetOk(key)
+	if !ok {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value.(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return &t, nil
 }
 
 func resourceCloudflareApiTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	name := d.Get("name").(string)
+	accountID := d.Get("account_id").(string)
 
 	tflog.Info(ctx, fmt.Sprintf("Creating Cloudflare API Token: name %s", name))
 
-	t := buildAPIToken(d)
-	t, err := client.CreateAPIToken(ctx, t)
+	t, err := buildAPIToken(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	t, err = createAPIToken(client, accountID, t)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error creating Cloudflare API Token %q: %w", name, err))
 	}
@@ -86,16 +117,10 @@ func resourceDataToApiTokenPolices(d *schema.ResourceData) []cloudflare.APIToken
 	for _, p := range policies {
 		policy := p.(map[string]interface{})
 
-		permissionGroups := expandInterfaceToStringList(policy["permission_groups"].(*schema.Set).List())
-		if len(permissionGroups) == 0 {
+		cfPermissionGroups := expandAPITokenPermissionGroups(policy["permission_groups"].(*schema.Set))
+		if len(cfPermissionGroups) == 0 {
 			continue
 		}
-		var cfPermissionGroups []cloudflare.APITokenPermissionGroups
-		for _, pg := range permissionGroups {
-			cfPermissionGroups = append(cfPermissionGroups, cloudflare.APITokenPermissionGroups{
-				ID: pg,
-			})
-		}
 
 		cfResources := map[string]interface{}{}
 		for k, v := range policy["resources"].(map[string]interface{}) {
@@ -118,16 +143,41 @@ func resourceDataToApiTokenPolices(d *schema.ResourceData) []cloudflare.APIToken
 	return cfPolicies
 }
 
+// expandAPITokenPermissionGroups returns the permission groups with the IDs
+// of the set, as granted by the policies of API tokens and account members.
+func expandAPITokenPermissionGroups(set *schema.Set) []cloudflare.APITokenPermissionGroups {
+	var cfPermissionGroups []cloudflare.APITokenPermissionGroups
+	for _, pg := range expandInterfaceToStringList(set.List()) {
+		cfPermissionGroups = append(cfPermissionGroups, cloudflare.APITokenPermissionGroups{
+			ID: pg,
+		})
+	}
+	return cfPermissionGroups
+}
+
+func flattenAPITokenPermissionGroups(cfPermissionGroups []cloudflare.APITokenPermissionGroups) []string {
+	permissionGroups := []string{}
+	for _, v := range cfPermissionGroups {
+		permissionGroups = append(permissionGroups, v.ID)
+	}
+	return permissionGroups
+}
+
 func resourceCloudflareApiTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	tokenID := d.Id()
+	accountID := d.Get("account_id").(string)
 
-	t, err := client.GetAPIToken(ctx, tokenID)
+	t, err := apiTokenOf(client, accountID, tokenID)
 
 	tflog.Debug(ctx, fmt.Sprintf("Cloudflare API Token: %+v", t))
 
 	if err != nil {
-		if strings.Contains(err.Error(), "HTTP status 404") {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
 			tflog.Info(ctx, fmt.Sprintf("Cloudflare API Token %s no longer exists", d.Id()))
 			d.SetId("")
 			return nil
@@ -138,14 +188,9 @@ func resourceCloudflareApiTokenRead(ctx context.Context, d *schema.ResourceData,
 	policies := []map[string]interface{}{}
 
 	for _, p := range t.Policies {
-		permissionGroups := []string{}
-		for _, v := range p.PermissionGroups {
-			permissionGroups = append(permissionGroups, v.ID)
-		}
-
 		policies = append(policies, map[string]interface{}{
 			"resources":         p.Resources,
-			"permission_groups": permissionGroups,
+			"permission_groups": flattenAPITokenPermissionGroups(p.PermissionGroups),
 			"effect":            p.Effect,
 		})
 	}
@@ -156,6 +201,17 @@ func resourceCloudflareApiTokenRead(ctx context.Context, d *schema.ResourceData,
 	d.Set("issued_on", t.IssuedOn.Format(time.RFC3339Nano))
 	d.Set("modified_on", t.ModifiedOn.Format(time.RFC3339Nano))
 
+	var notBefore, expiresOn string
+	if t.NotBefore != nil {
+		notBefore = t.NotBefore.UTC().Format(time.RFC3339)
+	}
+	if t.ExpiresOn != nil {
+		expiresOn = t.ExpiresOn.UTC().Format(time.RFC3339)
+	}
+	d.Set("not_before", notBefore)
+	d.Set("expires_on", expiresOn)
+	d.Set("expiring_soon", apiTokenExpiringSoon(t.ExpiresOn, d.Get("expiry_warning_days").(int), time.Now()))
+
 	var ipIn []string
 	var ipNotIn []string
 	if t.Condition != nil && t.Condition.RequestIP != nil && t.Condition.RequestIP.In != nil {
@@ -179,33 +235,164 @@ func resourceCloudflareApiTokenRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCloudflareApiTokenUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	name := d.Get("name").(string)
 	tokenID := d.Id()
+	accountID := d.Get("account_id").(string)
 
-	t := buildAPIToken(d)
+	t, err := buildAPIToken(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	tflog.Info(ctx, fmt.Sprintf("Updating Cloudflare API Token: name %s", name))
+	if d.HasChangesExcept("rotate_when_changed", "expiry_warning_days", "expiring_soon") {
+		tflog.Info(ctx, fmt.Sprintf("Updating Cloudflare API Token: name %s", name))
 
-	t, err := client.UpdateAPIToken(ctx, tokenID, t)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error updating Cloudflare API Token %q: %w", name, err))
+		_, err = updateAPIToken(client, accountID, tokenID, t)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating Cloudflare API Token %q: %w", name, err))
+		}
+	}
+
+	// Rolling the token keeps its ID and permissions but replaces its value.
+	if d.HasChange("rotate_when_changed") {
+		tflog.Info(ctx, fmt.Sprintf("Rolling Cloudflare API Token: name %s", name))
+
+		value, err := rollAPIToken(client, accountID, tokenID)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error rolling Cloudflare API Token %q: %w", name, err))
+		}
+		d.Set("value", value)
 	}
 
 	return resourceCloudflareApiTokenRead(ctx, d, meta)
 }
 
 func resourceCloudflareApiTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	tokenID := d.Id()
+	accountID := d.Get("account_id").(string)
 
 	tflog.Info(ctx, fmt.Sprintf("Deleting Cloudflare API Token: id %s", tokenID))
 
-	err := client.DeleteAPIToken(ctx, tokenID)
+	_, err := client.Raw(http.MethodDelete, apiTokensURI(accountID, tokenID), nil)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error deleting Cloudflare API Token: %w", err))
 	}
 
 	return nil
 }
+
+func resourceCloudflareApiTokenCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && d.HasChange("rotate_when_changed") {
+		if err := d.SetNewComputed("value"); err != nil {
+			return err
+		}
+	}
+
+	// Whether the token expires soon is worked out again on every plan, so
+	// that it doesn't wait for a refresh to change as the expiry approaches.
+	if !d.NewValueKnown("expires_on") || !d.NewValueKnown("expiry_warning_days") {
+		return d.SetNewComputed("expiring_soon")
+	}
+	var expiresOn *time.Time
+	if value := d.Get("expires_on").(string); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return err
+		}
+		expiresOn = &t
+	}
+	expiringSoon := apiTokenExpiringSoon(expiresOn, d.Get("expiry_warning_days").(int), time.Now())
+	if d.Id() == "" || d.Get("expiring_soon").(bool) != expiringSoon {
+		return d.SetNew("expiring_soon", expiringSoon)
+	}
+
+	return nil
+}
+
+// apiTokenExpiringSoon returns whether a token expiring on expiresOn, if
+// ever, expires within the given number of days from now.
+func apiTokenExpiringSoon(expiresOn *time.Time, days int, now time.Time) bool {
+	if expiresOn == nil {
+		return false
+	}
+	return expiresOn.Before(now.AddDate(0, 0, days))
+}
+
+// apiTokenTimeDiffSuppress ignores differences in the format of the same
+// instant, as the API always returns times in UTC.
+func apiTokenTimeDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
+func resourceCloudflareApiTokenImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// Tokens owned by an account are imported as "accountID/tokenID".
+	if attributes := strings.SplitN(d.Id(), "/", 2); len(attributes) == 2 {
+		d.SetId(attributes[1])
+		d.Set("account_id", attributes[0])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// apiTokensURI is the URI of the tokens of the account or, when there is no
+// account, of the user. cloudflare-go only manages the tokens of users, so
+// tokens are sent and read with raw requests to the URI.
+func apiTokensURI(accountID string, path ...string) string {
+	uri := "/user/tokens"
+	if accountID != "" {
+		uri = fmt.Sprintf("/accounts/%s/tokens", accountID)
+	}
+	for _, p := range path {
+		uri += "/" + p
+	}
+	return uri
+}
+
+func createAPIToken(client *cloudflare.API, accountID string, token cloudflare.APIToken) (cloudflare.APIToken, error) {
+	return apiTokenRequest(client, http.MethodPost, apiTokensURI(accountID), token)
+}
+
+func apiTokenOf(client *cloudflare.API, accountID, tokenID string) (cloudflare.APIToken, error) {
+	return apiTokenRequest(client, http.MethodGet, apiTokensURI(accountID, tokenID), nil)
+}
+
+func updateAPIToken(client *cloudflare.API, accountID, tokenID string, token cloudflare.APIToken) (cloudflare.APIToken, error) {
+	return apiTokenRequest(client, http.MethodPut, apiTokensURI(accountID, tokenID), token)
+}
+
+func rollAPIToken(client *cloudflare.API, accountID, tokenID string) (string, error) {
+	var value string
+	result, err := client.Raw(http.MethodPut, apiTokensURI(accountID, tokenID, "value"), struct{}{})
+	if err != nil {
+		return "", err
+	}
+	err = json.Unmarshal(result, &value)
+	return value, err
+}
+
+func apiTokenRequest(client *cloudflare.API, method, uri string, body interface{}) (cloudflare.APIToken, error) {
+	var token cloudflare.APIToken
+	result, err := client.Raw(method, uri, body)
+	if err != nil {
+		return token, err
+	}
+	err = json.Unmarshal(result, &token)
+	return token, err
+}
@@ -1,11 +1,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccAPIToken_Basic(t *testing.T) {
@@ -251,3 +257,216 @@ func testAPITokenConfigAllowDeny(resourceID, permissionID, zoneID string, allowA
 		}
 		`, resourceID, permissionID, add)
 }
+
+func TestAccAPIToken_AccountOwned(t *testing.T) {
+	rnd := generateRandomResourceName()
+	resourceID := "cloudflare_api_token." + rnd
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	permissionID := "82e64a83756745bbbb1c9c2701bf816b" // DNS read
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAPITokenAccountOwned(rnd, accountID, permissionID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceID, "account_id", accountID),
+					resource.TestCheckResourceAttr(resourceID, "status", "active"),
+					resource.TestCheckResourceAttrSet(resourceID, "value"),
+				),
+			},
+			{
+				ResourceName:            resourceID,
+				ImportState:             true,
+				ImportStateIdPrefix:     accountID + "/",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"value"},
+			},
+		},
+	})
+}
+
+func testAccCloudflareAPITokenAccountOwned(rnd, accountID, permissionID string) string {
+	return fmt.Sprintf(`
+	resource "cloudflare_api_token" "%[1]s" {
+		name       = "%[1]s"
+		account_id = "%[2]s"
+
+		policy {
+			permission_groups = [ "%[3]s" ]
+			resources = { "com.cloudflare.api.account.zone.*" = "*" }
+		}
+	}
+`, rnd, accountID, permissionID)
+}
+
+func TestAccAPIToken_Rotation(t *testing.T) {
+	rnd := generateRandomResourceName()
+	resourceID := "cloudflare_api_token." + rnd
+	permissionID := "82e64a83756745bbbb1c9c2701bf816b" // DNS read
+	expiresOn := time.Now().UTC().AddDate(0, 0, 90).Truncate(time.Second).Format(time.RFC3339)
+
+	var value string
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAPITokenRotation(rnd, permissionID, expiresOn, "2022-01"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceID, "expires_on", expiresOn),
+					resource.TestCheckResourceAttr(resourceID, "expiring_soon", "false"),
+					func(s *terraform.State) error {
+						value = s.RootModule().Resources[resourceID].Primary.Attributes["value"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccCloudflareAPITokenRotation(rnd, permissionID, expiresOn, "2022-04"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceID, "rotate_when_changed.rotation", "2022-04"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[resourceID].Primary.Attributes["value"] == value {
+							return fmt.Errorf("the value of the token wasn't rolled")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCloudflareAPITokenRotation(rnd, permissionID, expiresOn, rotation string) string {
+	return fmt.Sprintf(`
+	resource "cloudflare_api_token" "%[1]s" {
+		name       = "%[1]s"
+		expires_on = "%[3]s"
+
+		rotate_when_changed = {
+			rotation = "%[4]s"
+		}
+
+		policy {
+			permission_groups = [ "%[2]s" ]
+			resources = { "com.cloudflare.api.account.zone.*" = "*" }
+		}
+	}
+`, rnd, permissionID, expiresOn, rotation)
+}
+
+func TestAPITokenApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_api_token"]
+	config := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":       name,
+			"account_id": testAccCloudflareAccountID,
+			"policy": []interface{}{map[string]interface{}{
+				"permission_groups": []interface{}{"82e64a83756745bbbb1c9c2701bf816b"},
+				"resources":         map[string]interface{}{"com.cloudflare.api.account.zone.*": "*"},
+			}},
+		}
+	}
+	state := testMockAPIApply(t, r, m, nil, config("dns"))
+	assert.NotEmpty(t, state.Attributes["value"])
+	assert.Equal(t, "active", state.Attributes["status"])
+	token, err := apiTokenOf(m.defaultClient(), testAccCloudflareAccountID, state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "dns", token.Name)
+	_, err = m.defaultClient().GetAPIToken(ctx, state.ID)
+	assert.Error(t, err, "the token is owned by the account rather than the user")
+
+	state = testMockAPIApply(t, r, m, state, config("dns-read"))
+	token, err = apiTokenOf(m.defaultClient(), testAccCloudflareAccountID, state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "dns-read", token.Name)
+
+	d := r.Data(&terraform.InstanceState{ID: testAccCloudflareAccountID + "/" + state.ID})
+	imported, err := r.Importer.StateContext(ctx, d, m)
+	require.NoError(t, err)
+	assert.Equal(t, state.ID, imported[0].Id())
+	assert.Equal(t, testAccCloudflareAccountID, imported[0].Get("account_id"))
+
+	_, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state, "deleted tokens are removed from state")
+}
+
+func TestAPITokenRotate(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+
+	r := p.ResourcesMap["cloudflare_api_token"]
+	config := func(expiresOn time.Time, rotation string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":                "rotated",
+			"expires_on":          expiresOn.Format(time.RFC3339),
+			"rotate_when_changed": map[string]interface{}{"rotation": rotation},
+			"policy": []interface{}{map[string]interface{}{
+				"permission_groups": []interface{}{"82e64a83756745bbbb1c9c2701bf816b"},
+				"resources":         map[string]interface{}{"com.cloudflare.api.account.zone.*": "*"},
+			}},
+		}
+	}
+	// Times in other zones are the same instant as those returned in UTC.
+	expiresOn := time.Now().Add(90 * 24 * time.Hour).Truncate(time.Second).In(time.FixedZone("CEST", 2*60*60))
+	state := testMockAPIApply(t, r, m, nil, config(expiresOn, "2022-01"))
+	assert.Equal(t, "false", state.Attributes["expiring_soon"])
+	assert.Equal(t, expiresOn.UTC().Format(time.RFC3339), state.Attributes["expires_on"])
+
+	diff, err := testMockAPIDiff(t, r, m, state, config(expiresOn, "2022-04"))
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew(), "the token is rolled in place")
+	assert.True(t, diff.Attributes["value"].NewComputed)
+	rotated := testMockAPIApply(t, r, m, state, config(expiresOn, "2022-04"))
+	assert.Equal(t, state.ID, rotated.ID)
+	assert.NotEmpty(t, rotated.Attributes["value"])
+	assert.NotEqual(t, state.Attributes["value"], rotated.Attributes["value"])
+
+	rotated = testMockAPIApply(t, r, m, rotated, config(time.Now().Add(7*24*time.Hour).Truncate(time.Second), "2022-04"))
+	assert.Equal(t, "true", rotated.Attributes["expiring_soon"])
+
+	// Tokens coming up for expiry are flagged during plan, without waiting
+	// for a refresh.
+	soon := config(expiresOn, "2022-01")
+	soon["expiry_warning_days"] = 100
+	state = testMockAPIApply(t, r, m, nil, config(expiresOn, "2022-01"))
+	diff, err = testMockAPIDiff(t, r, m, state, soon)
+	require.NoError(t, err)
+	assert.Equal(t, "true", diff.Attributes["expiring_soon"].New)
+	assert.Equal(t, "true", testMockAPIApply(t, r, m, state, soon).Attributes["expiring_soon"])
+}
+
+func TestAPITokenExpiringSoon(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	expiresOn := func(t time.Time) *time.Time {
+		return &t
+	}
+
+	assert.False(t, apiTokenExpiringSoon(nil, 30, now), "tokens without expiry never expire")
+	assert.False(t, apiTokenExpiringSoon(expiresOn(now.AddDate(0, 0, 31)), 30, now))
+	assert.True(t, apiTokenExpiringSoon(expiresOn(now.AddDate(0, 0, 29)), 30, now))
+	assert.True(t, apiTokenExpiringSoon(expiresOn(now.AddDate(0, 0, -1)), 30, now), "expired tokens are flagged")
+	assert.False(t, apiTokenExpiringSoon(expiresOn(now.AddDate(0, 0, 1)), 0, now))
+}
+
+func TestAPITokenTimeDiffSuppress(t *testing.T) {
+	assert.True(t, apiTokenTimeDiffSuppress("expires_on", "2022-06-01T00:00:00Z", "2022-06-01T02:00:00+02:00", nil))
+	assert.False(t, apiTokenTimeDiffSuppress("expires_on", "2022-06-01T00:00:00Z", "2022-06-01T00:00:00+02:00", nil))
+	assert.False(t, apiTokenTimeDiffSuppress("expires_on", "", "2022-06-01T00:00:00Z", nil))
+}
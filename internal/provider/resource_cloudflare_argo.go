@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/cloudflare/cloudflare-go"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -25,7 +24,10 @@ func resourceCloudflareArgo() *schema.Resource {
 }
 
 func resourceCloudflareArgoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	tieredCaching := d.Get("tiered_caching").(string)
 	smartRouting := d.Get("smart_routing").(string)
@@ -58,7 +60,10 @@ func resourceCloudflareArgoRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceCloudflareArgoUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	tieredCaching := d.Get("tiered_caching").(string)
 	smartRouting := d.Get("smart_routing").(string)
@@ -83,19 +88,28 @@ func resourceCloudflareArgoUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceCloudflareArgoDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	tflog.Debug(ctx, fmt.Sprintf("Resetting Argo values to 'off'"))
 
-	_, smartRoutingErr := client.UpdateArgoSmartRouting(ctx, zoneID, "off")
-	if smartRoutingErr != nil {
-		return diag.FromErr(errors.Wrap(smartRoutingErr, "failed to update smart routing setting"))
+	// Settings the resource doesn't manage are left alone, as tiered caching
+	// may be managed by cloudflare_tiered_cache instead.
+	if d.Get("smart_routing").(string) != "" {
+		_, smartRoutingErr := client.UpdateArgoSmartRouting(ctx, zoneID, "off")
+		if smartRoutingErr != nil {
+			return diag.FromErr(errors.Wrap(smartRoutingErr, "failed to update smart routing setting"))
+		}
 	}
 
-	_, tieredCachingErr := client.UpdateArgoTieredCaching(ctx, zoneID, "off")
-	if tieredCachingErr != nil {
-		return diag.FromErr(errors.Wrap(tieredCachingErr, "failed to update tiered caching setting"))
+	if d.Get("tiered_caching").(string) != "" {
+		_, tieredCachingErr := client.UpdateArgoTieredCaching(ctx, zoneID, "off")
+		if tieredCachingErr != nil {
+			return diag.FromErr(errors.Wrap(tieredCachingErr, "failed to update tiered caching setting"))
+		}
 	}
 
 	return nil
@@ -1,11 +1,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccCloudflareArgoOnlySetTieredCaching(t *testing.T) {
@@ -68,3 +73,55 @@ func testAccCheckCloudflareArgoFullConfig(zoneID, name string) string {
     smart_routing  = "on"
   }`, zoneID, name)
 }
+
+func TestArgoWithTieredCache(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+	client := m.defaultClient()
+	ctx := context.Background()
+
+	configs := map[string]map[string]interface{}{
+		"cloudflare_tiered_cache": {"zone_id": testAccCloudflareZoneID, "cache_type": "smart"},
+		"cloudflare_argo":         {"zone_id": testAccCloudflareZoneID, "smart_routing": "on"},
+	}
+	states := make(map[string]*terraform.InstanceState)
+	for _, name := range []string{"cloudflare_tiered_cache", "cloudflare_argo"} {
+		states[name] = testMockAPIApply(t, p.ResourcesMap[name], m, nil, configs[name])
+	}
+	assert.NotContains(t, states["cloudflare_argo"].Attributes, "tiered_caching")
+
+	// Regional tiered cache is read even when it isn't configured, while
+	// cloudflare_argo ignores the tiered caching it doesn't manage.
+	require.NoError(t, updateZoneCacheSetting(client, testAccCloudflareZoneID, regionalTieredCacheSetting, "on"))
+	for name, state := range states {
+		state, diags := p.ResourcesMap[name].RefreshWithoutUpgrade(ctx, state, m)
+		require.False(t, diags.HasError(), "%v", diags)
+		diff, err := testMockAPIDiff(t, p.ResourcesMap[name], m, state, configs[name])
+		require.NoError(t, err)
+		assert.True(t, diff.Empty(), "%s: %v", name, diff)
+		states[name] = state
+	}
+	assert.Equal(t, "on", states["cloudflare_tiered_cache"].Attributes["regional_tiered_cache"])
+	assert.NotContains(t, states["cloudflare_argo"].Attributes, "tiered_caching")
+
+	_, diags := p.ResourcesMap["cloudflare_argo"].Apply(ctx, states["cloudflare_argo"], &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	smartRouting, err := client.ArgoSmartRouting(ctx, testAccCloudflareZoneID)
+	require.NoError(t, err)
+	assert.Equal(t, "off", smartRouting.Value)
+	tieredCaching, err := client.ArgoTieredCaching(ctx, testAccCloudflareZoneID)
+	require.NoError(t, err)
+	assert.Equal(t, "on", tieredCaching.Value, "tiered caching isn't managed by the destroyed resource")
+
+	_, diags = p.ResourcesMap["cloudflare_tiered_cache"].Apply(ctx, states["cloudflare_tiered_cache"], &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	tieredCaching, err = client.ArgoTieredCaching(ctx, testAccCloudflareZoneID)
+	require.NoError(t, err)
+	assert.Equal(t, "off", tieredCaching.Value)
+	regional, err := zoneCacheSettingOf(client, testAccCloudflareZoneID, regionalTieredCacheSetting)
+	require.NoError(t, err)
+	assert.Equal(t, "on", regional.Value, "regional tiered cache is left as is")
+}
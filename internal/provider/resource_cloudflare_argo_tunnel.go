@@ -27,7 +27,10 @@ func resourceCloudflareArgoTunnel() *schema.Resource {
 }
 
 func resourceCloudflareArgoTunnelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accID := d.Get("account_id").(string)
 	name := d.Get("name").(string)
 	secret := d.Get("secret").(string)
@@ -43,7 +46,10 @@ func resourceCloudflareArgoTunnelCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareArgoTunnelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accID := d.Get("account_id").(string)
 
 	tunnel, err := client.ArgoTunnel(ctx, accID, d.Id())
@@ -69,7 +75,10 @@ func resourceCloudflareArgoTunnelRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceCloudflareArgoTunnelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accID := d.Get("account_id").(string)
 
 	cleanupErr := client.CleanupArgoTunnelConnections(ctx, accID, d.Id())
@@ -88,7 +97,10 @@ func resourceCloudflareArgoTunnelDelete(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareArgoTunnelImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return nil, clientErr
+	}
 	attributes := strings.Split(d.Id(), "/")
 
 	if len(attributes) != 2 {
@@ -31,7 +31,10 @@ func resourceCloudflareAuthenticatedOriginPullsCertificate() *schema.Resource {
 }
 
 func resourceCloudflareAuthenticatedOriginPullsCertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	switch aopType, ok := d.GetOk("type"); ok {
@@ -101,7 +104,10 @@ func resourceCloudflareAuthenticatedOriginPullsCertificateCreate(ctx context.Con
 }
 
 func resourceCloudflareAuthenticatedOriginPullsCertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	certID := d.Id()
 
@@ -142,7 +148,10 @@ func resourceCloudflareAuthenticatedOriginPullsCertificateRead(ctx context.Conte
 }
 
 func resourceCloudflareAuthenticatedOriginPullsCertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	certID := d.Id()
 
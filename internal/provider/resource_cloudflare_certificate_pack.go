@@ -28,7 +28,10 @@ func resourceCloudflareCertificatePack() *schema.Resource {
 }
 
 func resourceCloudflareCertificatePackCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	certificatePackType := d.Get("type").(string)
 	certificateHostSet := d.Get("hosts").(*schema.Set)
@@ -93,7 +96,10 @@ func resourceCloudflareCertificatePackCreate(ctx context.Context, d *schema.Reso
 }
 
 func resourceCloudflareCertificatePackRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	certificatePack, err := client.CertificatePack(ctx, zoneID, d.Id())
@@ -132,7 +138,10 @@ func resourceCloudflareCertificatePackRead(ctx context.Context, d *schema.Resour
 }
 
 func resourceCloudflareCertificatePackDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	err := client.DeleteCertificatePack(ctx, zoneID, d.Id())
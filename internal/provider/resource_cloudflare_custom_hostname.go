@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/pkg/errors"
 )
@@ -23,11 +25,26 @@ func resourceCloudflareCustomHostname() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceCloudflareCustomHostnameImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
 	}
 }
 
+// customHostnameSSLValidatedStatuses are the statuses of certificates whose
+// validation records are set, or which no longer need them.
+var customHostnameSSLValidatedStatuses = []string{"pending_validation", "pending_issuance", "pending_deployment", "active"}
+
+// customHostnameSSLFailedStatuses are the statuses of certificates which
+// won't become active without being changed.
+var customHostnameSSLFailedStatuses = []string{"validation_timed_out", "issuance_timed_out", "deployment_timed_out", "deletion_timed_out", "expired"}
+
 func resourceCloudflareCustomHostnameRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	hostnameID := d.Id()
 
@@ -37,6 +54,7 @@ func resourceCloudflareCustomHostnameRead(ctx context.Context, d *schema.Resourc
 	}
 
 	d.Set("hostname", customHostname.Hostname)
+	d.Set("status", customHostname.Status)
 	d.Set("custom_origin_server", customHostname.CustomOriginServer)
 	d.Set("custom_origin_sni", customHostname.CustomOriginSNI)
 	var sslConfig []map[string]interface{}
@@ -109,7 +127,10 @@ func resourceCloudflareCustomHostnameRead(ctx context.Context, d *schema.Resourc
 }
 
 func resourceCloudflareCustomHostnameDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	hostnameID := d.Id()
 
@@ -122,7 +143,10 @@ func resourceCloudflareCustomHostnameDelete(ctx context.Context, d *schema.Resou
 }
 
 func resourceCloudflareCustomHostnameCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	certificate := buildCustomHostname(d)
@@ -134,11 +158,18 @@ func resourceCloudflareCustomHostnameCreate(ctx context.Context, d *schema.Resou
 
 	d.SetId(newCertificate.Result.ID)
 
+	if diags := waitForCustomHostname(ctx, d, client, d.Timeout(schema.TimeoutCreate)); diags.HasError() {
+		return diags
+	}
+
 	return resourceCloudflareCustomHostnameRead(ctx, d, meta)
 }
 
 func resourceCloudflareCustomHostnameUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	hostnameID := d.Id()
 	certificate := buildCustomHostname(d)
@@ -148,6 +179,10 @@ func resourceCloudflareCustomHostnameUpdate(ctx context.Context, d *schema.Resou
 		return diag.FromErr(errors.Wrap(err, "failed to update custom hostname certificate"))
 	}
 
+	if diags := waitForCustomHostname(ctx, d, client, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+		return diags
+	}
+
 	return resourceCloudflareCustomHostnameRead(ctx, d, meta)
 }
 
@@ -168,6 +203,74 @@ func resourceCloudflareCustomHostnameImport(ctx context.Context, d *schema.Resou
 	return []*schema.ResourceData{d}, nil
 }
 
+// waitForCustomHostname polls a custom hostname until it reaches the status
+// its resource is configured to wait for, if any. The validation errors of
+// hostnames that fail validation are returned as diagnostics.
+func waitForCustomHostname(ctx context.Context, d *schema.ResourceData, client *cloudflare.API, timeout time.Duration) diag.Diagnostics {
+	waitForActive := d.Get("wait_for_active_status").(bool)
+	if !waitForActive && !d.Get("wait_for_ssl_pending_validation").(bool) {
+		return nil
+	}
+	zoneID := d.Get("zone_id").(string)
+	hostnameID := d.Id()
+
+	var customHostname cloudflare.CustomHostname
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		var err error
+		customHostname, err = client.CustomHostname(ctx, zoneID, hostnameID)
+		if err != nil {
+			return resource.NonRetryableError(errors.Wrap(err, fmt.Sprintf("error reading custom hostname %q", hostnameID)))
+		}
+
+		sslStatus := "not requested"
+		if customHostname.SSL != nil {
+			sslStatus = customHostname.SSL.Status
+		}
+
+		switch customHostname.Status {
+		case cloudflare.BLOCKED, cloudflare.MOVED, cloudflare.DELETED:
+			return resource.NonRetryableError(fmt.Errorf("custom hostname %q is %s", customHostname.Hostname, customHostname.Status))
+		}
+		if contains(customHostnameSSLFailedStatuses, sslStatus) {
+			return resource.NonRetryableError(fmt.Errorf("certificate of custom hostname %q failed: %s", customHostname.Hostname, sslStatus))
+		}
+
+		if waitForActive {
+			if customHostname.Status == cloudflare.ACTIVE && (customHostname.SSL == nil || sslStatus == "active") {
+				return nil
+			}
+		} else if customHostname.SSL == nil || contains(customHostnameSSLValidatedStatuses, sslStatus) {
+			return nil
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Custom hostname %s is %s and its certificate is %s", customHostname.Hostname, customHostname.Status, sslStatus))
+		return resource.RetryableError(fmt.Errorf("custom hostname %q is %s and its certificate is %s", customHostname.Hostname, customHostname.Status, sslStatus))
+	})
+	if err == nil {
+		return nil
+	}
+
+	diags := diag.FromErr(err)
+	if customHostname.SSL != nil {
+		for _, e := range customHostname.SSL.ValidationErrors {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Certificate validation error for custom hostname %q", customHostname.Hostname),
+				Detail:   e.Message,
+			})
+		}
+	}
+	for _, e := range customHostname.VerificationErrors {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Ownership verification error for custom hostname %q", customHostname.Hostname),
+			Detail:   e,
+		})
+	}
+
+	return diags
+}
+
 // buildCustomHostname takes the existing schema and returns a
 // `cloudflare.CustomHostname`.
 func buildCustomHostname(d *schema.ResourceData) cloudflare.CustomHostname {
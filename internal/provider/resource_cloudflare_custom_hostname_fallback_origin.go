@@ -27,7 +27,10 @@ func resourceCloudflareCustomHostnameFallbackOrigin() *schema.Resource {
 }
 
 func resourceCloudflareCustomHostnameFallbackOriginRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	customHostnameFallbackOrigin, err := client.CustomHostnameFallbackOrigin(ctx, zoneID)
@@ -42,7 +45,10 @@ func resourceCloudflareCustomHostnameFallbackOriginRead(ctx context.Context, d *
 }
 
 func resourceCloudflareCustomHostnameFallbackOriginDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	err := client.DeleteCustomHostnameFallbackOrigin(ctx, zoneID)
@@ -54,7 +60,10 @@ func resourceCloudflareCustomHostnameFallbackOriginDelete(ctx context.Context, d
 }
 
 func resourceCloudflareCustomHostnameFallbackOriginCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	origin := d.Get("origin").(string)
 
@@ -101,7 +110,10 @@ func resourceCloudflareCustomHostnameFallbackOriginCreate(ctx context.Context, d
 }
 
 func resourceCloudflareCustomHostnameFallbackOriginUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	origin := d.Get("origin").(string)
 
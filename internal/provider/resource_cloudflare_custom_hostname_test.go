@@ -8,10 +8,15 @@ import (
 	"testing"
 
 	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
@@ -95,6 +100,77 @@ resource "cloudflare_custom_hostname" "%[2]s" {
 `, zoneID, rnd, domain)
 }
 
+func TestAccCloudflareCustomHostname_WaitForSSLPendingValidation(t *testing.T) {
+	t.Parallel()
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	rnd := generateRandomResourceName()
+	resourceName := "cloudflare_custom_hostname." + rnd
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareCustomHostnameWaitForSSLPendingValidation(zoneID, rnd, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "ssl.0.status", "pending_validation"),
+					resource.TestCheckResourceAttrSet(resourceName, "ssl.0.validation_records.0.txt_name"),
+					resource.TestCheckResourceAttrSet(resourceName, "ssl.0.validation_records.0.txt_value"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareCustomHostnameWaitForSSLPendingValidation(zoneID, rnd, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_custom_hostname" "%[2]s" {
+  zone_id  = "%[1]s"
+  hostname = "%[2]s.%[3]s"
+  ssl {
+    method = "txt"
+  }
+  wait_for_ssl_pending_validation = true
+}
+`, zoneID, rnd, domain)
+}
+
+func TestCustomHostnameWait(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+	r := p.ResourcesMap["cloudflare_custom_hostname"]
+	ctx := context.Background()
+
+	create := func(hostname, wait string) (*schema.ResourceData, diag.Diagnostics) {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"zone_id":  testAccCloudflareZoneID,
+			"hostname": hostname,
+			"ssl":      []interface{}{map[string]interface{}{"method": "txt"}},
+			wait:       true,
+		})
+		return d, r.CreateContext(ctx, d, m)
+	}
+
+	d, diags := create("shop.example.com", "wait_for_ssl_pending_validation")
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Contains(t, customHostnameSSLValidatedStatuses, d.Get("ssl.0.status"))
+	assert.Equal(t, "_acme-challenge.shop.example.com", d.Get("ssl.0.validation_records.0.txt_name"))
+
+	d, diags = create("store.example.com", "wait_for_active_status")
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "active", d.Get("status"))
+	assert.Equal(t, "active", d.Get("ssl.0.status"))
+
+	// Validation errors are reported along with the failure.
+	_, diags = create("shop.example.invalid", "wait_for_active_status")
+	require.Len(t, diags, 3)
+	assert.Equal(t, `certificate of custom hostname "shop.example.invalid" failed: validation_timed_out`, diags[0].Summary)
+	assert.Equal(t, "TXT record for shop.example.invalid not found", diags[1].Detail)
+	assert.Equal(t, `Ownership verification error for custom hostname "shop.example.invalid"`, diags[2].Summary)
+}
+
 func TestAccCloudflareCustomHostname_WithCustomOriginServer(t *testing.T) {
 	t.Parallel()
 	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
@@ -420,7 +496,7 @@ func testAccCheckCloudflareCustomHostnameExists(n string, customHostname *cloudf
 			return fmt.Errorf("No CustomHostname ID is set")
 		}
 
-		client := testAccProvider.Meta().(*cloudflare.API)
+		client := testAccProvider.Meta().(*providerMeta).defaultClient()
 		foundCustomHostname, err := client.CustomHostname(context.Background(), rs.Primary.Attributes["zone_id"], rs.Primary.ID)
 		if err != nil {
 			return err
@@ -26,7 +26,10 @@ func resourceCloudflareCustomPages() *schema.Resource {
 }
 
 func resourceCloudflareCustomPagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	accountID := d.Get("account_id").(string)
 	pageType := d.Get("type").(string)
@@ -73,7 +76,10 @@ func resourceCloudflareCustomPagesRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCloudflareCustomPagesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	zoneID := d.Get("zone_id").(string)
 
@@ -98,7 +104,10 @@ func resourceCloudflareCustomPagesUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareCustomPagesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	zoneID := d.Get("zone_id").(string)
 
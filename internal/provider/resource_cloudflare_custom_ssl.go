@@ -41,7 +41,10 @@ func resourceCloudflareCustomSsl() *schema.Resource {
 }
 
 func resourceCloudflareCustomSslCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	tflog.Debug(ctx, fmt.Sprintf("zone ID: %s", zoneID))
 	zcso, err := expandToZoneCustomSSLOptions(ctx, d)
@@ -82,7 +85,10 @@ func resourceCloudflareCustomSslCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCloudflareCustomSslUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	certID := d.Id()
 	var uErr error
@@ -129,7 +135,10 @@ func resourceCloudflareCustomSslUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCloudflareCustomSslRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	certID := d.Id()
 
@@ -163,7 +172,10 @@ func resourceCloudflareCustomSslRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceCloudflareCustomSslDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	certID := d.Id()
 
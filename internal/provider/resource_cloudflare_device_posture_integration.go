@@ -32,7 +32,10 @@ func resourceCloudflareDevicePostureIntegration() *schema.Resource {
 }
 
 func resourceCloudflareDevicePostureIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	newDevicePostureIntegration := cloudflare.DevicePostureIntegration{
@@ -67,7 +70,10 @@ func resourceCloudflareDevicePostureIntegrationRead(ctx context.Context, d *sche
 }
 
 func devicePostureIntegrationReadHelper(ctx context.Context, d *schema.ResourceData, meta interface{}, secret string) error {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return clientErr
+	}
 	accountID := d.Get("account_id").(string)
 
 	devicePostureIntegration, err := client.DevicePostureIntegration(ctx, accountID, d.Id())
@@ -90,7 +96,10 @@ func devicePostureIntegrationReadHelper(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareDevicePostureIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	updatedDevicePostureIntegration := cloudflare.DevicePostureIntegration{
@@ -120,7 +129,10 @@ func resourceCloudflareDevicePostureIntegrationUpdate(ctx context.Context, d *sc
 }
 
 func resourceCloudflareDevicePostureIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	appID := d.Id()
 	accountID := d.Get("account_id").(string)
 
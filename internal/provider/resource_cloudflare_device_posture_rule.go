@@ -26,7 +26,10 @@ func resourceCloudflareDevicePostureRule() *schema.Resource {
 }
 
 func resourceCloudflareDevicePostureRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	newDevicePostureRule := cloudflare.DevicePostureRule{
@@ -56,7 +59,10 @@ func resourceCloudflareDevicePostureRuleCreate(ctx context.Context, d *schema.Re
 }
 
 func resourceCloudflareDevicePostureRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	devicePostureRule, err := client.DevicePostureRule(ctx, accountID, d.Id())
@@ -81,7 +87,10 @@ func resourceCloudflareDevicePostureRuleRead(ctx context.Context, d *schema.Reso
 }
 
 func resourceCloudflareDevicePostureRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	updatedDevicePostureRule := cloudflare.DevicePostureRule{
@@ -114,7 +123,10 @@ func resourceCloudflareDevicePostureRuleUpdate(ctx context.Context, d *schema.Re
 }
 
 func resourceCloudflareDevicePostureRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	appID := d.Id()
 	accountID := d.Get("account_id").(string)
 
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareEmailRoutingAddress() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareEmailRoutingAddressSchema(),
+		CreateContext: resourceCloudflareEmailRoutingAddressCreate,
+		ReadContext:   resourceCloudflareEmailRoutingAddressRead,
+		DeleteContext: resourceCloudflareEmailRoutingAddressDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareEmailRoutingAddressImport,
+		},
+	}
+}
+
+func resourceCloudflareEmailRoutingAddressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	address := emailRoutingAddress{Email: d.Get("email").(string)}
+
+	result, err := client.Raw(http.MethodPost, emailRoutingAddressURI(accountID), address)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error adding Email Routing destination address %q: %w", address.Email, err))
+	}
+	if err := json.Unmarshal(result, &address); err != nil {
+		return diag.FromErr(fmt.Errorf("error adding Email Routing destination address %q: %w", address.Email, err))
+	}
+
+	d.SetId(address.Tag)
+
+	return resourceCloudflareEmailRoutingAddressRead(ctx, d, meta)
+}
+
+func resourceCloudflareEmailRoutingAddressRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	result, err := client.Raw(http.MethodGet, emailRoutingAddressURI(accountID, d.Id()), nil)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Email Routing destination address %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading Email Routing destination address %q: %w", d.Id(), err))
+	}
+
+	var address emailRoutingAddress
+	if err := json.Unmarshal(result, &address); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Email Routing destination address %q: %w", d.Id(), err))
+	}
+
+	d.Set("email", address.Email)
+	d.Set("verified", address.Verified)
+	d.Set("created", address.Created)
+
+	return nil
+}
+
+func resourceCloudflareEmailRoutingAddressDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	if _, err := client.Raw(http.MethodDelete, emailRoutingAddressURI(accountID, d.Id()), nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error removing Email Routing destination address %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareEmailRoutingAddressImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 2)
+
+	if len(attributes) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/addressID\"", d.Id())
+	}
+
+	accountID, addressID := attributes[0], attributes[1]
+	d.SetId(addressID)
+	d.Set("account_id", accountID)
+
+	resourceCloudflareEmailRoutingAddressRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
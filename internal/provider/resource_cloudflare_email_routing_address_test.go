@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailRoutingAddressApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_email_routing_address"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id": testAccCloudflareAccountID,
+		"email":      "owner@example.com",
+	})
+
+	diff, err := r.Diff(ctx, nil, config, m)
+	require.NoError(t, err)
+	state, diags := r.Apply(ctx, nil, diff, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.NotEmpty(t, state.ID)
+	assert.Empty(t, state.Attributes["verified"], "addresses are pending until their owner verifies them")
+
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.NotEmpty(t, state.Attributes["verified"])
+
+	diff, err = r.Diff(ctx, state, config, m)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "%v", diff)
+
+	_, diags = r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state)
+}
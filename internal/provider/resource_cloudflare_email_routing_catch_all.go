@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// emailRoutingCatchAllMatchers are the matchers of every catch-all rule.
+var emailRoutingCatchAllMatchers = []emailRoutingRuleMatcher{{Type: "all"}}
+
+func resourceCloudflareEmailRoutingCatchAll() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareEmailRoutingCatchAllSchema(),
+		CreateContext: resourceCloudflareEmailRoutingCatchAllCreate,
+		ReadContext:   resourceCloudflareEmailRoutingCatchAllRead,
+		UpdateContext: resourceCloudflareEmailRoutingCatchAllUpdate,
+		DeleteContext: resourceCloudflareEmailRoutingCatchAllDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareEmailRoutingCatchAllImport,
+		},
+	}
+}
+
+// Every zone has a catch-all rule, so creating the resource only updates it.
+func resourceCloudflareEmailRoutingCatchAllCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(d.Get("zone_id").(string))
+
+	return resourceCloudflareEmailRoutingCatchAllUpdate(ctx, d, meta)
+}
+
+func resourceCloudflareEmailRoutingCatchAllRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	result, err := client.Raw(http.MethodGet, emailRoutingURI(zoneID, "rules", "catch_all"), nil)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Zone %s no longer exists", zoneID))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading Email Routing catch-all rule of zone %q: %w", zoneID, err))
+	}
+
+	var rule emailRoutingRule
+	if err := json.Unmarshal(result, &rule); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Email Routing catch-all rule of zone %q: %w", zoneID, err))
+	}
+
+	d.Set("name", rule.Name)
+	d.Set("enabled", rule.Enabled)
+	d.Set("action", flattenEmailRoutingRuleActions(rule.Actions))
+
+	return nil
+}
+
+func resourceCloudflareEmailRoutingCatchAllUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	actions, err := expandEmailRoutingRuleActions(d.Get("action").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rule := emailRoutingRule{
+		Name:     d.Get("name").(string),
+		Enabled:  d.Get("enabled").(bool),
+		Matchers: emailRoutingCatchAllMatchers,
+		Actions:  actions,
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating Cloudflare Email Routing catch-all rule from struct: %+v", rule))
+
+	if _, err := client.Raw(http.MethodPut, emailRoutingURI(zoneID, "rules", "catch_all"), rule); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating Email Routing catch-all rule of zone %q: %w", zoneID, err))
+	}
+
+	return resourceCloudflareEmailRoutingCatchAllRead(ctx, d, meta)
+}
+
+// The catch-all rule can't be deleted, so it's disabled and set back to
+// dropping email instead.
+func resourceCloudflareEmailRoutingCatchAllDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	rule := emailRoutingRule{
+		Matchers: emailRoutingCatchAllMatchers,
+		Actions:  []emailRoutingRuleAction{{Type: "drop"}},
+	}
+	if _, err := client.Raw(http.MethodPut, emailRoutingURI(zoneID, "rules", "catch_all"), rule); err != nil {
+		return diag.FromErr(fmt.Errorf("error resetting Email Routing catch-all rule of zone %q: %w", zoneID, err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareEmailRoutingCatchAllImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("zone_id", d.Id())
+
+	resourceCloudflareEmailRoutingCatchAllRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareEmailRoutingRule() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareEmailRoutingRuleSchema(),
+		CreateContext: resourceCloudflareEmailRoutingRuleCreate,
+		ReadContext:   resourceCloudflareEmailRoutingRuleRead,
+		UpdateContext: resourceCloudflareEmailRoutingRuleUpdate,
+		DeleteContext: resourceCloudflareEmailRoutingRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareEmailRoutingRuleImport,
+		},
+	}
+}
+
+func resourceCloudflareEmailRoutingRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	rule, err := buildEmailRoutingRule(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating Cloudflare Email Routing rule from struct: %+v", rule))
+
+	result, err := client.Raw(http.MethodPost, emailRoutingURI(zoneID, "rules"), rule)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Email Routing rule for zone %q: %w", zoneID, err))
+	}
+	if err := json.Unmarshal(result, &rule); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Email Routing rule for zone %q: %w", zoneID, err))
+	}
+
+	d.SetId(rule.Tag)
+
+	return resourceCloudflareEmailRoutingRuleRead(ctx, d, meta)
+}
+
+func resourceCloudflareEmailRoutingRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	result, err := client.Raw(http.MethodGet, emailRoutingURI(zoneID, "rules", d.Id()), nil)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Email Routing rule %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading Email Routing rule %q: %w", d.Id(), err))
+	}
+
+	var rule emailRoutingRule
+	if err := json.Unmarshal(result, &rule); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Email Routing rule %q: %w", d.Id(), err))
+	}
+
+	matchers := make([]map[string]interface{}, 0, len(rule.Matchers))
+	for _, m := range rule.Matchers {
+		matchers = append(matchers, map[string]interface{}{
+			"type":  m.Type,
+			"field": m.Field,
+			"value": m.Value,
+		})
+	}
+
+	d.Set("name", rule.Name)
+	d.Set("enabled", rule.Enabled)
+	d.Set("priority", rule.Priority)
+	d.Set("matcher", matchers)
+	d.Set("action", flattenEmailRoutingRuleActions(rule.Actions))
+
+	return nil
+}
+
+func resourceCloudflareEmailRoutingRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	rule, err := buildEmailRoutingRule(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := client.Raw(http.MethodPut, emailRoutingURI(zoneID, "rules", d.Id()), rule); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating Email Routing rule %q: %w", d.Id(), err))
+	}
+
+	return resourceCloudflareEmailRoutingRuleRead(ctx, d, meta)
+}
+
+func resourceCloudflareEmailRoutingRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	if _, err := client.Raw(http.MethodDelete, emailRoutingURI(zoneID, "rules", d.Id()), nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting Email Routing rule %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareEmailRoutingRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 2)
+
+	if len(attributes) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"zoneID/ruleID\"", d.Id())
+	}
+
+	zoneID, ruleID := attributes[0], attributes[1]
+	d.SetId(ruleID)
+	d.Set("zone_id", zoneID)
+
+	resourceCloudflareEmailRoutingRuleRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildEmailRoutingRule(d *schema.ResourceData) (emailRoutingRule, error) {
+	rule := emailRoutingRule{
+		Name:     d.Get("name").(string),
+		Enabled:  d.Get("enabled").(bool),
+		Priority: d.Get("priority").(int),
+	}
+
+	for _, m := range d.Get("matcher").([]interface{}) {
+		m := m.(map[string]interface{})
+		rule.Matchers = append(rule.Matchers, emailRoutingRuleMatcher{
+			Type:  m["type"].(string),
+			Field: m["field"].(string),
+			Value: m["value"].(string),
+		})
+	}
+
+	actions, err := expandEmailRoutingRuleActions(d.Get("action").([]interface{}))
+	if err != nil {
+		return rule, err
+	}
+	rule.Actions = actions
+
+	return rule, nil
+}
+
+// expandEmailRoutingRuleActions returns the actions of a rule, checking that
+// only forwarding and Workers are given values.
+func expandEmailRoutingRuleActions(actions []interface{}) ([]emailRoutingRuleAction, error) {
+	var out []emailRoutingRuleAction
+	for i, a := range actions {
+		a := a.(map[string]interface{})
+		action := emailRoutingRuleAction{
+			Type:  a["type"].(string),
+			Value: expandInterfaceToStringList(a["value"]),
+		}
+
+		switch {
+		case action.Type == "drop" && len(action.Value) > 0:
+			return nil, fmt.Errorf("action %d drops email and can't have a value", i)
+		case action.Type != "drop" && len(action.Value) == 0:
+			return nil, fmt.Errorf("action %d of type %q must have a value", i, action.Type)
+		}
+
+		out = append(out, action)
+	}
+	return out, nil
+}
+
+func flattenEmailRoutingRuleActions(actions []emailRoutingRuleAction) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(actions))
+	for _, a := range actions {
+		out = append(out, map[string]interface{}{
+			"type":  a.Type,
+			"value": a.Value,
+		})
+	}
+	return out
+}
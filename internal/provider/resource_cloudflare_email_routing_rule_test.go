@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareEmailRoutingRule_Basic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_email_routing_rule.%s", rnd)
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareEmailRoutingRule(rnd, zoneID, domain, "forward"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "matcher.0.value", fmt.Sprintf("%s@%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "action.0.type", "forward"),
+					resource.TestCheckResourceAttr(name, "action.0.value.0", fmt.Sprintf("%s@example.com", rnd)),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareEmailRoutingRule(rnd, zoneID, domain, "drop"),
+				Check:  resource.TestCheckResourceAttr(name, "action.0.type", "drop"),
+			},
+			{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateIdPrefix: fmt.Sprintf("%s/", zoneID),
+				ImportStateVerify:   true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareEmailRoutingRule(name, zoneID, domain, action string) string {
+	value := ""
+	if action == "forward" {
+		value = fmt.Sprintf(`value = ["%s@example.com"]`, name)
+	}
+	return fmt.Sprintf(`
+resource "cloudflare_email_routing_rule" "%[1]s" {
+  zone_id = "%[2]s"
+  name    = "%[1]s"
+
+  matcher {
+    type  = "literal"
+    field = "to"
+    value = "%[1]s@%[3]s"
+  }
+
+  action {
+    type = "%[4]s"
+    %[5]s
+  }
+}`, name, zoneID, domain, action, value)
+}
+
+func TestEmailRoutingRuleApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_email_routing_rule"]
+	config := func(action map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"zone_id":  testAccCloudflareZoneID,
+			"name":     "info",
+			"priority": 1,
+			"matcher":  []interface{}{map[string]interface{}{"type": "literal", "field": "to", "value": "info@" + testAccCloudflareZoneName}},
+			"action":   []interface{}{action},
+		}
+	}
+
+	_, err := testMockAPIApplyE(t, r, m, nil, config(map[string]interface{}{"type": "forward"}))
+	assert.EqualError(t, err, `action 0 of type "forward" must have a value`)
+	_, err = testMockAPIApplyE(t, r, m, nil, config(map[string]interface{}{"type": "drop", "value": []interface{}{"owner@example.com"}}))
+	assert.EqualError(t, err, "action 0 drops email and can't have a value")
+
+	state := testMockAPIApply(t, r, m, nil, config(map[string]interface{}{"type": "forward", "value": []interface{}{"owner@example.com"}}))
+	assert.Equal(t, "true", state.Attributes["enabled"])
+	assert.Equal(t, "owner@example.com", state.Attributes["action.0.value.0"])
+
+	id := state.ID
+	state = testMockAPIApply(t, r, m, state, config(map[string]interface{}{"type": "worker", "value": []interface{}{"inbox"}}))
+	assert.Equal(t, id, state.ID, "the rule is updated in place")
+	assert.Equal(t, "worker", state.Attributes["action.0.type"])
+
+	_, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state)
+}
+
+func TestEmailRoutingCatchAllApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_email_routing_catch_all"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"zone_id": testAccCloudflareZoneID,
+		"action":  []interface{}{map[string]interface{}{"type": "forward", "value": []interface{}{"owner@example.com"}}},
+	})
+
+	diff, err := r.Diff(ctx, nil, config, m)
+	require.NoError(t, err)
+	state, diags := r.Apply(ctx, nil, diff, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, testAccCloudflareZoneID, state.ID)
+	assert.Equal(t, "true", state.Attributes["enabled"])
+
+	diff, err = r.Diff(ctx, state, config, m)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "%v", diff)
+
+	// Destroying the rule sets it back to dropping email.
+	_, diags = r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "false", state.Attributes["enabled"])
+	assert.Equal(t, "drop", state.Attributes["action.0.type"])
+}
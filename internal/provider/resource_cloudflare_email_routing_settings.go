@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareEmailRoutingSettings() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareEmailRoutingSettingsSchema(),
+		CreateContext: resourceCloudflareEmailRoutingSettingsCreate,
+		ReadContext:   resourceCloudflareEmailRoutingSettingsRead,
+		UpdateContext: resourceCloudflareEmailRoutingSettingsUpdate,
+		DeleteContext: resourceCloudflareEmailRoutingSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareEmailRoutingSettingsImport,
+		},
+	}
+}
+
+func resourceCloudflareEmailRoutingSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(d.Get("zone_id").(string))
+
+	return resourceCloudflareEmailRoutingSettingsUpdate(ctx, d, meta)
+}
+
+func resourceCloudflareEmailRoutingSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	settings, err := emailRoutingSettingsOf(client, zoneID)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Zone %s no longer exists", zoneID))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading Email Routing settings of zone %q: %w", zoneID, err))
+	}
+
+	records, err := emailRoutingDNSRecordsOf(client, zoneID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading DNS records needed by Email Routing on zone %q: %w", zoneID, err))
+	}
+
+	d.Set("enabled", settings.Enabled)
+	d.Set("status", settings.Status)
+	d.Set("dns_records", flattenEmailRoutingDNSRecords(records))
+
+	return nil
+}
+
+func resourceCloudflareEmailRoutingSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	if diags := setEmailRoutingEnabled(ctx, client, d.Get("zone_id").(string), d.Get("enabled").(bool)); diags.HasError() {
+		return diags
+	}
+
+	return resourceCloudflareEmailRoutingSettingsRead(ctx, d, meta)
+}
+
+func resourceCloudflareEmailRoutingSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	return setEmailRoutingEnabled(ctx, client, d.Get("zone_id").(string), false)
+}
+
+func resourceCloudflareEmailRoutingSettingsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("zone_id", d.Id())
+
+	resourceCloudflareEmailRoutingSettingsRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// setEmailRoutingEnabled enables or disables routing on the zone when it
+// isn't already. Routing isn't enabled on zones with MX or SPF records it
+// would conflict with: the API would otherwise replace them, which is left
+// to whatever manages them, such as cloudflare_record resources.
+func setEmailRoutingEnabled(ctx context.Context, client *cloudflare.API, zoneID string, enabled bool) diag.Diagnostics {
+	settings, err := emailRoutingSettingsOf(client, zoneID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Email Routing settings of zone %q: %w", zoneID, err))
+	}
+	if settings.Enabled == enabled {
+		return nil
+	}
+
+	if !enabled {
+		tflog.Debug(ctx, fmt.Sprintf("Disabling Email Routing on zone %s", zoneID))
+		if _, err := client.Raw(http.MethodPost, emailRoutingURI(zoneID, "disable"), struct{}{}); err != nil {
+			return diag.FromErr(fmt.Errorf("error disabling Email Routing on zone %q: %w", zoneID, err))
+		}
+		return nil
+	}
+
+	required, err := emailRoutingDNSRecordsOf(client, zoneID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading DNS records needed by Email Routing on zone %q: %w", zoneID, err))
+	}
+	var existing []cloudflare.DNSRecord
+	for _, recordType := range []string{"MX", "TXT"} {
+		records, err := client.DNSRecords(ctx, zoneID, cloudflare.DNSRecord{Type: recordType})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error listing %s records of zone %q: %w", recordType, zoneID, err))
+		}
+		existing = append(existing, records...)
+	}
+
+	adopted, conflicting := reconcileEmailRoutingDNSRecords(required, existing)
+	if len(conflicting) > 0 {
+		var diags diag.Diagnostics
+		for _, r := range conflicting {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s record %q conflicts with Email Routing", r.Type, r.Name),
+				Detail:   fmt.Sprintf("The record with ID %s and content %q routes the email of the zone elsewhere. Remove it, or the resource managing it, before enabling Email Routing.", r.ID, r.Content),
+			})
+		}
+		return diags
+	}
+	for _, r := range adopted {
+		tflog.Info(ctx, fmt.Sprintf("Email Routing uses existing %s record %s of zone %s", r.Type, r.ID, zoneID))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Enabling Email Routing on zone %s", zoneID))
+	if _, err := client.Raw(http.MethodPost, emailRoutingURI(zoneID, "enable"), struct{}{}); err != nil {
+		return diag.FromErr(fmt.Errorf("error enabling Email Routing on zone %q: %w", zoneID, err))
+	}
+
+	return nil
+}
+
+// reconcileEmailRoutingDNSRecords sorts the existing records of a zone into
+// those Email Routing needs, which it uses instead of adding its own, and
+// the MX and SPF records at the same names that route email elsewhere.
+func reconcileEmailRoutingDNSRecords(required []emailRoutingDNSRecord, existing []cloudflare.DNSRecord) (adopted, conflicting []cloudflare.DNSRecord) {
+	names := make(map[string]bool)
+	for _, r := range required {
+		names[strings.ToLower(r.Name)] = true
+	}
+
+	for _, e := range existing {
+		if !names[strings.ToLower(e.Name)] {
+			continue
+		}
+		isSPF := e.Type == "TXT" && strings.HasPrefix(strings.Trim(e.Content, `"`), "v=spf1")
+		if e.Type != "MX" && !isSPF {
+			continue
+		}
+
+		needed := false
+		for _, r := range required {
+			needed = needed || (r.Type == e.Type && strings.EqualFold(r.Name, e.Name) && r.Content == strings.Trim(e.Content, `"`))
+		}
+		if needed {
+			adopted = append(adopted, e)
+		} else {
+			conflicting = append(conflicting, e)
+		}
+	}
+
+	return adopted, conflicting
+}
+
+func emailRoutingSettingsOf(client *cloudflare.API, zoneID string) (emailRoutingSettings, error) {
+	var settings emailRoutingSettings
+	result, err := client.Raw(http.MethodGet, emailRoutingURI(zoneID), nil)
+	if err != nil {
+		return settings, err
+	}
+	err = json.Unmarshal(result, &settings)
+	return settings, err
+}
+
+func emailRoutingDNSRecordsOf(client *cloudflare.API, zoneID string) ([]emailRoutingDNSRecord, error) {
+	var records []emailRoutingDNSRecord
+	result, err := client.Raw(http.MethodGet, emailRoutingURI(zoneID, "dns"), nil)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(result, &records)
+	return records, err
+}
+
+func flattenEmailRoutingDNSRecords(records []emailRoutingDNSRecord) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(records))
+	for _, r := range records {
+		out = append(out, map[string]interface{}{
+			"type":     r.Type,
+			"name":     r.Name,
+			"content":  r.Content,
+			"priority": r.Priority,
+			"ttl":      r.TTL,
+		})
+	}
+	return out
+}
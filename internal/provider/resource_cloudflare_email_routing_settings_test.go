@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailRoutingSettingsApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+	client := m.defaultClient()
+	ctx := context.Background()
+
+	// One of the records routing needs is already managed elsewhere, and
+	// another routes email to a different mail server.
+	priority := uint16(10)
+	needed, err := client.CreateDNSRecord(ctx, testAccCloudflareZoneID, cloudflare.DNSRecord{Type: "MX", Name: "@", Content: "route1.mx.cloudflare.net", Priority: &priority})
+	require.NoError(t, err)
+	other, err := client.CreateDNSRecord(ctx, testAccCloudflareZoneID, cloudflare.DNSRecord{Type: "MX", Name: "@", Content: "mx.example.net", Priority: &priority})
+	require.NoError(t, err)
+
+	r := p.ResourcesMap["cloudflare_email_routing_settings"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"zone_id": testAccCloudflareZoneID,
+		"enabled": true,
+	})
+
+	diff, err := r.Diff(ctx, nil, config, m)
+	require.NoError(t, err)
+	_, diags := r.Apply(ctx, nil, diff, m)
+	require.True(t, diags.HasError())
+	assert.Equal(t, `MX record "`+testAccCloudflareZoneName+`" conflicts with Email Routing`, diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, other.Result.ID)
+
+	require.NoError(t, client.DeleteDNSRecord(ctx, testAccCloudflareZoneID, other.Result.ID))
+	state, diags := r.Apply(ctx, nil, diff, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "ready", state.Attributes["status"])
+	assert.Equal(t, "4", state.Attributes["dns_records.#"])
+
+	records, err := client.DNSRecords(ctx, testAccCloudflareZoneID, cloudflare.DNSRecord{})
+	require.NoError(t, err)
+	assert.Len(t, records, 4, "the existing record is used rather than duplicated")
+
+	diff, err = r.Diff(ctx, state, config, m)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "%v", diff)
+
+	// Disabling routing leaves the records it didn't add in place.
+	_, diags = r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	records, err = client.DNSRecords(ctx, testAccCloudflareZoneID, cloudflare.DNSRecord{})
+	require.NoError(t, err)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, needed.Result.ID, records[0].ID)
+	}
+}
@@ -23,7 +23,10 @@ func resourceCloudflareFallbackDomain() *schema.Resource {
 }
 
 func resourceCloudflareFallbackDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	domain, err := client.ListFallbackDomains(ctx, accountID)
@@ -39,7 +42,10 @@ func resourceCloudflareFallbackDomainRead(ctx context.Context, d *schema.Resourc
 }
 
 func resourceCloudflareFallbackDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	domainList := expandFallbackDomains(d.Get("domains").([]interface{}))
@@ -59,7 +65,10 @@ func resourceCloudflareFallbackDomainUpdate(ctx context.Context, d *schema.Resou
 }
 
 func resourceCloudflareFallbackDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	err := client.RestoreFallbackDomainDefaults(ctx, accountID)
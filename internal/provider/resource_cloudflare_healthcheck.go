@@ -33,7 +33,10 @@ func resourceCloudflareHealthcheck() *schema.Resource {
 }
 
 func resourceCloudflareHealthcheckRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	healthcheck, err := client.Healthcheck(ctx, zoneID, d.Id())
@@ -84,7 +87,10 @@ func resourceCloudflareHealthcheckRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCloudflareHealthcheckCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	healthcheck, err := healthcheckSetStruct(d)
@@ -116,7 +122,10 @@ func resourceCloudflareHealthcheckCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareHealthcheckUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	healthcheck, err := healthcheckSetStruct(d)
@@ -133,7 +142,10 @@ func resourceCloudflareHealthcheckUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareHealthcheckDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	err := client.DeleteHealthcheck(ctx, zoneID, d.Id())
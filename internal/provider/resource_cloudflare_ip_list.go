@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/cloudflare/cloudflare-go"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -22,11 +22,18 @@ func resourceCloudflareIPList() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceCloudflareIPListImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
 	}
 }
 
 func resourceCloudflareIPListCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	list, err := client.CreateIPList(ctx, accountID, d.Get("name").(string), d.Get("description").(string), d.Get("kind").(string))
@@ -37,8 +44,8 @@ func resourceCloudflareIPListCreate(ctx context.Context, d *schema.ResourceData,
 	d.SetId(list.ID)
 
 	if items, ok := d.GetOk("item"); ok {
-		IPListItems := buildIPListItemsCreateRequest(items.(*schema.Set).List())
-		_, err = client.CreateIPListItems(ctx, accountID, d.Id(), IPListItems)
+		IPListItems := buildIPListItems(items.(*schema.Set).List())
+		err = updateListItems(ctx, meta, client, accountID, d.Id(), IPListItems, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
 			return diag.FromErr(errors.Wrap(err, fmt.Sprintf("error creating IP List Items")))
 		}
@@ -64,7 +71,10 @@ func resourceCloudflareIPListImport(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCloudflareIPListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	list, err := client.GetIPList(ctx, accountID, d.Id())
@@ -103,7 +113,10 @@ func resourceCloudflareIPListRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceCloudflareIPListUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	_, err := client.UpdateIPList(ctx, accountID, d.Id(), d.Get("description").(string))
@@ -111,11 +124,11 @@ func resourceCloudflareIPListUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(errors.Wrap(err, fmt.Sprintf("error updating IP List description")))
 	}
 
-	if items, ok := d.GetOk("item"); ok {
-		IPListItems := buildIPListItemsCreateRequest(items.(*schema.Set).List())
-		_, err = client.ReplaceIPListItems(ctx, accountID, d.Id(), IPListItems)
+	if d.HasChange("item") {
+		IPListItems := buildIPListItems(d.Get("item").(*schema.Set).List())
+		err = updateListItems(ctx, meta, client, accountID, d.Id(), IPListItems, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
-			return diag.FromErr(errors.Wrap(err, fmt.Sprintf("error creating IP List Items")))
+			return diag.FromErr(errors.Wrap(err, fmt.Sprintf("error updating IP List Items")))
 		}
 	}
 
@@ -123,7 +136,10 @@ func resourceCloudflareIPListUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCloudflareIPListDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	_, err := client.DeleteIPList(ctx, accountID, d.Id())
@@ -134,11 +150,11 @@ func resourceCloudflareIPListDelete(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func buildIPListItemsCreateRequest(items []interface{}) []cloudflare.IPListItemCreateRequest {
-	var IPListItems []cloudflare.IPListItemCreateRequest
+func buildIPListItems(items []interface{}) []listItem {
+	var IPListItems []listItem
 
 	for _, item := range items {
-		IPListItems = append(IPListItems, cloudflare.IPListItemCreateRequest{
+		IPListItems = append(IPListItems, listItem{
 			IP:      item.(map[string]interface{})["value"].(string),
 			Comment: item.(map[string]interface{})["comment"].(string),
 		})
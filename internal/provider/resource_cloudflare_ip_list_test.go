@@ -153,7 +153,7 @@ func testAccCheckCloudflareIPListExists(n string, list *cloudflare.IPList) resou
 			return fmt.Errorf("No IP List ID is set")
 		}
 
-		client := testAccProvider.Meta().(*cloudflare.API)
+		client := testAccProvider.Meta().(*providerMeta).defaultClient()
 		foundIPList, err := client.GetIPList(context.Background(), accountID, rs.Primary.ID)
 		if err != nil {
 			return err
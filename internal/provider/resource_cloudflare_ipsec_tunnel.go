@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -28,27 +29,32 @@ func resourceCloudflareIPsecTunnel() *schema.Resource {
 
 func resourceCloudflareIPsecTunnelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	accountID := d.Get("account_id").(string)
-	client := meta.(*cloudflare.API)
-
-	newTunnel, err := client.CreateMagicTransitIPsecTunnels(ctx, accountID, []cloudflare.MagicTransitIPsecTunnel{
-		IPsecTunnelFromResource(d),
-	})
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
+	var newTunnel ipsecTunnel
+	err := createMagicTransitObject(client, magicTransitURI(accountID, "ipsec_tunnels"), "ipsec_tunnels", IPsecTunnelFromResource(d), &newTunnel)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error creating IPSec tunnel %s: %w", d.Get("name").(string), err))
 	}
 
-	d.SetId(newTunnel[0].ID)
+	d.SetId(newTunnel.ID)
 
-	// If PSK is not specified, call generate PSK and populate the field
+	// If PSK is not specified, call generate PSK and populate the field. The
+	// API never returns it so it's only ever set here.
 	psk, pskOk := d.Get("psk").(string)
 	if !pskOk || psk == "" {
 		psk, _, err = client.GenerateMagicTransitIPsecTunnelPSK(ctx, accountID, d.Id())
 		if err != nil {
-			defer d.SetId("")
 			tflog.Error(ctx, fmt.Sprintf("error creating PSK: %s %s", accountID, d.Id()))
 			// Need to delete the tunnel
-			return resourceCloudflareIPsecTunnelDelete(ctx, d, meta)
+			if deleteDiags := resourceCloudflareIPsecTunnelDelete(ctx, d, meta); deleteDiags.HasError() {
+				return deleteDiags
+			}
+			d.SetId("")
+			return diag.FromErr(fmt.Errorf("error generating PSK of IPsec tunnel %s: %w", d.Get("name").(string), err))
 		}
 		d.Set("psk", psk)
 	}
@@ -77,11 +83,16 @@ func resourceCloudflareIPsecTunnelImport(ctx context.Context, d *schema.Resource
 
 func resourceCloudflareIPsecTunnelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	accountID := d.Get("account_id").(string)
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
-	tunnel, err := client.GetMagicTransitIPsecTunnel(ctx, accountID, d.Id())
+	var tunnel ipsecTunnel
+	err := magicTransitObjectOf(client, magicTransitURI(accountID, "ipsec_tunnels", d.Id()), "ipsec_tunnel", &tunnel)
 	if err != nil {
-		if strings.Contains(err.Error(), "IPsec tunnel not found") {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) || strings.Contains(err.Error(), "IPsec tunnel not found") {
 			tflog.Info(ctx, fmt.Sprintf("IPsec tunnel %s not found", d.Id()))
 			d.SetId("")
 			return nil
@@ -93,14 +104,32 @@ func resourceCloudflareIPsecTunnelRead(ctx context.Context, d *schema.ResourceDa
 	d.Set("customer_endpoint", tunnel.CustomerEndpoint)
 	d.Set("cloudflare_endpoint", tunnel.CloudflareEndpoint)
 	d.Set("interface_address", tunnel.InterfaceAddress)
-	d.Set("health_check_enabled", tunnel.HealthCheck.Enabled)
-	d.Set("health_check_target", tunnel.HealthCheck.Target)
-	d.Set("health_check_type", tunnel.HealthCheck.Type)
+
+	if tunnel.HealthCheck != nil {
+		d.Set("health_check_enabled", tunnel.HealthCheck.Enabled)
+		d.Set("health_check_target", tunnel.HealthCheck.Target)
+		d.Set("health_check_type", tunnel.HealthCheck.Type)
+		if err := d.Set("health_check", []interface{}{map[string]interface{}{
+			"enabled":   tunnel.HealthCheck.Enabled,
+			"target":    tunnel.HealthCheck.Target,
+			"type":      tunnel.HealthCheck.Type,
+			"direction": tunnel.HealthCheck.Direction,
+			"rate":      tunnel.HealthCheck.Rate,
+		}}); err != nil {
+			return diag.FromErr(fmt.Errorf("error setting health check: %w", err))
+		}
+	}
+
+	if tunnel.PskMetadata != nil && tunnel.PskMetadata.LastGeneratedOn != nil {
+		d.Set("psk_generated_on", tunnel.PskMetadata.LastGeneratedOn.Format(time.RFC3339))
+	}
 
 	// Set Remote Identities
-	d.Set("hex_id", tunnel.RemoteIdentities.HexID)
-	d.Set("fqdn_id", tunnel.RemoteIdentities.FQDNID)
-	d.Set("user_id", tunnel.RemoteIdentities.UserID)
+	if tunnel.RemoteIdentities != nil {
+		d.Set("hex_id", tunnel.RemoteIdentities.HexID)
+		d.Set("fqdn_id", tunnel.RemoteIdentities.FQDNID)
+		d.Set("user_id", tunnel.RemoteIdentities.UserID)
+	}
 	d.Set("remote_id", accountID+"_"+d.Id())
 
 	if len(tunnel.Description) > 0 {
@@ -112,8 +141,19 @@ func resourceCloudflareIPsecTunnelRead(ctx context.Context, d *schema.ResourceDa
 
 func resourceCloudflareIPsecTunnelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	accountID := d.Get("account_id").(string)
-	client := meta.(*cloudflare.API)
-	_, err := client.UpdateMagicTransitIPsecTunnel(ctx, accountID, d.Id(), IPsecTunnelFromResource(d))
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	// The PSK is only sent when it changes rather than with every update.
+	tunnel := IPsecTunnelFromResource(d)
+	if !d.HasChange("psk") {
+		tunnel.Psk = ""
+	}
+
+	var updatedTunnel ipsecTunnel
+	err := updateMagicTransitObject(client, magicTransitURI(accountID, "ipsec_tunnels", d.Id()), "ipsec_tunnel", tunnel, &updatedTunnel)
 	if err != nil {
 		return diag.FromErr(errors.Wrap(err, fmt.Sprintf("error updating IPsec tunnel %q", d.Id())))
 	}
@@ -136,7 +176,10 @@ func resourceCloudflareIPsecTunnelUpdate(ctx context.Context, d *schema.Resource
 
 func resourceCloudflareIPsecTunnelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	accountID := d.Get("account_id").(string)
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	tflog.Info(ctx, fmt.Sprintf("Deleting IPsec tunnel:  %s", d.Id()))
 
@@ -148,12 +191,15 @@ func resourceCloudflareIPsecTunnelDelete(ctx context.Context, d *schema.Resource
 	return nil
 }
 
-func IPsecTunnelFromResource(d *schema.ResourceData) cloudflare.MagicTransitIPsecTunnel {
-	tunnel := cloudflare.MagicTransitIPsecTunnel{
-		Name:               d.Get("name").(string),
-		CustomerEndpoint:   d.Get("customer_endpoint").(string),
-		CloudflareEndpoint: d.Get("cloudflare_endpoint").(string),
-		InterfaceAddress:   d.Get("interface_address").(string),
+func IPsecTunnelFromResource(d *schema.ResourceData) ipsecTunnel {
+	tunnel := ipsecTunnel{
+		MagicTransitIPsecTunnel: cloudflare.MagicTransitIPsecTunnel{
+			Name:               d.Get("name").(string),
+			CustomerEndpoint:   d.Get("customer_endpoint").(string),
+			CloudflareEndpoint: d.Get("cloudflare_endpoint").(string),
+			InterfaceAddress:   d.Get("interface_address").(string),
+		},
+		HealthCheck: IPsecTunnelHealthcheckFromResource(d),
 	}
 
 	description, descriptionOk := d.GetOk("description")
@@ -168,3 +214,56 @@ func IPsecTunnelFromResource(d *schema.ResourceData) cloudflare.MagicTransitIPse
 
 	return tunnel
 }
+
+// IPsecTunnelHealthcheckFromResource reads the health check from the
+// `health_check` block or, when they changed, from the deprecated
+// `health_check_*` attributes. Both are read back from the API so the block
+// is always known once the tunnel exists.
+func IPsecTunnelHealthcheckFromResource(d *schema.ResourceData) *magicTransitTunnelHealthCheck {
+	var block map[string]interface{}
+	if healthcheck := d.Get("health_check").([]interface{}); len(healthcheck) > 0 && healthcheck[0] != nil {
+		block = healthcheck[0].(map[string]interface{})
+	}
+	if block != nil && !d.HasChanges("health_check_enabled", "health_check_target", "health_check_type") {
+		return &magicTransitTunnelHealthCheck{
+			MagicTransitTunnelHealthcheck: cloudflare.MagicTransitTunnelHealthcheck{
+				Enabled: block["enabled"].(bool),
+				Target:  block["target"].(string),
+				Type:    block["type"].(string),
+			},
+			Direction: block["direction"].(string),
+			Rate:      block["rate"].(string),
+		}
+	}
+
+	// Health checks are enabled unless they're explicitly disabled, and keep
+	// the settings only available in the block.
+	healthcheck := magicTransitTunnelHealthCheck{
+		MagicTransitTunnelHealthcheck: cloudflare.MagicTransitTunnelHealthcheck{Enabled: true},
+	}
+	if block != nil {
+		healthcheck.Direction = block["direction"].(string)
+		healthcheck.Rate = block["rate"].(string)
+	}
+
+	healthcheckEnabled, healthcheckEnabledOk := d.GetOkExists("health_check_enabled")
+	if healthcheckEnabledOk {
+		healthcheck.Enabled = healthcheckEnabled.(bool)
+	}
+
+	healthcheckTarget, healthcheckTargetOk := d.GetOk("health_check_target")
+	if healthcheckTargetOk {
+		healthcheck.Target = healthcheckTarget.(string)
+	}
+
+	healthcheckType, healthcheckTypeOk := d.GetOk("health_check_type")
+	if healthcheckTypeOk {
+		healthcheck.Type = healthcheckType.(string)
+	}
+
+	if healthcheckEnabledOk || healthcheckTargetOk || healthcheckTypeOk {
+		return &healthcheck
+	}
+
+	return nil
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccCloudflareIPsecTunnelExists(t *testing.T) {
@@ -56,7 +59,7 @@ func testAccCheckCloudflareIPsecTunnelExists(n string, tunnel *cloudflare.MagicT
 			return fmt.Errorf("No IPsec tunnel is set")
 		}
 
-		client := testAccProvider.Meta().(*cloudflare.API)
+		client := testAccProvider.Meta().(*providerMeta).defaultClient()
 		foundIPsecTunnel, err := client.GetMagicTransitIPsecTunnel(context.Background(), rs.Primary.Attributes["account_id"], rs.Primary.ID)
 		if err != nil {
 			return err
@@ -148,3 +151,107 @@ func testAccCheckCloudflareIPsecTunnelSimple(ID, description, accountID, psk str
 	psk = "%[4]s"
   }`, ID, description, accountID, psk)
 }
+
+func TestAccCloudflareIPsecTunnelHealthCheck(t *testing.T) {
+	skipMagicTransitTestForNonConfiguredDefaultZone(t)
+
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_ipsec_tunnel.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareIPsecTunnelHealthCheck(rnd, accountID, "mid"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "psk"),
+					resource.TestCheckResourceAttrSet(name, "psk_generated_on"),
+					resource.TestCheckResourceAttr(name, "health_check.0.enabled", "true"),
+					resource.TestCheckResourceAttr(name, "health_check.0.target", "203.0.113.1"),
+					resource.TestCheckResourceAttr(name, "health_check.0.direction", "bidirectional"),
+					resource.TestCheckResourceAttr(name, "health_check.0.rate", "mid"),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareIPsecTunnelHealthCheck(rnd, accountID, "low"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "health_check.0.rate", "low"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareIPsecTunnelHealthCheck(rnd, accountID, rate string) string {
+	return fmt.Sprintf(`
+  resource "cloudflare_ipsec_tunnel" "%[1]s" {
+	account_id          = "%[2]s"
+	name                = "%[1]s"
+	customer_endpoint   = "203.0.113.1"
+	cloudflare_endpoint = "162.159.64.41"
+	interface_address   = "10.212.0.9/31"
+
+	health_check {
+	  target    = "203.0.113.1"
+	  direction = "bidirectional"
+	  rate      = "%[3]s"
+	}
+  }`, rnd, accountID, rate)
+}
+
+func TestIPsecTunnelApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_ipsec_tunnel"]
+	config := map[string]interface{}{
+		"account_id":          testAccCloudflareAccountID,
+		"name":                "branch",
+		"customer_endpoint":   "203.0.113.1",
+		"cloudflare_endpoint": "162.159.64.41",
+		"interface_address":   "10.212.0.9/31",
+	}
+	healthCheck := func(rate string) map[string]interface{} {
+		return map[string]interface{}{
+			"health_check": []interface{}{map[string]interface{}{
+				"type":      "request",
+				"direction": "bidirectional",
+				"rate":      rate,
+			}},
+		}
+	}
+
+	// The PSK is generated once and kept, as it's never returned.
+	state := testMockAPIApply(t, r, m, nil, testMockAPIConfig(config, healthCheck("high")))
+	psk := state.Attributes["psk"]
+	assert.NotEmpty(t, psk)
+	assert.NotEmpty(t, state.Attributes["psk_generated_on"])
+	assert.Equal(t, "true", state.Attributes["health_check.0.enabled"])
+	assert.Equal(t, "203.0.113.1", state.Attributes["health_check.0.target"])
+	assert.Equal(t, "request", state.Attributes["health_check_type"])
+
+	state = testMockAPIApply(t, r, m, state, testMockAPIConfig(config, healthCheck("low")))
+	assert.Equal(t, psk, state.Attributes["psk"])
+	var tunnel ipsecTunnel
+	require.NoError(t, magicTransitObjectOf(m.defaultClient(), magicTransitURI(testAccCloudflareAccountID, "ipsec_tunnels", state.ID), "ipsec_tunnel", &tunnel))
+	assert.Equal(t, "low", tunnel.HealthCheck.Rate)
+	assert.Equal(t, "bidirectional", tunnel.HealthCheck.Direction)
+
+	// The deprecated attributes still update the health check, keeping the
+	// settings only available in the block.
+	state = testMockAPIApply(t, r, m, state, testMockAPIConfig(config, map[string]interface{}{"health_check_type": "reply"}))
+	require.NoError(t, magicTransitObjectOf(m.defaultClient(), magicTransitURI(testAccCloudflareAccountID, "ipsec_tunnels", state.ID), "ipsec_tunnel", &tunnel))
+	assert.Equal(t, "reply", tunnel.HealthCheck.Type)
+	assert.Equal(t, "low", tunnel.HealthCheck.Rate)
+	assert.Equal(t, "reply", state.Attributes["health_check.0.type"])
+
+	_, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state, "deleted tunnels are removed from state")
+}
@@ -0,0 +1,585 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareList() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareListSchema(),
+		CreateContext: resourceCloudflareListCreate,
+		ReadContext:   resourceCloudflareListRead,
+		UpdateContext: resourceCloudflareListUpdate,
+		DeleteContext: resourceCloudflareListDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareListImport,
+		},
+		CustomizeDiff: customdiff.All(
+			resourceCloudflareListValidateItems,
+			resourceCloudflareListItemsFileHash,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+// listItem is an item of a list of any kind. cloudflare-go only supports the
+// items of IP lists.
+type listItem struct {
+	ID       string            `json:"id,omitempty"`
+	IP       string            `json:"ip,omitempty"`
+	ASN      int               `json:"asn,omitempty"`
+	Hostname *listItemHostname `json:"hostname,omitempty"`
+	Redirect *listItemRedirect `json:"redirect,omitempty"`
+	Comment  string            `json:"comment,omitempty"`
+}
+
+// value returns the value of li, which identifies it within its list.
+func (li listItem) value() string {
+	switch {
+	case li.IP != "":
+		return li.IP
+	case li.Hostname != nil:
+		return li.Hostname.URLHostname
+	case li.Redirect != nil:
+		return li.Redirect.SourceURL
+	default:
+		return strconv.Itoa(li.ASN)
+	}
+}
+
+type listItemHostname struct {
+	URLHostname string `json:"url_hostname"`
+}
+
+type listItemRedirect struct {
+	SourceURL           string `json:"source_url"`
+	TargetURL           string `json:"target_url"`
+	StatusCode          int    `json:"status_code,omitempty"`
+	IncludeSubdomains   bool   `json:"include_subdomains"`
+	SubpathMatching     bool   `json:"subpath_matching"`
+	PreserveQueryString bool   `json:"preserve_query_string"`
+	PreservePathSuffix  bool   `json:"preserve_path_suffix"`
+}
+
+func resourceCloudflareListCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	list, err := client.CreateIPList(ctx, accountID, d.Get("name").(string), d.Get("description").(string), d.Get("kind").(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating list %s: %w", d.Get("name").(string), err))
+	}
+
+	d.SetId(list.ID)
+
+	items, err := expandConfiguredListItems(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateListItems(ctx, meta, client, accountID, d.Id(), items, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceCloudflareListRead(ctx, d, meta)
+}
+
+func resourceCloudflareListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Items are only read once they are managed by the list, so that lists
+	// whose items are managed by `cloudflare_list_item` resources don't change.
+	return readList(ctx, d, meta, d.Get("item").(*schema.Set).Len() > 0)
+}
+
+// readList reads a list into d, along with its items when readItems is set.
+func readList(ctx context.Context, d *schema.ResourceData, meta interface{}, readItems bool) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	list, err := client.GetIPList(ctx, accountID, d.Id())
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) || strings.Contains(err.Error(), "could not find list") {
+			tflog.Info(ctx, fmt.Sprintf("List %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading list with ID %q: %w", d.Id(), err))
+	}
+
+	d.Set("name", list.Name)
+	d.Set("description", list.Description)
+	d.Set("kind", list.Kind)
+
+	items, err := listListItems(ctx, meta, client, accountID, d.Id(), "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Items read from a file are only tracked by their hash.
+	if path := d.Get("items_file").(string); path != "" {
+		d.Set("item", []interface{}{})
+		d.Set("items_file_hash", hashListItemsFileEntries(listItemsFileEntries(items)))
+		return listItemsNotInFile(d.Get("kind").(string), path, items)
+	}
+
+	if !readItems {
+		d.Set("items_file_hash", "")
+		return nil
+	}
+
+	if err := d.Set("item", flattenListItems(items)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting items of list %q: %w", d.Id(), err))
+	}
+	d.Set("items_file_hash", "")
+
+	return nil
+}
+
+func resourceCloudflareListUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	if d.HasChange("description") {
+		if _, err := client.UpdateIPList(ctx, accountID, d.Id(), d.Get("description").(string)); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating description of list %q: %w", d.Id(), err))
+		}
+	}
+
+	if d.HasChange("item") || d.HasChange("items_file_hash") {
+		items, err := expandConfiguredListItems(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := updateListItems(ctx, meta, client, accountID, d.Id(), items, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceCloudflareListRead(ctx, d, meta)
+}
+
+func resourceCloudflareListDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	_, err := client.DeleteIPList(ctx, accountID, d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting list with ID %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareListImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 2)
+
+	if len(attributes) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/listID\"", d.Id())
+	}
+
+	accountID, listID := attributes[0], attributes[1]
+	d.SetId(listID)
+	d.Set("account_id", accountID)
+
+	readList(ctx, d, meta, true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceCloudflareListValidateItems checks that every item sets the value
+// of the kind of the list, and only that value.
+func resourceCloudflareListValidateItems(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("kind") || !d.NewValueKnown("item") {
+		return nil
+	}
+	kind := d.Get("kind").(string)
+
+	for _, item := range d.Get("item").(*schema.Set).List() {
+		if err := validateListItem(kind, item.(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceCloudflareListItemsFileHash sets the hash of the items of
+// `items_file`, so that changes to the file are planned.
+func resourceCloudflareListItemsFileHash(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("kind") || !d.NewValueKnown("items_file") {
+		return d.SetNewComputed("items_file_hash")
+	}
+
+	path := d.Get("items_file").(string)
+	if path == "" {
+		if d.Get("items_file_hash").(string) != "" {
+			return d.SetNew("items_file_hash", "")
+		}
+		return nil
+	}
+
+	items, err := listItemsFromFile(d.Get("kind").(string), path)
+	if err != nil {
+		return err
+	}
+
+	return d.SetNew("items_file_hash", hashListItemsFileEntries(listItemsFileEntries(items)))
+}
+
+func validateListItem(kind string, item map[string]interface{}) error {
+	var values []string
+	if item["ip"] != "" {
+		values = append(values, "ip")
+	}
+	if item["asn"] != 0 {
+		values = append(values, "asn")
+	}
+	if item["hostname"] != "" {
+		values = append(values, "hostname")
+	}
+	if len(item["redirect"].([]interface{})) > 0 {
+		values = append(values, "redirect")
+	}
+
+	if len(values) == 1 && values[0] == kind {
+		return nil
+	}
+	if len(values) == 0 {
+		values = []string{"nothing"}
+	}
+
+	return fmt.Errorf("items of %s lists must only set `%s`, but an item sets %s", kind, kind, strings.Join(values, " and "))
+}
+
+func expandListItems(items []interface{}) []listItem {
+	out := make([]listItem, 0, len(items))
+
+	for _, rawItem := range items {
+		item := rawItem.(map[string]interface{})
+		li := listItem{
+			IP:      item["ip"].(string),
+			ASN:     item["asn"].(int),
+			Comment: item["comment"].(string),
+		}
+		if hostname := item["hostname"].(string); hostname != "" {
+			li.Hostname = &listItemHostname{URLHostname: hostname}
+		}
+		for _, rawRedirect := range item["redirect"].([]interface{}) {
+			redirect := rawRedirect.(map[string]interface{})
+			li.Redirect = &listItemRedirect{
+				SourceURL:           redirect["source_url"].(string),
+				TargetURL:           redirect["target_url"].(string),
+				StatusCode:          redirect["status_code"].(int),
+				IncludeSubdomains:   redirect["include_subdomains"].(bool),
+				SubpathMatching:     redirect["subpath_matching"].(bool),
+				PreserveQueryString: redirect["preserve_query_string"].(bool),
+				PreservePathSuffix:  redirect["preserve_path_suffix"].(bool),
+			}
+		}
+		out = append(out, li)
+	}
+
+	return out
+}
+
+// expandConfiguredListItems returns the items configured for a list, either
+// with `item` blocks or in `items_file`.
+func expandConfiguredListItems(d *schema.ResourceData) ([]listItem, error) {
+	if path := d.Get("items_file").(string); path != "" {
+		return listItemsFromFile(d.Get("kind").(string), path)
+	}
+	return expandListItems(d.Get("item").(*schema.Set).List()), nil
+}
+
+// listItemsFromFile returns the items of a file of list items for a list of
+// the given kind.
+func listItemsFromFile(kind, path string) ([]listItem, error) {
+	if kind == "redirect" {
+		return nil, fmt.Errorf("items of redirect lists can't be read from a file")
+	}
+
+	entries, err := readListItemsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]listItem, 0, len(entries))
+	for _, entry := range entries {
+		li := listItem{Comment: entry.Comment}
+		switch kind {
+		case "ip":
+			li.IP = entry.Value
+		case "hostname":
+			li.Hostname = &listItemHostname{URLHostname: entry.Value}
+		case "asn":
+			asn, err := strconv.Atoi(entry.Value)
+			if err != nil || asn < 0 {
+				return nil, fmt.Errorf("invalid ASN %q in list items file %q", entry.Value, path)
+			}
+			li.ASN = asn
+		}
+		items = append(items, li)
+	}
+
+	return items, nil
+}
+
+// listItemsNotInFile warns about the items of a list that aren't in its
+// items file, such as items of `cloudflare_list_item` resources, as they are
+// removed on the next apply.
+func listItemsNotInFile(kind, path string, items []listItem) diag.Diagnostics {
+	fileItems, err := listItemsFromFile(kind, path)
+	if err != nil {
+		return nil
+	}
+
+	inFile := make(map[string]bool, len(fileItems))
+	for _, li := range fileItems {
+		inFile[li.value()] = true
+	}
+
+	var missing int
+	for _, li := range items {
+		if !inFile[li.value()] {
+			missing++
+		}
+	}
+	if missing == 0 {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("List has %d items that aren't in %q", missing, path),
+		Detail:   "Items of lists read from `items_file` can't be managed elsewhere, such as with `cloudflare_list_item` resources, and are removed on the next apply.",
+	}}
+}
+
+func listItemsFileEntries(items []listItem) []listItemsFileEntry {
+	entries := make([]listItemsFileEntry, 0, len(items))
+	for _, li := range items {
+		entries = append(entries, listItemsFileEntry{Value: li.value(), Comment: li.Comment})
+	}
+	return entries
+}
+
+// flattenListItems returns the items of a list. Every attribute is set, even
+// to its zero value, so that items hash the same as configured ones.
+func flattenListItems(items []listItem) []interface{} {
+	out := make([]interface{}, 0, len(items))
+
+	for _, li := range items {
+		item := map[string]interface{}{
+			"ip":       li.IP,
+			"asn":      li.ASN,
+			"hostname": "",
+			"redirect": []interface{}{},
+			"comment":  li.Comment,
+		}
+		if li.Hostname != nil {
+			item["hostname"] = li.Hostname.URLHostname
+		}
+		if li.Redirect != nil {
+			item["redirect"] = []interface{}{map[string]interface{}{
+				"source_url":            li.Redirect.SourceURL,
+				"target_url":            li.Redirect.TargetURL,
+				"status_code":           li.Redirect.StatusCode,
+				"include_subdomains":    li.Redirect.IncludeSubdomains,
+				"subpath_matching":      li.Redirect.SubpathMatching,
+				"preserve_query_string": li.Redirect.PreserveQueryString,
+				"preserve_path_suffix":  li.Redirect.PreservePathSuffix,
+			}}
+		}
+		out = append(out, item)
+	}
+
+	return out
+}
+
+// listListItems returns every item of a list, or only those matching search
+// when it's set. The items are paginated with cursors, which cloudflare-go
+// only follows for the items of IP lists.
+func listListItems(ctx context.Context, meta interface{}, client *cloudflare.API, accountID, listID, search string) ([]listItem, error) {
+	var items []listItem
+
+	query := url.Values{"per_page": {"500"}}
+	if search != "" {
+		query.Set("search", search)
+	}
+	for {
+		uri := fmt.Sprintf("/accounts/%s/rules/lists/%s/items?%s", accountID, listID, query.Encode())
+		body, _, err := apiRequest(ctx, meta, client, http.MethodGet, uri, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("error reading items of list %q: %w", listID, err)
+		}
+
+		var page struct {
+			Result     []listItem `json:"result"`
+			ResultInfo struct {
+				Cursors struct {
+					After string `json:"after"`
+				} `json:"cursors"`
+			} `json:"result_info"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("error reading items of list %q: %w", listID, err)
+		}
+		items = append(items, page.Result...)
+
+		if page.ResultInfo.Cursors.After == "" {
+			break
+		}
+		query.Set("cursor", page.ResultInfo.Cursors.After)
+	}
+
+	return items, nil
+}
+
+// listItemKey returns the key of li, which is the same for items with the
+// same value and settings.
+func listItemKey(li listItem) string {
+	li.ID = ""
+	key, _ := json.Marshal(li)
+	return string(key)
+}
+
+// diffListItems returns the items to add to and remove from a list holding
+// the remote items for it to hold the desired ones instead.
+func diffListItems(remote, desired []listItem) (add, remove []listItem) {
+	wanted := make(map[string]bool, len(desired))
+	for _, li := range desired {
+		wanted[listItemKey(li)] = true
+	}
+
+	for _, li := range remote {
+		key := listItemKey(li)
+		if wanted[key] {
+			delete(wanted, key)
+			continue
+		}
+		remove = append(remove, li)
+	}
+
+	for _, li := range desired {
+		if wanted[listItemKey(li)] {
+			add = append(add, li)
+		}
+	}
+
+	return add, remove
+}
+
+// updateListItems makes a list hold the desired items, by removing and then
+// adding only the items that differ, in chunks.
+func updateListItems(ctx context.Context, meta interface{}, client *cloudflare.API, accountID, listID string, desired []listItem, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	remote, err := listListItems(ctx, meta, client, accountID, listID, "")
+	if err != nil {
+		return err
+	}
+
+	add, remove := diffListItems(remote, desired)
+	tflog.Debug(ctx, fmt.Sprintf("adding %d and removing %d items of list %s", len(add), len(remove), listID))
+
+	for start := 0; start < len(remove); start += listItemsChunkSize {
+		end := start + listItemsChunkSize
+		if end > len(remove) {
+			end = len(remove)
+		}
+
+		ids := make([]map[string]string, 0, end-start)
+		for _, li := range remove[start:end] {
+			ids = append(ids, map[string]string{"id": li.ID})
+		}
+
+		if err := listItemsOperation(ctx, client, accountID, listID, http.MethodDelete, map[string]interface{}{"items": ids}, deadline); err != nil {
+			return fmt.Errorf("error removing items of list %q: %w", listID, err)
+		}
+	}
+
+	for start := 0; start < len(add); start += listItemsChunkSize {
+		end := start + listItemsChunkSize
+		if end > len(add) {
+			end = len(add)
+		}
+
+		if err := listItemsOperation(ctx, client, accountID, listID, http.MethodPost, add[start:end], deadline); err != nil {
+			return fmt.Errorf("error adding items to list %q: %w", listID, err)
+		}
+	}
+
+	return nil
+}
+
+// listItemsOperation sends a request changing the items of a list and waits,
+// until the deadline at most, for the bulk operation it starts to complete.
+func listItemsOperation(ctx context.Context, client *cloudflare.API, accountID, listID, method string, body interface{}, deadline time.Time) error {
+	result, err := client.Raw(method, fmt.Sprintf("/accounts/%s/rules/lists/%s/items", accountID, listID), body)
+	if err != nil {
+		return err
+	}
+
+	var operation struct {
+		OperationID string `json:"operation_id"`
+	}
+	if err := json.Unmarshal(result, &operation); err != nil {
+		return err
+	}
+
+	return waitForListBulkOperation(ctx, client, accountID, operation.OperationID, time.Until(deadline))
+}
+
+// waitForListBulkOperation polls the status of an asynchronous operation on
+// the items of a list until it completes.
+func waitForListBulkOperation(ctx context.Context, client *cloudflare.API, accountID, operationID string, timeout time.Duration) error {
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		result, err := client.Raw(http.MethodGet, fmt.Sprintf("/accounts/%s/rules/lists/bulk_operations/%s", accountID, operationID), nil)
+		if err != nil {
+			return resource.NonRetryableError(fmt.Errorf("error reading status of list operation %q: %w", operationID, err))
+		}
+
+		var operation cloudflare.IPListBulkOperation
+		if err := json.Unmarshal(result, &operation); err != nil {
+			return resource.NonRetryableError(fmt.Errorf("error reading status of list operation %q: %w", operationID, err))
+		}
+
+		switch operation.Status {
+		case "completed":
+			return nil
+		case "failed":
+			return resource.NonRetryableError(fmt.Errorf("list operation %q failed: %s", operationID, operation.Error))
+		default:
+			return resource.RetryableError(fmt.Errorf("list operation %q is %s", operationID, operation.Status))
+		}
+	})
+}
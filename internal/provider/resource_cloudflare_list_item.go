@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareListItem() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareListItemSchema(),
+		CreateContext: resourceCloudflareListItemCreate,
+		ReadContext:   resourceCloudflareListItemRead,
+		DeleteContext: resourceCloudflareListItemDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareListItemImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func resourceCloudflareListItemCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	listID := d.Get("list_id").(string)
+
+	item := expandListItems([]interface{}{map[string]interface{}{
+		"ip":       d.Get("ip"),
+		"asn":      d.Get("asn"),
+		"hostname": d.Get("hostname"),
+		"redirect": d.Get("redirect"),
+		"comment":  d.Get("comment"),
+	}})[0]
+
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutCreate))
+	if err := listItemsOperation(ctx, client, accountID, listID, http.MethodPost, []listItem{item}, deadline); err != nil {
+		return diag.FromErr(fmt.Errorf("error adding item %s to list %q: %w", item.value(), listID, err))
+	}
+
+	// Adding items doesn't return their identifiers, so the item is looked
+	// up by its value.
+	items, err := listListItems(ctx, meta, client, accountID, listID, item.value())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, li := range items {
+		if listItemKey(li) == listItemKey(item) {
+			d.SetId(li.ID)
+			break
+		}
+	}
+	if d.Id() == "" {
+		return diag.FromErr(fmt.Errorf("error finding item %s added to list %q", item.value(), listID))
+	}
+
+	return resourceCloudflareListItemRead(ctx, d, meta)
+}
+
+func resourceCloudflareListItemRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	listID := d.Get("list_id").(string)
+
+	result, err := client.Raw(http.MethodGet, fmt.Sprintf("/accounts/%s/rules/lists/%s/items/%s", accountID, listID, d.Id()), nil)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Item %s of list %s no longer exists", d.Id(), listID))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading item %q of list %q: %w", d.Id(), listID, err))
+	}
+
+	var item listItem
+	if err := json.Unmarshal(result, &item); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading item %q of list %q: %w", d.Id(), listID, err))
+	}
+
+	for k, v := range flattenListItems([]listItem{item})[0].(map[string]interface{}) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("error setting %s of item %q: %w", k, d.Id(), err))
+		}
+	}
+
+	return nil
+}
+
+func resourceCloudflareListItemDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	listID := d.Get("list_id").(string)
+
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutDelete))
+	body := map[string]interface{}{"items": []map[string]string{{"id": d.Id()}}}
+	if err := listItemsOperation(ctx, client, accountID, listID, http.MethodDelete, body, deadline); err != nil {
+		return diag.FromErr(fmt.Errorf("error removing item %q from list %q: %w", d.Id(), listID, err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareListItemImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 3)
+
+	if len(attributes) != 3 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/listID/itemID\"", d.Id())
+	}
+
+	accountID, listID, itemID := attributes[0], attributes[1], attributes[2]
+	d.SetId(itemID)
+	d.Set("account_id", accountID)
+	d.Set("list_id", listID)
+
+	resourceCloudflareListItemRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareListItem_Basic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_list_item.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareListItem(rnd, accountID, "example.com/old"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "redirect.0.source_url", "example.com/old"),
+					resource.TestCheckResourceAttr(name, "redirect.0.status_code", "301"),
+					resource.TestCheckResourceAttr(name, "comment", "moved"),
+					resource.TestCheckResourceAttr(fmt.Sprintf("cloudflare_list.%s", rnd), "item.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareListItem(rnd, accountID, "example.com/older"),
+				Check:  resource.TestCheckResourceAttr(name, "redirect.0.source_url", "example.com/older"),
+			},
+			{
+				ResourceName: name,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[name]
+					return fmt.Sprintf("%s/%s/%s", accountID, rs.Primary.Attributes["list_id"], rs.Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareListItem(name, accountID, source string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_list" "%[1]s" {
+  account_id = "%[2]s"
+  name       = "%[1]s"
+  kind       = "redirect"
+}
+
+resource "cloudflare_list_item" "%[1]s" {
+  account_id = "%[2]s"
+  list_id    = cloudflare_list.%[1]s.id
+  comment    = "moved"
+
+  redirect {
+    source_url = "%[3]s"
+    target_url = "https://example.com/new"
+  }
+}`, name, accountID, source)
+}
+
+func TestListItemApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	list, err := m.defaultClient().CreateIPList(ctx, testAccCloudflareAccountID, "hosts", "", "hostname")
+	require.NoError(t, err)
+
+	// Items owned elsewhere are left alone.
+	require.NoError(t, updateListItems(ctx, m, m.defaultClient(), testAccCloudflareAccountID, list.ID, []listItem{
+		{Hostname: &listItemHostname{URLHostname: "www.example.com"}},
+	}, time.Minute))
+
+	r := p.ResourcesMap["cloudflare_list_item"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"account_id": testAccCloudflareAccountID,
+		"list_id":    list.ID,
+		"hostname":   "example.com",
+		"comment":    "apex",
+	})
+
+	diags := r.CreateContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, "example.com", d.Get("hostname"))
+	assert.Equal(t, "apex", d.Get("comment"))
+
+	items, err := listListItems(ctx, m, m.defaultClient(), testAccCloudflareAccountID, list.ID, "")
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	diags = r.DeleteContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	diags = r.ReadContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "", d.Id())
+
+	items, err = listListItems(ctx, m, m.defaultClient(), testAccCloudflareAccountID, list.ID, "")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "www.example.com", items[0].value())
+}
@@ -0,0 +1,372 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareList_Redirect(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_list.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareListRedirect(rnd, accountID, "https://example.com/new"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "kind", "redirect"),
+					resource.TestCheckResourceAttr(name, "item.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "item.*", map[string]string{
+						"redirect.0.source_url":            "example.com/old",
+						"redirect.0.target_url":            "https://example.com/new",
+						"redirect.0.status_code":           "308",
+						"redirect.0.include_subdomains":    "true",
+						"redirect.0.preserve_query_string": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "item.*", map[string]string{
+						"redirect.0.source_url":  "example.com/blog",
+						"redirect.0.status_code": "301",
+						"comment":                "blog",
+					}),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareListRedirect(rnd, accountID, "https://example.com/newer"),
+				Check: resource.TestCheckTypeSetElemNestedAttrs(name, "item.*", map[string]string{
+					"redirect.0.source_url": "example.com/old",
+					"redirect.0.target_url": "https://example.com/newer",
+				}),
+			},
+			{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateIdPrefix: fmt.Sprintf("%s/", accountID),
+				ImportStateVerify:   true,
+			},
+		},
+	})
+}
+
+func TestAccCloudflareList_HostnameAndASN(t *testing.T) {
+	rnd := generateRandomResourceName()
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareListHostnameAndASN(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("cloudflare_list."+rnd+"_hostnames", "item.*", map[string]string{"hostname": "app.example.com"}),
+					resource.TestCheckTypeSetElemNestedAttrs("cloudflare_list."+rnd+"_asns", "item.*", map[string]string{"asn": "13335", "comment": "Cloudflare"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareList_ItemOfAnotherKind(t *testing.T) {
+	rnd := generateRandomResourceName()
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "cloudflare_list" "%[1]s" {
+  account_id = "%[2]s"
+  name       = "%[1]s"
+  kind       = "asn"
+
+  item {
+    ip = "192.0.2.1"
+  }
+}`, rnd, accountID),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("items of asn lists must only set `asn`, but an item sets ip"),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareListRedirect(name, accountID, target string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_list" "%[1]s" {
+  account_id  = "%[2]s"
+  name        = "%[1]s"
+  description = "redirects"
+  kind        = "redirect"
+
+  item {
+    redirect {
+      source_url            = "example.com/old"
+      target_url            = "%[3]s"
+      status_code           = 308
+      include_subdomains    = true
+      preserve_query_string = true
+    }
+  }
+
+  item {
+    comment = "blog"
+
+    redirect {
+      source_url       = "example.com/blog"
+      target_url       = "https://blog.example.com"
+      subpath_matching = true
+    }
+  }
+}`, name, accountID, target)
+}
+
+func testAccCheckCloudflareListHostnameAndASN(name, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_list" "%[1]s_hostnames" {
+  account_id = "%[2]s"
+  name       = "%[1]s_hostnames"
+  kind       = "hostname"
+
+  item {
+    hostname = "app.example.com"
+  }
+}
+
+resource "cloudflare_list" "%[1]s_asns" {
+  account_id = "%[2]s"
+  name       = "%[1]s_asns"
+  kind       = "asn"
+
+  item {
+    asn     = 13335
+    comment = "Cloudflare"
+  }
+}`, name, accountID)
+}
+
+func TestListApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+
+	// Enough items to span several pages of the listing.
+	var items []interface{}
+	for i := 0; i < 1200; i++ {
+		items = append(items, map[string]interface{}{"redirect": []interface{}{map[string]interface{}{
+			"source_url": fmt.Sprintf("example.com/%d", i),
+			"target_url": fmt.Sprintf("https://example.com/new/%d", i),
+		}}})
+	}
+
+	r := p.ResourcesMap["cloudflare_list"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"account_id": testAccCloudflareAccountID,
+		"name":       "redirects",
+		"kind":       "redirect",
+		"item":       items,
+	})
+	configured := d.Get("item").(*schema.Set)
+
+	ctx := context.Background()
+	diags := r.CreateContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, "redirect", d.Get("kind"))
+
+	// The items read back hash the same as the configured ones, so nothing
+	// changes.
+	read := d.Get("item").(*schema.Set)
+	assert.Equal(t, 1200, read.Len())
+	assert.Equal(t, 0, configured.Difference(read).Len())
+	assert.Contains(t, read.List(), map[string]interface{}{
+		"ip": "", "asn": 0, "hostname": "", "comment": "",
+		"redirect": []interface{}{map[string]interface{}{
+			"source_url": "example.com/7", "target_url": "https://example.com/new/7", "status_code": 301,
+			"include_subdomains": false, "subpath_matching": false, "preserve_query_string": false, "preserve_path_suffix": false,
+		}},
+	})
+
+	diags = r.DeleteContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	diags = r.ReadContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestListItemsFile(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "items.csv")
+	writeItems := func(from, to int) {
+		lines := []string{"# threat intel feed"}
+		for i := from; i < to; i++ {
+			line := fmt.Sprintf("10.%d.%d.1", i/256, i%256)
+			if i%3 == 0 {
+				line += ", scanner"
+			}
+			lines = append(lines, line)
+		}
+		require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644))
+	}
+
+	r := p.ResourcesMap["cloudflare_list"]
+	config := map[string]interface{}{
+		"account_id": testAccCloudflareAccountID,
+		"name":       "threat_intel",
+		"kind":       "ip",
+		"items_file": path,
+	}
+	itemIDs := func(state *terraform.InstanceState) map[string]string {
+		items, err := listListItems(ctx, m, m.defaultClient(), testAccCloudflareAccountID, state.ID, "")
+		require.NoError(t, err)
+		ids := make(map[string]string)
+		for _, li := range items {
+			ids[li.IP] = li.ID
+		}
+		return ids
+	}
+
+	// Only a hash of the items is kept in state.
+	writeItems(0, 2500)
+	state := testMockAPIApply(t, r, m, nil, config)
+	assert.Equal(t, "0", state.Attributes["item.#"])
+	assert.Len(t, state.Attributes["items_file_hash"], 64)
+	created := itemIDs(state)
+	assert.Len(t, created, 2500)
+
+	// Items that are still in the file are kept rather than replaced.
+	writeItems(500, 3000)
+	diff, err := testMockAPIDiff(t, r, m, state, config)
+	require.NoError(t, err)
+	assert.Contains(t, diff.Attributes, "items_file_hash")
+
+	state = testMockAPIApply(t, r, m, state, config)
+	updated := itemIDs(state)
+	assert.Len(t, updated, 2500)
+	assert.NotContains(t, updated, "10.0.0.1")
+	assert.Equal(t, created["10.1.244.1"], updated["10.1.244.1"])
+	assert.Contains(t, updated, "10.11.183.1")
+
+	// Items added elsewhere are warned about, as they are removed.
+	items, err := listListItems(ctx, m, m.defaultClient(), testAccCloudflareAccountID, state.ID, "")
+	require.NoError(t, err)
+	items = append(items, listItem{IP: "192.0.2.1"}, listItem{IP: "10.0.0.1"})
+	require.NoError(t, updateListItems(ctx, m, m.defaultClient(), testAccCloudflareAccountID, state.ID, items, time.Minute))
+	_, diags := r.RefreshWithoutUpgrade(ctx, state, m)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, `List has 2 items that aren't in "`+path+`"`, diags[0].Summary)
+
+	// Repeated values are rejected during plan, as the list would never
+	// match the file.
+	require.NoError(t, os.WriteFile(path, []byte("192.0.2.1\n192.0.2.2, scanner\n192.0.2.1, scanner\n"), 0644))
+	_, err = testMockAPIDiff(t, r, m, state, config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `line 3 of list items file "`+path+`" repeats "192.0.2.1" from line 1`)
+}
+
+func TestListItemsApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_list"]
+	config := map[string]interface{}{
+		"account_id": testAccCloudflareAccountID,
+		"name":       "hosts",
+		"kind":       "hostname",
+	}
+	refresh := func(state *terraform.InstanceState) *terraform.InstanceState {
+		state, diags := r.RefreshWithoutUpgrade(ctx, state, m)
+		require.False(t, diags.HasError(), "%v", diags)
+		return state
+	}
+	itemCount := func(state *terraform.InstanceState) int {
+		return r.Data(state).Get("item").(*schema.Set).Len()
+	}
+
+	// Lists without items leave items added elsewhere alone.
+	state := testMockAPIApply(t, r, m, nil, config)
+	require.NoError(t, updateListItems(ctx, m, m.defaultClient(), testAccCloudflareAccountID, state.ID, []listItem{
+		{Hostname: &listItemHostname{URLHostname: "www.example.com"}},
+	}, time.Minute))
+	state = refresh(state)
+	assert.Equal(t, 0, itemCount(state))
+	diff, err := testMockAPIDiff(t, r, m, state, config)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "%v", diff)
+
+	// Once set, items are managed by the list.
+	withItems := testMockAPIConfig(config, map[string]interface{}{
+		"item": []interface{}{
+			map[string]interface{}{"hostname": "example.com"},
+			map[string]interface{}{"hostname": "api.example.com"},
+		},
+	})
+	state = refresh(testMockAPIApply(t, r, m, state, withItems))
+	assert.Equal(t, 2, itemCount(state))
+
+	// Removing every item empties the list.
+	state = refresh(testMockAPIApply(t, r, m, state, config))
+	assert.Equal(t, 0, itemCount(state))
+	items, err := listListItems(ctx, m, m.defaultClient(), testAccCloudflareAccountID, state.ID, "")
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestDiffListItems(t *testing.T) {
+	remote := []listItem{
+		{ID: "1", IP: "192.0.2.1"},
+		{ID: "2", IP: "192.0.2.2", Comment: "old"},
+		{ID: "3", IP: "192.0.2.3"},
+	}
+	desired := []listItem{
+		{IP: "192.0.2.1"},
+		{IP: "192.0.2.2", Comment: "new"},
+		{IP: "192.0.2.4"},
+	}
+
+	add, remove := diffListItems(remote, desired)
+	assert.Equal(t, []listItem{{IP: "192.0.2.2", Comment: "new"}, {IP: "192.0.2.4"}}, add)
+	assert.Equal(t, []listItem{{ID: "2", IP: "192.0.2.2", Comment: "old"}, {ID: "3", IP: "192.0.2.3"}}, remove)
+}
+
+func TestValidateListItem(t *testing.T) {
+	item := func(values map[string]interface{}) map[string]interface{} {
+		out := map[string]interface{}{"ip": "", "asn": 0, "hostname": "", "redirect": []interface{}{}, "comment": ""}
+		for k, v := range values {
+			out[k] = v
+		}
+		return out
+	}
+
+	assert.NoError(t, validateListItem("ip", item(map[string]interface{}{"ip": "192.0.2.0/24"})))
+	assert.NoError(t, validateListItem("asn", item(map[string]interface{}{"asn": 13335})))
+	assert.NoError(t, validateListItem("redirect", item(map[string]interface{}{"redirect": []interface{}{map[string]interface{}{}}})))
+	assert.EqualError(t, validateListItem("hostname", item(nil)), "items of hostname lists must only set `hostname`, but an item sets nothing")
+	assert.EqualError(t, validateListItem("hostname", item(map[string]interface{}{"hostname": "example.com", "ip": "192.0.2.1"})),
+		"items of hostname lists must only set `hostname`, but an item sets ip and hostname")
+}
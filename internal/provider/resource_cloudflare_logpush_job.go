@@ -88,7 +88,10 @@ func getJobFromResource(d *schema.ResourceData) (cloudflare.LogpushJob, *AccessI
 }
 
 func resourceCloudflareLogpushJobRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	jobID, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("could not extract Logpush job from resource - invalid identifier (%s): %w", d.Id(), err))
@@ -138,7 +141,10 @@ func resourceCloudflareLogpushJobRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceCloudflareLogpushJobCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	job, identifier, err := getJobFromResource(d)
 	if err != nil {
@@ -168,7 +174,10 @@ func resourceCloudflareLogpushJobCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareLogpushJobUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	job, identifier, err := getJobFromResource(d)
 	if err != nil {
@@ -191,7 +200,10 @@ func resourceCloudflareLogpushJobUpdate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareLogpushJobDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	job, identifier, err := getJobFromResource(d)
 	if err != nil {
@@ -25,7 +25,10 @@ func resourceCloudflareLogpushOwnershipChallengeNoop(ctx context.Context, d *sch
 }
 
 func resourceCloudflareLogpushOwnershipChallengeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	destinationConf := d.Get("destination_conf").(string)
 	identifier, err := initIdentifier(d)
@@ -27,7 +27,10 @@ func resourceCloudflareMagicFirewallRuleset() *schema.Resource {
 }
 
 func resourceCloudflareMagicFirewallRulesetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	rules, err := buildMagicFirewallRulesetRulesFromResource(d.Get("rules"))
@@ -67,7 +70,10 @@ func resourceCloudflareMagicFirewallRulesetImport(ctx context.Context, d *schema
 }
 
 func resourceCloudflareMagicFirewallRulesetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	ruleset, err := client.GetMagicFirewallRuleset(ctx, accountID, d.Id())
@@ -88,7 +94,10 @@ func resourceCloudflareMagicFirewallRulesetRead(ctx context.Context, d *schema.R
 }
 
 func resourceCloudflareMagicFirewallRulesetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	rules, err := buildMagicFirewallRulesetRulesFromResource(d.Get("rules"))
@@ -105,7 +114,10 @@ func resourceCloudflareMagicFirewallRulesetUpdate(ctx context.Context, d *schema
 }
 
 func resourceCloudflareMagicFirewallRulesetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	err := client.DeleteMagicFirewallRuleset(ctx, accountID, d.Id())
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareMagicWANSite() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareMagicWANSiteSchema(),
+		CreateContext: resourceCloudflareMagicWANSiteCreate,
+		ReadContext:   resourceCloudflareMagicWANSiteRead,
+		UpdateContext: resourceCloudflareMagicWANSiteUpdate,
+		DeleteContext: resourceCloudflareMagicWANSiteDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareMagicWANSiteImport,
+		},
+		Description: "Provides a resource, that manages Magic WAN sites, the branch offices connected with a Magic WAN Connector.",
+	}
+}
+
+func resourceCloudflareMagicWANSiteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var site magicWANSite
+	err := createMagicTransitObject(client, magicTransitURI(accountID, "sites"), "sites", magicWANSiteFromResource(d), &site)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Magic WAN site %s: %w", d.Get("name").(string), err))
+	}
+
+	d.SetId(site.ID)
+
+	return resourceCloudflareMagicWANSiteRead(ctx, d, meta)
+}
+
+func resourceCloudflareMagicWANSiteImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 2)
+
+	if len(attributes) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/siteID\"", d.Id())
+	}
+
+	accountID, siteID := attributes[0], attributes[1]
+	d.SetId(siteID)
+	d.Set("account_id", accountID)
+
+	readDiags := resourceCloudflareMagicWANSiteRead(ctx, d, meta)
+	if readDiags.HasError() {
+		return nil, errors.New("failed to read Magic WAN site state")
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceCloudflareMagicWANSiteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var site magicWANSite
+	err := magicTransitObjectOf(client, magicTransitURI(accountID, "sites", d.Id()), "site", &site)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Magic WAN site %s not found", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading Magic WAN site ID %q: %w", d.Id(), err))
+	}
+
+	d.Set("name", site.Name)
+	d.Set("description", site.Description)
+	d.Set("connector_id", site.ConnectorID)
+	d.Set("secondary_connector_id", site.SecondaryConnectorID)
+	d.Set("ha_mode", site.HAMode)
+
+	var location []interface{}
+	if site.Location != nil {
+		location = []interface{}{map[string]interface{}{
+			"lat": site.Location.Lat,
+			"lon": site.Location.Lon,
+		}}
+	}
+	if err := d.Set("location", location); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting location: %w", err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareMagicWANSiteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var site magicWANSite
+	err := updateMagicTransitObject(client, magicTransitURI(accountID, "sites", d.Id()), "site", magicWANSiteFromResource(d), &site)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating Magic WAN site %q: %w", d.Id(), err))
+	}
+
+	return resourceCloudflareMagicWANSiteRead(ctx, d, meta)
+}
+
+func resourceCloudflareMagicWANSiteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting Magic WAN site: %s", d.Id()))
+
+	err := deleteMagicTransitObject(client, magicTransitURI(accountID, "sites", d.Id()))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting Magic WAN site: %w", err))
+	}
+
+	return nil
+}
+
+func magicWANSiteFromResource(d *schema.ResourceData) magicWANSite {
+	site := magicWANSite{
+		Name:                 d.Get("name").(string),
+		Description:          d.Get("description").(string),
+		ConnectorID:          d.Get("connector_id").(string),
+		SecondaryConnectorID: d.Get("secondary_connector_id").(string),
+		HAMode:               d.Get("ha_mode").(bool),
+	}
+
+	if location := d.Get("location").([]interface{}); len(location) > 0 && location[0] != nil {
+		location := location[0].(map[string]interface{})
+		site.Location = &magicWANSiteLocation{
+			Lat: location["lat"].(string),
+			Lon: location["lon"].(string),
+		}
+	}
+
+	return site
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareMagicWANSiteACL() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareMagicWANSiteACLSchema(),
+		CreateContext: resourceCloudflareMagicWANSiteACLCreate,
+		ReadContext:   resourceCloudflareMagicWANSiteACLRead,
+		UpdateContext: resourceCloudflareMagicWANSiteACLUpdate,
+		DeleteContext: resourceCloudflareMagicWANSiteACLDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareMagicWANSiteACLImport,
+		},
+		Description: "Provides a resource, that manages the ACLs allowing traffic between the LANs of Magic WAN sites.",
+	}
+}
+
+func resourceCloudflareMagicWANSiteACLCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var acl magicWANSiteACL
+	err := createMagicTransitObject(client, magicTransitURI(accountID, "sites", siteID, "acls"), "acls", magicWANSiteACLFromResource(d), &acl)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating ACL %s of Magic WAN site %s: %w", d.Get("name").(string), siteID, err))
+	}
+
+	d.SetId(acl.ID)
+
+	return resourceCloudflareMagicWANSiteACLRead(ctx, d, meta)
+}
+
+func resourceCloudflareMagicWANSiteACLImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 3)
+
+	if len(attributes) != 3 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/siteID/aclID\"", d.Id())
+	}
+
+	accountID, siteID, aclID := attributes[0], attributes[1], attributes[2]
+	d.SetId(aclID)
+	d.Set("account_id", accountID)
+	d.Set("site_id", siteID)
+
+	readDiags := resourceCloudflareMagicWANSiteACLRead(ctx, d, meta)
+	if readDiags.HasError() {
+		return nil, errors.New("failed to read Magic WAN site ACL state")
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceCloudflareMagicWANSiteACLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var acl magicWANSiteACL
+	err := magicTransitObjectOf(client, magicTransitURI(accountID, "sites", siteID, "acls", d.Id()), "acl", &acl)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("ACL %s of Magic WAN site %s not found", d.Id(), siteID))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading ACL ID %q of Magic WAN site %s: %w", d.Id(), siteID, err))
+	}
+
+	d.Set("name", acl.Name)
+	d.Set("description", acl.Description)
+	d.Set("forward_locally", acl.ForwardLocally)
+
+	if err := d.Set("lan_1", flattenMagicWANSiteACLConfiguration(acl.LAN1)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting lan_1: %w", err))
+	}
+	if err := d.Set("lan_2", flattenMagicWANSiteACLConfiguration(acl.LAN2)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting lan_2: %w", err))
+	}
+	if err := d.Set("protocols", acl.Protocols); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting protocols: %w", err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareMagicWANSiteACLUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var acl magicWANSiteACL
+	err := updateMagicTransitObject(client, magicTransitURI(accountID, "sites", siteID, "acls", d.Id()), "acl", magicWANSiteACLFromResource(d), &acl)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating ACL %q of Magic WAN site %s: %w", d.Id(), siteID, err))
+	}
+
+	return resourceCloudflareMagicWANSiteACLRead(ctx, d, meta)
+}
+
+func resourceCloudflareMagicWANSiteACLDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting ACL %s of Magic WAN site %s", d.Id(), siteID))
+
+	err := deleteMagicTransitObject(client, magicTransitURI(accountID, "sites", siteID, "acls", d.Id()))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting ACL of Magic WAN site: %w", err))
+	}
+
+	return nil
+}
+
+func magicWANSiteACLFromResource(d *schema.ResourceData) magicWANSiteACL {
+	return magicWANSiteACL{
+		Name:           d.Get("name").(string),
+		Description:    d.Get("description").(string),
+		LAN1:           expandMagicWANSiteACLConfiguration(d.Get("lan_1.0").(map[string]interface{})),
+		LAN2:           expandMagicWANSiteACLConfiguration(d.Get("lan_2.0").(map[string]interface{})),
+		Protocols:      expandInterfaceToStringList(d.Get("protocols").(*schema.Set).List()),
+		ForwardLocally: d.Get("forward_locally").(bool),
+	}
+}
+
+func expandMagicWANSiteACLConfiguration(lan map[string]interface{}) magicWANSiteACLConfiguration {
+	configuration := magicWANSiteACLConfiguration{
+		LANID:   lan["lan_id"].(string),
+		Subnets: expandInterfaceToStringList(lan["subnets"]),
+	}
+	for _, port := range lan["ports"].([]interface{}) {
+		configuration.Ports = append(configuration.Ports, port.(int))
+	}
+	return configuration
+}
+
+func flattenMagicWANSiteACLConfiguration(configuration magicWANSiteACLConfiguration) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"lan_id":   configuration.LANID,
+		"lan_name": configuration.LANName,
+		"ports":    configuration.Ports,
+		"subnets":  configuration.Subnets,
+	}}
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareMagicWANSiteACL_Basic(t *testing.T) {
+	skipMagicTransitTestForNonConfiguredDefaultZone(t)
+
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_magic_wan_site_acl.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareMagicWANSiteACL(rnd, accountID, "tcp"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "lan_1.0.lan_name", rnd+"-office"),
+					resource.TestCheckResourceAttr(name, "lan_2.0.lan_name", rnd+"-lab"),
+					resource.TestCheckResourceAttr(name, "lan_2.0.ports.0", "443"),
+					resource.TestCheckResourceAttr(name, "protocols.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareMagicWANSiteACL(rnd, accountID, "udp"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(name, "protocols.*", "udp"),
+				),
+			},
+			{
+				ResourceName: name,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[name]
+					return fmt.Sprintf("%s/%s/%s", accountID, rs.Primary.Attributes["site_id"], rs.Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareMagicWANSiteACL(rnd, accountID, protocol string) string {
+	return fmt.Sprintf(`
+  resource "cloudflare_magic_wan_site" "%[1]s" {
+	account_id = "%[2]s"
+	name       = "%[1]s"
+  }
+
+  resource "cloudflare_magic_wan_site_lan" "%[1]s_office" {
+	account_id = "%[2]s"
+	site_id    = cloudflare_magic_wan_site.%[1]s.id
+	name       = "%[1]s-office"
+	physport   = 2
+  }
+
+  resource "cloudflare_magic_wan_site_lan" "%[1]s_lab" {
+	account_id = "%[2]s"
+	site_id    = cloudflare_magic_wan_site.%[1]s.id
+	name       = "%[1]s-lab"
+	physport   = 3
+  }
+
+  resource "cloudflare_magic_wan_site_acl" "%[1]s" {
+	account_id = "%[2]s"
+	site_id    = cloudflare_magic_wan_site.%[1]s.id
+	name       = "%[1]s"
+	protocols  = ["%[3]s"]
+
+	lan_1 {
+	  lan_id = cloudflare_magic_wan_site_lan.%[1]s_office.id
+	}
+
+	lan_2 {
+	  lan_id = cloudflare_magic_wan_site_lan.%[1]s_lab.id
+	  ports  = [443]
+	}
+  }`, rnd, accountID, protocol)
+}
+
+func TestMagicWANSiteACLApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	client := m.defaultClient()
+	var site magicWANSite
+	require.NoError(t, createMagicTransitObject(client, magicTransitURI(testAccCloudflareAccountID, "sites"), "sites", magicWANSite{Name: "branch"}, &site))
+	lansURI := magicTransitURI(testAccCloudflareAccountID, "sites", site.ID, "lans")
+	var office, lab magicWANSiteLAN
+	require.NoError(t, createMagicTransitObject(client, lansURI, "lans", magicWANSiteLAN{Name: "office", Physport: 2}, &office))
+	require.NoError(t, createMagicTransitObject(client, lansURI, "lans", magicWANSiteLAN{Name: "lab", Physport: 3}, &lab))
+
+	r := p.ResourcesMap["cloudflare_magic_wan_site_acl"]
+	config := func(protocols ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"account_id": testAccCloudflareAccountID,
+			"site_id":    site.ID,
+			"name":       "office to lab",
+			"lan_1": []interface{}{map[string]interface{}{
+				"lan_id":  office.ID,
+				"subnets": []interface{}{"192.168.1.0/25"},
+			}},
+			"lan_2": []interface{}{map[string]interface{}{
+				"lan_id": lab.ID,
+				"ports":  []interface{}{22, 443},
+			}},
+			"protocols":       protocols,
+			"forward_locally": true,
+		}
+	}
+
+	state := testMockAPIApply(t, r, m, nil, config("tcp"))
+	assert.Equal(t, "office", state.Attributes["lan_1.0.lan_name"])
+	assert.Equal(t, "lab", state.Attributes["lan_2.0.lan_name"])
+	assert.Equal(t, "443", state.Attributes["lan_2.0.ports.1"])
+	assert.Equal(t, "true", state.Attributes["forward_locally"])
+
+	state = testMockAPIApply(t, r, m, state, config("tcp", "icmp"))
+	var acl magicWANSiteACL
+	require.NoError(t, magicTransitObjectOf(client, magicTransitURI(testAccCloudflareAccountID, "sites", site.ID, "acls", state.ID), "acl", &acl))
+	assert.ElementsMatch(t, []string{"tcp", "icmp"}, acl.Protocols)
+	assert.Equal(t, []string{"192.168.1.0/25"}, acl.LAN1.Subnets)
+
+	d := r.Data(&terraform.InstanceState{ID: testAccCloudflareAccountID + "/" + site.ID + "/" + state.ID})
+	imported, err := r.Importer.StateContext(ctx, d, m)
+	require.NoError(t, err)
+	assert.Equal(t, lab.ID, imported[0].Get("lan_2.0.lan_id"))
+
+	_, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state, "deleted ACLs are removed from state")
+}
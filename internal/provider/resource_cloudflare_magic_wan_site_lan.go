@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareMagicWANSiteLAN() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareMagicWANSiteLANSchema(),
+		CreateContext: resourceCloudflareMagicWANSiteLANCreate,
+		ReadContext:   resourceCloudflareMagicWANSiteLANRead,
+		UpdateContext: resourceCloudflareMagicWANSiteLANUpdate,
+		DeleteContext: resourceCloudflareMagicWANSiteLANDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareMagicWANSiteLANImport,
+		},
+		Description: "Provides a resource, that manages the LANs of Magic WAN sites.",
+	}
+}
+
+func resourceCloudflareMagicWANSiteLANCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var lan magicWANSiteLAN
+	err := createMagicTransitObject(client, magicTransitURI(accountID, "sites", siteID, "lans"), "lans", magicWANSiteLANFromResource(d), &lan)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating LAN %s of Magic WAN site %s: %w", d.Get("name").(string), siteID, err))
+	}
+
+	d.SetId(lan.ID)
+
+	return resourceCloudflareMagicWANSiteLANRead(ctx, d, meta)
+}
+
+func resourceCloudflareMagicWANSiteLANImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 3)
+
+	if len(attributes) != 3 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/siteID/lanID\"", d.Id())
+	}
+
+	accountID, siteID, lanID := attributes[0], attributes[1], attributes[2]
+	d.SetId(lanID)
+	d.Set("account_id", accountID)
+	d.Set("site_id", siteID)
+
+	readDiags := resourceCloudflareMagicWANSiteLANRead(ctx, d, meta)
+	if readDiags.HasError() {
+		return nil, errors.New("failed to read Magic WAN site LAN state")
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceCloudflareMagicWANSiteLANRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var lan magicWANSiteLAN
+	err := magicTransitObjectOf(client, magicTransitURI(accountID, "sites", siteID, "lans", d.Id()), "lan", &lan)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("LAN %s of Magic WAN site %s not found", d.Id(), siteID))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading LAN ID %q of Magic WAN site %s: %w", d.Id(), siteID, err))
+	}
+
+	d.Set("name", lan.Name)
+	d.Set("physport", lan.Physport)
+	d.Set("vlan_tag", lan.VlanTag)
+	d.Set("ha_link", lan.HALink)
+
+	if err := d.Set("nat", flattenMagicWANSiteNat(lan.Nat)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting nat: %w", err))
+	}
+	if err := d.Set("static_addressing", flattenMagicWANSiteLANStaticAddressing(lan.StaticAddressing)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting static addressing: %w", err))
+	}
+
+	routedSubnets := make([]interface{}, 0, len(lan.RoutedSubnets))
+	for _, subnet := range lan.RoutedSubnets {
+		routedSubnets = append(routedSubnets, map[string]interface{}{
+			"prefix":   subnet.Prefix,
+			"next_hop": subnet.NextHop,
+			"nat":      flattenMagicWANSiteNat(subnet.Nat),
+		})
+	}
+	if err := d.Set("routed_subnet", routedSubnets); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting routed subnets: %w", err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareMagicWANSiteLANUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var lan magicWANSiteLAN
+	err := updateMagicTransitObject(client, magicTransitURI(accountID, "sites", siteID, "lans", d.Id()), "lan", magicWANSiteLANFromResource(d), &lan)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating LAN %q of Magic WAN site %s: %w", d.Id(), siteID, err))
+	}
+
+	return resourceCloudflareMagicWANSiteLANRead(ctx, d, meta)
+}
+
+func resourceCloudflareMagicWANSiteLANDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting LAN %s of Magic WAN site %s", d.Id(), siteID))
+
+	err := deleteMagicTransitObject(client, magicTransitURI(accountID, "sites", siteID, "lans", d.Id()))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting LAN of Magic WAN site: %w", err))
+	}
+
+	return nil
+}
+
+func magicWANSiteLANFromResource(d *schema.ResourceData) magicWANSiteLAN {
+	lan := magicWANSiteLAN{
+		Name:     d.Get("name").(string),
+		Physport: d.Get("physport").(int),
+		VlanTag:  d.Get("vlan_tag").(int),
+		HALink:   d.Get("ha_link").(bool),
+		Nat:      expandMagicWANSiteNat(d.Get("nat").([]interface{})),
+	}
+
+	if addressing := d.Get("static_addressing").([]interface{}); len(addressing) > 0 && addressing[0] != nil {
+		addressing := addressing[0].(map[string]interface{})
+		lan.StaticAddressing = &magicWANSiteLANStaticAddressing{
+			Address:          addressing["address"].(string),
+			SecondaryAddress: addressing["secondary_address"].(string),
+			VirtualAddress:   addressing["virtual_address"].(string),
+		}
+
+		if relay, ok := addressing["dhcp_relay"].([]interface{}); ok && len(relay) > 0 && relay[0] != nil {
+			lan.StaticAddressing.DHCPRelay = &magicWANSiteLANDHCPRelay{
+				ServerAddresses: expandInterfaceToStringList(relay[0].(map[string]interface{})["server_addresses"]),
+			}
+		}
+
+		if server, ok := addressing["dhcp_server"].([]interface{}); ok && len(server) > 0 && server[0] != nil {
+			server := server[0].(map[string]interface{})
+			lan.StaticAddressing.DHCPServer = &magicWANSiteLANDHCPServer{
+				DHCPPoolStart: server["dhcp_pool_start"].(string),
+				DHCPPoolEnd:   server["dhcp_pool_end"].(string),
+				DNSServer:     server["dns_server"].(string),
+			}
+			if reservations := server["reservations"].(map[string]interface{}); len(reservations) > 0 {
+				lan.StaticAddressing.DHCPServer.Reservations = make(map[string]string, len(reservations))
+				for mac, address := range reservations {
+					lan.StaticAddressing.DHCPServer.Reservations[mac] = address.(string)
+				}
+			}
+		}
+	}
+
+	for _, subnet := range d.Get("routed_subnet").([]interface{}) {
+		subnet := subnet.(map[string]interface{})
+		lan.RoutedSubnets = append(lan.RoutedSubnets, magicWANSiteLANRoutedSubnet{
+			Prefix:  subnet["prefix"].(string),
+			NextHop: subnet["next_hop"].(string),
+			Nat:     expandMagicWANSiteNat(subnet["nat"].([]interface{})),
+		})
+	}
+
+	return lan
+}
+
+func expandMagicWANSiteNat(nat []interface{}) *magicWANSiteNat {
+	if len(nat) == 0 || nat[0] == nil {
+		return nil
+	}
+	return &magicWANSiteNat{StaticPrefix: nat[0].(map[string]interface{})["static_prefix"].(string)}
+}
+
+func flattenMagicWANSiteNat(nat *magicWANSiteNat) []interface{} {
+	if nat == nil || nat.StaticPrefix == "" {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{"static_prefix": nat.StaticPrefix}}
+}
+
+func flattenMagicWANSiteLANStaticAddressing(addressing *magicWANSiteLANStaticAddressing) []interface{} {
+	if addressing == nil {
+		return nil
+	}
+
+	var relay, server []interface{}
+	if addressing.DHCPRelay != nil {
+		relay = []interface{}{map[string]interface{}{
+			"server_addresses": addressing.DHCPRelay.ServerAddresses,
+		}}
+	}
+	if addressing.DHCPServer != nil {
+		server = []interface{}{map[string]interface{}{
+			"dhcp_pool_start": addressing.DHCPServer.DHCPPoolStart,
+			"dhcp_pool_end":   addressing.DHCPServer.DHCPPoolEnd,
+			"dns_server":      addressing.DHCPServer.DNSServer,
+			"reservations":    addressing.DHCPServer.Reservations,
+		}}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"address":           addressing.Address,
+		"secondary_address": addressing.SecondaryAddress,
+		"virtual_address":   addressing.VirtualAddress,
+		"dhcp_relay":        relay,
+		"dhcp_server":       server,
+	}}
+}
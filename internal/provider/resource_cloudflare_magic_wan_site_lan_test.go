@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareMagicWANSiteLAN_Basic(t *testing.T) {
+	skipMagicTransitTestForNonConfiguredDefaultZone(t)
+
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_magic_wan_site_lan.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareMagicWANSiteLAN(rnd, accountID, "192.168.1.200"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "physport", "2"),
+					resource.TestCheckResourceAttr(name, "static_addressing.0.address", "192.168.1.1/24"),
+					resource.TestCheckResourceAttr(name, "static_addressing.0.dhcp_server.0.dhcp_pool_end", "192.168.1.200"),
+					resource.TestCheckResourceAttr(name, "routed_subnet.0.prefix", "10.100.0.0/24"),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareMagicWANSiteLAN(rnd, accountID, "192.168.1.250"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "static_addressing.0.dhcp_server.0.dhcp_pool_end", "192.168.1.250"),
+				),
+			},
+			{
+				ResourceName: name,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[name]
+					return fmt.Sprintf("%s/%s/%s", accountID, rs.Primary.Attributes["site_id"], rs.Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareMagicWANSiteLAN(rnd, accountID, poolEnd string) string {
+	return fmt.Sprintf(`
+  resource "cloudflare_magic_wan_site" "%[1]s" {
+	account_id = "%[2]s"
+	name       = "%[1]s"
+  }
+
+  resource "cloudflare_magic_wan_site_lan" "%[1]s" {
+	account_id = "%[2]s"
+	site_id    = cloudflare_magic_wan_site.%[1]s.id
+	name       = "%[1]s"
+	physport   = 2
+
+	static_addressing {
+	  address = "192.168.1.1/24"
+
+	  dhcp_server {
+		dhcp_pool_start = "192.168.1.100"
+		dhcp_pool_end   = "%[3]s"
+		dns_server      = "192.168.1.1"
+	  }
+	}
+
+	routed_subnet {
+	  prefix   = "10.100.0.0/24"
+	  next_hop = "192.168.1.2"
+	}
+  }`, rnd, accountID, poolEnd)
+}
+
+func TestMagicWANSiteLANApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	var site magicWANSite
+	err := createMagicTransitObject(m.defaultClient(), magicTransitURI(testAccCloudflareAccountID, "sites"), "sites", magicWANSite{Name: "branch"}, &site)
+	require.NoError(t, err)
+
+	r := p.ResourcesMap["cloudflare_magic_wan_site_lan"]
+	config := func(addressing map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"account_id":        testAccCloudflareAccountID,
+			"site_id":           site.ID,
+			"name":              "office",
+			"physport":          2,
+			"vlan_tag":          10,
+			"nat":               []interface{}{map[string]interface{}{"static_prefix": "203.0.113.0/24"}},
+			"static_addressing": []interface{}{addressing},
+			"routed_subnet": []interface{}{map[string]interface{}{
+				"prefix":   "10.100.0.0/24",
+				"next_hop": "192.168.1.2",
+				"nat":      []interface{}{map[string]interface{}{"static_prefix": "203.0.114.0/24"}},
+			}},
+		}
+	}
+
+	dhcpServer := map[string]interface{}{
+		"address": "192.168.1.1/24",
+		"dhcp_server": []interface{}{map[string]interface{}{
+			"dhcp_pool_start": "192.168.1.100",
+			"dhcp_pool_end":   "192.168.1.200",
+			"reservations":    map[string]interface{}{"00:11:22:33:44:55": "192.168.1.10"},
+		}},
+	}
+	state := testMockAPIApply(t, r, m, nil, config(dhcpServer))
+	assert.Equal(t, site.ID, state.Attributes["site_id"])
+	assert.Equal(t, "192.168.1.10", state.Attributes["static_addressing.0.dhcp_server.0.reservations.00:11:22:33:44:55"])
+	assert.Equal(t, "203.0.114.0/24", state.Attributes["routed_subnet.0.nat.0.static_prefix"])
+	dhcpRelay := map[string]interface{}{
+		"address": "192.168.1.1/24",
+		"dhcp_relay": []interface{}{map[string]interface{}{
+			"server_addresses": []interface{}{"192.168.10.1"},
+		}},
+	}
+
+	state = testMockAPIApply(t, r, m, state, config(dhcpRelay))
+	var lan magicWANSiteLAN
+	require.NoError(t, magicTransitObjectOf(m.defaultClient(), magicTransitURI(testAccCloudflareAccountID, "sites", site.ID, "lans", state.ID), "lan", &lan))
+	assert.Nil(t, lan.StaticAddressing.DHCPServer)
+	assert.Equal(t, []string{"192.168.10.1"}, lan.StaticAddressing.DHCPRelay.ServerAddresses)
+
+	d := r.Data(&terraform.InstanceState{ID: testAccCloudflareAccountID + "/" + site.ID + "/" + state.ID})
+	imported, err := r.Importer.StateContext(ctx, d, m)
+	require.NoError(t, err)
+	assert.Equal(t, site.ID, imported[0].Get("site_id"))
+	assert.Equal(t, 10, imported[0].Get("vlan_tag"))
+
+	_, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state, "deleted LANs are removed from state")
+}
+
+func TestMagicWANSiteLANDHCPValidation(t *testing.T) {
+	r := resourceCloudflareMagicWANSiteLAN()
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"site_id":  "8a3a0e7c7a3e4b9c9fd4b9e1c4f6a2b1",
+		"name":     "office",
+		"physport": 2,
+		"static_addressing": []interface{}{map[string]interface{}{
+			"address":     "192.168.1.1/24",
+			"dhcp_relay":  []interface{}{map[string]interface{}{"server_addresses": []interface{}{"192.168.10.1"}}},
+			"dhcp_server": []interface{}{map[string]interface{}{"dhcp_pool_start": "192.168.1.100", "dhcp_pool_end": "192.168.1.200"}},
+		}},
+	}))
+	assert.True(t, diags.HasError(), "a LAN has either a DHCP relay or a DHCP server")
+}
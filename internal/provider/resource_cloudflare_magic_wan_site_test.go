@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareMagicWANSite_Basic(t *testing.T) {
+	skipMagicTransitTestForNonConfiguredDefaultZone(t)
+
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_magic_wan_site.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareMagicWANSite(rnd, accountID, rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "description", rnd),
+					resource.TestCheckResourceAttr(name, "ha_mode", "false"),
+					resource.TestCheckResourceAttr(name, "location.0.lat", "51.5072"),
+					resource.TestCheckResourceAttr(name, "location.0.lon", "-0.1276"),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareMagicWANSite(rnd, accountID, rnd+"-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "description", rnd+"-updated"),
+				),
+			},
+			{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateIdPrefix: accountID + "/",
+				ImportStateVerify:   true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareMagicWANSite(rnd, accountID, description string) string {
+	return fmt.Sprintf(`
+  resource "cloudflare_magic_wan_site" "%[1]s" {
+	account_id  = "%[2]s"
+	name        = "%[1]s"
+	description = "%[3]s"
+
+	location {
+	  lat = "51.5072"
+	  lon = "-0.1276"
+	}
+  }`, rnd, accountID, description)
+}
+
+func TestMagicWANSiteApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_magic_wan_site"]
+	config := map[string]interface{}{
+		"account_id": testAccCloudflareAccountID,
+		"name":       "branch",
+	}
+
+	ha := map[string]interface{}{
+		"connector_id":           "d7a7f9c1e5b2446b8a9ecbd5a3c2f1e0",
+		"secondary_connector_id": "0e1f2c3a5d5bce9a8b6442b5e1c9f7a7",
+		"ha_mode":                true,
+		"location":               []interface{}{map[string]interface{}{"lat": "51.5072", "lon": "-0.1276"}},
+	}
+	state := testMockAPIApply(t, r, m, nil, testMockAPIConfig(config, ha))
+	assert.Equal(t, "true", state.Attributes["ha_mode"])
+	assert.Equal(t, "51.5072", state.Attributes["location.0.lat"])
+
+	ha["description"] = "Branch office"
+	updated := testMockAPIApply(t, r, m, state, testMockAPIConfig(config, ha))
+	assert.Equal(t, state.ID, updated.ID)
+	var site magicWANSite
+	require.NoError(t, magicTransitObjectOf(m.defaultClient(), magicTransitURI(testAccCloudflareAccountID, "sites", state.ID), "site", &site))
+	assert.Equal(t, "Branch office", site.Description)
+
+	diff, err := testMockAPIDiff(t, r, m, updated, config)
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew(), "the high availability mode can't be changed in place")
+
+	d := r.Data(&terraform.InstanceState{ID: testAccCloudflareAccountID + "/" + state.ID})
+	imported, err := r.Importer.StateContext(ctx, d, m)
+	require.NoError(t, err)
+	assert.Equal(t, "Branch office", imported[0].Get("description"))
+	assert.Equal(t, "0e1f2c3a5d5bce9a8b6442b5e1c9f7a7", imported[0].Get("secondary_connector_id"))
+
+	_, diags := r.Apply(ctx, updated, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	updated, diags = r.RefreshWithoutUpgrade(ctx, updated, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, updated, "deleted sites are removed from state")
+}
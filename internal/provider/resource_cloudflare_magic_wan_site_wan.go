@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareMagicWANSiteWAN() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareMagicWANSiteWANSchema(),
+		CreateContext: resourceCloudflareMagicWANSiteWANCreate,
+		ReadContext:   resourceCloudflareMagicWANSiteWANRead,
+		UpdateContext: resourceCloudflareMagicWANSiteWANUpdate,
+		DeleteContext: resourceCloudflareMagicWANSiteWANDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareMagicWANSiteWANImport,
+		},
+		Description: "Provides a resource, that manages the WANs of Magic WAN sites.",
+	}
+}
+
+func resourceCloudflareMagicWANSiteWANCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var wan magicWANSiteWAN
+	err := createMagicTransitObject(client, magicTransitURI(accountID, "sites", siteID, "wans"), "wans", magicWANSiteWANFromResource(d), &wan)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating WAN %s of Magic WAN site %s: %w", d.Get("name").(string), siteID, err))
+	}
+
+	d.SetId(wan.ID)
+
+	return resourceCloudflareMagicWANSiteWANRead(ctx, d, meta)
+}
+
+func resourceCloudflareMagicWANSiteWANImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 3)
+
+	if len(attributes) != 3 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/siteID/wanID\"", d.Id())
+	}
+
+	accountID, siteID, wanID := attributes[0], attributes[1], attributes[2]
+	d.SetId(wanID)
+	d.Set("account_id", accountID)
+	d.Set("site_id", siteID)
+
+	readDiags := resourceCloudflareMagicWANSiteWANRead(ctx, d, meta)
+	if readDiags.HasError() {
+		return nil, errors.New("failed to read Magic WAN site WAN state")
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceCloudflareMagicWANSiteWANRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var wan magicWANSiteWAN
+	err := magicTransitObjectOf(client, magicTransitURI(accountID, "sites", siteID, "wans", d.Id()), "wan", &wan)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("WAN %s of Magic WAN site %s not found", d.Id(), siteID))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading WAN ID %q of Magic WAN site %s: %w", d.Id(), siteID, err))
+	}
+
+	d.Set("name", wan.Name)
+	d.Set("physport", wan.Physport)
+	d.Set("vlan_tag", wan.VlanTag)
+	d.Set("priority", wan.Priority)
+
+	var addressing []interface{}
+	if wan.StaticAddressing != nil {
+		addressing = []interface{}{map[string]interface{}{
+			"address":           wan.StaticAddressing.Address,
+			"gateway_address":   wan.StaticAddressing.GatewayAddress,
+			"secondary_address": wan.StaticAddressing.SecondaryAddress,
+		}}
+	}
+	if err := d.Set("static_addressing", addressing); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting static addressing: %w", err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareMagicWANSiteWANUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	var wan magicWANSiteWAN
+	err := updateMagicTransitObject(client, magicTransitURI(accountID, "sites", siteID, "wans", d.Id()), "wan", magicWANSiteWANFromResource(d), &wan)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating WAN %q of Magic WAN site %s: %w", d.Id(), siteID, err))
+	}
+
+	return resourceCloudflareMagicWANSiteWANRead(ctx, d, meta)
+}
+
+func resourceCloudflareMagicWANSiteWANDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	accountID := d.Get("account_id").(string)
+	siteID := d.Get("site_id").(string)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting WAN %s of Magic WAN site %s", d.Id(), siteID))
+
+	err := deleteMagicTransitObject(client, magicTransitURI(accountID, "sites", siteID, "wans", d.Id()))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting WAN of Magic WAN site: %w", err))
+	}
+
+	return nil
+}
+
+func magicWANSiteWANFromResource(d *schema.ResourceData) magicWANSiteWAN {
+	wan := magicWANSiteWAN{
+		Name:     d.Get("name").(string),
+		Physport: d.Get("physport").(int),
+		VlanTag:  d.Get("vlan_tag").(int),
+		Priority: d.Get("priority").(int),
+	}
+
+	if addressing := d.Get("static_addressing").([]interface{}); len(addressing) > 0 && addressing[0] != nil {
+		addressing := addressing[0].(map[string]interface{})
+		wan.StaticAddressing = &magicWANSiteWANStaticAddressing{
+			Address:          addressing["address"].(string),
+			GatewayAddress:   addressing["gateway_address"].(string),
+			SecondaryAddress: addressing["secondary_address"].(string),
+		}
+	}
+
+	return wan
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareMagicWANSiteWAN_Basic(t *testing.T) {
+	skipMagicTransitTestForNonConfiguredDefaultZone(t)
+
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_magic_wan_site_wan.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareMagicWANSiteWAN(rnd, accountID, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "physport", "1"),
+					resource.TestCheckResourceAttr(name, "priority", "1"),
+					resource.TestCheckResourceAttr(name, "static_addressing.0.gateway_address", "203.0.113.1"),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareMagicWANSiteWAN(rnd, accountID, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "priority", "2"),
+				),
+			},
+			{
+				ResourceName: name,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[name]
+					return fmt.Sprintf("%s/%s/%s", accountID, rs.Primary.Attributes["site_id"], rs.Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareMagicWANSiteWAN(rnd, accountID string, priority int) string {
+	return fmt.Sprintf(`
+  resource "cloudflare_magic_wan_site" "%[1]s" {
+	account_id = "%[2]s"
+	name       = "%[1]s"
+  }
+
+  resource "cloudflare_magic_wan_site_wan" "%[1]s" {
+	account_id = "%[2]s"
+	site_id    = cloudflare_magic_wan_site.%[1]s.id
+	name       = "%[1]s"
+	physport   = 1
+	priority   = %[3]d
+
+	static_addressing {
+	  address         = "203.0.113.2/24"
+	  gateway_address = "203.0.113.1"
+	}
+  }`, rnd, accountID, priority)
+}
+
+func TestMagicWANSiteWANApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	var site magicWANSite
+	err := createMagicTransitObject(m.defaultClient(), magicTransitURI(testAccCloudflareAccountID, "sites"), "sites", magicWANSite{Name: "branch"}, &site)
+	require.NoError(t, err)
+
+	r := p.ResourcesMap["cloudflare_magic_wan_site_wan"]
+	config := func(addressing ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"account_id":        testAccCloudflareAccountID,
+			"site_id":           site.ID,
+			"name":              "isp",
+			"physport":          1,
+			"priority":          1,
+			"static_addressing": addressing,
+		}
+	}
+
+	static := map[string]interface{}{"address": "203.0.113.2/24", "gateway_address": "203.0.113.1"}
+	state := testMockAPIApply(t, r, m, nil, config(static))
+	assert.Equal(t, "203.0.113.1", state.Attributes["static_addressing.0.gateway_address"])
+
+	// WANs without static addressing use DHCP.
+
+	state = testMockAPIApply(t, r, m, state, config())
+	var wan magicWANSiteWAN
+	require.NoError(t, magicTransitObjectOf(m.defaultClient(), magicTransitURI(testAccCloudflareAccountID, "sites", site.ID, "wans", state.ID), "wan", &wan))
+	assert.Nil(t, wan.StaticAddressing)
+	assert.Equal(t, "0", state.Attributes["static_addressing.#"])
+
+	d := r.Data(&terraform.InstanceState{ID: testAccCloudflareAccountID + "/" + site.ID + "/" + state.ID})
+	imported, err := r.Importer.StateContext(ctx, d, m)
+	require.NoError(t, err)
+	assert.Equal(t, "isp", imported[0].Get("name"))
+	assert.Equal(t, 1, imported[0].Get("priority"))
+
+	_, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state, "deleted WANs are removed from state")
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,6 +12,49 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// notificationPolicyAlertTypeFilters are the alert types of notification
+// policies along with the filters each of them can be narrowed down with.
+var notificationPolicyAlertTypeFilters = map[string][]string{
+	"access_custom_certificate_expiration_type":   {},
+	"advanced_ddos_attack_l4_alert":               {"megabits_per_second", "packets_per_second", "protocol"},
+	"advanced_ddos_attack_l7_alert":               {"zones", "requests_per_second", "target_hostname", "target_zone_name"},
+	"bgp_hijack_notification":                     {},
+	"billing_usage_alert":                         {"product", "limit"},
+	"clickhouse_alert_fw_anomaly":                 {"zones", "services"},
+	"clickhouse_alert_fw_ent_anomaly":             {"zones", "services"},
+	"dedicated_ssl_certificate_event_type":        {},
+	"dos_attack_l4":                               {},
+	"dos_attack_l7":                               {},
+	"expiring_service_token_alert":                {},
+	"failing_logpush_job_disabled_alert":          {},
+	"g6_pool_toggle_alert":                        {"pool_id", "enabled"},
+	"health_check_status_notification":            {"health_check_id", "status"},
+	"http_alert_edge_error":                       {"zones", "slo"},
+	"http_alert_origin_error":                     {"zones", "slo"},
+	"load_balancing_health_alert":                 {"pool_id", "new_health", "event_source"},
+	"real_origin_monitoring":                      {},
+	"secondary_dns_all_primaries_failing":         {"zones"},
+	"secondary_dns_primaries_failing":             {"zones"},
+	"secondary_dns_zone_successfully_updated":     {"zones"},
+	"secondary_dns_zone_validation_warning":       {"zones"},
+	"stream_live_notifications":                   {"input_id", "event_type"},
+	"traffic_anomalies_alert":                     {"zones", "traffic_exclusions"},
+	"universal_ssl_event_type":                    {},
+	"workers_alert":                               {},
+	"zone_aop_custom_certificate_expiration_type": {},
+}
+
+// notificationPolicyAlertTypes returns the sorted alert types of notification
+// policies.
+func notificationPolicyAlertTypes() []string {
+	alertTypes := make([]string, 0, len(notificationPolicyAlertTypeFilters))
+	for alertType := range notificationPolicyAlertTypeFilters {
+		alertTypes = append(alertTypes, alertType)
+	}
+	sort.Strings(alertTypes)
+	return alertTypes
+}
+
 func resourceCloudflareNotificationPolicy() *schema.Resource {
 	return &schema.Resource{
 		Schema:        resourceCloudflareNotificationPolicySchema(),
@@ -21,11 +65,15 @@ func resourceCloudflareNotificationPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceNotificationPolicyImport,
 		},
+		CustomizeDiff: resourceCloudflareNotificationPolicyValidate,
 	}
 }
 
 func resourceCloudflareNotificationPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	notificationPolicy := buildNotificationPolicy(d)
@@ -41,7 +89,10 @@ func resourceCloudflareNotificationPolicyCreate(ctx context.Context, d *schema.R
 }
 
 func resourceCloudflareNotificationPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	policyID := d.Id()
 	accountID := d.Get("account_id").(string)
 
@@ -81,7 +132,10 @@ func resourceCloudflareNotificationPolicyRead(ctx context.Context, d *schema.Res
 }
 
 func resourceCloudflareNotificationPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	policyID := d.Id()
 	accountID := d.Get("account_id").(string)
 
@@ -98,7 +152,10 @@ func resourceCloudflareNotificationPolicyUpdate(ctx context.Context, d *schema.R
 }
 
 func resourceCloudflareNotificationPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	policyID := d.Id()
 	accountID := d.Get("account_id").(string)
 
@@ -110,6 +167,47 @@ func resourceCloudflareNotificationPolicyDelete(ctx context.Context, d *schema.R
 	return nil
 }
 
+// resourceCloudflareNotificationPolicyValidate checks the filters of a policy
+// are supported by its alert type, which the API only does when the policy
+// is applied.
+func resourceCloudflareNotificationPolicyValidate(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	alertType := d.Get("alert_type").(string)
+	allowed, ok := notificationPolicyAlertTypeFilters[alertType]
+	if !ok {
+		return nil
+	}
+
+	filters := d.Get("filters").([]interface{})
+	if len(filters) == 0 || filters[0] == nil {
+		return nil
+	}
+
+	var keys []string
+	for key, values := range filters[0].(map[string]interface{}) {
+		if values.(*schema.Set).Len() > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if contains(allowed, key) {
+			continue
+		}
+		if len(allowed) == 0 {
+			return fmt.Errorf("alert type %q doesn't support filters, remove the %q filter", alertType, key)
+		}
+		return fmt.Errorf("alert type %q doesn't support the %q filter, supported filters are: %s", alertType, key, strings.Join(allowed, ", "))
+	}
+
+	// Pool toggle alerts need to know which state to alert on.
+	if alertType == "g6_pool_toggle_alert" && contains(keys, "pool_id") && !contains(keys, "enabled") {
+		return fmt.Errorf("the \"pool_id\" filter of alert type %q requires the \"enabled\" filter", alertType)
+	}
+
+	return nil
+}
+
 func resourceNotificationPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	attributes := strings.SplitN(d.Id(), "/", 2)
 
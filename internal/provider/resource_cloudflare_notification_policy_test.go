@@ -1,14 +1,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccCloudflareNotificationPolicy_Basic(t *testing.T) {
@@ -192,3 +196,97 @@ func TestFlattenExpandFilters(t *testing.T) {
 		assert.EqualValuesf(t, filters[k], expandedFilters[k], "values should equal without order")
 	}
 }
+
+func TestNotificationPolicyFiltersValidation(t *testing.T) {
+	p := New("dev")()
+	r := p.ResourcesMap["cloudflare_notification_policy"]
+	ctx := context.Background()
+
+	config := func(alertType string, filters map[string]interface{}) *terraform.ResourceConfig {
+		raw := map[string]interface{}{
+			"account_id": testAccCloudflareAccountID,
+			"name":       "policy",
+			"enabled":    true,
+			"alert_type": alertType,
+		}
+		if filters != nil {
+			raw["filters"] = []interface{}{filters}
+		}
+		return terraform.NewResourceConfigRaw(raw)
+	}
+
+	_, err := r.Diff(ctx, nil, config("billing_usage_alert", map[string]interface{}{
+		"product": []interface{}{"worker_requests"},
+		"limit":   []interface{}{"100"},
+	}), nil)
+	assert.NoError(t, err)
+
+	_, err = r.Diff(ctx, nil, config("billing_usage_alert", map[string]interface{}{
+		"pool_id": []interface{}{"17b5962d775c646f3f9725cbc7a53df4"},
+	}), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `alert type "billing_usage_alert" doesn't support the "pool_id" filter, supported filters are: product, limit`)
+
+	_, err = r.Diff(ctx, nil, config("universal_ssl_event_type", map[string]interface{}{
+		"zones": []interface{}{testAccCloudflareZoneID},
+	}), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `alert type "universal_ssl_event_type" doesn't support filters, remove the "zones" filter`)
+
+	_, err = r.Diff(ctx, nil, config("g6_pool_toggle_alert", map[string]interface{}{
+		"pool_id": []interface{}{"17b5962d775c646f3f9725cbc7a53df4"},
+	}), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `the "pool_id" filter of alert type "g6_pool_toggle_alert" requires the "enabled" filter`)
+
+	_, err = r.Diff(ctx, nil, config("load_balancing_health_alert", map[string]interface{}{
+		"pool_id":      []interface{}{"17b5962d775c646f3f9725cbc7a53df4"},
+		"new_health":   []interface{}{"Unhealthy"},
+		"event_source": []interface{}{"pool"},
+	}), nil)
+	assert.NoError(t, err, "load balancing health alerts can be filtered by pool alone")
+
+	diags := r.Validate(config("billing_usage_alerts", nil))
+	assert.True(t, diags.HasError(), "alert types are validated")
+	diags = r.Validate(config("load_balancing_health_alert", map[string]interface{}{
+		"new_health": []interface{}{"unhealthy"},
+	}))
+	assert.True(t, diags.HasError(), "filter values are validated")
+}
+
+func TestNotificationPolicyApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_notification_policy"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id": testAccCloudflareAccountID,
+		"name":       "stream",
+		"enabled":    true,
+		"alert_type": "stream_live_notifications",
+		"email_integration": []interface{}{
+			map[string]interface{}{"id": "test@example.com"},
+		},
+		"filters": []interface{}{map[string]interface{}{
+			"input_id":   []interface{}{"9e7c0e3a0b4b4fbbbf5a3e4f0c0c4f1a"},
+			"event_type": []interface{}{"live_input.disconnected", "live_input.errored"},
+		}},
+	})
+	diff, err := r.Diff(ctx, nil, config, m)
+	require.NoError(t, err)
+	state, diags := r.Apply(ctx, nil, diff, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "1", state.Attributes["filters.0.input_id.#"])
+	assert.Equal(t, "2", state.Attributes["filters.0.event_type.#"])
+
+	diff, err = r.Diff(ctx, state, config, m)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "%v", diff)
+
+	policy, err := m.defaultClient().GetNotificationPolicy(ctx, testAccCloudflareAccountID, state.ID)
+	require.NoError(t, err)
+	sort.Strings(policy.Result.Filters["event_type"])
+	assert.Equal(t, []string{"live_input.disconnected", "live_input.errored"}, policy.Result.Filters["event_type"])
+}
@@ -26,7 +26,10 @@ func resourceCloudflareNotificationPolicyWebhooks() *schema.Resource {
 }
 
 func resourceCloudflareNotificationPolicyWebhooksCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	notificationWebhooks := buildNotificationPolicyWebhooks(d)
@@ -47,7 +50,10 @@ func resourceCloudflareNotificationPolicyWebhooksCreate(ctx context.Context, d *
 }
 
 func resourceCloudflareNotificationPolicyWebhooksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	webhooksDestinationID := d.Id()
 	accountID := d.Get("account_id").(string)
 
@@ -74,7 +80,10 @@ func resourceCloudflareNotificationPolicyWebhooksRead(ctx context.Context, d *sc
 }
 
 func resourceCloudflareNotificationPolicyWebhooksUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	webhooksID := d.Id()
 	accountID := d.Get("account_id").(string)
 
@@ -90,7 +99,10 @@ func resourceCloudflareNotificationPolicyWebhooksUpdate(ctx context.Context, d *
 }
 
 func resourceCloudflareNotificationPolicyWebhooksDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	webhooksID := d.Id()
 	accountID := d.Get("account_id").(string)
 
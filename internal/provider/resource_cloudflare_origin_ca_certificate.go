@@ -29,7 +29,10 @@ func resourceCloudflareOriginCACertificate() *schema.Resource {
 }
 
 func resourceCloudflareOriginCACertificateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	hostnames := []string{}
 	hostnamesRaw := d.Get("hostnames").(*schema.Set)
@@ -63,7 +66,10 @@ func resourceCloudflareOriginCACertificateCreate(ctx context.Context, d *schema.
 }
 
 func resourceCloudflareOriginCACertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	certID := d.Id()
 	cert, err := client.OriginCertificate(ctx, certID)
 
@@ -109,7 +115,10 @@ func resourceCloudflareOriginCACertificateRead(ctx context.Context, d *schema.Re
 }
 
 func resourceCloudflareOriginCACertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	certID := d.Id()
 
 	tflog.Info(ctx, fmt.Sprintf("Revoking Cloudflare OriginCACertificate: id %s", certID))
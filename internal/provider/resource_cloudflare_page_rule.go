@@ -36,7 +36,10 @@ func suppressEquivalentURLs(k, old, new string, d *schema.ResourceData) bool {
 }
 
 func resourceCloudflarePageRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	newPageRuleTargets := []cloudflare.PageRuleTarget{
@@ -104,7 +107,10 @@ func pageRuleActionsToMap(vs []cloudflare.PageRuleAction) map[string]interface{}
 }
 
 func resourceCloudflarePageRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	pageRule, err := client.PageRule(ctx, zoneID, d.Id())
@@ -145,7 +151,10 @@ func resourceCloudflarePageRuleRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCloudflarePageRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	updatePageRule := cloudflare.PageRule{}
@@ -202,7 +211,10 @@ func resourceCloudflarePageRuleUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceCloudflarePageRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	log.Printf("[INFO] Deleting Cloudflare Page Rule: %s, %s", zoneID, d.Id())
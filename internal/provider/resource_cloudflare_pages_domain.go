@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// pagesDomainFailedStatuses are the statuses of domains that won't become
+// active without being changed.
+var pagesDomainFailedStatuses = []string{"blocked", "error", "deactivated"}
+
+func resourceCloudflarePagesDomain() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflarePagesDomainSchema(),
+		CreateContext: resourceCloudflarePagesDomainCreate,
+		ReadContext:   resourceCloudflarePagesDomainRead,
+		UpdateContext: resourceCloudflarePagesDomainUpdate,
+		DeleteContext: resourceCloudflarePagesDomainDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflarePagesDomainImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func resourceCloudflarePagesDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	projectName := d.Get("project_name").(string)
+	domain := d.Get("domain").(string)
+
+	if _, err := client.Raw(http.MethodPost, pagesProjectURI(accountID, projectName, "domains"), pagesDomain{Name: domain}); err != nil {
+		return diag.FromErr(fmt.Errorf("error adding domain %q to Pages project %q: %w", domain, projectName, err))
+	}
+
+	d.SetId(domain)
+
+	if diags := waitForPagesDomain(ctx, d, client, d.Timeout(schema.TimeoutCreate)); diags.HasError() {
+		return diags
+	}
+
+	return resourceCloudflarePagesDomainRead(ctx, d, meta)
+}
+
+func resourceCloudflarePagesDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	projectName := d.Get("project_name").(string)
+
+	result, err := client.Raw(http.MethodGet, pagesProjectURI(accountID, projectName, "domains", d.Id()), nil)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Pages domain %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading Pages domain %q: %w", d.Id(), err))
+	}
+
+	var domain pagesDomain
+	if err := json.Unmarshal(result, &domain); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Pages domain %q: %w", d.Id(), err))
+	}
+
+	d.Set("domain", domain.Name)
+	d.Set("status", domain.Status)
+
+	return nil
+}
+
+// Only waiting for the domain can be changed in place.
+func resourceCloudflarePagesDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	if diags := waitForPagesDomain(ctx, d, client, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+		return diags
+	}
+
+	return resourceCloudflarePagesDomainRead(ctx, d, meta)
+}
+
+func resourceCloudflarePagesDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	projectName := d.Get("project_name").(string)
+
+	if _, err := client.Raw(http.MethodDelete, pagesProjectURI(accountID, projectName, "domains", d.Id()), nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error removing domain %q from Pages project %q: %w", d.Id(), projectName, err))
+	}
+
+	return nil
+}
+
+func resourceCloudflarePagesDomainImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 3)
+
+	if len(attributes) != 3 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/projectName/domain\"", d.Id())
+	}
+
+	accountID, projectName, domain := attributes[0], attributes[1], attributes[2]
+	d.SetId(domain)
+	d.Set("account_id", accountID)
+	d.Set("project_name", projectName)
+	d.Set("wait_for_active_status", true)
+
+	resourceCloudflarePagesDomainRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForPagesDomain polls a domain until it's active, if its resource is
+// configured to wait for it. The verification and validation errors of
+// domains that fail are returned as diagnostics.
+func waitForPagesDomain(ctx context.Context, d *schema.ResourceData, client *cloudflare.API, timeout time.Duration) diag.Diagnostics {
+	if !d.Get("wait_for_active_status").(bool) {
+		return nil
+	}
+	accountID := d.Get("account_id").(string)
+	projectName := d.Get("project_name").(string)
+
+	var domain pagesDomain
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		result, err := client.Raw(http.MethodGet, pagesProjectURI(accountID, projectName, "domains", d.Id()), nil)
+		if err != nil {
+			return resource.NonRetryableError(fmt.Errorf("error reading Pages domain %q: %w", d.Id(), err))
+		}
+		if err := json.Unmarshal(result, &domain); err != nil {
+			return resource.NonRetryableError(fmt.Errorf("error reading Pages domain %q: %w", d.Id(), err))
+		}
+
+		if contains(pagesDomainFailedStatuses, domain.Status) {
+			return resource.NonRetryableError(fmt.Errorf("domain %q of Pages project %q is %s", domain.Name, projectName, domain.Status))
+		}
+		if domain.Status == "active" {
+			return nil
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Pages domain %s is %s", domain.Name, domain.Status))
+		return resource.RetryableError(fmt.Errorf("domain %q of Pages project %q is %s", domain.Name, projectName, domain.Status))
+	})
+	if err == nil {
+		return nil
+	}
+
+	diags := diag.FromErr(err)
+	if v := domain.VerificationData; v != nil && v.ErrorMessage != "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Verification error for Pages domain %q", domain.Name),
+			Detail:   v.ErrorMessage,
+		})
+	}
+	if v := domain.ValidationData; v != nil && v.ErrorMessage != "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Certificate validation error for Pages domain %q", domain.Name),
+			Detail:   v.ErrorMessage,
+		})
+	}
+
+	return diags
+}
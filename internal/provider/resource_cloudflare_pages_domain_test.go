@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagesDomainWait(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	_, err := m.defaultClient().Raw(http.MethodPost, "/accounts/"+testAccCloudflareAccountID+"/pages/projects", pagesProject{Name: "site", ProductionBranch: "main"})
+	require.NoError(t, err)
+
+	r := p.ResourcesMap["cloudflare_pages_domain"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"account_id":   testAccCloudflareAccountID,
+		"project_name": "site",
+		"domain":       "www.example.com",
+	})
+	diags := r.CreateContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "www.example.com", d.Id())
+	assert.Equal(t, "active", d.Get("status"))
+
+	// Verification errors are reported along with the failure.
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"account_id":   testAccCloudflareAccountID,
+		"project_name": "site",
+		"domain":       "www.example.invalid",
+	})
+	diags = r.CreateContext(ctx, d, m)
+	require.True(t, diags.HasError())
+	assert.Equal(t, `domain "www.example.invalid" of Pages project "site" is error`, diags[0].Summary)
+	require.Len(t, diags, 2)
+	assert.Equal(t, `Verification error for Pages domain "www.example.invalid"`, diags[1].Summary)
+	assert.Contains(t, diags[1].Detail, "CNAME record")
+
+	diags = r.DeleteContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	diags = r.ReadContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "", d.Id())
+}
@@ -0,0 +1,395 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflarePagesProject() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflarePagesProjectSchema(),
+		CreateContext: resourceCloudflarePagesProjectCreate,
+		ReadContext:   resourceCloudflarePagesProjectRead,
+		UpdateContext: resourceCloudflarePagesProjectUpdate,
+		DeleteContext: resourceCloudflarePagesProjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflarePagesProjectImport,
+		},
+		// Projects can't be connected to or disconnected from a repository
+		// once created.
+		CustomizeDiff: customdiff.ForceNewIfChange("source.#", func(ctx context.Context, old, new, meta interface{}) bool {
+			return true
+		}),
+	}
+}
+
+func resourceCloudflarePagesProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	project := pagesProject{
+		Name:              d.Get("name").(string),
+		ProductionBranch:  d.Get("production_branch").(string),
+		Source:            expandPagesProjectSource(d),
+		BuildConfig:       expandPagesBuildConfig(d.Get("build_config").([]interface{})),
+		DeploymentConfigs: expandPagesDeploymentConfigs(nil, d.Get("deployment_configs").([]interface{})),
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating Cloudflare Pages project %s", project.Name))
+
+	if _, err := client.Raw(http.MethodPost, fmt.Sprintf("/accounts/%s/pages/projects", accountID), project); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Pages project %q: %w", project.Name, err))
+	}
+
+	d.SetId(project.Name)
+
+	return resourceCloudflarePagesProjectRead(ctx, d, meta)
+}
+
+func resourceCloudflarePagesProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	result, err := client.Raw(http.MethodGet, pagesProjectURI(accountID, d.Id()), nil)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Pages project %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading Pages project %q: %w", d.Id(), err))
+	}
+
+	var project pagesProject
+	if err := json.Unmarshal(result, &project); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Pages project %q: %w", d.Id(), err))
+	}
+
+	d.Set("name", project.Name)
+	d.Set("production_branch", project.ProductionBranch)
+	d.Set("subdomain", project.Subdomain)
+	d.Set("domains", project.Domains)
+	d.Set("created_on", project.CreatedOn)
+
+	if err := d.Set("source", flattenPagesProjectSource(project.Source)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting source of Pages project %q: %w", d.Id(), err))
+	}
+	if err := d.Set("build_config", flattenPagesBuildConfig(project.BuildConfig)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting build config of Pages project %q: %w", d.Id(), err))
+	}
+	if err := d.Set("deployment_configs", flattenPagesDeploymentConfigs(d, project.DeploymentConfigs)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting deployment configs of Pages project %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflarePagesProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	oldConfigs, newConfigs := d.GetChange("deployment_configs")
+	project := pagesProject{
+		ProductionBranch:  d.Get("production_branch").(string),
+		Source:            expandPagesProjectSource(d),
+		BuildConfig:       expandPagesBuildConfig(d.Get("build_config").([]interface{})),
+		DeploymentConfigs: expandPagesDeploymentConfigs(oldConfigs.([]interface{}), newConfigs.([]interface{})),
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating Cloudflare Pages project %s", d.Id()))
+
+	if _, err := client.Raw(http.MethodPatch, pagesProjectURI(accountID, d.Id()), project); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating Pages project %q: %w", d.Id(), err))
+	}
+
+	return resourceCloudflarePagesProjectRead(ctx, d, meta)
+}
+
+func resourceCloudflarePagesProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	if _, err := client.Raw(http.MethodDelete, pagesProjectURI(accountID, d.Id()), nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting Pages project %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflarePagesProjectImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 2)
+
+	if len(attributes) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/projectName\"", d.Id())
+	}
+
+	accountID, projectName := attributes[0], attributes[1]
+	d.SetId(projectName)
+	d.Set("account_id", accountID)
+
+	resourceCloudflarePagesProjectRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandPagesProjectSource(d *schema.ResourceData) *pagesProjectSource {
+	if _, ok := d.GetOk("source"); !ok {
+		return nil
+	}
+
+	config := d.Get("source.0.config.0").(map[string]interface{})
+	return &pagesProjectSource{
+		Type: d.Get("source.0.type").(string),
+		Config: &pagesProjectSourceConfig{
+			Owner:                        config["owner"].(string),
+			RepoName:                     config["repo_name"].(string),
+			ProductionBranch:             d.Get("production_branch").(string),
+			PRCommentsEnabled:            config["pr_comments_enabled"].(bool),
+			DeploymentsEnabled:           config["deployments_enabled"].(bool),
+			ProductionDeploymentsEnabled: config["production_deployments_enabled"].(bool),
+			PreviewDeploymentSetting:     config["preview_deployment_setting"].(string),
+			PreviewBranchIncludes:        expandInterfaceToStringList(config["preview_branch_includes"]),
+			PreviewBranchExcludes:        expandInterfaceToStringList(config["preview_branch_excludes"]),
+		},
+	}
+}
+
+func flattenPagesProjectSource(source *pagesProjectSource) []interface{} {
+	if source == nil || source.Config == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"type": source.Type,
+		"config": []interface{}{map[string]interface{}{
+			"owner":                          source.Config.Owner,
+			"repo_name":                      source.Config.RepoName,
+			"pr_comments_enabled":            source.Config.PRCommentsEnabled,
+			"deployments_enabled":            source.Config.DeploymentsEnabled,
+			"production_deployments_enabled": source.Config.ProductionDeploymentsEnabled,
+			"preview_deployment_setting":     source.Config.PreviewDeploymentSetting,
+			"preview_branch_includes":        source.Config.PreviewBranchIncludes,
+			"preview_branch_excludes":        source.Config.PreviewBranchExcludes,
+		}},
+	}}
+}
+
+func expandPagesBuildConfig(configs []interface{}) *pagesBuildConfig {
+	if len(configs) == 0 || configs[0] == nil {
+		return nil
+	}
+
+	config := configs[0].(map[string]interface{})
+	return &pagesBuildConfig{
+		BuildCommand:      config["build_command"].(string),
+		DestinationDir:    config["destination_dir"].(string),
+		RootDir:           config["root_dir"].(string),
+		WebAnalyticsTag:   config["web_analytics_tag"].(string),
+		WebAnalyticsToken: config["web_analytics_token"].(string),
+	}
+}
+
+func flattenPagesBuildConfig(config *pagesBuildConfig) []interface{} {
+	if config == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"build_command":       config.BuildCommand,
+		"destination_dir":     config.DestinationDir,
+		"root_dir":            config.RootDir,
+		"web_analytics_tag":   config.WebAnalyticsTag,
+		"web_analytics_token": config.WebAnalyticsToken,
+	}}
+}
+
+// expandPagesDeploymentConfigs returns the deployment configurations to send
+// for the configured ones, removing the settings of the previous ones that
+// are no longer configured.
+func expandPagesDeploymentConfigs(old, new []interface{}) *pagesDeploymentConfigs {
+	newConfigs := firstMapOf(new)
+	if newConfigs == nil {
+		return nil
+	}
+	oldConfigs := firstMapOf(old)
+
+	configs := &pagesDeploymentConfigs{}
+	for _, env := range pagesDeploymentEnvironments {
+		newConfig := firstMapOf(newConfigs[env])
+		if newConfig == nil {
+			continue
+		}
+		var oldConfig map[string]interface{}
+		if oldConfigs != nil {
+			oldConfig = firstMapOf(oldConfigs[env])
+		}
+
+		config := expandPagesDeploymentConfig(oldConfig, newConfig)
+		if env == "preview" {
+			configs.Preview = config
+		} else {
+			configs.Production = config
+		}
+	}
+
+	return configs
+}
+
+func expandPagesDeploymentConfig(old, new map[string]interface{}) *pagesDeploymentConfig {
+	config := &pagesDeploymentConfig{
+		EnvVars:                 make(map[string]*pagesEnvVar),
+		CompatibilityDate:       new["compatibility_date"].(string),
+		CompatibilityFlags:      expandInterfaceToStringList(new["compatibility_flags"]),
+		KVNamespaces:            make(map[string]*pagesNamespaceRef),
+		DurableObjectNamespaces: make(map[string]*pagesNamespaceRef),
+		D1Databases:             make(map[string]*pagesD1DatabaseRef),
+		R2Buckets:               make(map[string]*pagesR2BucketRef),
+	}
+
+	// Settings that were removed are sent as null, which is all that
+	// removes them.
+	for _, attr := range []string{"environment_variables", "secrets"} {
+		for name := range pagesDeploymentConfigMap(old, attr) {
+			config.EnvVars[name] = nil
+		}
+	}
+	for name := range pagesDeploymentConfigMap(old, "kv_namespaces") {
+		config.KVNamespaces[name] = nil
+	}
+	for name := range pagesDeploymentConfigMap(old, "durable_object_namespaces") {
+		config.DurableObjectNamespaces[name] = nil
+	}
+	for name := range pagesDeploymentConfigMap(old, "d1_databases") {
+		config.D1Databases[name] = nil
+	}
+	for name := range pagesDeploymentConfigMap(old, "r2_buckets") {
+		config.R2Buckets[name] = nil
+	}
+
+	for name, value := range pagesDeploymentConfigMap(new, "environment_variables") {
+		config.EnvVars[name] = &pagesEnvVar{Type: "plain_text", Value: value.(string)}
+	}
+	for name, value := range pagesDeploymentConfigMap(new, "secrets") {
+		config.EnvVars[name] = &pagesEnvVar{Type: "secret_text", Value: value.(string)}
+	}
+	for name, id := range pagesDeploymentConfigMap(new, "kv_namespaces") {
+		config.KVNamespaces[name] = &pagesNamespaceRef{NamespaceID: id.(string)}
+	}
+	for name, id := range pagesDeploymentConfigMap(new, "durable_object_namespaces") {
+		config.DurableObjectNamespaces[name] = &pagesNamespaceRef{NamespaceID: id.(string)}
+	}
+	for name, id := range pagesDeploymentConfigMap(new, "d1_databases") {
+		config.D1Databases[name] = &pagesD1DatabaseRef{ID: id.(string)}
+	}
+	for name, bucket := range pagesDeploymentConfigMap(new, "r2_buckets") {
+		config.R2Buckets[name] = &pagesR2BucketRef{Name: bucket.(string)}
+	}
+
+	return config
+}
+
+func flattenPagesDeploymentConfigs(d *schema.ResourceData, configs *pagesDeploymentConfigs) []interface{} {
+	if configs == nil {
+		return []interface{}{}
+	}
+
+	out := make(map[string]interface{})
+	for _, env := range pagesDeploymentEnvironments {
+		config := configs.Preview
+		if env == "production" {
+			config = configs.Production
+		}
+		if config == nil {
+			out[env] = []interface{}{}
+			continue
+		}
+
+		// Secrets can't be read back, so their configured values are kept.
+		configured, _ := d.Get(fmt.Sprintf("deployment_configs.0.%s.0.secrets", env)).(map[string]interface{})
+
+		envVars := make(map[string]interface{})
+		secrets := make(map[string]interface{})
+		for name, v := range config.EnvVars {
+			if v == nil {
+				continue
+			}
+			if v.Type == "secret_text" {
+				secrets[name] = configured[name]
+				if secrets[name] == nil {
+					secrets[name] = ""
+				}
+			} else {
+				envVars[name] = v.Value
+			}
+		}
+		kvNamespaces := make(map[string]interface{})
+		for name, ns := range config.KVNamespaces {
+			kvNamespaces[name] = ns.NamespaceID
+		}
+		durableObjectNamespaces := make(map[string]interface{})
+		for name, ns := range config.DurableObjectNamespaces {
+			durableObjectNamespaces[name] = ns.NamespaceID
+		}
+		d1Databases := make(map[string]interface{})
+		for name, db := range config.D1Databases {
+			d1Databases[name] = db.ID
+		}
+		r2Buckets := make(map[string]interface{})
+		for name, bucket := range config.R2Buckets {
+			r2Buckets[name] = bucket.Name
+		}
+
+		out[env] = []interface{}{map[string]interface{}{
+			"environment_variables":     envVars,
+			"secrets":                   secrets,
+			"compatibility_date":        config.CompatibilityDate,
+			"compatibility_flags":       config.CompatibilityFlags,
+			"kv_namespaces":             kvNamespaces,
+			"durable_object_namespaces": durableObjectNamespaces,
+			"d1_databases":              d1Databases,
+			"r2_buckets":                r2Buckets,
+		}}
+	}
+
+	return []interface{}{out}
+}
+
+// pagesDeploymentConfigMap returns the named map of a deployment
+// configuration, which is empty if the configuration is nil.
+func pagesDeploymentConfigMap(config map[string]interface{}, attr string) map[string]interface{} {
+	m, _ := config[attr].(map[string]interface{})
+	return m
+}
+
+// firstMapOf returns the first element of a list block, or nil if it's empty.
+func firstMapOf(v interface{}) map[string]interface{} {
+	list, _ := v.([]interface{})
+	if len(list) == 0 {
+		return nil
+	}
+	m, _ := list[0].(map[string]interface{})
+	return m
+}
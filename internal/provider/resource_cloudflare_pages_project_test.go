@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflarePagesProject_Basic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_pages_project.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflarePagesProject(rnd, accountID, "main"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "production_branch", "main"),
+					resource.TestCheckResourceAttr(name, "subdomain", rnd+".pages.dev"),
+					resource.TestCheckResourceAttr(name, "deployment_configs.0.production.0.environment_variables.ENVIRONMENT", "production"),
+					resource.TestCheckResourceAttr(name, "deployment_configs.0.production.0.compatibility_date", "2022-08-15"),
+				),
+			},
+			{
+				Config: testAccCheckCloudflarePagesProject(rnd, accountID, "release"),
+				Check:  resource.TestCheckResourceAttr(name, "production_branch", "release"),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("%s/%s", accountID, rnd),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deployment_configs.0.production.0.secrets"},
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflarePagesProject(name, accountID, branch string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_workers_kv_namespace" "%[1]s" {
+  title = "%[1]s"
+}
+
+resource "cloudflare_pages_project" "%[1]s" {
+  account_id        = "%[2]s"
+  name              = "%[1]s"
+  production_branch = "%[3]s"
+
+  build_config {
+    build_command   = "npm run build"
+    destination_dir = "dist"
+  }
+
+  deployment_configs {
+    production {
+      environment_variables = {
+        ENVIRONMENT = "production"
+      }
+      secrets = {
+        API_KEY = "%[1]s"
+      }
+      kv_namespaces = {
+        CACHE = cloudflare_workers_kv_namespace.%[1]s.id
+      }
+      compatibility_date = "2022-08-15"
+    }
+  }
+}`, name, accountID, branch)
+}
+
+func TestPagesProjectApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+
+	r := p.ResourcesMap["cloudflare_pages_project"]
+	config := func(production map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"account_id":        testAccCloudflareAccountID,
+			"name":              "site",
+			"production_branch": "main",
+			"source": []interface{}{map[string]interface{}{
+				"type":   "github",
+				"config": []interface{}{map[string]interface{}{"owner": "example", "repo_name": "site"}},
+			}},
+			"build_config":       []interface{}{map[string]interface{}{"build_command": "npm run build", "destination_dir": "dist"}},
+			"deployment_configs": []interface{}{map[string]interface{}{"production": []interface{}{production}}},
+		}
+	}
+	remote := func() pagesProject {
+		result, err := m.defaultClient().Raw(http.MethodGet, pagesProjectURI(testAccCloudflareAccountID, "site"), nil)
+		require.NoError(t, err)
+		var project pagesProject
+		require.NoError(t, json.Unmarshal(result, &project))
+		return project
+	}
+
+	state := testMockAPIApply(t, r, m, nil, config(map[string]interface{}{
+		"environment_variables": map[string]interface{}{"ENVIRONMENT": "production", "DEBUG": "false"},
+		"secrets":               map[string]interface{}{"API_KEY": "secret"},
+		"kv_namespaces":         map[string]interface{}{"CACHE": "kv-id"},
+		"r2_buckets":            map[string]interface{}{"ASSETS": "assets"},
+	}))
+	assert.Equal(t, "site", state.ID)
+	assert.Equal(t, "site.pages.dev", state.Attributes["subdomain"])
+	assert.Equal(t, "main", remote().Source.Config.ProductionBranch, "the production branch of the repository is the one of the project")
+	assert.Equal(t, "secret", state.Attributes["deployment_configs.0.production.0.secrets.API_KEY"], "secrets keep their configured values")
+	assert.Equal(t, "2022-08-15", state.Attributes["deployment_configs.0.production.0.compatibility_date"])
+	assert.Equal(t, "2022-08-15", state.Attributes["deployment_configs.0.preview.0.compatibility_date"], "unconfigured environments are read back")
+
+	// Settings that are no longer configured are removed.
+	testMockAPIApply(t, r, m, state, config(map[string]interface{}{
+		"environment_variables": map[string]interface{}{"ENVIRONMENT": "production"},
+		"d1_databases":          map[string]interface{}{"DB": "d1-id"},
+	}))
+	production := remote().DeploymentConfigs.Production
+	assert.Equal(t, map[string]*pagesEnvVar{"ENVIRONMENT": {Type: "plain_text", Value: "production"}}, production.EnvVars)
+	assert.Empty(t, production.KVNamespaces)
+	assert.Empty(t, production.R2Buckets)
+	assert.Equal(t, map[string]*pagesD1DatabaseRef{"DB": {ID: "d1-id"}}, production.D1Databases)
+}
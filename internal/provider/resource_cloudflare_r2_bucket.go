@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareR2Bucket() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareR2BucketSchema(),
+		CreateContext: resourceCloudflareR2BucketCreate,
+		ReadContext:   resourceCloudflareR2BucketRead,
+		DeleteContext: resourceCloudflareR2BucketDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareR2BucketImport,
+		},
+	}
+}
+
+func resourceCloudflareR2BucketCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	bucket := r2Bucket{
+		Name:         d.Get("name").(string),
+		LocationHint: strings.ToLower(d.Get("location").(string)),
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating Cloudflare R2 bucket from struct: %+v", bucket))
+
+	if _, err := client.Raw(http.MethodPost, fmt.Sprintf("/accounts/%s/r2/buckets", accountID), bucket); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating R2 bucket %q: %w", bucket.Name, err))
+	}
+
+	d.SetId(bucket.Name)
+
+	return resourceCloudflareR2BucketRead(ctx, d, meta)
+}
+
+func resourceCloudflareR2BucketRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	result, err := client.Raw(http.MethodGet, r2BucketURI(accountID, d.Id()), nil)
+	if err != nil {
+		if isR2NotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("R2 bucket %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading R2 bucket %q: %w", d.Id(), err))
+	}
+
+	var bucket r2Bucket
+	if err := json.Unmarshal(result, &bucket); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading R2 bucket %q: %w", d.Id(), err))
+	}
+
+	d.Set("name", bucket.Name)
+	d.Set("location", strings.ToLower(bucket.Location))
+	d.Set("creation_date", bucket.CreationDate)
+
+	return nil
+}
+
+func resourceCloudflareR2BucketDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	if _, err := client.Raw(http.MethodDelete, r2BucketURI(accountID, d.Id()), nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting R2 bucket %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareR2BucketImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 2)
+
+	if len(attributes) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/bucketName\"", d.Id())
+	}
+
+	accountID, bucketName := attributes[0], attributes[1]
+	d.SetId(bucketName)
+	d.Set("account_id", accountID)
+
+	resourceCloudflareR2BucketRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareR2BucketCORS() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareR2BucketCORSSchema(),
+		CreateContext: resourceCloudflareR2BucketCORSUpdate,
+		ReadContext:   resourceCloudflareR2BucketCORSRead,
+		UpdateContext: resourceCloudflareR2BucketCORSUpdate,
+		DeleteContext: resourceCloudflareR2BucketCORSDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareR2BucketCORSImport,
+		},
+	}
+}
+
+func resourceCloudflareR2BucketCORSRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	// Buckets without CORS rules and missing buckets are both not found.
+	result, err := client.Raw(http.MethodGet, r2BucketURI(accountID, d.Id(), "cors"), nil)
+	if err != nil {
+		if isR2NotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("CORS rules of R2 bucket %s no longer exist", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading CORS rules of R2 bucket %q: %w", d.Id(), err))
+	}
+
+	var cors r2CORSRules
+	if err := json.Unmarshal(result, &cors); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading CORS rules of R2 bucket %q: %w", d.Id(), err))
+	}
+
+	d.Set("bucket_name", d.Id())
+	if err := d.Set("rule", flattenR2CORSRules(cors.Rules)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting CORS rules of R2 bucket %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareR2BucketCORSUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	bucketName := d.Get("bucket_name").(string)
+
+	cors := r2CORSRules{Rules: expandR2CORSRules(d.Get("rule").([]interface{}))}
+
+	if _, err := client.Raw(http.MethodPut, r2BucketURI(accountID, bucketName, "cors"), cors); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting CORS rules of R2 bucket %q: %w", bucketName, err))
+	}
+
+	d.SetId(bucketName)
+
+	return resourceCloudflareR2BucketCORSRead(ctx, d, meta)
+}
+
+func resourceCloudflareR2BucketCORSDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	if _, err := client.Raw(http.MethodDelete, r2BucketURI(accountID, d.Id(), "cors"), nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error removing CORS rules of R2 bucket %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareR2BucketCORSImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 2)
+
+	if len(attributes) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/bucketName\"", d.Id())
+	}
+
+	accountID, bucketName := attributes[0], attributes[1]
+	d.SetId(bucketName)
+	d.Set("account_id", accountID)
+
+	resourceCloudflareR2BucketCORSRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandR2CORSRules(rules []interface{}) []r2CORSRule {
+	out := make([]r2CORSRule, 0, len(rules))
+
+	for _, rawRule := range rules {
+		rule := rawRule.(map[string]interface{})
+		out = append(out, r2CORSRule{
+			ID: rule["id"].(string),
+			Allowed: r2CORSAllowed{
+				Origins: expandInterfaceToStringList(rule["allowed_origins"]),
+				Methods: expandInterfaceToStringList(rule["allowed_methods"]),
+				Headers: expandInterfaceToStringList(rule["allowed_headers"]),
+			},
+			ExposeHeaders: expandInterfaceToStringList(rule["expose_headers"]),
+			MaxAgeSeconds: rule["max_age_seconds"].(int),
+		})
+	}
+
+	return out
+}
+
+func flattenR2CORSRules(rules []r2CORSRule) []interface{} {
+	out := make([]interface{}, 0, len(rules))
+
+	for _, r := range rules {
+		out = append(out, map[string]interface{}{
+			"id":              r.ID,
+			"allowed_origins": r.Allowed.Origins,
+			"allowed_methods": r.Allowed.Methods,
+			"allowed_headers": r.Allowed.Headers,
+			"expose_headers":  r.ExposeHeaders,
+			"max_age_seconds": r.MaxAgeSeconds,
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestR2BucketCORSApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	_, err := m.defaultClient().Raw(http.MethodPost, "/accounts/"+testAccCloudflareAccountID+"/r2/buckets", r2Bucket{Name: "assets"})
+	require.NoError(t, err)
+
+	r := p.ResourcesMap["cloudflare_r2_bucket_cors"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id":  testAccCloudflareAccountID,
+		"bucket_name": "assets",
+		"rule": []interface{}{map[string]interface{}{
+			"allowed_origins": []interface{}{"https://example.com"},
+			"allowed_methods": []interface{}{"GET", "HEAD"},
+			"expose_headers":  []interface{}{"ETag"},
+			"max_age_seconds": 3600,
+		}},
+	})
+
+	diff, err := r.Diff(ctx, nil, config, m)
+	require.NoError(t, err)
+	state, diags := r.Apply(ctx, nil, diff, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "assets", state.ID)
+	assert.Equal(t, "HEAD", state.Attributes["rule.0.allowed_methods.1"])
+	assert.Equal(t, "3600", state.Attributes["rule.0.max_age_seconds"])
+
+	diff, err = r.Diff(ctx, state, config, m)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "%v", diff)
+
+	// Buckets without CORS rules have no configuration to read.
+	_, diags = r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state)
+}
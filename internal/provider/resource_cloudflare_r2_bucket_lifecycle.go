@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+func resourceCloudflareR2BucketLifecycle() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareR2BucketLifecycleSchema(),
+		CreateContext: resourceCloudflareR2BucketLifecycleUpdate,
+		ReadContext:   resourceCloudflareR2BucketLifecycleRead,
+		UpdateContext: resourceCloudflareR2BucketLifecycleUpdate,
+		DeleteContext: resourceCloudflareR2BucketLifecycleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareR2BucketLifecycleImport,
+		},
+	}
+}
+
+func resourceCloudflareR2BucketLifecycleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	result, err := client.Raw(http.MethodGet, r2BucketURI(accountID, d.Id(), "lifecycle"), nil)
+	if err != nil {
+		if isR2NotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("R2 bucket %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading lifecycle rules of R2 bucket %q: %w", d.Id(), err))
+	}
+
+	var lifecycle r2LifecycleRules
+	if err := json.Unmarshal(result, &lifecycle); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading lifecycle rules of R2 bucket %q: %w", d.Id(), err))
+	}
+
+	d.Set("bucket_name", d.Id())
+	if err := d.Set("rule", flattenR2LifecycleRules(lifecycle.Rules)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting lifecycle rules of R2 bucket %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareR2BucketLifecycleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	bucketName := d.Get("bucket_name").(string)
+
+	rules, err := expandR2LifecycleRules(d.Get("rule").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := client.Raw(http.MethodPut, r2BucketURI(accountID, bucketName, "lifecycle"), r2LifecycleRules{Rules: rules}); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting lifecycle rules of R2 bucket %q: %w", bucketName, err))
+	}
+
+	d.SetId(bucketName)
+
+	return resourceCloudflareR2BucketLifecycleRead(ctx, d, meta)
+}
+
+func resourceCloudflareR2BucketLifecycleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	if _, err := client.Raw(http.MethodPut, r2BucketURI(accountID, d.Id(), "lifecycle"), r2LifecycleRules{Rules: []r2LifecycleRule{}}); err != nil {
+		return diag.FromErr(fmt.Errorf("error removing lifecycle rules of R2 bucket %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareR2BucketLifecycleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 2)
+
+	if len(attributes) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/bucketName\"", d.Id())
+	}
+
+	accountID, bucketName := attributes[0], attributes[1]
+	d.SetId(bucketName)
+	d.Set("account_id", accountID)
+
+	resourceCloudflareR2BucketLifecycleRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandR2LifecycleRules(rules []interface{}) ([]r2LifecycleRule, error) {
+	out := make([]r2LifecycleRule, 0, len(rules))
+
+	for _, rawRule := range rules {
+		rule := rawRule.(map[string]interface{})
+		r := r2LifecycleRule{
+			ID:         rule["id"].(string),
+			Enabled:    rule["enabled"].(bool),
+			Conditions: r2LifecycleConditions{Prefix: rule["prefix"].(string)},
+		}
+
+		days, date := rule["delete_objects_after_days"].(int), rule["delete_objects_on_date"].(string)
+		switch {
+		case days > 0 && date != "":
+			return nil, fmt.Errorf("lifecycle rule %q must only set one of `delete_objects_after_days` and `delete_objects_on_date`", r.ID)
+		case days > 0:
+			r.DeleteObjectsTransition = &r2LifecycleTransition{Condition: r2LifecycleCondition{Type: "Age", MaxAge: days * secondsPerDay}}
+		case date != "":
+			r.DeleteObjectsTransition = &r2LifecycleTransition{Condition: r2LifecycleCondition{Type: "Date", Date: date}}
+		}
+
+		if days := rule["abort_multipart_uploads_after_days"].(int); days > 0 {
+			r.AbortMultipartUploadsTransition = &r2LifecycleTransition{Condition: r2LifecycleCondition{Type: "Age", MaxAge: days * secondsPerDay}}
+		}
+
+		if r.DeleteObjectsTransition == nil && r.AbortMultipartUploadsTransition == nil {
+			return nil, fmt.Errorf("lifecycle rule %q must either delete objects or abort multipart uploads", r.ID)
+		}
+
+		out = append(out, r)
+	}
+
+	return out, nil
+}
+
+func flattenR2LifecycleRules(rules []r2LifecycleRule) []interface{} {
+	out := make([]interface{}, 0, len(rules))
+
+	for _, r := range rules {
+		rule := map[string]interface{}{
+			"id":      r.ID,
+			"enabled": r.Enabled,
+			"prefix":  r.Conditions.Prefix,
+		}
+		if t := r.DeleteObjectsTransition; t != nil {
+			switch t.Condition.Type {
+			case "Age":
+				rule["delete_objects_after_days"] = t.Condition.MaxAge / secondsPerDay
+			case "Date":
+				rule["delete_objects_on_date"] = t.Condition.Date
+			}
+		}
+		if t := r.AbortMultipartUploadsTransition; t != nil {
+			rule["abort_multipart_uploads_after_days"] = t.Condition.MaxAge / secondsPerDay
+		}
+		out = append(out, rule)
+	}
+
+	return out
+}
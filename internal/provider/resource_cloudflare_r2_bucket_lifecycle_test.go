@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestR2BucketLifecycleApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	_, err := m.defaultClient().Raw(http.MethodPost, "/accounts/"+testAccCloudflareAccountID+"/r2/buckets", r2Bucket{Name: "logs"})
+	require.NoError(t, err)
+
+	r := p.ResourcesMap["cloudflare_r2_bucket_lifecycle"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id":  testAccCloudflareAccountID,
+		"bucket_name": "logs",
+		"rule": []interface{}{
+			map[string]interface{}{"id": "expire", "prefix": "tmp/", "delete_objects_after_days": 30},
+			map[string]interface{}{"id": "archive", "enabled": false, "delete_objects_on_date": "2030-01-01T00:00:00Z", "abort_multipart_uploads_after_days": 2},
+		},
+	})
+
+	diff, err := r.Diff(ctx, nil, config, m)
+	require.NoError(t, err)
+	state, diags := r.Apply(ctx, nil, diff, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "logs", state.ID)
+	assert.Equal(t, "30", state.Attributes["rule.0.delete_objects_after_days"])
+	assert.Equal(t, "2030-01-01T00:00:00Z", state.Attributes["rule.1.delete_objects_on_date"])
+	assert.Equal(t, "false", state.Attributes["rule.1.enabled"])
+
+	// Ages are sent in seconds and read back in days.
+	diff, err = r.Diff(ctx, state, config, m)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "%v", diff)
+
+	_, diags = r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "0", state.Attributes["rule.#"], "the bucket is left without rules")
+}
+
+func TestExpandR2LifecycleRules(t *testing.T) {
+	rules, err := expandR2LifecycleRules([]interface{}{map[string]interface{}{
+		"id": "expire", "enabled": true, "prefix": "", "delete_objects_after_days": 1, "delete_objects_on_date": "", "abort_multipart_uploads_after_days": 0,
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []r2LifecycleRule{{
+		ID:                      "expire",
+		Enabled:                 true,
+		DeleteObjectsTransition: &r2LifecycleTransition{Condition: r2LifecycleCondition{Type: "Age", MaxAge: 86400}},
+	}}, rules)
+
+	_, err = expandR2LifecycleRules([]interface{}{map[string]interface{}{
+		"id": "both", "enabled": true, "prefix": "", "delete_objects_after_days": 1, "delete_objects_on_date": "2030-01-01T00:00:00Z", "abort_multipart_uploads_after_days": 0,
+	}})
+	assert.EqualError(t, err, "lifecycle rule \"both\" must only set one of `delete_objects_after_days` and `delete_objects_on_date`")
+
+	_, err = expandR2LifecycleRules([]interface{}{map[string]interface{}{
+		"id": "noop", "enabled": true, "prefix": "", "delete_objects_after_days": 0, "delete_objects_on_date": "", "abort_multipart_uploads_after_days": 0,
+	}})
+	assert.EqualError(t, err, "lifecycle rule \"noop\" must either delete objects or abort multipart uploads")
+}
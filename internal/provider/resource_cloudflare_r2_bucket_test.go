@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareR2Bucket_Basic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_r2_bucket.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAccount(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareR2Bucket(rnd, accountID, 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "location", "weur"),
+					resource.TestCheckResourceAttrSet(name, "creation_date"),
+					resource.TestCheckResourceAttr("cloudflare_r2_bucket_lifecycle."+rnd, "rule.0.delete_objects_after_days", "30"),
+					resource.TestCheckResourceAttr("cloudflare_r2_bucket_cors."+rnd, "rule.0.allowed_methods.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareR2Bucket(rnd, accountID, 7),
+				Check:  resource.TestCheckResourceAttr("cloudflare_r2_bucket_lifecycle."+rnd, "rule.0.delete_objects_after_days", "7"),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s", accountID, rnd),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "cloudflare_r2_bucket_lifecycle." + rnd,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s", accountID, rnd),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareR2Bucket(name, accountID string, days int) string {
+	return fmt.Sprintf(`
+resource "cloudflare_r2_bucket" "%[1]s" {
+  account_id = "%[2]s"
+  name       = "%[1]s"
+  location   = "WEUR"
+}
+
+resource "cloudflare_r2_bucket_lifecycle" "%[1]s" {
+  account_id  = "%[2]s"
+  bucket_name = cloudflare_r2_bucket.%[1]s.name
+
+  rule {
+    id                        = "expire-logs"
+    prefix                    = "logs/"
+    delete_objects_after_days = %[3]d
+  }
+}
+
+resource "cloudflare_r2_bucket_cors" "%[1]s" {
+  account_id  = "%[2]s"
+  bucket_name = cloudflare_r2_bucket.%[1]s.name
+
+  rule {
+    allowed_origins = ["https://example.com"]
+    allowed_methods = ["GET", "HEAD"]
+  }
+}`, name, accountID, days)
+}
+
+func TestR2BucketApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_r2_bucket"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id": testAccCloudflareAccountID,
+		"name":       "assets",
+		"location":   "WEUR",
+	})
+
+	diff, err := r.Diff(ctx, nil, config, m)
+	require.NoError(t, err)
+	state, diags := r.Apply(ctx, nil, diff, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "assets", state.ID)
+	assert.Equal(t, "weur", state.Attributes["location"])
+	assert.NotEmpty(t, state.Attributes["creation_date"])
+
+	// The API reports locations in upper case, which isn't a change.
+	diff, err = r.Diff(ctx, state, config, m)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "%v", diff)
+
+	// Neither is a location stored in another case.
+	for _, location := range []string{"weur", "Weur"} {
+		state.Attributes["location"] = "WEUR"
+		diff, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"account_id": testAccCloudflareAccountID,
+			"name":       "assets",
+			"location":   location,
+		}), m)
+		require.NoError(t, err)
+		assert.True(t, diff.Empty(), "%v", diff)
+	}
+	state.Attributes["location"] = "weur"
+
+	// Moving the bucket replaces it.
+	diff, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id": testAccCloudflareAccountID,
+		"name":       "assets",
+		"location":   "enam",
+	}), m)
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+
+	// Creating a bucket that already exists fails rather than adopting it.
+	diff, err = r.Diff(ctx, nil, config, m)
+	require.NoError(t, err)
+	_, diags = r.Apply(ctx, nil, diff, m)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `error creating R2 bucket "assets"`)
+
+	_, diags = r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state)
+}
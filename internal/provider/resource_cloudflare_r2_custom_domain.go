@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareR2CustomDomain() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareR2CustomDomainSchema(),
+		CreateContext: resourceCloudflareR2CustomDomainCreate,
+		ReadContext:   resourceCloudflareR2CustomDomainRead,
+		UpdateContext: resourceCloudflareR2CustomDomainUpdate,
+		DeleteContext: resourceCloudflareR2CustomDomainDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareR2CustomDomainImport,
+		},
+	}
+}
+
+func resourceCloudflareR2CustomDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	bucketName := d.Get("bucket_name").(string)
+
+	domain := r2CustomDomain{
+		Domain:  d.Get("domain").(string),
+		ZoneID:  d.Get("zone_id").(string),
+		Enabled: d.Get("enabled").(bool),
+		MinTLS:  d.Get("min_tls").(string),
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating Cloudflare R2 custom domain from struct: %+v", domain))
+
+	if _, err := client.Raw(http.MethodPost, r2BucketURI(accountID, bucketName, "domains", "custom"), domain); err != nil {
+		return diag.FromErr(fmt.Errorf("error connecting custom domain %q to R2 bucket %q: %w", domain.Domain, bucketName, err))
+	}
+
+	d.SetId(domain.Domain)
+
+	return resourceCloudflareR2CustomDomainRead(ctx, d, meta)
+}
+
+func resourceCloudflareR2CustomDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	bucketName := d.Get("bucket_name").(string)
+
+	result, err := client.Raw(http.MethodGet, r2BucketURI(accountID, bucketName, "domains", "custom", url.PathEscape(d.Id())), nil)
+	if err != nil {
+		if isR2NotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("R2 custom domain %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading R2 custom domain %q: %w", d.Id(), err))
+	}
+
+	var domain r2CustomDomain
+	if err := json.Unmarshal(result, &domain); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading R2 custom domain %q: %w", d.Id(), err))
+	}
+
+	d.Set("domain", domain.Domain)
+	d.Set("zone_id", domain.ZoneID)
+	d.Set("enabled", domain.Enabled)
+	d.Set("min_tls", domain.MinTLS)
+	if domain.Status != nil {
+		d.Set("ownership_status", domain.Status.Ownership)
+		d.Set("ssl_status", domain.Status.SSL)
+	}
+
+	return nil
+}
+
+func resourceCloudflareR2CustomDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	bucketName := d.Get("bucket_name").(string)
+
+	domain := r2CustomDomain{
+		Enabled: d.Get("enabled").(bool),
+		MinTLS:  d.Get("min_tls").(string),
+	}
+
+	if _, err := client.Raw(http.MethodPut, r2BucketURI(accountID, bucketName, "domains", "custom", url.PathEscape(d.Id())), domain); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating R2 custom domain %q: %w", d.Id(), err))
+	}
+
+	return resourceCloudflareR2CustomDomainRead(ctx, d, meta)
+}
+
+func resourceCloudflareR2CustomDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	bucketName := d.Get("bucket_name").(string)
+
+	if _, err := client.Raw(http.MethodDelete, r2BucketURI(accountID, bucketName, "domains", "custom", url.PathEscape(d.Id())), nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error disconnecting custom domain %q from R2 bucket %q: %w", d.Id(), bucketName, err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareR2CustomDomainImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 3)
+
+	if len(attributes) != 3 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/bucketName/domain\"", d.Id())
+	}
+
+	accountID, bucketName, domain := attributes[0], attributes[1], attributes[2]
+	d.SetId(domain)
+	d.Set("account_id", accountID)
+	d.Set("bucket_name", bucketName)
+
+	resourceCloudflareR2CustomDomainRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareR2CustomDomain_Basic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_r2_custom_domain.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareR2CustomDomain(rnd, accountID, zoneID, domain, "1.2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "domain", fmt.Sprintf("%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+					resource.TestCheckResourceAttr(name, "min_tls", "1.2"),
+					resource.TestCheckResourceAttrSet(name, "ssl_status"),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareR2CustomDomain(rnd, accountID, zoneID, domain, "1.3"),
+				Check:  resource.TestCheckResourceAttr(name, "min_tls", "1.3"),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s/%s.%s", accountID, rnd, rnd, domain),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareR2CustomDomain(name, accountID, zoneID, domain, minTLS string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_r2_bucket" "%[1]s" {
+  account_id = "%[2]s"
+  name       = "%[1]s"
+}
+
+resource "cloudflare_r2_custom_domain" "%[1]s" {
+  account_id  = "%[2]s"
+  bucket_name = cloudflare_r2_bucket.%[1]s.name
+  domain      = "%[1]s.%[4]s"
+  zone_id     = "%[3]s"
+  min_tls     = "%[5]s"
+}`, name, accountID, zoneID, domain, minTLS)
+}
+
+func TestR2CustomDomainApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	_, err := m.defaultClient().Raw(http.MethodPost, "/accounts/"+testAccCloudflareAccountID+"/r2/buckets", r2Bucket{Name: "assets"})
+	require.NoError(t, err)
+
+	r := p.ResourcesMap["cloudflare_r2_custom_domain"]
+	config := func(enabled bool) map[string]interface{} {
+		return map[string]interface{}{
+			"account_id":  testAccCloudflareAccountID,
+			"bucket_name": "assets",
+			"domain":      "assets." + testAccCloudflareZoneName,
+			"zone_id":     testAccCloudflareZoneID,
+			"enabled":     enabled,
+		}
+	}
+
+	state := testMockAPIApply(t, r, m, nil, config(true))
+	assert.Equal(t, "assets."+testAccCloudflareZoneName, state.ID)
+	assert.Equal(t, "1.0", state.Attributes["min_tls"], "the default minimum TLS version is read back")
+	assert.Equal(t, "active", state.Attributes["ssl_status"])
+
+	id := state.ID
+	state = testMockAPIApply(t, r, m, state, config(false))
+	assert.Equal(t, id, state.ID, "the domain is updated in place")
+	assert.Equal(t, "false", state.Attributes["enabled"])
+
+	_, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state)
+}
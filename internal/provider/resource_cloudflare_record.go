@@ -41,7 +41,10 @@ func resourceCloudflareRecord() *schema.Resource {
 }
 
 func resourceCloudflareRecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	newRecord := cloudflare.DNSRecord{
 		Type:   d.Get("type").(string),
@@ -180,7 +183,10 @@ func resourceCloudflareRecordCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCloudflareRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	record, err := client.DNSRecord(ctx, zoneID, d.Id())
@@ -240,7 +246,10 @@ func resourceCloudflareRecordRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceCloudflareRecordUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	updateRecord := cloudflare.DNSRecord{
@@ -313,7 +322,10 @@ func resourceCloudflareRecordUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCloudflareRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	tflog.Info(ctx, fmt.Sprintf("Deleting Cloudflare Record: %s, %s", zoneID, d.Id()))
@@ -341,7 +353,10 @@ func expandStringMap(inVal interface{}) map[string]string {
 }
 
 func resourceCloudflareRecordImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return nil, clientErr
+	}
 
 	// split the id so we can lookup
 	idAttr := strings.SplitN(d.Id(), "/", 2)
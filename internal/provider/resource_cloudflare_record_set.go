@@ -0,0 +1,634 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareRecordSet() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareRecordSetSchema(),
+		CreateContext: resourceCloudflareRecordSetCreate,
+		ReadContext:   resourceCloudflareRecordSetRead,
+		UpdateContext: resourceCloudflareRecordSetUpdate,
+		DeleteContext: resourceCloudflareRecordSetDelete,
+		CustomizeDiff: resourceCloudflareRecordSetValidateRecords,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareRecordSetImport,
+		},
+		Description: "Provides a Cloudflare resource to manage the DNS records of a zone, or the records matching a filter, as a whole. The records are read with a single listing and only those that changed are written, which is much faster than a `cloudflare_record` per record in zones with many records.",
+	}
+}
+
+// recordSetDataTypes are the record types whose API representation includes
+// structured data.
+var recordSetDataTypes = []string{"CAA", "CERT", "DNSKEY", "DS", "LOC", "NAPTR", "SMIMEA", "SRV", "SSHFP", "TLSA", "URI"}
+
+// recordSetHostnameTypes are the record types whose value is a hostname,
+// which is case insensitive and may be fully qualified.
+var recordSetHostnameTypes = []string{"CNAME", "MX", "NS", "PTR"}
+
+// recordSetPageSize is the number of records listed per request, which is
+// the most the API allows.
+var recordSetPageSize = 5000
+
+// taggedDNSRecord is a DNS record along with its tags, which cloudflare-go
+// doesn't support yet.
+type taggedDNSRecord struct {
+	cloudflare.DNSRecord
+	Tags []string `json:"tags,omitempty"`
+}
+
+// recordSetScope is the zone and filter of a record set, which determine the
+// records it manages.
+type recordSetScope struct {
+	zoneID   string
+	zoneName string
+	name     string
+	types    []string
+	tag      string
+}
+
+func newRecordSetScope(ctx context.Context, client *cloudflare.API, d *schema.ResourceData) (recordSetScope, error) {
+	zoneID := d.Get("zone_id").(string)
+
+	zone, err := client.ZoneDetails(ctx, zoneID)
+	if err != nil {
+		return recordSetScope{}, err
+	}
+
+	scope := recordSetScope{zoneID: zoneID, zoneName: strings.ToLower(zone.Name)}
+	if name, ok := d.GetOk("filter.0.name"); ok {
+		scope.name = scope.qualify(name.(string))
+	}
+	if types, ok := d.GetOk("filter.0.types"); ok {
+		scope.types = expandInterfaceToStringList(types.(*schema.Set).List())
+	}
+	if tag, ok := d.GetOk("filter.0.tag"); ok {
+		scope.tag = tag.(string)
+	}
+
+	return scope, nil
+}
+
+// recordSetID returns the ID of a record set, which is its zone followed by
+// its filter as "zoneID/name/types/tag", types being separated by commas.
+// Trailing empty parts are omitted, so that a record set without a filter is
+// identified by its zone alone.
+func recordSetID(d *schema.ResourceData) string {
+	types := expandInterfaceToStringList(d.Get("filter.0.types").(*schema.Set).List())
+	sort.Strings(types)
+
+	parts := []string{
+		d.Get("zone_id").(string),
+		strings.ToLower(d.Get("filter.0.name").(string)),
+		strings.Join(types, ","),
+		d.Get("filter.0.tag").(string),
+	}
+	for len(parts) > 1 && parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// qualify returns the fully qualified form of a record name, which may be
+// relative to the zone.
+func (s recordSetScope) qualify(name string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	switch {
+	case name == "@" || name == s.zoneName:
+		return s.zoneName
+	case strings.HasSuffix(name, "."+s.zoneName):
+		return name
+	default:
+		return name + "." + s.zoneName
+	}
+}
+
+// contains reports whether a record with the fully qualified name and type
+// is managed by the record set. Without a filter on types, only records of
+// the types a record set can declare are managed, so that records of other
+// types, such as HTTPS, are left untouched.
+func (s recordSetScope) contains(name, recordType string) bool {
+	if s.name != "" && name != s.name {
+		return false
+	}
+	if len(s.types) == 0 {
+		return contains(recordSetRecordTypes, recordType)
+	}
+	return contains(s.types, recordType)
+}
+
+// list returns the records managed by the record set, fetching all of them
+// with one paginated listing. Records are listed with the raw client as
+// cloudflare-go can't filter them by tag.
+func (s recordSetScope) list(ctx context.Context, client *cloudflare.API) ([]cloudflare.DNSRecord, error) {
+	query := url.Values{"per_page": {strconv.Itoa(recordSetPageSize)}}
+	if s.name != "" {
+		query.Set("name", s.name)
+	}
+	if s.tag != "" {
+		query.Set("tag", s.tag)
+	}
+
+	var records []cloudflare.DNSRecord
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		result, err := client.Raw(http.MethodGet, fmt.Sprintf("/zones/%s/dns_records?%s", s.zoneID, query.Encode()), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error listing DNS records of zone %q: %w", s.zoneID, err)
+		}
+
+		var pageRecords []cloudflare.DNSRecord
+		if err := json.Unmarshal(result, &pageRecords); err != nil {
+			return nil, fmt.Errorf("error listing DNS records of zone %q: %w", s.zoneID, err)
+		}
+		records = append(records, pageRecords...)
+
+		if len(pageRecords) < recordSetPageSize {
+			break
+		}
+	}
+
+	var managed []cloudflare.DNSRecord
+	for _, r := range records {
+		if s.contains(strings.ToLower(r.Name), r.Type) {
+			managed = append(managed, r)
+		}
+	}
+
+	return managed, nil
+}
+
+// create creates r, with the tag of the filter if there is one so that the
+// record is part of the record set. Records that are updated already have
+// the tag, as they were listed.
+func (s recordSetScope) create(client *cloudflare.API, r cloudflare.DNSRecord) error {
+	record := taggedDNSRecord{DNSRecord: r}
+	if s.tag != "" {
+		record.Tags = []string{s.tag}
+	}
+
+	_, err := client.Raw(http.MethodPost, fmt.Sprintf("/zones/%s/dns_records", s.zoneID), record)
+	return err
+}
+
+// recordSetStyle remembers how the records of a record set are written in
+// the configuration, so that records read from the API are represented the
+// same way and don't show up as changes.
+type recordSetStyle struct {
+	// names maps fully qualified names to how they are written.
+	names map[string]string
+	// data records, by name and type, whether `data` is used rather than
+	// `value`.
+	data map[string]bool
+}
+
+func newRecordSetStyle(scope recordSetScope, records []interface{}) recordSetStyle {
+	style := recordSetStyle{names: make(map[string]string), data: make(map[string]bool)}
+	for _, r := range records {
+		record := r.(map[string]interface{})
+		name := scope.qualify(record["name"].(string))
+		style.names[name] = record["name"].(string)
+		style.data[name+"/"+record["type"].(string)] = len(record["data"].([]interface{})) > 0
+	}
+	return style
+}
+
+// flatten returns a record read from the API as an element of the `record`
+// attribute.
+func (s recordSetStyle) flatten(scope recordSetScope, r cloudflare.DNSRecord) map[string]interface{} {
+	fqdn := strings.ToLower(r.Name)
+
+	name, ok := s.names[fqdn]
+	if !ok {
+		name = strings.TrimSuffix(fqdn, "."+scope.zoneName)
+	}
+
+	useData, ok := s.data[fqdn+"/"+r.Type]
+	if !ok {
+		useData = r.Data != nil && contains(recordSetDataTypes, r.Type)
+	}
+
+	record := map[string]interface{}{
+		"name":     name,
+		"type":     r.Type,
+		"value":    "",
+		"data":     []interface{}{},
+		"ttl":      r.TTL,
+		"priority": 0,
+		"proxied":  r.Proxied != nil && *r.Proxied,
+	}
+	if useData {
+		record["data"] = []interface{}{flattenRecordSetData(r.Data)}
+	} else {
+		record["value"] = r.Content
+	}
+	if (r.Type == "MX" || r.Type == "URI") && r.Priority != nil {
+		record["priority"] = int(*r.Priority)
+	}
+
+	return record
+}
+
+// flattenRecordSetData converts the structured data of a record to the
+// `data` block, with every field set so that it hashes the same way as the
+// configuration.
+func flattenRecordSetData(data interface{}) map[string]interface{} {
+	apiData, _ := data.(map[string]interface{})
+	dataSchema := resourceCloudflareRecordSetRecord().Schema["data"].Elem.(*schema.Resource).Schema
+
+	flattened := make(map[string]interface{}, len(dataSchema))
+	for key, s := range dataSchema {
+		value := apiData[key]
+		switch s.Type {
+		case schema.TypeInt:
+			n, _ := value.(float64)
+			flattened[key] = int(n)
+		case schema.TypeFloat:
+			n, _ := value.(float64)
+			flattened[key] = n
+		default:
+			switch value := value.(type) {
+			case string:
+				flattened[key] = value
+			case float64:
+				flattened[key] = strconv.FormatFloat(value, 'f', -1, 64)
+			default:
+				flattened[key] = ""
+			}
+		}
+	}
+
+	return flattened
+}
+
+// expandRecordSetRecord returns the API representation of an element of the
+// `record` attribute.
+func expandRecordSetRecord(scope recordSetScope, record map[string]interface{}) (cloudflare.DNSRecord, error) {
+	r := cloudflare.DNSRecord{
+		Name:    scope.qualify(record["name"].(string)),
+		Type:    record["type"].(string),
+		Content: record["value"].(string),
+		TTL:     record["ttl"].(int),
+		Proxied: cloudflare.BoolPtr(record["proxied"].(bool)),
+	}
+
+	if data := record["data"].([]interface{}); len(data) > 0 && data[0] != nil {
+		dataMap := make(map[string]interface{})
+		for id, value := range data[0].(map[string]interface{}) {
+			newData, err := transformToCloudflareDNSData(r.Type, id, value)
+			if err != nil {
+				return r, err
+			} else if newData == nil {
+				continue
+			}
+			dataMap[id] = newData
+		}
+		r.Data = dataMap
+	}
+
+	if r.Type == "MX" || r.Type == "URI" {
+		priority := uint16(record["priority"].(int))
+		r.Priority = &priority
+	}
+
+	return r, nil
+}
+
+// hashRecordSetRecord hashes an element of the `record` attribute. Names and
+// hostname values are compared the way the API does, ignoring case and a
+// trailing dot, so that records read back as the API normalised them don't
+// show up as changes.
+func hashRecordSetRecord(v interface{}) int {
+	record := make(map[string]interface{})
+	for k, v := range v.(map[string]interface{}) {
+		record[k] = v
+	}
+
+	record["name"] = strings.ToLower(strings.TrimSuffix(record["name"].(string), "."))
+	if contains(recordSetHostnameTypes, record["type"].(string)) {
+		record["value"] = strings.ToLower(strings.TrimSuffix(record["value"].(string), "."))
+	}
+
+	return recordSetRecordHash(record)
+}
+
+var recordSetRecordHash = schema.HashResource(resourceCloudflareRecordSetRecord())
+
+// matchRecordSet compares the existing records of a record set with the
+// desired ones. It returns the existing records that are desired as is, the
+// desired records that don't exist and the existing records that aren't
+// desired.
+func matchRecordSet(scope recordSetScope, existing []cloudflare.DNSRecord, desired []interface{}) (unchanged []cloudflare.DNSRecord, missing []map[string]interface{}, extra []cloudflare.DNSRecord) {
+	hash := hashRecordSetRecord
+	style := newRecordSetStyle(scope, desired)
+
+	wanted := make(map[int]bool, len(desired))
+	for _, r := range desired {
+		wanted[hash(r)] = true
+	}
+
+	found := make(map[int]bool, len(existing))
+	for _, r := range existing {
+		h := hash(style.flatten(scope, r))
+		if wanted[h] && !found[h] {
+			found[h] = true
+			unchanged = append(unchanged, r)
+		} else {
+			extra = append(extra, r)
+		}
+	}
+
+	for _, r := range desired {
+		if !found[hash(r)] {
+			missing = append(missing, r.(map[string]interface{}))
+		}
+	}
+
+	return unchanged, missing, extra
+}
+
+// recordSetChanges are the API calls needed to bring the records of a zone
+// in line with a record set.
+type recordSetChanges struct {
+	create []cloudflare.DNSRecord
+	update []cloudflare.DNSRecord
+	delete []cloudflare.DNSRecord
+}
+
+// diffRecordSet determines the changes to make to the existing records. Where
+// a desired record has the name and type of an existing record that isn't
+// desired, the existing record is updated rather than replaced.
+func diffRecordSet(scope recordSetScope, existing []cloudflare.DNSRecord, desired []interface{}) (recordSetChanges, error) {
+	var changes recordSetChanges
+
+	_, missing, extra := matchRecordSet(scope, existing, desired)
+	replaced := make([]bool, len(extra))
+
+	for _, m := range missing {
+		r, err := expandRecordSetRecord(scope, m)
+		if err != nil {
+			return changes, err
+		}
+		if !scope.contains(r.Name, r.Type) {
+			return changes, fmt.Errorf("%s record %q doesn't match the filter of the record set", r.Type, r.Name)
+		}
+
+		update := false
+		for i, e := range extra {
+			if !replaced[i] && strings.EqualFold(e.Name, r.Name) && e.Type == r.Type {
+				replaced[i] = true
+				r.ID = e.ID
+				update = true
+				break
+			}
+		}
+
+		if update {
+			changes.update = append(changes.update, r)
+		} else {
+			changes.create = append(changes.create, r)
+		}
+	}
+
+	for i, e := range extra {
+		if !replaced[i] {
+			changes.delete = append(changes.delete, e)
+		}
+	}
+
+	return changes, nil
+}
+
+// forEachRecord calls f for every record with at most concurrency calls in
+// flight, returning an error diagnostic for each call that failed.
+func forEachRecord(records []cloudflare.DNSRecord, concurrency int, f func(cloudflare.DNSRecord) error) diag.Diagnostics {
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		diags diag.Diagnostics
+	)
+
+	slots := make(chan struct{}, concurrency)
+	for _, r := range records {
+		r := r
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			if err := f(r); err != nil {
+				mu.Lock()
+				diags = append(diags, diag.FromErr(err)...)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return diags
+}
+
+func resourceCloudflareRecordSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(recordSetID(d))
+
+	return resourceCloudflareRecordSetApply(ctx, d, meta)
+}
+
+func resourceCloudflareRecordSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceCloudflareRecordSetApply(ctx, d, meta)
+}
+
+func resourceCloudflareRecordSetApply(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	scope, err := newRecordSetScope(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error finding zone %q: %w", d.Get("zone_id").(string), err))
+	}
+
+	existing, err := scope.list(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	changes, err := diffRecordSet(scope, existing, d.Get("record").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Applying Cloudflare record set of zone %s: %d records to create, %d to update and %d to delete", scope.zoneID, len(changes.create), len(changes.update), len(changes.delete)))
+
+	concurrency := d.Get("max_concurrency").(int)
+
+	// Records are deleted first and created last, so that records replacing
+	// others of a different type don't conflict with them.
+	diags := forEachRecord(changes.delete, concurrency, func(r cloudflare.DNSRecord) error {
+		if err := client.DeleteDNSRecord(ctx, scope.zoneID, r.ID); err != nil {
+			return fmt.Errorf("error deleting %s record %q: %w", r.Type, r.Name, err)
+		}
+		return nil
+	})
+	if !diags.HasError() {
+		diags = forEachRecord(changes.update, concurrency, func(r cloudflare.DNSRecord) error {
+			if err := client.UpdateDNSRecord(ctx, scope.zoneID, r.ID, r); err != nil {
+				return fmt.Errorf("error updating %s record %q: %w", r.Type, r.Name, err)
+			}
+			return nil
+		})
+	}
+	if !diags.HasError() {
+		diags = forEachRecord(changes.create, concurrency, func(r cloudflare.DNSRecord) error {
+			if err := scope.create(client, r); err != nil {
+				return fmt.Errorf("error creating %s record %q: %w", r.Type, r.Name, err)
+			}
+			return nil
+		})
+	}
+
+	return append(diags, resourceCloudflareRecordSetRead(ctx, d, meta)...)
+}
+
+func resourceCloudflareRecordSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	scope, err := newRecordSetScope(ctx, client, d)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Zone %s of record set no longer exists", d.Get("zone_id").(string)))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error finding zone %q: %w", d.Get("zone_id").(string), err))
+	}
+
+	existing, err := scope.list(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	style := newRecordSetStyle(scope, d.Get("record").(*schema.Set).List())
+	records := make([]interface{}, 0, len(existing))
+	for _, r := range existing {
+		records = append(records, style.flatten(scope, r))
+	}
+
+	if err := d.Set("record", records); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting records: %w", err))
+	}
+
+	// Record sets were once identified by their zone alone.
+	d.SetId(recordSetID(d))
+
+	return nil
+}
+
+func resourceCloudflareRecordSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	scope, err := newRecordSetScope(ctx, client, d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error finding zone %q: %w", d.Get("zone_id").(string), err))
+	}
+
+	existing, err := scope.list(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only the records known to the record set are deleted, leaving any
+	// created since it was last refreshed.
+	managed, _, _ := matchRecordSet(scope, existing, d.Get("record").(*schema.Set).List())
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting %d records of Cloudflare record set of zone %s", len(managed), scope.zoneID))
+
+	return forEachRecord(managed, d.Get("max_concurrency").(int), func(r cloudflare.DNSRecord) error {
+		if err := client.DeleteDNSRecord(ctx, scope.zoneID, r.ID); err != nil {
+			return fmt.Errorf("error deleting %s record %q: %w", r.Type, r.Name, err)
+		}
+		return nil
+	})
+}
+
+func resourceCloudflareRecordSetImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// The record set of a whole zone is imported by zone ID and a filtered
+	// one with "zoneID/name/types/tag", where any part of the filter may be
+	// empty or omitted.
+	idAttr := strings.SplitN(d.Id(), "/", 4)
+	zoneID := idAttr[0]
+	if zoneID == "" {
+		return nil, fmt.Errorf("invalid id %q specified, should be in format \"zoneID\" or \"zoneID/name/types/tag\" for import", d.Id())
+	}
+
+	d.Set("zone_id", zoneID)
+	d.Set("max_concurrency", 4)
+
+	filter := make(map[string]interface{})
+	if len(idAttr) > 1 && idAttr[1] != "" {
+		filter["name"] = idAttr[1]
+	}
+	if len(idAttr) > 2 && idAttr[2] != "" {
+		filter["types"] = expandStringListToSet(strings.Split(idAttr[2], ","))
+	}
+	if len(idAttr) > 3 && idAttr[3] != "" {
+		filter["tag"] = idAttr[3]
+	}
+	if len(filter) > 0 {
+		d.Set("filter", []interface{}{filter})
+	}
+	d.SetId(recordSetID(d))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceCloudflareRecordSetValidateRecords validates the records that
+// don't depend on the zone during plan.
+func resourceCloudflareRecordSetValidateRecords(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("record") {
+		return nil
+	}
+
+	for _, r := range d.Get("record").(*schema.Set).List() {
+		record := r.(map[string]interface{})
+		name, recordType := record["name"].(string), record["type"].(string)
+
+		valueOk := record["value"].(string) != ""
+		dataOk := len(record["data"].([]interface{})) > 0
+		if valueOk == dataOk {
+			return fmt.Errorf("%s record %q: either 'value' (present: %t) or 'data' (present: %t) must be provided", recordType, name, valueOk, dataOk)
+		}
+
+		if record["proxied"].(bool) && record["ttl"].(int) != 1 {
+			return fmt.Errorf("%s record %q: ttl must be set to 1 when `proxied` is true", recordType, name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareRecordSet_Basic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_record_set.%s", rnd)
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareRecordSetDestroy(rnd, domain),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareRecordSetConfig(rnd, zoneID, "192.0.2.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "zone_id", zoneID),
+					resource.TestCheckResourceAttr(name, "record.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "record.*", map[string]string{"type": "A", "value": "192.0.2.1"}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "record.*", map[string]string{"type": "MX", "priority": "10"}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "record.*", map[string]string{"type": "CAA", "data.0.tag": "issue"}),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareRecordSetConfig(rnd, zoneID, "192.0.2.2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "record.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "record.*", map[string]string{"type": "A", "value": "192.0.2.2"}),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s", zoneID, rnd),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareRecordSetConfig(name, zoneID, ip string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_record_set" "%[1]s" {
+  zone_id = "%[2]s"
+
+  filter {
+    name = "%[1]s"
+  }
+
+  record {
+    name  = "%[1]s"
+    type  = "A"
+    value = "%[3]s"
+  }
+
+  record {
+    name     = "%[1]s"
+    type     = "MX"
+    value    = "mx.example.com"
+    priority = 10
+  }
+
+  record {
+    name = "%[1]s"
+    type = "CAA"
+    data {
+      flags = "0"
+      tag   = "issue"
+      value = "letsencrypt.org"
+    }
+  }
+}`, name, zoneID, ip)
+}
+
+func testAccCheckCloudflareRecordSetDestroy(name, domain string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*providerMeta).defaultClient()
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "cloudflare_record_set" {
+				continue
+			}
+
+			records, err := client.DNSRecords(context.Background(), rs.Primary.Attributes["zone_id"], cloudflare.DNSRecord{Name: name + "." + domain})
+			if err != nil {
+				return err
+			}
+			if len(records) > 0 {
+				return fmt.Errorf("%d records of record set %s still exist", len(records), name)
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestRecordSetApply(t *testing.T) {
+	for _, env := range []string{"CLOUDFLARE_API_TOKEN", "CLOUDFLARE_API_KEY", "CLOUDFLARE_EMAIL", "CLOUDFLARE_ACCOUNT_ID", "CLOUDFLARE_PROFILE", "CLOUDFLARE_REQUEST_RECORDING_PATH"} {
+		t.Setenv(env, "")
+	}
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+
+	p := New("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_token":    "0123456789abcdefghijklmnopqrstuvwxyzABCD",
+		"api_hostname": server.Hostname(),
+		"rps":          1000,
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+	m := p.Meta().(*providerMeta)
+	client := m.defaultClient()
+	client.BaseURL = strings.Replace(client.BaseURL, "https://", "http://", 1)
+
+	ctx := context.Background()
+	for _, r := range []cloudflare.DNSRecord{
+		{Name: "www", Type: "A", Content: "192.0.2.1"},
+		{Name: "old", Type: "CNAME", Content: "www." + testAccCloudflareZoneName},
+		{Name: "@", Type: "MX", Content: "mx.example.com", Priority: cloudflare.Uint16Ptr(10)},
+	} {
+		_, err := client.CreateDNSRecord(ctx, testAccCloudflareZoneID, r)
+		require.NoError(t, err)
+	}
+	// Enough records to span several pages of the listing.
+	defer func(size int) { recordSetPageSize = size }(recordSetPageSize)
+	recordSetPageSize = 100
+	var records []interface{}
+	for i := 0; i < 150; i++ {
+		records = append(records, map[string]interface{}{"name": fmt.Sprintf("host-%d", i), "type": "AAAA", "value": fmt.Sprintf("2001:db8::%x", i)})
+	}
+
+	records = append(records,
+		map[string]interface{}{"name": "WWW", "type": "A", "value": "192.0.2.2"},
+		map[string]interface{}{"name": "@", "type": "MX", "value": "mx.example.com", "priority": 10},
+		map[string]interface{}{"name": "_sip._tcp", "type": "SRV", "data": []interface{}{map[string]interface{}{
+			"service": "_sip", "proto": "_tcp", "name": testAccCloudflareZoneName, "priority": 10, "weight": 5, "port": 5060, "target": "sip." + testAccCloudflareZoneName,
+		}}},
+	)
+
+	r := p.ResourcesMap["cloudflare_record_set"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"zone_id": testAccCloudflareZoneID,
+		"record":  records,
+	})
+	diags = r.CreateContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, testAccCloudflareZoneID, d.Id())
+	assert.Equal(t, 153, d.Get("record.#"))
+
+	existing, err := client.DNSRecords(ctx, testAccCloudflareZoneID, cloudflare.DNSRecord{})
+	require.NoError(t, err)
+	var names []string
+	for _, r := range existing {
+		if r.Type != "AAAA" {
+			names = append(names, r.Type+" "+r.Name+" "+r.Content)
+		}
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{
+		"A www." + testAccCloudflareZoneName + " 192.0.2.2",
+		"MX " + testAccCloudflareZoneName + " mx.example.com",
+		"SRV _sip._tcp." + testAccCloudflareZoneName + " 5\t5060\tsip." + testAccCloudflareZoneName,
+	}, names)
+
+	// The records read back are written as configured, so nothing changes.
+	scope, err := newRecordSetScope(ctx, client, d)
+	require.NoError(t, err)
+	changes, err := diffRecordSet(scope, existing, d.Get("record").(*schema.Set).List())
+	require.NoError(t, err)
+	assert.Equal(t, recordSetChanges{}, changes)
+	assert.Contains(t, d.Get("record").(*schema.Set).List(), map[string]interface{}{
+		"name": "WWW", "type": "A", "value": "192.0.2.2", "data": []interface{}{}, "ttl": 1, "priority": 0, "proxied": false,
+	})
+
+	diags = r.DeleteContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	existing, err = client.DNSRecords(ctx, testAccCloudflareZoneID, cloudflare.DNSRecord{})
+	require.NoError(t, err)
+	assert.Empty(t, existing)
+}
+
+func TestRecordSetTagFilter(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+
+	p := New("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_token":    "0123456789abcdefghijklmnopqrstuvwxyzABCD",
+		"api_hostname": server.Hostname(),
+		"rps":          1000,
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+	m := p.Meta().(*providerMeta)
+	client := m.defaultClient()
+	client.BaseURL = strings.Replace(client.BaseURL, "https://", "http://", 1)
+
+	ctx := context.Background()
+	_, err := client.CreateDNSRecord(ctx, testAccCloudflareZoneID, cloudflare.DNSRecord{Name: "www", Type: "A", Content: "192.0.2.1"})
+	require.NoError(t, err)
+
+	r := p.ResourcesMap["cloudflare_record_set"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"zone_id": testAccCloudflareZoneID,
+		"filter":  []interface{}{map[string]interface{}{"tag": "env:production"}},
+		"record": []interface{}{
+			map[string]interface{}{"name": "www", "type": "A", "value": "192.0.2.2"},
+		},
+	})
+	diags = r.CreateContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, testAccCloudflareZoneID+"///env:production", d.Id())
+	assert.Equal(t, 1, d.Get("record.#"))
+
+	// The untagged record is outside of the record set, and the record
+	// created by it is tagged so that it's read back.
+	existing, err := client.DNSRecords(ctx, testAccCloudflareZoneID, cloudflare.DNSRecord{})
+	require.NoError(t, err)
+	assert.Len(t, existing, 2)
+	diags = r.ReadContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 1, d.Get("record.#"))
+
+	diags = r.DeleteContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	existing, err = client.DNSRecords(ctx, testAccCloudflareZoneID, cloudflare.DNSRecord{})
+	require.NoError(t, err)
+	require.Len(t, existing, 1)
+	assert.Equal(t, "192.0.2.1", existing[0].Content)
+}
+
+func TestRecordSetImport(t *testing.T) {
+	r := resourceCloudflareRecordSet()
+	for id, expected := range map[string]string{
+		testAccCloudflareZoneID:                           testAccCloudflareZoneID,
+		testAccCloudflareZoneID + "/WWW":                  testAccCloudflareZoneID + "/www",
+		testAccCloudflareZoneID + "/www/TXT,A/env:prod":   testAccCloudflareZoneID + "/www/A,TXT/env:prod",
+		testAccCloudflareZoneID + "///team:dns/edge":      testAccCloudflareZoneID + "///team:dns/edge",
+		testAccCloudflareZoneID + "/_acme-challenge/TXT/": testAccCloudflareZoneID + "/_acme-challenge/TXT",
+	} {
+		d := r.Data(nil)
+		d.SetId(id)
+		imported, err := r.Importer.StateContext(context.Background(), d, nil)
+		require.NoError(t, err, id)
+		assert.Equal(t, expected, imported[0].Id(), id)
+	}
+
+	d := r.Data(nil)
+	d.SetId(testAccCloudflareZoneID + "/www/TXT,A/env:prod")
+	_, err := r.Importer.StateContext(context.Background(), d, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "www", d.Get("filter.0.name"))
+	assert.Equal(t, 2, d.Get("filter.0.types.#"))
+	assert.Equal(t, "env:prod", d.Get("filter.0.tag"))
+
+	d = r.Data(nil)
+	d.SetId("/www")
+	_, err = r.Importer.StateContext(context.Background(), d, nil)
+	assert.Error(t, err)
+}
+
+func TestDiffRecordSet(t *testing.T) {
+	scope := recordSetScope{zoneID: testAccCloudflareZoneID, zoneName: "example.com", name: "www.example.com", types: []string{"A", "TXT"}}
+	record := func(name, recordType, value string) map[string]interface{} {
+		return map[string]interface{}{"name": name, "type": recordType, "value": value, "data": []interface{}{}, "ttl": 1, "priority": 0, "proxied": false}
+	}
+
+	existing := []cloudflare.DNSRecord{
+		{ID: "1", Name: "www.example.com", Type: "A", Content: "192.0.2.1", TTL: 1},
+		{ID: "2", Name: "www.example.com", Type: "A", Content: "192.0.2.2", TTL: 1},
+		{ID: "3", Name: "www.example.com", Type: "TXT", Content: "stale", TTL: 1},
+	}
+	changes, err := diffRecordSet(scope, existing, []interface{}{
+		record("www", "A", "192.0.2.1"),
+		record("www", "A", "192.0.2.3"),
+	})
+	require.NoError(t, err)
+	assert.Len(t, changes.create, 0)
+	require.Len(t, changes.update, 1)
+	assert.Equal(t, "2", changes.update[0].ID)
+	assert.Equal(t, "192.0.2.3", changes.update[0].Content)
+	require.Len(t, changes.delete, 1)
+	assert.Equal(t, "3", changes.delete[0].ID)
+
+	_, err = diffRecordSet(scope, existing, []interface{}{record("api", "A", "192.0.2.1")})
+	assert.EqualError(t, err, `A record "api.example.com" doesn't match the filter of the record set`)
+
+	// Without a filter, records of types that can't be declared are left
+	// untouched, and names and hostnames are compared the way the API does.
+	scope = recordSetScope{zoneID: testAccCloudflareZoneID, zoneName: "example.com"}
+	existing = []cloudflare.DNSRecord{
+		{ID: "1", Name: "alias.example.com", Type: "CNAME", Content: "target.example.net", TTL: 1},
+		{ID: "2", Name: "example.com", Type: "HTTPS", Content: "1 . alpn=h2", TTL: 1},
+	}
+	var managed []cloudflare.DNSRecord
+	for _, r := range existing {
+		if scope.contains(r.Name, r.Type) {
+			managed = append(managed, r)
+		}
+	}
+	changes, err = diffRecordSet(scope, managed, []interface{}{record("Alias.example.com.", "CNAME", "Target.Example.net.")})
+	require.NoError(t, err)
+	assert.Equal(t, recordSetChanges{}, changes)
+}
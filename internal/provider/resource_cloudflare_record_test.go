@@ -89,6 +89,32 @@ func TestAccCloudflareRecord_Basic(t *testing.T) {
 	})
 }
 
+func TestAccCloudflareRecord_ZoneName(t *testing.T) {
+	t.Parallel()
+	var record cloudflare.DNSRecord
+	zoneName := os.Getenv("CLOUDFLARE_DOMAIN")
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	rnd := generateRandomResourceName()
+	resourceName := fmt.Sprintf("cloudflare_record.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareRecordConfigZoneName(zoneName, "tf-acctest-zone-name", rnd),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudflareRecordExists(resourceName, &record),
+					resource.TestCheckResourceAttr(resourceName, "zone_name", zoneName),
+					resource.TestCheckResourceAttr(resourceName, "zone_id", zoneID),
+					resource.TestCheckResourceAttr(resourceName, "hostname", fmt.Sprintf("tf-acctest-zone-name.%s", zoneName)),
+				),
+			},
+		},
+	})
+}
+
 func TestAccCloudflareRecord_CaseInsensitive(t *testing.T) {
 	t.Parallel()
 	var record cloudflare.DNSRecord
@@ -520,7 +546,7 @@ func testAccCheckCloudflareRecordRecreated(before, after *cloudflare.DNSRecord)
 }
 
 func testAccCheckCloudflareRecordDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*cloudflare.API)
+	client := testAccProvider.Meta().(*providerMeta).defaultClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "cloudflare_record" {
@@ -538,7 +564,7 @@ func testAccCheckCloudflareRecordDestroy(s *terraform.State) error {
 
 func testAccManuallyDeleteRecord(record *cloudflare.DNSRecord) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*cloudflare.API)
+		client := testAccProvider.Meta().(*providerMeta).defaultClient()
 		err := client.DeleteDNSRecord(context.Background(), record.ZoneID, record.ID)
 		if err != nil {
 			return err
@@ -603,7 +629,7 @@ func testAccCheckCloudflareRecordExists(n string, record *cloudflare.DNSRecord)
 			return fmt.Errorf("No Record ID is set")
 		}
 
-		client := testAccProvider.Meta().(*cloudflare.API)
+		client := testAccProvider.Meta().(*providerMeta).defaultClient()
 		foundRecord, err := client.DNSRecord(context.Background(), rs.Primary.Attributes["zone_id"], rs.Primary.ID)
 		if err != nil {
 			return err
@@ -630,6 +656,17 @@ resource "cloudflare_record" "%[3]s" {
 }`, zoneID, name, rnd)
 }
 
+func testAccCheckCloudflareRecordConfigZoneName(zoneName, name, rnd string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_record" "%[3]s" {
+	zone_name = "%[1]s"
+	name = "%[2]s"
+	value = "192.168.0.10"
+	type = "A"
+	ttl = 3600
+}`, zoneName, name, rnd)
+}
+
 func testAccCheckCloudflareRecordConfigApex(zoneID, rnd string) string {
 	return fmt.Sprintf(`
 resource "cloudflare_record" "%[2]s" {
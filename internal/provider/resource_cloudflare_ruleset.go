@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/wirefilter"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/pkg/errors"
@@ -31,6 +32,7 @@ func resourceCloudflareRuleset() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceCloudflareRulesetImport,
 		},
+		CustomizeDiff: resourceCloudflareRulesetValidateExpressionScopes,
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -63,20 +65,23 @@ is fixed, when configuring a ruleset with overrides, you will need to set
 }
 
 func resourceCloudflareRulesetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	zoneID := d.Get("zone_id").(string)
 	rulesetPhase := d.Get("phase").(string)
 
-	var ruleset cloudflare.Ruleset
+	var phaseRuleset cloudflare.Ruleset
 	var sempahoreErr error
 	if accountID != "" {
-		ruleset, sempahoreErr = client.GetAccountRulesetPhase(ctx, accountID, rulesetPhase)
+		phaseRuleset, sempahoreErr = client.GetAccountRulesetPhase(ctx, accountID, rulesetPhase)
 	} else {
-		ruleset, sempahoreErr = client.GetZoneRulesetPhase(ctx, zoneID, rulesetPhase)
+		phaseRuleset, sempahoreErr = client.GetZoneRulesetPhase(ctx, zoneID, rulesetPhase)
 	}
 
-	if len(ruleset.Rules) > 0 {
+	if len(phaseRuleset.Rules) > 0 {
 		deleteRulesetURL := accountLevelRulesetDeleteURL
 		if accountID == "" {
 			deleteRulesetURL = zoneLevelRulesetDeleteURL
@@ -87,11 +92,13 @@ func resourceCloudflareRulesetCreate(ctx context.Context, d *schema.ResourceData
 	rulesetName := d.Get("name").(string)
 	rulesetDescription := d.Get("description").(string)
 	rulesetKind := d.Get("kind").(string)
-	rs := cloudflare.Ruleset{
-		Name:        rulesetName,
-		Description: rulesetDescription,
-		Kind:        rulesetKind,
-		Phase:       rulesetPhase,
+	rs := ruleset{
+		Ruleset: cloudflare.Ruleset{
+			Name:        rulesetName,
+			Description: rulesetDescription,
+			Kind:        rulesetKind,
+			Phase:       rulesetPhase,
+		},
 	}
 
 	rules, err := buildRulesetRulesFromResource(d)
@@ -103,13 +110,13 @@ func resourceCloudflareRulesetCreate(ctx context.Context, d *schema.ResourceData
 		rs.Rules = rules
 	}
 
-	if sempahoreErr == nil && len(ruleset.Rules) == 0 && ruleset.Description == "" {
+	if sempahoreErr == nil && len(phaseRuleset.Rules) == 0 && phaseRuleset.Description == "" {
 		log.Print("[DEBUG] default ruleset created by the UI with empty rules found, recreating from scratch")
 		var deleteRulesetErr error
 		if accountID != "" {
-			deleteRulesetErr = client.DeleteAccountRuleset(ctx, accountID, ruleset.ID)
+			deleteRulesetErr = client.DeleteAccountRuleset(ctx, accountID, phaseRuleset.ID)
 		} else {
-			deleteRulesetErr = client.DeleteZoneRuleset(ctx, zoneID, ruleset.ID)
+			deleteRulesetErr = client.DeleteZoneRuleset(ctx, zoneID, phaseRuleset.ID)
 		}
 
 		if deleteRulesetErr != nil {
@@ -117,37 +124,26 @@ func resourceCloudflareRulesetCreate(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
-	var rulesetCreateErr error
-	if accountID != "" {
-		ruleset, rulesetCreateErr = client.CreateAccountRuleset(ctx, accountID, rs)
-	} else {
-		ruleset, rulesetCreateErr = client.CreateZoneRuleset(ctx, zoneID, rs)
-	}
-
+	created, rulesetCreateErr := createRuleset(client, accountID, zoneID, rs)
 	if rulesetCreateErr != nil {
 		return diag.FromErr(fmt.Errorf("error creating ruleset %s: %w", rulesetName, rulesetCreateErr))
 	}
 
-	rulesetEntryPoint := cloudflare.Ruleset{
-		Description: rulesetDescription,
-		Rules:       rules,
+	rulesetEntryPoint := ruleset{
+		Ruleset: cloudflare.Ruleset{Description: rulesetDescription},
+		Rules:   rules,
 	}
 
 	// For "custom" rulesets, we don't send a follow up PUT it to the entrypoint
 	// endpoint.
 	if rulesetKind != string(cloudflare.RulesetKindCustom) {
-		if accountID != "" {
-			_, err = client.UpdateAccountRulesetPhase(ctx, accountID, rulesetPhase, rulesetEntryPoint)
-		} else {
-			_, err = client.UpdateZoneRulesetPhase(ctx, zoneID, rulesetPhase, rulesetEntryPoint)
-		}
-
+		_, err = updateRulesetPhase(client, accountID, zoneID, rulesetPhase, rulesetEntryPoint)
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("error updating ruleset phase entrypoint %s: %w", rulesetName, err))
 		}
 	}
 
-	d.SetId(ruleset.ID)
+	d.SetId(created.ID)
 
 	return resourceCloudflareRulesetRead(ctx, d, meta)
 }
@@ -157,19 +153,14 @@ func resourceCloudflareRulesetImport(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceCloudflareRulesetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	zoneID := d.Get("zone_id").(string)
 
-	var ruleset cloudflare.Ruleset
-	var err error
-
-	if accountID != "" {
-		ruleset, err = client.GetAccountRuleset(ctx, accountID, d.Id())
-	} else {
-		ruleset, err = client.GetZoneRuleset(ctx, zoneID, d.Id())
-	}
-
+	ruleset, err := rulesetOf(client, accountID, zoneID, d.Id())
 	if err != nil {
 		if strings.Contains(err.Error(), "could not find ruleset") {
 			log.Printf("[INFO] Ruleset %s no longer exists", d.Id())
@@ -190,7 +181,10 @@ func resourceCloudflareRulesetRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceCloudflareRulesetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	zoneID := d.Get("zone_id").(string)
 
@@ -200,12 +194,7 @@ func resourceCloudflareRulesetUpdate(ctx context.Context, d *schema.ResourceData
 	}
 
 	description := d.Get("description").(string)
-	if accountID != "" {
-		_, err = client.UpdateAccountRuleset(ctx, accountID, d.Id(), description, rules)
-	} else {
-		_, err = client.UpdateZoneRuleset(ctx, zoneID, d.Id(), description, rules)
-	}
-
+	_, err = updateRuleset(client, accountID, zoneID, d.Id(), description, rules)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error updating ruleset with ID %q: %w", d.Id(), err))
 	}
@@ -214,7 +203,10 @@ func resourceCloudflareRulesetUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceCloudflareRulesetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	zoneID := d.Get("zone_id").(string)
 	var err error
@@ -232,9 +224,42 @@ func resourceCloudflareRulesetDelete(ctx context.Context, d *schema.ResourceData
 	return nil
 }
 
+// resourceCloudflareRulesetValidateExpressionScopes validates the expression
+// of each rule against the fields available in the phase of the ruleset, as
+// the schema only knows the expression and not the phase. Counting
+// expressions of rate limiting rules may also use response fields.
+// Expressions that aren't known until apply are skipped, and unknown fields
+// are left to the warnings of the schema.
+func resourceCloudflareRulesetValidateExpressionScopes(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("phase") {
+		return nil
+	}
+
+	scope := wirefilter.ScopeForPhase(d.Get("phase").(string))
+	rules := d.Get("rules").([]interface{})
+
+	for i := range rules {
+		expressions := map[string]wirefilter.Scope{
+			fmt.Sprintf("rules.%d.expression", i):                      scope,
+			fmt.Sprintf("rules.%d.ratelimit.0.counting_expression", i): scope | wirefilter.ScopeResponse,
+		}
+		for key, scope := range expressions {
+			expr, ok := d.GetOk(key)
+			if !ok || !d.NewValueKnown(key) {
+				continue
+			}
+			if _, err := wirefilter.Validate(expr.(string), scope); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // buildStateFromRulesetRules receives the current ruleset rules and returns an
 // interface for the state file.
-func buildStateFromRulesetRules(rules []cloudflare.RulesetRule) interface{} {
+func buildStateFromRulesetRules(rules []rulesetRule) interface{} {
 	var rulesData []map[string]interface{}
 	for _, r := range rules {
 		rule := map[string]interface{}{
@@ -262,6 +287,12 @@ func buildStateFromRulesetRules(rules []cloudflare.RulesetRule) interface{} {
 				requestFields          []string
 				responseFields         []string
 				cookieFields           []string
+				edgeTTL                []map[string]interface{}
+				browserTTL             []map[string]interface{}
+				serveStale             []map[string]interface{}
+				cacheKey               []map[string]interface{}
+				fromValue              []map[string]interface{}
+				fromList               []map[string]interface{}
 			)
 			actionParameterRules := make(map[string]string)
 
@@ -386,7 +417,67 @@ func buildStateFromRulesetRules(rules []cloudflare.RulesetRule) interface{} {
 				}
 			}
 
-			actionParameters = append(actionParameters, map[string]interface{}{
+			if !reflect.ValueOf(r.ActionParameters.EdgeTTL).IsNil() {
+				var statusCodeTTLs []map[string]interface{}
+				for _, statusCodeTTL := range r.ActionParameters.EdgeTTL.StatusCodeTTL {
+					var statusCodeRange []map[string]interface{}
+					if statusCodeTTL.StatusCodeRange != nil {
+						statusCodeRange = append(statusCodeRange, map[string]interface{}{
+							"from": statusCodeTTL.StatusCodeRange.From,
+							"to":   statusCodeTTL.StatusCodeRange.To,
+						})
+					}
+
+					statusCodeTTLs = append(statusCodeTTLs, map[string]interface{}{
+						"status_code":       statusCodeTTL.StatusCode,
+						"status_code_range": statusCodeRange,
+						"value":             statusCodeTTL.Value,
+					})
+				}
+
+				edgeTTL = append(edgeTTL, map[string]interface{}{
+					"mode":            r.ActionParameters.EdgeTTL.Mode,
+					"default":         r.ActionParameters.EdgeTTL.Default,
+					"status_code_ttl": statusCodeTTLs,
+				})
+			}
+
+			if !reflect.ValueOf(r.ActionParameters.BrowserTTL).IsNil() {
+				browserTTL = append(browserTTL, map[string]interface{}{
+					"mode":    r.ActionParameters.BrowserTTL.Mode,
+					"default": r.ActionParameters.BrowserTTL.Default,
+				})
+			}
+
+			if !reflect.ValueOf(r.ActionParameters.ServeStale).IsNil() {
+				serveStale = append(serveStale, map[string]interface{}{
+					"disable_stale_while_updating": r.ActionParameters.ServeStale.DisableStaleWhileUpdating,
+				})
+			}
+
+			if !reflect.ValueOf(r.ActionParameters.CacheKey).IsNil() {
+				cacheKey = buildStateFromRulesetRuleCacheKey(r.ActionParameters.CacheKey)
+			}
+
+			if !reflect.ValueOf(r.ActionParameters.FromValue).IsNil() {
+				fromValue = append(fromValue, map[string]interface{}{
+					"status_code": r.ActionParameters.FromValue.StatusCode,
+					"target_url": []map[string]interface{}{{
+						"value":      r.ActionParameters.FromValue.TargetURL.Value,
+						"expression": r.ActionParameters.FromValue.TargetURL.Expression,
+					}},
+					"preserve_query_string": r.ActionParameters.FromValue.PreserveQueryString,
+				})
+			}
+
+			if !reflect.ValueOf(r.ActionParameters.FromList).IsNil() {
+				fromList = append(fromList, map[string]interface{}{
+					"name": r.ActionParameters.FromList.Name,
+					"key":  r.ActionParameters.FromList.Key,
+				})
+			}
+
+			parameters := map[string]interface{}{
 				"id":              r.ActionParameters.ID,
 				"increment":       r.ActionParameters.Increment,
 				"headers":         headers,
@@ -405,7 +496,27 @@ func buildStateFromRulesetRules(rules []cloudflare.RulesetRule) interface{} {
 				"request_fields":  requestFields,
 				"response_fields": responseFields,
 				"cookie_fields":   cookieFields,
-			})
+				"edge_ttl":        edgeTTL,
+				"browser_ttl":     browserTTL,
+				"serve_stale":     serveStale,
+				"cache_key":       cacheKey,
+				"from_value":      fromValue,
+				"from_list":       fromList,
+			}
+
+			if r.ActionParameters.Cache != nil {
+				parameters["cache"] = *r.ActionParameters.Cache
+			}
+
+			if r.ActionParameters.RespectStrongETags != nil {
+				parameters["respect_strong_etags"] = *r.ActionParameters.RespectStrongETags
+			}
+
+			if r.ActionParameters.OriginErrorPagePassthru != nil {
+				parameters["origin_error_page_passthru"] = *r.ActionParameters.OriginErrorPagePassthru
+			}
+
+			actionParameters = append(actionParameters, parameters)
 
 			rule["action_parameters"] = actionParameters
 		}
@@ -453,8 +564,8 @@ func buildStateFromRulesetRules(rules []cloudflare.RulesetRule) interface{} {
 }
 
 // receives the resource config and builds a ruleset rule array.
-func buildRulesetRulesFromResource(d *schema.ResourceData) ([]cloudflare.RulesetRule, error) {
-	var rulesetRules []cloudflare.RulesetRule
+func buildRulesetRulesFromResource(d *schema.ResourceData) ([]rulesetRule, error) {
+	var rulesetRules []rulesetRule
 
 	rules, ok := d.Get("rules").([]interface{})
 	if !ok {
@@ -462,7 +573,7 @@ func buildRulesetRulesFromResource(d *schema.ResourceData) ([]cloudflare.Ruleset
 	}
 
 	for rulesCounter, v := range rules {
-		var rule cloudflare.RulesetRule
+		var rule rulesetRule
 
 		resourceRule, ok := v.(map[string]interface{})
 		if !ok {
@@ -470,7 +581,7 @@ func buildRulesetRulesFromResource(d *schema.ResourceData) ([]cloudflare.Ruleset
 		}
 
 		if len(resourceRule["action_parameters"].([]interface{})) > 0 {
-			rule.ActionParameters = &cloudflare.RulesetRuleActionParameters{}
+			rule.ActionParameters = &rulesetRuleActionParameters{}
 			for _, parameter := range resourceRule["action_parameters"].([]interface{}) {
 				for pKey, pValue := range parameter.(map[string]interface{}) {
 					switch pKey {
@@ -672,11 +783,115 @@ func buildRulesetRulesFromResource(d *schema.ResourceData) ([]cloudflare.Ruleset
 						}
 						rule.ActionParameters.CookieFields = fields
 
+					case "cache", "respect_strong_etags", "origin_error_page_passthru":
+						//nolint:staticcheck
+						if value, ok := d.GetOkExists(fmt.Sprintf("rules.%d.action_parameters.0.%s", rulesCounter, pKey)); ok {
+							enabled := cloudflare.BoolPtr(value.(bool))
+							switch pKey {
+							case "cache":
+								rule.ActionParameters.Cache = enabled
+							case "respect_strong_etags":
+								rule.ActionParameters.RespectStrongETags = enabled
+							case "origin_error_page_passthru":
+								rule.ActionParameters.OriginErrorPagePassthru = enabled
+							}
+						}
+
+					case "edge_ttl":
+						for _, edgeTTLValue := range pValue.([]interface{}) {
+							edgeTTLConfig := edgeTTLValue.(map[string]interface{})
+							edgeTTL := rulesetRuleActionParametersEdgeTTL{
+								Mode:    edgeTTLConfig["mode"].(string),
+								Default: edgeTTLConfig["default"].(int),
+							}
+
+							for _, statusCodeTTLValue := range edgeTTLConfig["status_code_ttl"].([]interface{}) {
+								statusCodeTTLConfig := statusCodeTTLValue.(map[string]interface{})
+								statusCodeTTL := rulesetRuleActionParametersStatusCodeTTL{
+									StatusCode: statusCodeTTLConfig["status_code"].(int),
+									Value:      statusCodeTTLConfig["value"].(int),
+								}
+
+								for _, statusCodeRangeValue := range statusCodeTTLConfig["status_code_range"].([]interface{}) {
+									statusCodeRangeConfig := statusCodeRangeValue.(map[string]interface{})
+									statusCodeTTL.StatusCodeRange = &rulesetRuleActionParametersStatusCodeRange{
+										From: statusCodeRangeConfig["from"].(int),
+										To:   statusCodeRangeConfig["to"].(int),
+									}
+								}
+
+								if statusCodeTTL.StatusCode != 0 && statusCodeTTL.StatusCodeRange != nil {
+									return nil, fmt.Errorf("status_code_ttl of rule %d can't have both a status_code and a status_code_range", rulesCounter)
+								}
+
+								edgeTTL.StatusCodeTTL = append(edgeTTL.StatusCodeTTL, statusCodeTTL)
+							}
+
+							rule.ActionParameters.EdgeTTL = &edgeTTL
+						}
+
+					case "browser_ttl":
+						for i := range pValue.([]interface{}) {
+							rule.ActionParameters.BrowserTTL = &rulesetRuleActionParametersBrowserTTL{
+								Mode:    pValue.([]interface{})[i].(map[string]interface{})["mode"].(string),
+								Default: pValue.([]interface{})[i].(map[string]interface{})["default"].(int),
+							}
+						}
+
+					case "serve_stale":
+						for i := range pValue.([]interface{}) {
+							rule.ActionParameters.ServeStale = &rulesetRuleActionParametersServeStale{
+								DisableStaleWhileUpdating: pValue.([]interface{})[i].(map[string]interface{})["disable_stale_while_updating"].(bool),
+							}
+						}
+
+					case "cache_key":
+						for _, cacheKeyValue := range pValue.([]interface{}) {
+							rule.ActionParameters.CacheKey = buildRulesetRuleCacheKey(cacheKeyValue.(map[string]interface{}))
+						}
+
+					case "from_value":
+						for _, fromValue := range pValue.([]interface{}) {
+							fromValueConfig := fromValue.(map[string]interface{})
+							rule.ActionParameters.FromValue = &rulesetRuleActionParametersFromValue{
+								StatusCode:          fromValueConfig["status_code"].(int),
+								PreserveQueryString: fromValueConfig["preserve_query_string"].(bool),
+							}
+
+							for _, targetURLValue := range fromValueConfig["target_url"].([]interface{}) {
+								if targetURLValue == nil {
+									continue
+								}
+								targetURLConfig := targetURLValue.(map[string]interface{})
+								rule.ActionParameters.FromValue.TargetURL = rulesetRuleActionParametersTargetURL{
+									Value:      targetURLConfig["value"].(string),
+									Expression: targetURLConfig["expression"].(string),
+								}
+							}
+
+							targetURL := rule.ActionParameters.FromValue.TargetURL
+							if (targetURL.Value == "") == (targetURL.Expression == "") {
+								return nil, fmt.Errorf("target_url of rule %d must have either a value or an expression", rulesCounter)
+							}
+						}
+
+					case "from_list":
+						for i := range pValue.([]interface{}) {
+							rule.ActionParameters.FromList = &rulesetRuleActionParametersFromList{
+								Name: pValue.([]interface{})[i].(map[string]interface{})["name"].(string),
+								Key:  pValue.([]interface{})[i].(map[string]interface{})["key"].(string),
+							}
+						}
+
 					default:
 						log.Printf("[DEBUG] unknown key encountered in buildRulesetRulesFromResource for action parameters: %s", pKey)
 					}
 				}
 			}
+
+			if rule.ActionParameters.FromValue != nil && rule.ActionParameters.FromList != nil {
+				return nil, fmt.Errorf("rule %d can't redirect both from_value and from_list", rulesCounter)
+			}
 		}
 
 		if len(resourceRule["ratelimit"].([]interface{})) > 0 {
@@ -756,3 +971,159 @@ func buildRulesetRulesFromResource(d *schema.ResourceData) ([]cloudflare.Ruleset
 
 	return rulesetRules, nil
 }
+
+// buildRulesetRuleCacheKey receives the cache key configuration of a rule
+// and builds the cache key of its action parameters.
+func buildRulesetRuleCacheKey(cacheKeyConfig map[string]interface{}) *rulesetRuleActionParametersCacheKey {
+	cacheKey := rulesetRuleActionParametersCacheKey{
+		CacheByDeviceType:       cacheKeyConfig["cache_by_device_type"].(bool),
+		IgnoreQueryStringsOrder: cacheKeyConfig["ignore_query_strings_order"].(bool),
+		CacheDeceptionArmor:     cacheKeyConfig["cache_deception_armor"].(bool),
+	}
+
+	for _, customKeyValue := range cacheKeyConfig["custom_key"].([]interface{}) {
+		var customKey rulesetRuleActionParametersCustomKey
+		if customKeyValue == nil {
+			cacheKey.CustomKey = &customKey
+			continue
+		}
+		customKeyConfig := customKeyValue.(map[string]interface{})
+
+		for _, queryStringValue := range customKeyConfig["query_string"].([]interface{}) {
+			customKey.Query = &rulesetRuleActionParametersCustomKeyQuery{}
+			if queryStringValue == nil {
+				continue
+			}
+			queryStringConfig := queryStringValue.(map[string]interface{})
+			customKey.Query.Include = buildRulesetRuleCustomKeyList(queryStringConfig["include"].(*schema.Set))
+			customKey.Query.Exclude = buildRulesetRuleCustomKeyList(queryStringConfig["exclude"].(*schema.Set))
+		}
+
+		for _, headerValue := range customKeyConfig["header"].([]interface{}) {
+			customKey.Header = &rulesetRuleActionParametersCustomKeyHeader{}
+			if headerValue == nil {
+				continue
+			}
+			headerConfig := headerValue.(map[string]interface{})
+			customKey.Header.Include = expandInterfaceToStringList(headerConfig["include"].(*schema.Set).List())
+			customKey.Header.CheckPresence = expandInterfaceToStringList(headerConfig["check_presence"].(*schema.Set).List())
+			customKey.Header.ExcludeOrigin = headerConfig["exclude_origin"].(bool)
+		}
+
+		for _, cookieValue := range customKeyConfig["cookie"].([]interface{}) {
+			customKey.Cookie = &rulesetRuleActionParametersCustomKeyCookie{}
+			if cookieValue == nil {
+				continue
+			}
+			cookieConfig := cookieValue.(map[string]interface{})
+			customKey.Cookie.Include = expandInterfaceToStringList(cookieConfig["include"].(*schema.Set).List())
+			customKey.Cookie.CheckPresence = expandInterfaceToStringList(cookieConfig["check_presence"].(*schema.Set).List())
+		}
+
+		for _, userValue := range customKeyConfig["user"].([]interface{}) {
+			customKey.User = &rulesetRuleActionParametersCustomKeyUser{}
+			if userValue == nil {
+				continue
+			}
+			userConfig := userValue.(map[string]interface{})
+			customKey.User.DeviceType = userConfig["device_type"].(bool)
+			customKey.User.Geo = userConfig["geo"].(bool)
+			customKey.User.Lang = userConfig["lang"].(bool)
+		}
+
+		for _, hostValue := range customKeyConfig["host"].([]interface{}) {
+			customKey.Host = &rulesetRuleActionParametersCustomKeyHost{}
+			if hostValue == nil {
+				continue
+			}
+			customKey.Host.Resolved = hostValue.(map[string]interface{})["resolved"].(bool)
+		}
+
+		cacheKey.CustomKey = &customKey
+	}
+
+	return &cacheKey
+}
+
+// buildRulesetRuleCustomKeyList builds the query string parameters of a
+// custom cache key, where "*" stands for all of them.
+func buildRulesetRuleCustomKeyList(parameters *schema.Set) *rulesetRuleActionParametersCustomKeyList {
+	if parameters.Len() == 0 {
+		return nil
+	}
+	if parameters.Contains(rulesetCacheKeyQueryStringAllWildcard) {
+		return &rulesetRuleActionParametersCustomKeyList{All: true}
+	}
+	return &rulesetRuleActionParametersCustomKeyList{List: expandInterfaceToStringList(parameters.List())}
+}
+
+// buildStateFromRulesetRuleCacheKey receives the cache key of a rule and
+// returns an interface for the state file.
+func buildStateFromRulesetRuleCacheKey(cacheKey *rulesetRuleActionParametersCacheKey) []map[string]interface{} {
+	var customKey []map[string]interface{}
+
+	if cacheKey.CustomKey != nil {
+		var queryString, header, cookie, user, host []map[string]interface{}
+
+		if cacheKey.CustomKey.Query != nil {
+			queryString = append(queryString, map[string]interface{}{
+				"include": buildStateFromRulesetRuleCustomKeyList(cacheKey.CustomKey.Query.Include),
+				"exclude": buildStateFromRulesetRuleCustomKeyList(cacheKey.CustomKey.Query.Exclude),
+			})
+		}
+
+		if cacheKey.CustomKey.Header != nil {
+			header = append(header, map[string]interface{}{
+				"include":        cacheKey.CustomKey.Header.Include,
+				"check_presence": cacheKey.CustomKey.Header.CheckPresence,
+				"exclude_origin": cacheKey.CustomKey.Header.ExcludeOrigin,
+			})
+		}
+
+		if cacheKey.CustomKey.Cookie != nil {
+			cookie = append(cookie, map[string]interface{}{
+				"include":        cacheKey.CustomKey.Cookie.Include,
+				"check_presence": cacheKey.CustomKey.Cookie.CheckPresence,
+			})
+		}
+
+		if cacheKey.CustomKey.User != nil {
+			user = append(user, map[string]interface{}{
+				"device_type": cacheKey.CustomKey.User.DeviceType,
+				"geo":         cacheKey.CustomKey.User.Geo,
+				"lang":        cacheKey.CustomKey.User.Lang,
+			})
+		}
+
+		if cacheKey.CustomKey.Host != nil {
+			host = append(host, map[string]interface{}{
+				"resolved": cacheKey.CustomKey.Host.Resolved,
+			})
+		}
+
+		customKey = append(customKey, map[string]interface{}{
+			"query_string": queryString,
+			"header":       header,
+			"cookie":       cookie,
+			"user":         user,
+			"host":         host,
+		})
+	}
+
+	return []map[string]interface{}{{
+		"cache_by_device_type":       cacheKey.CacheByDeviceType,
+		"ignore_query_strings_order": cacheKey.IgnoreQueryStringsOrder,
+		"cache_deception_armor":      cacheKey.CacheDeceptionArmor,
+		"custom_key":                 customKey,
+	}}
+}
+
+func buildStateFromRulesetRuleCustomKeyList(parameters *rulesetRuleActionParametersCustomKeyList) []string {
+	if parameters == nil {
+		return nil
+	}
+	if parameters.All {
+		return []string{rulesetCacheKeyQueryStringAllWildcard}
+	}
+	return parameters.List
+}
@@ -7,9 +7,13 @@ import (
 	"os"
 	"testing"
 
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
@@ -1483,6 +1487,99 @@ func TestAccCloudflareRuleset_LogCustomField(t *testing.T) {
 	})
 }
 
+func TestAccCloudflareRuleset_CacheSettings(t *testing.T) {
+	t.Parallel()
+	rnd := generateRandomResourceName()
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	resourceName := "cloudflare_ruleset." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareRulesetCacheSettings(rnd, "my basic cache settings ruleset", zoneID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "my basic cache settings ruleset"),
+					resource.TestCheckResourceAttr(resourceName, "phase", "http_request_cache_settings"),
+
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action", "set_cache_settings"),
+
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.cache", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.edge_ttl.0.mode", "override_origin"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.edge_ttl.0.default", "60"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.edge_ttl.0.status_code_ttl.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.edge_ttl.0.status_code_ttl.0.status_code", "200"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.edge_ttl.0.status_code_ttl.1.status_code_range.0.from", "500"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.browser_ttl.0.mode", "respect_origin"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.serve_stale.0.disable_stale_while_updating", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.cache_key.0.cache_deception_armor", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.cache_key.0.custom_key.0.query_string.0.exclude.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.cache_key.0.custom_key.0.header.0.include.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.cache_key.0.custom_key.0.user.0.device_type", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.cache_key.0.custom_key.0.host.0.resolved", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.respect_strong_etags", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.origin_error_page_passthru", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareRuleset_DynamicRedirect(t *testing.T) {
+	t.Parallel()
+	rnd := generateRandomResourceName()
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	resourceName := "cloudflare_ruleset." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareRulesetDynamicRedirect(rnd, "my basic dynamic redirect ruleset", zoneID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "phase", "http_request_dynamic_redirect"),
+
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action", "redirect"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.from_value.0.status_code", "301"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.from_value.0.target_url.0.value", "some_host.com"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.from_value.0.preserve_query_string", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rules.1.action_parameters.0.from_value.0.target_url.0.expression", `concat("https://example.com", http.request.uri.path)`),
+					resource.TestCheckResourceAttr(resourceName, "rules.1.action_parameters.0.from_value.0.preserve_query_string", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareRuleset_BulkRedirect(t *testing.T) {
+	t.Parallel()
+	rnd := generateRandomResourceName()
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	resourceName := "cloudflare_ruleset." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareRulesetBulkRedirect(rnd, "my basic bulk redirect ruleset", accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "phase", "http_request_redirect"),
+
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action", "redirect"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.from_list.0.name", rnd),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.action_parameters.0.from_list.0.key", "http.request.full_uri"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckCloudflareRulesetMagicTransitSingle(rnd, name, accountID string) string {
 	return fmt.Sprintf(`
   resource "cloudflare_ruleset" "%[1]s" {
@@ -2554,3 +2651,294 @@ func testAccCheckCloudflareRulesetLogCustomField(rnd, name, zoneID string) strin
     }
   }`, rnd, name, zoneID)
 }
+
+func testAccCheckCloudflareRulesetCacheSettings(rnd, name, zoneID string) string {
+	return fmt.Sprintf(`
+  resource "cloudflare_ruleset" "%[1]s" {
+    zone_id     = "%[3]s"
+    name        = "%[2]s"
+    description = "%[1]s ruleset description"
+    kind        = "zone"
+    phase       = "http_request_cache_settings"
+
+    rules {
+      action = "set_cache_settings"
+      action_parameters {
+        cache = true
+        edge_ttl {
+          mode    = "override_origin"
+          default = 60
+          status_code_ttl {
+            status_code = 200
+            value       = 50
+          }
+          status_code_ttl {
+            status_code_range {
+              from = 500
+              to   = 599
+            }
+            value = -1
+          }
+        }
+        browser_ttl {
+          mode = "respect_origin"
+        }
+        serve_stale {
+          disable_stale_while_updating = true
+        }
+        cache_key {
+          ignore_query_strings_order = false
+          cache_deception_armor      = true
+          custom_key {
+            query_string {
+              exclude = ["*"]
+            }
+            header {
+              include        = ["habc", "hdef"]
+              check_presence = ["habc_t", "hdef_t"]
+              exclude_origin = true
+            }
+            cookie {
+              include        = ["cabc", "cdef"]
+              check_presence = ["cabc_t", "cdef_t"]
+            }
+            user {
+              device_type = true
+              geo         = false
+            }
+            host {
+              resolved = true
+            }
+          }
+        }
+        respect_strong_etags       = true
+        origin_error_page_passthru = false
+      }
+      expression  = "true"
+      description = "%[1]s set cache settings rule"
+      enabled     = true
+    }
+  }`, rnd, name, zoneID)
+}
+
+func testAccCheckCloudflareRulesetDynamicRedirect(rnd, name, zoneID string) string {
+	return fmt.Sprintf(`
+  resource "cloudflare_ruleset" "%[1]s" {
+    zone_id     = "%[3]s"
+    name        = "%[2]s"
+    description = "%[1]s ruleset description"
+    kind        = "zone"
+    phase       = "http_request_dynamic_redirect"
+
+    rules {
+      action = "redirect"
+      action_parameters {
+        from_value {
+          status_code = 301
+          target_url {
+            value = "some_host.com"
+          }
+          preserve_query_string = true
+        }
+      }
+      expression  = "(http.request.uri.path eq \"/old\")"
+      description = "%[1]s static redirect rule"
+      enabled     = true
+    }
+
+    rules {
+      action = "redirect"
+      action_parameters {
+        from_value {
+          status_code = 302
+          target_url {
+            expression = "concat(\"https://example.com\", http.request.uri.path)"
+          }
+        }
+      }
+      expression  = "(http.host eq \"old.example.com\")"
+      description = "%[1]s dynamic redirect rule"
+      enabled     = true
+    }
+  }`, rnd, name, zoneID)
+}
+
+func testAccCheckCloudflareRulesetBulkRedirect(rnd, name, accountID string) string {
+	return fmt.Sprintf(`
+  resource "cloudflare_list" "%[1]s" {
+    account_id  = "%[3]s"
+    name        = "%[1]s"
+    description = "%[1]s redirects"
+    kind        = "redirect"
+
+    item {
+      redirect {
+        source_url  = "example.com/old"
+        target_url  = "https://example.com/new"
+        status_code = 301
+      }
+    }
+  }
+
+  resource "cloudflare_ruleset" "%[1]s" {
+    account_id  = "%[3]s"
+    name        = "%[2]s"
+    description = "%[1]s ruleset description"
+    kind        = "root"
+    phase       = "http_request_redirect"
+
+    rules {
+      action = "redirect"
+      action_parameters {
+        from_list {
+          name = cloudflare_list.%[1]s.name
+          key  = "http.request.full_uri"
+        }
+      }
+      expression  = "http.request.full_uri in $%[1]s"
+      description = "%[1]s bulk redirect rule"
+      enabled     = true
+    }
+  }`, rnd, name, accountID)
+}
+
+func TestRulesetCacheSettingsApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_ruleset"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"zone_id": testAccCloudflareZoneID,
+		"name":    "cache settings",
+		"kind":    "zone",
+		"phase":   "http_request_cache_settings",
+		"rules": []interface{}{map[string]interface{}{
+			"action":     "set_cache_settings",
+			"expression": "true",
+			"enabled":    true,
+			"action_parameters": []interface{}{map[string]interface{}{
+				"cache": false,
+				"edge_ttl": []interface{}{map[string]interface{}{
+					"mode":    "override_origin",
+					"default": 60,
+					"status_code_ttl": []interface{}{
+						map[string]interface{}{"status_code": 200, "value": 50},
+						map[string]interface{}{
+							"status_code_range": []interface{}{map[string]interface{}{"from": 500}},
+							"value":             -1,
+						},
+					},
+				}},
+				"cache_key": []interface{}{map[string]interface{}{
+					"cache_deception_armor": true,
+					"custom_key": []interface{}{map[string]interface{}{
+						"query_string": []interface{}{map[string]interface{}{
+							"include": []interface{}{"*"},
+						}},
+						"user": []interface{}{map[string]interface{}{
+							"lang": true,
+						}},
+					}},
+				}},
+			}},
+		}},
+	})
+
+	diff, err := r.Diff(ctx, nil, config, m)
+	require.NoError(t, err)
+	state, diags := r.Apply(ctx, nil, diff, m)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	rs, err := rulesetOf(m.defaultClient(), "", testAccCloudflareZoneID, state.ID)
+	require.NoError(t, err)
+	require.Len(t, rs.Rules, 1)
+	parameters := rs.Rules[0].ActionParameters
+	require.NotNil(t, parameters.Cache)
+	assert.False(t, *parameters.Cache)
+	assert.Nil(t, parameters.RespectStrongETags, "unset booleans aren't sent")
+	assert.Equal(t, []rulesetRuleActionParametersStatusCodeTTL{
+		{StatusCode: 200, Value: 50},
+		{StatusCodeRange: &rulesetRuleActionParametersStatusCodeRange{From: 500}, Value: -1},
+	}, parameters.EdgeTTL.StatusCodeTTL)
+	assert.Equal(t, &rulesetRuleActionParametersCustomKeyList{All: true}, parameters.CacheKey.CustomKey.Query.Include)
+	assert.Nil(t, parameters.CacheKey.CustomKey.Query.Exclude)
+	assert.True(t, parameters.CacheKey.CustomKey.User.Lang)
+
+	assert.Equal(t, "false", state.Attributes["rules.0.action_parameters.0.cache"])
+	assert.Equal(t, "1", state.Attributes["rules.0.action_parameters.0.cache_key.0.custom_key.0.query_string.0.include.#"])
+	assert.Equal(t, "500", state.Attributes["rules.0.action_parameters.0.edge_ttl.0.status_code_ttl.1.status_code_range.0.from"])
+
+	diff, err = r.Diff(ctx, state, config, m)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "%v", diff)
+}
+
+func TestRulesetRedirectApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+
+	r := p.ResourcesMap["cloudflare_ruleset"]
+	config := func(actionParameters map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"zone_id": testAccCloudflareZoneID,
+			"name":    "redirects",
+			"kind":    "zone",
+			"phase":   "http_request_dynamic_redirect",
+			"rules": []interface{}{map[string]interface{}{
+				"action":            "redirect",
+				"expression":        `http.host eq "old.example.com"`,
+				"enabled":           true,
+				"action_parameters": []interface{}{actionParameters},
+			}},
+		}
+	}
+	fromValue := map[string]interface{}{
+		"status_code": 308,
+		"target_url": []interface{}{map[string]interface{}{
+			"expression": `concat("https://example.com", http.request.uri.path)`,
+		}},
+		"preserve_query_string": true,
+	}
+	fromList := map[string]interface{}{
+		"name": "redirects",
+		"key":  "http.request.full_uri",
+	}
+
+	state := testMockAPIApply(t, r, m, nil, config(map[string]interface{}{"from_value": []interface{}{fromValue}}))
+
+	rs, err := rulesetOf(m.defaultClient(), "", testAccCloudflareZoneID, state.ID)
+	require.NoError(t, err)
+	require.Len(t, rs.Rules, 1)
+	assert.Equal(t, &rulesetRuleActionParametersFromValue{
+		StatusCode:          308,
+		TargetURL:           rulesetRuleActionParametersTargetURL{Expression: `concat("https://example.com", http.request.uri.path)`},
+		PreserveQueryString: true,
+	}, rs.Rules[0].ActionParameters.FromValue)
+	assert.Equal(t, "308", state.Attributes["rules.0.action_parameters.0.from_value.0.status_code"])
+
+	// Rulesets without redirects, such as those written before from_value and
+	// from_list were added, have no redirect in state.
+	state = testMockAPIApply(t, r, m, state, config(map[string]interface{}{"from_list": []interface{}{fromList}}))
+	assert.Equal(t, "0", state.Attributes["rules.0.action_parameters.0.from_value.#"])
+	assert.Equal(t, "http.request.full_uri", state.Attributes["rules.0.action_parameters.0.from_list.0.key"])
+
+	_, err = testMockAPIApplyE(t, r, m, state, config(map[string]interface{}{
+		"from_value": []interface{}{fromValue},
+		"from_list":  []interface{}{fromList},
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rule 0 can't redirect both from_value and from_list")
+
+	fromValue["target_url"] = []interface{}{map[string]interface{}{
+		"value":      "https://example.com",
+		"expression": "http.request.full_uri",
+	}}
+	_, err = testMockAPIApplyE(t, r, m, state, config(map[string]interface{}{"from_value": []interface{}{fromValue}}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "target_url of rule 0 must have either a value or an expression")
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareSecondaryDNSPeer() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareSecondaryDNSPeerSchema(),
+		CreateContext: resourceCloudflareSecondaryDNSPeerCreate,
+		ReadContext:   resourceCloudflareSecondaryDNSPeerRead,
+		UpdateContext: resourceCloudflareSecondaryDNSPeerUpdate,
+		DeleteContext: resourceCloudflareSecondaryDNSPeerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareSecondaryDNSPeerImport,
+		},
+		Description: "Provides a Cloudflare resource to manage the peers (primary nameservers) that secondary DNS zones are transferred in from.",
+	}
+}
+
+func resourceCloudflareSecondaryDNSPeerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	peer, err := client.CreateSecondaryDNSPrimary(ctx, accountID, buildSecondaryDNSPeer(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating secondary DNS peer %q: %w", d.Get("name").(string), err))
+	}
+
+	d.SetId(peer.ID)
+
+	return resourceCloudflareSecondaryDNSPeerRead(ctx, d, meta)
+}
+
+func resourceCloudflareSecondaryDNSPeerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	peer, err := client.GetSecondaryDNSPrimary(ctx, accountID, d.Id())
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Secondary DNS peer %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error finding secondary DNS peer %q: %w", d.Id(), err))
+	}
+
+	d.Set("name", peer.Name)
+	d.Set("ip", peer.IP)
+	d.Set("port", peer.Port)
+	d.Set("ixfr_enable", peer.IxfrEnable)
+	d.Set("tsig_id", peer.TsigID)
+
+	return nil
+}
+
+func resourceCloudflareSecondaryDNSPeerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	peer := buildSecondaryDNSPeer(d)
+	peer.ID = d.Id()
+
+	_, err := client.UpdateSecondaryDNSPrimary(ctx, accountID, peer)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating secondary DNS peer %q: %w", d.Id(), err))
+	}
+
+	return resourceCloudflareSecondaryDNSPeerRead(ctx, d, meta)
+}
+
+func resourceCloudflareSecondaryDNSPeerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting Cloudflare secondary DNS peer: id %s", d.Id()))
+
+	// DeleteSecondaryDNSPrimary in cloudflare-go targets the zone scoped path
+	// rather than the account scoped one peers live under so the request is
+	// made directly instead.
+	_, err := client.Raw(http.MethodDelete, fmt.Sprintf("/accounts/%s/secondary_dns/primaries/%s", accountID, d.Id()), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting secondary DNS peer %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareSecondaryDNSPeerImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 2)
+
+	if len(attributes) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/peerID\"", d.Id())
+	}
+
+	accountID, peerID := attributes[0], attributes[1]
+	d.SetId(peerID)
+	d.Set("account_id", accountID)
+
+	resourceCloudflareSecondaryDNSPeerRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildSecondaryDNSPeer(d *schema.ResourceData) cloudflare.SecondaryDNSPrimary {
+	return cloudflare.SecondaryDNSPrimary{
+		Name:       d.Get("name").(string),
+		IP:         d.Get("ip").(string),
+		Port:       d.Get("port").(int),
+		IxfrEnable: d.Get("ixfr_enable").(bool),
+		TsigID:     d.Get("tsig_id").(string),
+	}
+}
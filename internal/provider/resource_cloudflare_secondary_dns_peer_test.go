@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudflareSecondaryDNSPeer_Basic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_secondary_dns_peer.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareSecondaryDNSPeerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareSecondaryDNSPeerConfig(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "account_id", accountID),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "ip", "192.0.2.53"),
+					resource.TestCheckResourceAttr(name, "port", "53"),
+					resource.TestCheckResourceAttr(name, "ixfr_enable", "false"),
+					resource.TestCheckResourceAttr(name, "tsig_id", ""),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareSecondaryDNSPeerConfigWithTSIG(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "port", "5353"),
+					resource.TestCheckResourceAttr(name, "ixfr_enable", "true"),
+					resource.TestCheckResourceAttrPair(name, "tsig_id", "cloudflare_secondary_dns_tsig."+rnd, "id"),
+				),
+			},
+			{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: fmt.Sprintf("%s/", accountID),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareSecondaryDNSPeerConfig(name, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_secondary_dns_peer" "%[1]s" {
+  account_id = "%[2]s"
+  name       = "%[1]s"
+  ip         = "192.0.2.53"
+}`, name, accountID)
+}
+
+func testAccCheckCloudflareSecondaryDNSPeerConfigWithTSIG(name, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_secondary_dns_tsig" "%[1]s" {
+  account_id = "%[2]s"
+  name       = "%[1]s"
+  algo       = "hmac-sha256."
+  secret     = "caf79a7804b04337c9c66ccd7bef9190a1e1679b5dd03d8aa10f7ad45e1a9dab92b417896c15d4d007c7c14194538d2a5d0feffdecc5a7f0e1c570cfa700837c"
+}
+
+resource "cloudflare_secondary_dns_peer" "%[1]s" {
+  account_id  = "%[2]s"
+  name        = "%[1]s"
+  ip          = "192.0.2.53"
+  port        = 5353
+  ixfr_enable = true
+  tsig_id     = cloudflare_secondary_dns_tsig.%[1]s.id
+}`, name, accountID)
+}
+
+func testAccCheckCloudflareSecondaryDNSPeerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*providerMeta).defaultClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_secondary_dns_peer" {
+			continue
+		}
+
+		_, err := client.GetSecondaryDNSPrimary(context.Background(), rs.Primary.Attributes["account_id"], rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("secondary DNS peer %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareSecondaryDNSTSIG() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareSecondaryDNSTSIGSchema(),
+		CreateContext: resourceCloudflareSecondaryDNSTSIGCreate,
+		ReadContext:   resourceCloudflareSecondaryDNSTSIGRead,
+		UpdateContext: resourceCloudflareSecondaryDNSTSIGUpdate,
+		DeleteContext: resourceCloudflareSecondaryDNSTSIGDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareSecondaryDNSTSIGImport,
+		},
+		Description: "Provides a Cloudflare resource to manage TSIG keys used to authenticate zone transfers from secondary DNS peers.",
+	}
+}
+
+func resourceCloudflareSecondaryDNSTSIGCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	tsig, err := client.CreateSecondaryDNSTSIG(ctx, accountID, buildSecondaryDNSTSIG(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating secondary DNS TSIG %q: %w", d.Get("name").(string), err))
+	}
+
+	d.SetId(tsig.ID)
+
+	return resourceCloudflareSecondaryDNSTSIGRead(ctx, d, meta)
+}
+
+func resourceCloudflareSecondaryDNSTSIGRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	tsig, err := client.GetSecondaryDNSTSIG(ctx, accountID, d.Id())
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Secondary DNS TSIG %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error finding secondary DNS TSIG %q: %w", d.Id(), err))
+	}
+
+	d.Set("name", tsig.Name)
+	d.Set("algo", tsig.Algo)
+
+	// The secret is only returned on some endpoints so keep whatever is in
+	// the configuration when it is omitted.
+	if tsig.Secret != "" {
+		d.Set("secret", tsig.Secret)
+	}
+
+	return nil
+}
+
+func resourceCloudflareSecondaryDNSTSIGUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	tsig := buildSecondaryDNSTSIG(d)
+	tsig.ID = d.Id()
+
+	_, err := client.UpdateSecondaryDNSTSIG(ctx, accountID, tsig)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating secondary DNS TSIG %q: %w", d.Id(), err))
+	}
+
+	return resourceCloudflareSecondaryDNSTSIGRead(ctx, d, meta)
+}
+
+func resourceCloudflareSecondaryDNSTSIGDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting Cloudflare secondary DNS TSIG: id %s", d.Id()))
+
+	err := client.DeleteSecondaryDNSTSIG(ctx, accountID, d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting secondary DNS TSIG %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareSecondaryDNSTSIGImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 2)
+
+	if len(attributes) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/tsigID\"", d.Id())
+	}
+
+	accountID, tsigID := attributes[0], attributes[1]
+	d.SetId(tsigID)
+	d.Set("account_id", accountID)
+
+	resourceCloudflareSecondaryDNSTSIGRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func buildSecondaryDNSTSIG(d *schema.ResourceData) cloudflare.SecondaryDNSTSIG {
+	return cloudflare.SecondaryDNSTSIG{
+		Name:   d.Get("name").(string),
+		Algo:   d.Get("algo").(string),
+		Secret: d.Get("secret").(string),
+	}
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudflareSecondaryDNSTSIG_Basic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_secondary_dns_tsig.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareSecondaryDNSTSIGDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareSecondaryDNSTSIGConfig(rnd, accountID, "hmac-sha256."),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "account_id", accountID),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "algo", "hmac-sha256."),
+					resource.TestCheckResourceAttr(name, "secret", "caf79a7804b04337c9c66ccd7bef9190a1e1679b5dd03d8aa10f7ad45e1a9dab92b417896c15d4d007c7c14194538d2a5d0feffdecc5a7f0e1c570cfa700837c"),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareSecondaryDNSTSIGConfig(rnd, accountID, "hmac-sha512."),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "algo", "hmac-sha512."),
+				),
+			},
+			{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: fmt.Sprintf("%s/", accountID),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareSecondaryDNSTSIGConfig(name, accountID, algo string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_secondary_dns_tsig" "%[1]s" {
+  account_id = "%[2]s"
+  name       = "%[1]s"
+  algo       = "%[3]s"
+  secret     = "caf79a7804b04337c9c66ccd7bef9190a1e1679b5dd03d8aa10f7ad45e1a9dab92b417896c15d4d007c7c14194538d2a5d0feffdecc5a7f0e1c570cfa700837c"
+}`, name, accountID, algo)
+}
+
+func testAccCheckCloudflareSecondaryDNSTSIGDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*providerMeta).defaultClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_secondary_dns_tsig" {
+			continue
+		}
+
+		_, err := client.GetSecondaryDNSTSIG(context.Background(), rs.Primary.Attributes["account_id"], rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("secondary DNS TSIG %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
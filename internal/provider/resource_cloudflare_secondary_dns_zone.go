@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareSecondaryDNSZone() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareSecondaryDNSZoneSchema(),
+		CreateContext: resourceCloudflareSecondaryDNSZoneCreate,
+		ReadContext:   resourceCloudflareSecondaryDNSZoneRead,
+		UpdateContext: resourceCloudflareSecondaryDNSZoneUpdate,
+		DeleteContext: resourceCloudflareSecondaryDNSZoneDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "Provides a Cloudflare resource to manage the secondary DNS configuration of a zone, transferring it in from one or more peers.",
+	}
+}
+
+func resourceCloudflareSecondaryDNSZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	zoneID := d.Get("zone_id").(string)
+
+	tflog.Info(ctx, fmt.Sprintf("Creating Cloudflare secondary DNS zone: zone ID %s", zoneID))
+
+	_, err := client.CreateSecondaryDNSZone(ctx, zoneID, buildSecondaryDNSZone(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating secondary DNS zone %q: %w", zoneID, err))
+	}
+
+	d.SetId(zoneID)
+
+	if d.Get("force_axfr").(bool) {
+		if err := client.ForceSecondaryDNSZoneAXFR(ctx, zoneID); err != nil {
+			return diag.FromErr(fmt.Errorf("error forcing AXFR for secondary DNS zone %q: %w", zoneID, err))
+		}
+	}
+
+	return resourceCloudflareSecondaryDNSZoneRead(ctx, d, meta)
+}
+
+func resourceCloudflareSecondaryDNSZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	zoneID := d.Get("zone_id").(string)
+
+	// In the event zoneID isn't populated at this point, we're likely to be
+	// performing an import so set the zoneID to the d.Id() from the passthrough.
+	if zoneID == "" {
+		zoneID = d.Id()
+	}
+
+	zone, err := client.GetSecondaryDNSZone(ctx, zoneID)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Secondary DNS zone %s no longer exists", zoneID))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error finding secondary DNS zone %q: %w", zoneID, err))
+	}
+
+	d.Set("zone_id", zoneID)
+	d.Set("name", zone.Name)
+	d.Set("peers", zone.Primaries)
+	d.Set("auto_refresh_seconds", zone.AutoRefreshSeconds)
+	d.Set("soa_serial", zone.SoaSerial)
+	d.Set("created_time", zone.CreatedTime.Format(time.RFC3339))
+	d.Set("checked_time", zone.CheckedTime.Format(time.RFC3339))
+	d.Set("modified_time", zone.ModifiedTime.Format(time.RFC3339))
+
+	return nil
+}
+
+func resourceCloudflareSecondaryDNSZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	zoneID := d.Get("zone_id").(string)
+
+	tflog.Info(ctx, fmt.Sprintf("Updating Cloudflare secondary DNS zone: zone ID %s", zoneID))
+
+	if d.HasChanges("name", "peers", "auto_refresh_seconds") {
+		_, err := client.UpdateSecondaryDNSZone(ctx, zoneID, buildSecondaryDNSZone(d))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating secondary DNS zone %q: %w", zoneID, err))
+		}
+	}
+
+	if d.Get("force_axfr").(bool) {
+		if err := client.ForceSecondaryDNSZoneAXFR(ctx, zoneID); err != nil {
+			return diag.FromErr(fmt.Errorf("error forcing AXFR for secondary DNS zone %q: %w", zoneID, err))
+		}
+	}
+
+	return resourceCloudflareSecondaryDNSZoneRead(ctx, d, meta)
+}
+
+func resourceCloudflareSecondaryDNSZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+
+	zoneID := d.Get("zone_id").(string)
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting Cloudflare secondary DNS zone: zone ID %s", zoneID))
+
+	err := client.DeleteSecondaryDNSZone(ctx, zoneID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting secondary DNS zone %q: %w", zoneID, err))
+	}
+
+	return nil
+}
+
+func buildSecondaryDNSZone(d *schema.ResourceData) cloudflare.SecondaryDNSZone {
+	return cloudflare.SecondaryDNSZone{
+		Name:               d.Get("name").(string),
+		Primaries:          expandInterfaceToStringList(d.Get("peers").(*schema.Set).List()),
+		AutoRefreshSeconds: d.Get("auto_refresh_seconds").(int),
+	}
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudflareSecondaryDNSZone_Basic(t *testing.T) {
+	skipSecondaryDNSTestForNonConfiguredDefaultZone(t)
+
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_secondary_dns_zone.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareSecondaryDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareSecondaryDNSZoneConfig(rnd, accountID, zoneID, domain, 86400, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "zone_id", zoneID),
+					resource.TestCheckResourceAttr(name, "name", domain),
+					resource.TestCheckResourceAttr(name, "auto_refresh_seconds", "86400"),
+					resource.TestCheckResourceAttr(name, "peers.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(name, "peers.*", "cloudflare_secondary_dns_peer."+rnd, "id"),
+					resource.TestCheckResourceAttrSet(name, "created_time"),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareSecondaryDNSZoneConfig(rnd, accountID, zoneID, domain, 3600, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "auto_refresh_seconds", "3600"),
+					resource.TestCheckResourceAttr(name, "force_axfr", "true"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_axfr"},
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareSecondaryDNSZoneConfig(name, accountID, zoneID, domain string, autoRefresh int, forceAXFR bool) string {
+	return fmt.Sprintf(`
+resource "cloudflare_secondary_dns_peer" "%[1]s" {
+  account_id = "%[2]s"
+  name       = "%[1]s"
+  ip         = "192.0.2.53"
+}
+
+resource "cloudflare_secondary_dns_zone" "%[1]s" {
+  zone_id              = "%[3]s"
+  name                 = "%[4]s"
+  peers                = [cloudflare_secondary_dns_peer.%[1]s.id]
+  auto_refresh_seconds = %[5]d
+  force_axfr           = %[6]t
+}`, name, accountID, zoneID, domain, autoRefresh, forceAXFR)
+}
+
+func testAccCheckCloudflareSecondaryDNSZoneDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*providerMeta).defaultClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudflare_secondary_dns_zone" {
+			continue
+		}
+
+		_, err := client.GetSecondaryDNSZone(context.Background(), rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("secondary DNS zone configuration for %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
@@ -27,7 +27,10 @@ func resourceCloudflareSpectrumApplication() *schema.Resource {
 }
 
 func resourceCloudflareSpectrumApplicationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	newSpectrumApp := applicationFromResource(d)
 	zoneID := d.Get("zone_id").(string)
@@ -51,7 +54,10 @@ func resourceCloudflareSpectrumApplicationCreate(ctx context.Context, d *schema.
 }
 
 func resourceCloudflareSpectrumApplicationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	application := applicationFromResource(d)
@@ -67,7 +73,10 @@ func resourceCloudflareSpectrumApplicationUpdate(ctx context.Context, d *schema.
 }
 
 func resourceCloudflareSpectrumApplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	applicationID := d.Id()
 
@@ -132,7 +141,10 @@ func resourceCloudflareSpectrumApplicationRead(ctx context.Context, d *schema.Re
 }
 
 func resourceCloudflareSpectrumApplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	applicationID := d.Id()
 
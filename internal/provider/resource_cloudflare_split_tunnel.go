@@ -25,7 +25,10 @@ func resourceCloudflareSplitTunnel() *schema.Resource {
 }
 
 func resourceCloudflareSplitTunnelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	mode := d.Get("mode").(string)
 
@@ -42,7 +45,10 @@ func resourceCloudflareSplitTunnelRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCloudflareSplitTunnelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	mode := d.Get("mode").(string)
 
@@ -66,7 +72,10 @@ func resourceCloudflareSplitTunnelUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareSplitTunnelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	mode := d.Get("mode").(string)
 
@@ -26,7 +26,10 @@ func resourceCloudflareStaticRoute() *schema.Resource {
 }
 
 func resourceCloudflareStaticRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	newStaticRoute, err := client.CreateMagicTransitStaticRoute(ctx, accountID, staticRouteFromResource(d))
@@ -57,7 +60,10 @@ func resourceCloudflareStaticRouteImport(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareStaticRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	staticRoute, err := client.GetMagicTransitStaticRoute(ctx, accountID, d.Id())
@@ -91,7 +97,10 @@ func resourceCloudflareStaticRouteRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCloudflareStaticRouteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	_, err := client.UpdateMagicTransitStaticRoute(ctx, accountID, d.Id(), staticRouteFromResource(d))
@@ -103,7 +112,10 @@ func resourceCloudflareStaticRouteUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareStaticRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	tflog.Info(ctx, fmt.Sprintf("Deleting Static Route:  %s", d.Id()))
@@ -26,7 +26,10 @@ func resourceCloudflareTeamsAccount() *schema.Resource {
 }
 
 func resourceCloudflareTeamsAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	configuration, err := client.TeamsAccountConfiguration(ctx, accountID)
@@ -97,7 +100,10 @@ func resourceCloudflareTeamsAccountRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareTeamsAccountUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	blockPageConfig := inflateBlockPageConfig(d.Get("block_page"))
 	fipsConfig := inflateFIPSConfig(d.Get("fips"))
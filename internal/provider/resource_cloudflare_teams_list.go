@@ -21,11 +21,15 @@ func resourceCloudflareTeamsList() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceCloudflareTeamsListImport,
 		},
+		CustomizeDiff: resourceCloudflareTeamsListItemsFileHash,
 	}
 }
 
 func resourceCloudflareTeamsListCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	newTeamsList := cloudflare.TeamsList{
 		Name:        d.Get("name").(string),
@@ -33,12 +37,22 @@ func resourceCloudflareTeamsListCreate(ctx context.Context, d *schema.ResourceDa
 		Description: d.Get("description").(string),
 	}
 
-	itemValues := d.Get("items").(*schema.Set).List()
-	for _, v := range itemValues {
-		newTeamsList.Items = append(newTeamsList.Items, cloudflare.TeamsListItem{Value: v.(string)})
+	itemValues, err := teamsListConfiguredItems(d)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Creating Cloudflare Teams List from struct: %+v", newTeamsList))
+	// The list is created with the first chunk of its items, and the rest are
+	// appended to it.
+	firstChunk := itemValues
+	if len(firstChunk) > listItemsChunkSize {
+		firstChunk = firstChunk[:listItemsChunkSize]
+	}
+	for _, v := range firstChunk {
+		newTeamsList.Items = append(newTeamsList.Items, cloudflare.TeamsListItem{Value: v})
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating Cloudflare Teams List %q with %d items", newTeamsList.Name, len(itemValues)))
 
 	accountID := d.Get("account_id").(string)
 
@@ -49,11 +63,18 @@ func resourceCloudflareTeamsListCreate(ctx context.Context, d *schema.ResourceDa
 
 	d.SetId(list.ID)
 
+	if err := patchTeamsListItems(ctx, client, accountID, d.Id(), itemValues[len(firstChunk):], nil); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceCloudflareTeamsListRead(ctx, d, meta)
 }
 
 func resourceCloudflareTeamsListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	list, err := client.TeamsList(ctx, accountID, d.Id())
@@ -70,20 +91,29 @@ func resourceCloudflareTeamsListRead(ctx context.Context, d *schema.ResourceData
 	d.Set("type", list.Type)
 	d.Set("description", list.Description)
 
-	listItems, _, err := client.TeamsListItems(ctx, cloudflare.TeamsListItemsParams{
-		AccountID: accountID,
-		ListID:    d.Id(),
-	})
+	listItems, err := teamsListAllItems(ctx, client, accountID, d.Id())
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error finding Teams List %q: %w", d.Id(), err))
 	}
+
+	// Items read from a file are only tracked by their hash.
+	if d.Get("items_file").(string) != "" {
+		d.Set("items", []string{})
+		d.Set("items_file_hash", hashTeamsListItems(convertListItemsToSchema(listItems)))
+		return nil
+	}
+
 	d.Set("items", convertListItemsToSchema(listItems))
+	d.Set("items_file_hash", "")
 
 	return nil
 }
 
 func resourceCloudflareTeamsListUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	updatedTeamsList := cloudflare.TeamsList{
 		ID:          d.Id(),
@@ -104,25 +134,31 @@ func resourceCloudflareTeamsListUpdate(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(fmt.Errorf("failed to find Teams List ID in update response; resource was empty"))
 	}
 
-	if d.HasChange("items") {
-		oldItemsIface, newItemsIface := d.GetChange("items")
-		oldItems := oldItemsIface.(*schema.Set).List()
-		newItems := newItemsIface.(*schema.Set).List()
-		patchTeamsList := cloudflare.PatchTeamsList{ID: d.Id()}
-		setListItemDiff(&patchTeamsList, oldItems, newItems)
-		l, err := client.PatchTeamsList(ctx, accountID, patchTeamsList)
+	if d.HasChange("items") || d.HasChange("items_file_hash") {
+		itemValues, err := teamsListConfiguredItems(d)
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("error updating Teams List for account %q: %w", accountID, err))
+			return diag.FromErr(err)
 		}
 
-		teamsList.Items = l.Items
+		listItems, err := teamsListAllItems(ctx, client, accountID, d.Id())
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error finding Teams List %q: %w", d.Id(), err))
+		}
+
+		appendValues, removeValues := teamsListItemDiff(convertListItemsToSchema(listItems), itemValues)
+		if err := patchTeamsListItems(ctx, client, accountID, d.Id(), appendValues, removeValues); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceCloudflareTeamsListRead(ctx, d, meta)
 }
 
 func resourceCloudflareTeamsListDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	appID := d.Id()
 	accountID := d.Get("account_id").(string)
 
@@ -157,23 +193,138 @@ func resourceCloudflareTeamsListImport(ctx context.Context, d *schema.ResourceDa
 	return []*schema.ResourceData{d}, nil
 }
 
-func setListItemDiff(patchList *cloudflare.PatchTeamsList, oldItems, newItems []interface{}) {
+// resourceCloudflareTeamsListItemsFileHash sets the hash of the items of
+// `items_file`, so that changes to the file are planned.
+func resourceCloudflareTeamsListItemsFileHash(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("items_file") {
+		return d.SetNewComputed("items_file_hash")
+	}
+
+	path := d.Get("items_file").(string)
+	if path == "" {
+		if d.Get("items_file_hash").(string) != "" {
+			return d.SetNew("items_file_hash", "")
+		}
+		return nil
+	}
+
+	itemValues, err := teamsListItemsFromFile(path)
+	if err != nil {
+		return err
+	}
+
+	return d.SetNew("items_file_hash", hashTeamsListItems(itemValues))
+}
+
+// teamsListConfiguredItems returns the values of the items configured for a
+// list, either with `items` or in `items_file`.
+func teamsListConfiguredItems(d *schema.ResourceData) ([]string, error) {
+	if path := d.Get("items_file").(string); path != "" {
+		return teamsListItemsFromFile(path)
+	}
+
+	var itemValues []string
+	for _, v := range d.Get("items").(*schema.Set).List() {
+		itemValues = append(itemValues, v.(string))
+	}
+	return itemValues, nil
+}
+
+func teamsListItemsFromFile(path string) ([]string, error) {
+	entries, err := readListItemsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	itemValues := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Comment != "" {
+			return nil, fmt.Errorf("items of Teams Lists can't have comments, but %q in list items file %q does", entry.Value, path)
+		}
+		itemValues = append(itemValues, entry.Value)
+	}
+	return itemValues, nil
+}
+
+func hashTeamsListItems(itemValues []string) string {
+	entries := make([]listItemsFileEntry, 0, len(itemValues))
+	for _, v := range itemValues {
+		entries = append(entries, listItemsFileEntry{Value: v})
+	}
+	return hashListItemsFileEntries(entries)
+}
+
+// teamsListAllItems returns every item of a list, which are paginated.
+func teamsListAllItems(ctx context.Context, client *cloudflare.API, accountID, listID string) ([]cloudflare.TeamsListItem, error) {
+	var listItems []cloudflare.TeamsListItem
+
+	for page := 1; ; page++ {
+		items, resultInfo, err := client.TeamsListItems(ctx, cloudflare.TeamsListItemsParams{
+			AccountID:         accountID,
+			ListID:            listID,
+			PaginationOptions: cloudflare.PaginationOptions{Page: page, PerPage: listItemsChunkSize},
+		})
+		if err != nil {
+			return nil, err
+		}
+		listItems = append(listItems, items...)
+
+		if page >= resultInfo.TotalPages {
+			return listItems, nil
+		}
+	}
+}
+
+// teamsListItemDiff returns the values to append to and remove from a list
+// holding the remote values for it to hold the desired ones instead.
+func teamsListItemDiff(remote, desired []string) (appendValues, removeValues []string) {
 	counts := make(map[string]int)
-	for _, val := range newItems {
-		counts[val.(string)] += 1
+	for _, val := range desired {
+		counts[val] = 1
+	}
+	for _, val := range remote {
+		counts[val] -= 1
 	}
-	for _, val := range oldItems {
-		counts[val.(string)] -= 1
+
+	for _, val := range desired {
+		if counts[val] > 0 {
+			appendValues = append(appendValues, val)
+			counts[val] = 0
+		}
+	}
+	for _, val := range remote {
+		if counts[val] < 0 {
+			removeValues = append(removeValues, val)
+			counts[val] = 0
+		}
 	}
 
-	for key, val := range counts {
-		if val > 0 {
-			patchList.Append = append(patchList.Append, cloudflare.TeamsListItem{Value: key})
+	return appendValues, removeValues
+}
+
+// patchTeamsListItems appends and removes items of a list in chunks.
+func patchTeamsListItems(ctx context.Context, client *cloudflare.API, accountID, listID string, appendValues, removeValues []string) error {
+	tflog.Debug(ctx, fmt.Sprintf("Appending %d and removing %d items of Teams List %s", len(appendValues), len(removeValues), listID))
+
+	for start := 0; start < len(appendValues) || start < len(removeValues); start += listItemsChunkSize {
+		patchTeamsList := cloudflare.PatchTeamsList{
+			ID:     listID,
+			Append: []cloudflare.TeamsListItem{},
+			Remove: []string{},
+		}
+		for i := start; i < len(appendValues) && i < start+listItemsChunkSize; i++ {
+			patchTeamsList.Append = append(patchTeamsList.Append, cloudflare.TeamsListItem{Value: appendValues[i]})
 		}
-		if val < 0 {
-			patchList.Remove = append(patchList.Remove, key)
+		for i := start; i < len(removeValues) && i < start+listItemsChunkSize; i++ {
+			patchTeamsList.Remove = append(patchTeamsList.Remove, removeValues[i])
+		}
+
+		if _, err := client.PatchTeamsList(ctx, accountID, patchTeamsList); err != nil {
+			return fmt.Errorf("error updating items of Teams List %q for account %q: %w", listID, accountID, err)
 		}
 	}
+
+	return nil
 }
 
 func convertListItemsToSchema(listItems []cloudflare.TeamsListItem) []string {
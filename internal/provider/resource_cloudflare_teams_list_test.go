@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
-	"github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccCloudflareTeamsListBasic(t *testing.T) {
@@ -102,7 +106,7 @@ resource "cloudflare_teams_list" "%[1]s" {
 }
 
 func testAccCheckCloudflareTeamsListDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*cloudflare.API)
+	client := testAccProvider.Meta().(*providerMeta).defaultClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "cloudflare_teams_list" {
@@ -117,3 +121,56 @@ func testAccCheckCloudflareTeamsListDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestTeamsListItemsFile(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "serials.txt")
+	writeItems := func(from, to int) {
+		var lines []string
+		for i := from; i < to; i++ {
+			lines = append(lines, fmt.Sprintf("serial-%05d", i))
+		}
+		require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644))
+	}
+
+	r := p.ResourcesMap["cloudflare_teams_list"]
+	config := map[string]interface{}{
+		"account_id": testAccCloudflareAccountID,
+		"name":       "serials",
+		"type":       "SERIAL",
+		"items_file": path,
+	}
+	itemValues := func(state *terraform.InstanceState) []string {
+		items, err := teamsListAllItems(ctx, m.defaultClient(), testAccCloudflareAccountID, state.ID)
+		require.NoError(t, err)
+		return convertListItemsToSchema(items)
+	}
+
+	// More items than are created with the list or read in a single page.
+	writeItems(0, 2500)
+	state := testMockAPIApply(t, r, m, nil, config)
+	assert.Equal(t, "0", state.Attributes["items.#"])
+	assert.Len(t, itemValues(state), 2500)
+
+	writeItems(1000, 3500)
+	state = testMockAPIApply(t, r, m, state, config)
+	values := itemValues(state)
+	assert.Len(t, values, 2500)
+	assert.NotContains(t, values, "serial-00999")
+	assert.Contains(t, values, "serial-03499")
+
+	require.NoError(t, os.WriteFile(path, []byte("serial-1, with a comment\n"), 0644))
+	_, err := testMockAPIDiff(t, r, m, state, config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("items of Teams Lists can't have comments, but \"serial-1\" in list items file %q does", path))
+}
+
+func TestTeamsListItemDiff(t *testing.T) {
+	appendValues, removeValues := teamsListItemDiff([]string{"a", "b", "b", "c"}, []string{"c", "d", "a"})
+	assert.Equal(t, []string{"d"}, appendValues)
+	assert.Equal(t, []string{"b"}, removeValues)
+}
@@ -25,7 +25,10 @@ func resourceCloudflareTeamsLocation() *schema.Resource {
 }
 
 func resourceCloudflareTeamsLocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	location, err := client.TeamsLocation(ctx, accountID, d.Id())
@@ -66,7 +69,10 @@ func resourceCloudflareTeamsLocationRead(ctx context.Context, d *schema.Resource
 	return nil
 }
 func resourceCloudflareTeamsLocationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	accountID := d.Get("account_id").(string)
 	networks, err := inflateTeamsLocationNetworks(d.Get("networks"))
@@ -91,7 +97,10 @@ func resourceCloudflareTeamsLocationCreate(ctx context.Context, d *schema.Resour
 	return resourceCloudflareTeamsLocationRead(ctx, d, meta)
 }
 func resourceCloudflareTeamsLocationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	networks, err := inflateTeamsLocationNetworks(d.Get("networks"))
 	if err != nil {
@@ -116,7 +125,10 @@ func resourceCloudflareTeamsLocationUpdate(ctx context.Context, d *schema.Resour
 }
 
 func resourceCloudflareTeamsLocationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	id := d.Id()
 	accountID := d.Get("account_id").(string)
 
@@ -25,7 +25,10 @@ func resourceCloudflareTeamsProxyEndpoint() *schema.Resource {
 }
 
 func resourceCloudflareTeamsProxyEndpointRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	endpoint, err := client.TeamsProxyEndpoint(ctx, accountID, d.Id())
@@ -54,7 +57,10 @@ func resourceCloudflareTeamsProxyEndpointRead(ctx context.Context, d *schema.Res
 }
 
 func resourceCloudflareTeamsProxyEndpointCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	accountID := d.Get("account_id").(string)
 	newProxyEndpoint := cloudflare.TeamsProxyEndpoint{
@@ -74,7 +80,10 @@ func resourceCloudflareTeamsProxyEndpointCreate(ctx context.Context, d *schema.R
 }
 
 func resourceCloudflareTeamsProxyEndpointUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	updatedProxyEndpoint := cloudflare.TeamsProxyEndpoint{
 		ID:   d.Id(),
@@ -97,7 +106,10 @@ func resourceCloudflareTeamsProxyEndpointUpdate(ctx context.Context, d *schema.R
 }
 
 func resourceCloudflareTeamsProxyEndpointDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	id := d.Id()
 	accountID := d.Get("account_id").(string)
 
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tieredCacheTypes are the topologies of tiered caches. Both smart and
+// generic tiered caching are Argo tiered caching, with smart topology
+// enabled or not.
+var tieredCacheTypes = []string{"smart", "generic", "off"}
+
+// cloudflare-go doesn't support smart topology nor regional tiered cache, so
+// they're managed with raw requests.
+const (
+	tieredCacheSmartTopologySetting = "cache/tiered_cache_smart_topology_enable"
+	regionalTieredCacheSetting      = "cache/regional_tiered_cache"
+)
+
+type zoneCacheSetting struct {
+	Value string `json:"value"`
+}
+
+func resourceCloudflareTieredCache() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareTieredCacheSchema(),
+		CreateContext: resourceCloudflareTieredCacheCreate,
+		ReadContext:   resourceCloudflareTieredCacheRead,
+		UpdateContext: resourceCloudflareTieredCacheUpdate,
+		DeleteContext: resourceCloudflareTieredCacheDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareTieredCacheImport,
+		},
+		CustomizeDiff: resourceCloudflareTieredCacheValidate,
+	}
+}
+
+func resourceCloudflareTieredCacheCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(d.Get("zone_id").(string))
+
+	return resourceCloudflareTieredCacheUpdate(ctx, d, meta)
+}
+
+func resourceCloudflareTieredCacheRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	tieredCaching, err := client.ArgoTieredCaching(ctx, zoneID)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Zone %s no longer exists", zoneID))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading tiered caching setting of zone %q: %w", zoneID, err))
+	}
+	smartTopology, err := zoneCacheSettingOf(client, zoneID, tieredCacheSmartTopologySetting)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading smart tiered cache setting of zone %q: %w", zoneID, err))
+	}
+
+	switch {
+	case tieredCaching.Value != "on":
+		d.Set("cache_type", "off")
+	case smartTopology.Value == "on":
+		d.Set("cache_type", "smart")
+	default:
+		d.Set("cache_type", "generic")
+	}
+
+	regional, err := zoneCacheSettingOf(client, zoneID, regionalTieredCacheSetting)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading regional tiered cache setting of zone %q: %w", zoneID, err))
+	}
+	d.Set("regional_tiered_cache", regional.Value)
+
+	return nil
+}
+
+// Smart topology and regional tiered cache are only enabled after tiered
+// caching, and disabled before it.
+func resourceCloudflareTieredCacheUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+	cacheType := d.Get("cache_type").(string)
+	// Regional tiered cache is only updated when it's configured.
+	var regional string
+	if d.HasChange("regional_tiered_cache") {
+		regional = d.Get("regional_tiered_cache").(string)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Setting tiered cache of zone %s to %s", zoneID, cacheType))
+
+	if regional == "off" {
+		if err := updateZoneCacheSetting(client, zoneID, regionalTieredCacheSetting, "off"); err != nil {
+			return diag.FromErr(fmt.Errorf("error disabling regional tiered cache of zone %q: %w", zoneID, err))
+		}
+	}
+
+	if cacheType == "off" {
+		if err := setTieredCacheOff(ctx, client, zoneID); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		if _, err := client.UpdateArgoTieredCaching(ctx, zoneID, "on"); err != nil {
+			return diag.FromErr(fmt.Errorf("error enabling tiered caching of zone %q: %w", zoneID, err))
+		}
+		smartTopology := "off"
+		if cacheType == "smart" {
+			smartTopology = "on"
+		}
+		if err := updateZoneCacheSetting(client, zoneID, tieredCacheSmartTopologySetting, smartTopology); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating smart tiered cache setting of zone %q: %w", zoneID, err))
+		}
+	}
+
+	if regional == "on" {
+		if err := updateZoneCacheSetting(client, zoneID, regionalTieredCacheSetting, "on"); err != nil {
+			return diag.FromErr(fmt.Errorf("error enabling regional tiered cache of zone %q: %w", zoneID, err))
+		}
+	}
+
+	return resourceCloudflareTieredCacheRead(ctx, d, meta)
+}
+
+func resourceCloudflareTieredCacheDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	// Regional tiered cache is left as is, as the configuration it may have
+	// been set in isn't known on delete and it has no effect without tiered
+	// caching.
+	return diag.FromErr(setTieredCacheOff(ctx, client, zoneID))
+}
+
+func resourceCloudflareTieredCacheImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("zone_id", d.Id())
+
+	resourceCloudflareTieredCacheRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceCloudflareTieredCacheValidate(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Only a configured regional tiered cache is checked, as the one read
+	// into state is left as is when tiered caching is turned off.
+	regional := d.GetRawConfig().GetAttr("regional_tiered_cache")
+	if regional.IsNull() || !regional.IsKnown() {
+		return nil
+	}
+	if regional.AsString() == "on" && d.Get("cache_type").(string) == "off" {
+		return fmt.Errorf("regional tiered cache requires tiered caching, set cache_type to \"smart\" or \"generic\"")
+	}
+	return nil
+}
+
+func setTieredCacheOff(ctx context.Context, client *cloudflare.API, zoneID string) error {
+	if err := updateZoneCacheSetting(client, zoneID, tieredCacheSmartTopologySetting, "off"); err != nil {
+		return fmt.Errorf("error disabling smart tiered cache of zone %q: %w", zoneID, err)
+	}
+	if _, err := client.UpdateArgoTieredCaching(ctx, zoneID, "off"); err != nil {
+		return fmt.Errorf("error disabling tiered caching of zone %q: %w", zoneID, err)
+	}
+	return nil
+}
+
+func zoneCacheSettingOf(client *cloudflare.API, zoneID, setting string) (zoneCacheSetting, error) {
+	var s zoneCacheSetting
+	result, err := client.Raw(http.MethodGet, fmt.Sprintf("/zones/%s/%s", zoneID, setting), nil)
+	if err != nil {
+		return s, err
+	}
+	err = json.Unmarshal(result, &s)
+	return s, err
+}
+
+func updateZoneCacheSetting(client *cloudflare.API, zoneID, setting, value string) error {
+	_, err := client.Raw(http.MethodPatch, fmt.Sprintf("/zones/%s/%s", zoneID, setting), zoneCacheSetting{Value: value})
+	return err
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareTieredCache_Basic(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_tiered_cache.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareTieredCacheConfig(zoneID, rnd, "smart"),
+				Check:  resource.TestCheckResourceAttr(name, "cache_type", "smart"),
+			},
+			{
+				Config: testAccCheckCloudflareTieredCacheConfig(zoneID, rnd, "generic"),
+				Check:  resource.TestCheckResourceAttr(name, "cache_type", "generic"),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     zoneID,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareTieredCacheConfig(zoneID, name, cacheType string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_tiered_cache" "%[2]s" {
+  zone_id    = "%[1]s"
+  cache_type = "%[3]s"
+}`, zoneID, name, cacheType)
+}
+
+func TestTieredCacheApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+	client := m.defaultClient()
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_tiered_cache"]
+	config := func(cacheType, regional string) map[string]interface{} {
+		raw := map[string]interface{}{
+			"zone_id":    testAccCloudflareZoneID,
+			"cache_type": cacheType,
+		}
+		if regional != "" {
+			raw["regional_tiered_cache"] = regional
+		}
+		return raw
+	}
+	settings := func() (string, string, string) {
+		tieredCaching, err := client.ArgoTieredCaching(ctx, testAccCloudflareZoneID)
+		require.NoError(t, err)
+		smartTopology, err := zoneCacheSettingOf(client, testAccCloudflareZoneID, tieredCacheSmartTopologySetting)
+		require.NoError(t, err)
+		regional, err := zoneCacheSettingOf(client, testAccCloudflareZoneID, regionalTieredCacheSetting)
+		require.NoError(t, err)
+		return tieredCaching.Value, smartTopology.Value, regional.Value
+	}
+
+	state := testMockAPIApply(t, r, m, nil, config("smart", "on"))
+	assert.Equal(t, testAccCloudflareZoneID, state.ID)
+	tieredCaching, smartTopology, regional := settings()
+	assert.Equal(t, []string{"on", "on", "on"}, []string{tieredCaching, smartTopology, regional})
+
+	state = testMockAPIApply(t, r, m, state, config("generic", "on"))
+	tieredCaching, smartTopology, _ = settings()
+	assert.Equal(t, []string{"on", "off"}, []string{tieredCaching, smartTopology})
+
+	_, err := testMockAPIDiff(t, r, m, state, config("off", "on"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `regional tiered cache requires tiered caching, set cache_type to "smart" or "generic"`)
+
+	state = testMockAPIApply(t, r, m, state, config("off", "off"))
+	assert.Equal(t, "off", state.Attributes["cache_type"])
+	tieredCaching, smartTopology, regional = settings()
+	assert.Equal(t, []string{"off", "off", "off"}, []string{tieredCaching, smartTopology, regional})
+
+	// Regional tiered cache that isn't configured doesn't stop tiered caching
+	// from being turned off.
+	state = testMockAPIApply(t, r, m, state, config("generic", "on"))
+	state = testMockAPIApply(t, r, m, state, config("generic", ""))
+	state = testMockAPIApply(t, r, m, state, config("off", ""))
+	assert.Equal(t, "on", state.Attributes["regional_tiered_cache"])
+	tieredCaching, _, regional = settings()
+	assert.Equal(t, []string{"off", "on"}, []string{tieredCaching, regional})
+
+	// Tiered caching enabled elsewhere, e.g. by cloudflare_argo, is generic
+	// tiered caching.
+	_, err = client.UpdateArgoTieredCaching(ctx, testAccCloudflareZoneID, "on")
+	require.NoError(t, err)
+	state, diags := r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "generic", state.Attributes["cache_type"])
+
+	_, diags = r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	tieredCaching, _, _ = settings()
+	assert.Equal(t, "off", tieredCaching)
+}
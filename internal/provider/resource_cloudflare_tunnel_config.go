@@ -0,0 +1,463 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareTunnelConfig() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareTunnelConfigSchema(),
+		CreateContext: resourceCloudflareTunnelConfigUpdate,
+		ReadContext:   resourceCloudflareTunnelConfigRead,
+		UpdateContext: resourceCloudflareTunnelConfigUpdate,
+		DeleteContext: resourceCloudflareTunnelConfigDelete,
+		CustomizeDiff: resourceCloudflareTunnelConfigValidateIngressRules,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareTunnelConfigImport,
+		},
+		Description: "Provides a Cloudflare resource to manage the configuration of a remotely managed tunnel, routing requests for hostnames to origins reachable from `cloudflared`.",
+	}
+}
+
+// tunnelConfiguration is the remotely managed configuration of a tunnel, in
+// the format of the `cloudflared` configuration file. cloudflare-go doesn't
+// support it yet so requests are made directly.
+type tunnelConfiguration struct {
+	Ingress       []tunnelIngressRule  `json:"ingress,omitempty"`
+	WarpRouting   *tunnelWarpRouting   `json:"warp-routing,omitempty"`
+	OriginRequest *tunnelOriginRequest `json:"originRequest,omitempty"`
+}
+
+type tunnelIngressRule struct {
+	Hostname      string               `json:"hostname,omitempty"`
+	Path          string               `json:"path,omitempty"`
+	Service       string               `json:"service"`
+	OriginRequest *tunnelOriginRequest `json:"originRequest,omitempty"`
+}
+
+type tunnelWarpRouting struct {
+	Enabled bool `json:"enabled"`
+}
+
+// tunnelOriginRequest holds the settings of requests to origins. Durations
+// are in seconds.
+type tunnelOriginRequest struct {
+	ConnectTimeout         int                `json:"connectTimeout,omitempty"`
+	TLSTimeout             int                `json:"tlsTimeout,omitempty"`
+	TCPKeepAlive           int                `json:"tcpKeepAlive,omitempty"`
+	KeepAliveTimeout       int                `json:"keepAliveTimeout,omitempty"`
+	NoHappyEyeballs        bool               `json:"noHappyEyeballs,omitempty"`
+	KeepAliveConnections   int                `json:"keepAliveConnections,omitempty"`
+	HTTPHostHeader         string             `json:"httpHostHeader,omitempty"`
+	OriginServerName       string             `json:"originServerName,omitempty"`
+	CAPool                 string             `json:"caPool,omitempty"`
+	NoTLSVerify            bool               `json:"noTLSVerify,omitempty"`
+	DisableChunkedEncoding bool               `json:"disableChunkedEncoding,omitempty"`
+	BastionMode            bool               `json:"bastionMode,omitempty"`
+	HTTP2Origin            bool               `json:"http2Origin,omitempty"`
+	ProxyAddress           string             `json:"proxyAddress,omitempty"`
+	ProxyPort              int                `json:"proxyPort,omitempty"`
+	ProxyType              string             `json:"proxyType,omitempty"`
+	IPRules                []tunnelIPRule     `json:"ipRules,omitempty"`
+	Access                 *tunnelAccessCheck `json:"access,omitempty"`
+}
+
+type tunnelIPRule struct {
+	Prefix string `json:"prefix"`
+	Ports  []int  `json:"ports,omitempty"`
+	Allow  bool   `json:"allow"`
+}
+
+type tunnelAccessCheck struct {
+	Required bool     `json:"required,omitempty"`
+	TeamName string   `json:"teamName"`
+	AudTag   []string `json:"audTag"`
+}
+
+type tunnelConfigurationResult struct {
+	TunnelID string              `json:"tunnel_id"`
+	Version  int                 `json:"version"`
+	Config   tunnelConfiguration `json:"config"`
+}
+
+func tunnelConfigurationURI(accountID, tunnelID string) string {
+	return fmt.Sprintf("/accounts/%s/cfd_tunnel/%s/configurations", accountID, tunnelID)
+}
+
+func resourceCloudflareTunnelConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	res, err := client.Raw(http.MethodGet, tunnelConfigurationURI(accountID, d.Id()), nil)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Tunnel %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error finding configuration of tunnel %q: %w", d.Id(), err))
+	}
+
+	var result tunnelConfigurationResult
+	if err := json.Unmarshal(res, &result); err != nil {
+		return diag.FromErr(fmt.Errorf("error parsing configuration of tunnel %q: %w", d.Id(), err))
+	}
+
+	d.Set("tunnel_id", d.Id())
+	d.Set("version", result.Version)
+	if err := d.Set("config", flattenTunnelConfiguration(result.Config)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting configuration of tunnel %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareTunnelConfigUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+	tunnelID := d.Get("tunnel_id").(string)
+
+	config := expandTunnelConfiguration(d.Get("config").([]interface{}))
+
+	tflog.Debug(ctx, fmt.Sprintf("Cloudflare tunnel configuration: %#v", config))
+
+	_, err := client.Raw(http.MethodPut, tunnelConfigurationURI(accountID, tunnelID), map[string]interface{}{"config": config})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating configuration of tunnel %q: %w", tunnelID, err))
+	}
+
+	d.SetId(tunnelID)
+
+	return resourceCloudflareTunnelConfigRead(ctx, d, meta)
+}
+
+func resourceCloudflareTunnelConfigDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	accountID := d.Get("account_id").(string)
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting Cloudflare tunnel configuration: tunnel ID %s", d.Id()))
+
+	// Tunnel configurations can't be deleted, so the configuration is emptied
+	// instead, leaving `cloudflared` to respond to every request with an
+	// error.
+	_, err := client.Raw(http.MethodPut, tunnelConfigurationURI(accountID, d.Id()), map[string]interface{}{"config": tunnelConfiguration{}})
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error deleting configuration of tunnel %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareTunnelConfigImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	attributes := strings.SplitN(d.Id(), "/", 2)
+
+	if len(attributes) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/tunnelID\"", d.Id())
+	}
+
+	accountID, tunnelID := attributes[0], attributes[1]
+	d.SetId(tunnelID)
+	d.Set("account_id", accountID)
+	d.Set("tunnel_id", tunnelID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceCloudflareTunnelConfigValidateIngressRules checks during plan that
+// only the last ingress rule matches every request, as `cloudflared` refuses
+// to run with rules that can't ever match.
+func resourceCloudflareTunnelConfigValidateIngressRules(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rules, _ := d.Get("config.0.ingress_rule").([]interface{})
+
+	return validateTunnelIngressRules(rules, func(i int) bool {
+		key := fmt.Sprintf("config.0.ingress_rule.%d", i)
+		return d.NewValueKnown(key+".hostname") && d.NewValueKnown(key+".path")
+	})
+}
+
+// validateTunnelIngressRules validates the order of ingress rules, skipping
+// the rules for which known returns false as whether they match every
+// request isn't known yet.
+func validateTunnelIngressRules(rules []interface{}, known func(i int) bool) error {
+	last := len(rules) - 1
+	for i, r := range rules {
+		if !known(i) {
+			continue
+		}
+
+		rule, _ := r.(map[string]interface{})
+		hostname, _ := rule["hostname"].(string)
+		path, _ := rule["path"].(string)
+		catchAll := hostname == "" && path == ""
+
+		if catchAll && i != last {
+			return fmt.Errorf("ingress rule %d matches every request, which leaves the rules after it unreachable: only the last rule may omit both `hostname` and `path`", i+1)
+		}
+		if !catchAll && i == last {
+			return fmt.Errorf("the last ingress rule must match every request, without a `hostname` or `path`, such as a rule with service `http_status:404`")
+		}
+	}
+
+	return nil
+}
+
+// tunnelIngressServices are the services built into `cloudflared`.
+var tunnelIngressServices = []string{"hello_world", "bastion", "socks5"}
+
+func validateTunnelIngressService(v interface{}, k string) ([]string, []error) {
+	service := v.(string)
+
+	if contains(tunnelIngressServices, service) {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(service, "http_status:") {
+		status, err := strconv.Atoi(strings.TrimPrefix(service, "http_status:"))
+		if err != nil || status < 100 || status > 599 {
+			return nil, []error{fmt.Errorf("%s: %q must be followed by an HTTP status code", k, "http_status:")}
+		}
+		return nil, nil
+	}
+
+	u, err := url.Parse(service)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: invalid service %q: %w", k, service, err)}
+	}
+	if u.Scheme == "unix" || u.Scheme == "unix+tls" {
+		if u.Opaque == "" && u.Path == "" {
+			return nil, []error{fmt.Errorf("%s: service %q must include the path of the socket", k, service)}
+		}
+		return nil, nil
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, []error{fmt.Errorf("%s: service %q must be a URL with a scheme and host such as %q, or one of %s, or %q followed by a status code", k, service, "http://localhost:8080", strings.Join(tunnelIngressServices, ", "), "http_status:")}
+	}
+	if u.Path != "" && u.Path != "/" {
+		return nil, []error{fmt.Errorf("%s: service %q must not include a path, use the `path` of the rule to match paths", k, service)}
+	}
+
+	return nil, nil
+}
+
+func validateTunnelIngressHostname(v interface{}, k string) ([]string, []error) {
+	hostname := v.(string)
+
+	if strings.Contains(strings.TrimPrefix(hostname, "*"), "*") {
+		return nil, []error{fmt.Errorf("%s: hostname %q may only contain a wildcard at the start", k, hostname)}
+	}
+	if strings.Contains(hostname, ":") || strings.Contains(hostname, "/") {
+		return nil, []error{fmt.Errorf("%s: hostname %q must not contain a port or path", k, hostname)}
+	}
+
+	return nil, nil
+}
+
+func validateTunnelConfigDuration(v interface{}, path cty.Path) diag.Diagnostics {
+	duration, err := time.ParseDuration(v.(string))
+	if err == nil && (duration < 0 || duration%time.Second != 0) {
+		err = errors.New("durations must be a positive whole number of seconds")
+	}
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid duration",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
+
+func suppressEquivalentTunnelConfigDuration(k, old, new string, d *schema.ResourceData) bool {
+	oldDuration, oldErr := time.ParseDuration(old)
+	newDuration, newErr := time.ParseDuration(new)
+
+	return oldErr == nil && newErr == nil && oldDuration == newDuration
+}
+
+func expandTunnelConfiguration(v []interface{}) tunnelConfiguration {
+	var config tunnelConfiguration
+	if len(v) == 0 || v[0] == nil {
+		return config
+	}
+	m := v[0].(map[string]interface{})
+
+	for _, r := range m["ingress_rule"].([]interface{}) {
+		rule := r.(map[string]interface{})
+		config.Ingress = append(config.Ingress, tunnelIngressRule{
+			Hostname:      rule["hostname"].(string),
+			Path:          rule["path"].(string),
+			Service:       rule["service"].(string),
+			OriginRequest: expandTunnelOriginRequest(rule["origin_request"].([]interface{})),
+		})
+	}
+
+	if warpRouting := m["warp_routing"].([]interface{}); len(warpRouting) > 0 && warpRouting[0] != nil {
+		config.WarpRouting = &tunnelWarpRouting{Enabled: warpRouting[0].(map[string]interface{})["enabled"].(bool)}
+	}
+
+	config.OriginRequest = expandTunnelOriginRequest(m["origin_request"].([]interface{}))
+
+	return config
+}
+
+func expandTunnelOriginRequest(v []interface{}) *tunnelOriginRequest {
+	if len(v) == 0 || v[0] == nil {
+		return nil
+	}
+	m := v[0].(map[string]interface{})
+
+	seconds := func(key string) int {
+		duration, _ := time.ParseDuration(m[key].(string))
+		return int(duration / time.Second)
+	}
+
+	originRequest := &tunnelOriginRequest{
+		ConnectTimeout:         seconds("connect_timeout"),
+		TLSTimeout:             seconds("tls_timeout"),
+		TCPKeepAlive:           seconds("tcp_keep_alive"),
+		KeepAliveTimeout:       seconds("keep_alive_timeout"),
+		NoHappyEyeballs:        m["no_happy_eyeballs"].(bool),
+		KeepAliveConnections:   m["keep_alive_connections"].(int),
+		HTTPHostHeader:         m["http_host_header"].(string),
+		OriginServerName:       m["origin_server_name"].(string),
+		CAPool:                 m["ca_pool"].(string),
+		NoTLSVerify:            m["no_tls_verify"].(bool),
+		DisableChunkedEncoding: m["disable_chunked_encoding"].(bool),
+		BastionMode:            m["bastion_mode"].(bool),
+		HTTP2Origin:            m["http2_origin"].(bool),
+		ProxyAddress:           m["proxy_address"].(string),
+		ProxyPort:              m["proxy_port"].(int),
+		ProxyType:              m["proxy_type"].(string),
+	}
+
+	for _, r := range m["ip_rule"].([]interface{}) {
+		rule := r.(map[string]interface{})
+		ipRule := tunnelIPRule{
+			Prefix: rule["prefix"].(string),
+			Allow:  rule["allow"].(bool),
+		}
+		for _, port := range rule["ports"].([]interface{}) {
+			ipRule.Ports = append(ipRule.Ports, port.(int))
+		}
+		originRequest.IPRules = append(originRequest.IPRules, ipRule)
+	}
+
+	if access := m["access"].([]interface{}); len(access) > 0 && access[0] != nil {
+		a := access[0].(map[string]interface{})
+		originRequest.Access = &tunnelAccessCheck{
+			Required: a["required"].(bool),
+			TeamName: a["team_name"].(string),
+			AudTag:   expandInterfaceToStringList(a["aud_tag"].(*schema.Set).List()),
+		}
+	}
+
+	return originRequest
+}
+
+func flattenTunnelConfiguration(config tunnelConfiguration) []interface{} {
+	var rules []interface{}
+	for _, rule := range config.Ingress {
+		rules = append(rules, map[string]interface{}{
+			"hostname":       rule.Hostname,
+			"path":           rule.Path,
+			"service":        rule.Service,
+			"origin_request": flattenTunnelOriginRequest(rule.OriginRequest),
+		})
+	}
+
+	m := map[string]interface{}{
+		"ingress_rule":   rules,
+		"origin_request": flattenTunnelOriginRequest(config.OriginRequest),
+	}
+	if config.WarpRouting != nil {
+		m["warp_routing"] = []interface{}{map[string]interface{}{"enabled": config.WarpRouting.Enabled}}
+	}
+
+	return []interface{}{m}
+}
+
+func flattenTunnelOriginRequest(originRequest *tunnelOriginRequest) []interface{} {
+	if originRequest == nil {
+		return nil
+	}
+
+	duration := func(seconds int) string {
+		if seconds == 0 {
+			return ""
+		}
+		return (time.Duration(seconds) * time.Second).String()
+	}
+
+	m := map[string]interface{}{
+		"connect_timeout":          duration(originRequest.ConnectTimeout),
+		"tls_timeout":              duration(originRequest.TLSTimeout),
+		"tcp_keep_alive":           duration(originRequest.TCPKeepAlive),
+		"keep_alive_timeout":       duration(originRequest.KeepAliveTimeout),
+		"no_happy_eyeballs":        originRequest.NoHappyEyeballs,
+		"keep_alive_connections":   originRequest.KeepAliveConnections,
+		"http_host_header":         originRequest.HTTPHostHeader,
+		"origin_server_name":       originRequest.OriginServerName,
+		"ca_pool":                  originRequest.CAPool,
+		"no_tls_verify":            originRequest.NoTLSVerify,
+		"disable_chunked_encoding": originRequest.DisableChunkedEncoding,
+		"bastion_mode":             originRequest.BastionMode,
+		"http2_origin":             originRequest.HTTP2Origin,
+		"proxy_address":            originRequest.ProxyAddress,
+		"proxy_port":               originRequest.ProxyPort,
+		"proxy_type":               originRequest.ProxyType,
+	}
+
+	var ipRules []interface{}
+	for _, rule := range originRequest.IPRules {
+		var ports []interface{}
+		for _, port := range rule.Ports {
+			ports = append(ports, port)
+		}
+		ipRules = append(ipRules, map[string]interface{}{
+			"prefix": rule.Prefix,
+			"ports":  ports,
+			"allow":  rule.Allow,
+		})
+	}
+	m["ip_rule"] = ipRules
+
+	if originRequest.Access != nil {
+		m["access"] = []interface{}{map[string]interface{}{
+			"required":  originRequest.Access.Required,
+			"team_name": originRequest.Access.TeamName,
+			"aud_tag":   originRequest.Access.AudTag,
+		}}
+	}
+
+	return []interface{}{m}
+}
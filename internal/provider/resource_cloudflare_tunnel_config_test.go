@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareTunnelConfig_Basic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_tunnel_config.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareTunnelConfigBasic(rnd, accountID, domain, "10s"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "account_id", accountID),
+					resource.TestCheckResourceAttrPair(name, "tunnel_id", "cloudflare_argo_tunnel."+rnd, "id"),
+					resource.TestCheckResourceAttr(name, "config.0.warp_routing.0.enabled", "true"),
+					resource.TestCheckResourceAttr(name, "config.0.origin_request.0.connect_timeout", "10s"),
+					resource.TestCheckResourceAttr(name, "config.0.ingress_rule.#", "3"),
+					resource.TestCheckResourceAttr(name, "config.0.ingress_rule.0.hostname", fmt.Sprintf("%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "config.0.ingress_rule.1.origin_request.0.no_tls_verify", "true"),
+					resource.TestCheckResourceAttr(name, "config.0.ingress_rule.2.service", "http_status:404"),
+					resource.TestCheckResourceAttrSet(name, "version"),
+				),
+			},
+			{
+				Config: testAccCloudflareTunnelConfigBasic(rnd, accountID, domain, "1m30s"),
+				Check:  resource.TestCheckResourceAttr(name, "config.0.origin_request.0.connect_timeout", "1m30s"),
+			},
+			{
+				ResourceName:        name,
+				ImportState:         true,
+				ImportStateIdPrefix: fmt.Sprintf("%s/", accountID),
+				ImportStateVerify:   true,
+			},
+		},
+	})
+}
+
+func TestAccCloudflareTunnelConfig_CatchAllRule(t *testing.T) {
+	rnd := generateRandomResourceName()
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareTunnelConfigWithoutCatchAll(rnd, accountID),
+				ExpectError: regexp.MustCompile("the last ingress rule must match every request"),
+				PlanOnly:    true,
+			},
+		},
+	})
+}
+
+func testAccCloudflareTunnelConfigBasic(name, accountID, domain, connectTimeout string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_argo_tunnel" "%[1]s" {
+  account_id = "%[2]s"
+  name       = "%[1]s"
+  secret     = "AQIDBAUGBwgBAgMEBQYHCAECAwQFBgcIAQIDBAUGBwg="
+}
+
+resource "cloudflare_tunnel_config" "%[1]s" {
+  account_id = "%[2]s"
+  tunnel_id  = cloudflare_argo_tunnel.%[1]s.id
+
+  config {
+    warp_routing {
+      enabled = true
+    }
+
+    origin_request {
+      connect_timeout = "%[4]s"
+    }
+
+    ingress_rule {
+      hostname = "%[1]s.%[3]s"
+      service  = "http://localhost:8080"
+    }
+
+    ingress_rule {
+      hostname = "*.%[1]s.%[3]s"
+      path     = "^/api/"
+      service  = "https://localhost:8443"
+
+      origin_request {
+        no_tls_verify = true
+      }
+    }
+
+    ingress_rule {
+      service = "http_status:404"
+    }
+  }
+}`, name, accountID, domain, connectTimeout)
+}
+
+func testAccCloudflareTunnelConfigWithoutCatchAll(name, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_tunnel_config" "%[1]s" {
+  account_id = "%[2]s"
+  tunnel_id  = "f70ff985-a4ef-4643-bbbc-4a0ed4fc8415"
+
+  config {
+    ingress_rule {
+      hostname = "%[1]s.example.com"
+      service  = "http://localhost:8080"
+    }
+  }
+}`, name, accountID)
+}
+
+func TestValidateTunnelIngressRules(t *testing.T) {
+	known := func(int) bool { return true }
+	rule := func(hostname, path string) interface{} {
+		return map[string]interface{}{"hostname": hostname, "path": path, "service": "http://localhost"}
+	}
+
+	assert.NoError(t, validateTunnelIngressRules([]interface{}{rule("a.example.com", ""), rule("", "^/api"), rule("", "")}, known))
+	assert.EqualError(t, validateTunnelIngressRules([]interface{}{rule("", ""), rule("a.example.com", "")}, known),
+		"ingress rule 1 matches every request, which leaves the rules after it unreachable: only the last rule may omit both `hostname` and `path`")
+	assert.EqualError(t, validateTunnelIngressRules([]interface{}{rule("a.example.com", "")}, known),
+		"the last ingress rule must match every request, without a `hostname` or `path`, such as a rule with service `http_status:404`")
+	assert.NoError(t, validateTunnelIngressRules([]interface{}{rule("a.example.com", "")}, func(int) bool { return false }),
+		"rules with unknown values are validated once they are known")
+}
+
+func TestValidateTunnelIngressService(t *testing.T) {
+	for _, service := range []string{"http://localhost:8080", "https://10.0.0.1", "ssh://localhost:22", "tcp://db:5432", "unix:/run/app.sock", "unix+tls:/run/app.sock", "hello_world", "http_status:404"} {
+		_, errs := validateTunnelIngressService(service, "service")
+		assert.Empty(t, errs, service)
+	}
+
+	for _, service := range []string{"localhost:8080", "http_status:abc", "http://localhost/app", "unix:", "bogus"} {
+		_, errs := validateTunnelIngressService(service, "service")
+		assert.NotEmpty(t, errs, service)
+	}
+}
+
+func TestTunnelConfigurationRoundTrip(t *testing.T) {
+	r := resourceCloudflareTunnelConfig()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"account_id": "f037e56e89293a057740de681ac9abbe",
+		"tunnel_id":  "f70ff985-a4ef-4643-bbbc-4a0ed4fc8415",
+		"config": []interface{}{map[string]interface{}{
+			"warp_routing": []interface{}{map[string]interface{}{"enabled": true}},
+			"origin_request": []interface{}{map[string]interface{}{
+				"connect_timeout": "90s",
+				"ip_rule":         []interface{}{map[string]interface{}{"prefix": "10.0.0.0/8", "ports": []interface{}{22, 443}, "allow": true}},
+				"access":          []interface{}{map[string]interface{}{"required": true, "team_name": "acme", "aud_tag": []interface{}{"aud"}}},
+			}},
+			"ingress_rule": []interface{}{
+				map[string]interface{}{"hostname": "app.example.com", "service": "http://localhost:8080", "origin_request": []interface{}{map[string]interface{}{"http_host_header": "app.internal"}}},
+				map[string]interface{}{"service": "http_status:404"},
+			},
+		}},
+	})
+
+	body, err := json.Marshal(expandTunnelConfiguration(d.Get("config").([]interface{})))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"warp-routing": {"enabled": true},
+		"originRequest": {
+			"connectTimeout": 90,
+			"ipRules": [{"prefix": "10.0.0.0/8", "ports": [22, 443], "allow": true}],
+			"access": {"required": true, "teamName": "acme", "audTag": ["aud"]}
+		},
+		"ingress": [
+			{"hostname": "app.example.com", "service": "http://localhost:8080", "originRequest": {"httpHostHeader": "app.internal"}},
+			{"service": "http_status:404"}
+		]
+	}`, string(body))
+
+	var config tunnelConfiguration
+	require.NoError(t, json.Unmarshal(body, &config))
+	require.NoError(t, d.Set("config", flattenTunnelConfiguration(config)))
+	assert.Equal(t, "1m30s", d.Get("config.0.origin_request.0.connect_timeout"))
+	assert.Equal(t, 443, d.Get("config.0.origin_request.0.ip_rule.0.ports.1"))
+	assert.Equal(t, "app.internal", d.Get("config.0.ingress_rule.0.origin_request.0.http_host_header"))
+	assert.Equal(t, "http_status:404", d.Get("config.0.ingress_rule.1.service"))
+	assert.True(t, suppressEquivalentTunnelConfigDuration("", "1m30s", "90s", nil))
+}
@@ -27,7 +27,10 @@ func resourceCloudflareTunnelRoute() *schema.Resource {
 }
 
 func resourceCloudflareTunnelRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 	network := d.Get("network").(string)
 	virtualNetworkID := d.Get("virtual_network_id").(string)
@@ -71,7 +74,10 @@ func resourceCloudflareTunnelRouteRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCloudflareTunnelRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	virtualNetworkID := d.Get("virtual_network_id").(string)
 
 	resource := cloudflare.TunnelRoutesCreateParams{
@@ -101,7 +107,10 @@ func resourceCloudflareTunnelRouteCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareTunnelRouteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	resource := cloudflare.TunnelRoutesUpdateParams{
 		AccountID:        d.Get("account_id").(string),
@@ -124,7 +133,10 @@ func resourceCloudflareTunnelRouteUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareTunnelRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	network := d.Get("network").(string)
 
 	resource := cloudflare.TunnelRoutesDeleteParams{
@@ -30,7 +30,10 @@ handle overlapping private IPs in your origins.`,
 }
 
 func resourceCloudflareTunnelVirtualNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	accountID := d.Get("account_id").(string)
 
 	tunnelVirtualNetworks, err := client.ListTunnelVirtualNetworks(ctx, cloudflare.TunnelVirtualNetworksListParams{
@@ -62,7 +65,10 @@ func resourceCloudflareTunnelVirtualNetworkRead(ctx context.Context, d *schema.R
 }
 
 func resourceCloudflareTunnelVirtualNetworkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	name := d.Get("name").(string)
 
 	resource := cloudflare.TunnelVirtualNetworkCreateParams{
@@ -86,7 +92,10 @@ func resourceCloudflareTunnelVirtualNetworkCreate(ctx context.Context, d *schema
 }
 
 func resourceCloudflareTunnelVirtualNetworkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	resource := cloudflare.TunnelVirtualNetworkUpdateParams{
 		AccountID:        d.Get("account_id").(string),
@@ -108,7 +117,10 @@ func resourceCloudflareTunnelVirtualNetworkUpdate(ctx context.Context, d *schema
 }
 
 func resourceCloudflareTunnelVirtualNetworkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	err := client.DeleteTunnelVirtualNetwork(ctx, cloudflare.TunnelVirtualNetworkDeleteParams{
 		AccountID: d.Get("account_id").(string),
@@ -91,7 +91,7 @@ func testAccCheckCloudflareTunnelVirtualNetworkExists(name string, virtualNetwor
 			return errors.New("No Tunnel Virtual Network is set")
 		}
 
-		client := testAccProvider.Meta().(*cloudflare.API)
+		client := testAccProvider.Meta().(*providerMeta).defaultClient()
 		foundTunnelVirtualNetworks, err := client.ListTunnelVirtualNetworks(context.Background(), cloudflare.TunnelVirtualNetworksListParams{
 			AccountID: rs.Primary.Attributes["account_id"],
 			IsDeleted: cloudflare.BoolPtr(false),
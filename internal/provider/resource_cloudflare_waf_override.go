@@ -25,7 +25,10 @@ func resourceCloudflareWAFOverride() *schema.Resource {
 }
 
 func resourceCloudflareWAFOverrideRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	override, err := client.WAFOverride(ctx, zoneID, d.Id())
@@ -62,7 +65,10 @@ func resourceCloudflareWAFOverrideRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCloudflareWAFOverrideCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	newOverride, _ := buildWAFOverride(d)
 
@@ -77,7 +83,10 @@ func resourceCloudflareWAFOverrideCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareWAFOverrideUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	overrideID := d.Get("override_id").(string)
 	updatedOverride, _ := buildWAFOverride(d)
@@ -91,7 +100,10 @@ func resourceCloudflareWAFOverrideUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareWAFOverrideDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	overrideID := d.Get("override_id").(string)
 	zoneID := d.Get("zone_id").(string)
 
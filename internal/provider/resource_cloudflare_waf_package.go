@@ -26,7 +26,10 @@ func resourceCloudflareWAFPackage() *schema.Resource {
 }
 
 func resourceCloudflareWAFPackageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	packageID := d.Get("package_id").(string)
 	zoneID := d.Get("zone_id").(string)
@@ -50,7 +53,10 @@ func resourceCloudflareWAFPackageRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceCloudflareWAFPackageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	packageID := d.Get("package_id").(string)
 	zoneID := d.Get("zone_id").(string)
@@ -86,7 +92,10 @@ func resourceCloudflareWAFPackageCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareWAFPackageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	packageID := d.Get("package_id").(string)
 	zoneID := d.Get("zone_id").(string)
@@ -117,7 +126,10 @@ func resourceCloudflareWAFPackageDelete(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareWAFPackageUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	packageID := d.Get("package_id").(string)
 	zoneID := d.Get("zone_id").(string)
@@ -138,7 +150,10 @@ func resourceCloudflareWAFPackageUpdate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareWAFPackageImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return nil, clientErr
+	}
 
 	// split the id so we can lookup
 	idAttr := strings.SplitN(d.Id(), "/", 2)
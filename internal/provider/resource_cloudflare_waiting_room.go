@@ -2,7 +2,10 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -12,6 +15,35 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// waitingRoomQueueingMethods are the orders in which queued users are let
+// into the route, shared by waiting rooms and their events.
+var waitingRoomQueueingMethods = []string{"fifo", "random", "passthrough", "reject"}
+
+var (
+	waitingRoomCookieSameSiteValues = []string{"auto", "lax", "none", "strict"}
+	waitingRoomCookieSecureValues   = []string{"auto", "always", "never"}
+)
+
+// cloudflare-go doesn't support the queueing method, cookie attributes nor
+// additional routes of waiting rooms, so waiting rooms are sent and read with
+// raw requests.
+type waitingRoom struct {
+	cloudflare.WaitingRoom
+	QueueingMethod   string                       `json:"queueing_method,omitempty"`
+	CookieAttributes *waitingRoomCookieAttributes `json:"cookie_attributes,omitempty"`
+	AdditionalRoutes []waitingRoomRoute           `json:"additional_routes"`
+}
+
+type waitingRoomCookieAttributes struct {
+	SameSite string `json:"samesite,omitempty"`
+	Secure   string `json:"secure,omitempty"`
+}
+
+type waitingRoomRoute struct {
+	Host string `json:"host"`
+	Path string `json:"path"`
+}
+
 func resourceCloudflareWaitingRoom() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceCloudflareWaitingRoomCreate,
@@ -30,7 +62,34 @@ func resourceCloudflareWaitingRoom() *schema.Resource {
 	}
 }
 
-func buildWaitingRoom(d *schema.ResourceData) cloudflare.WaitingRoom {
+func buildWaitingRoom(d *schema.ResourceData) waitingRoom {
+	additionalRoutes := []waitingRoomRoute{}
+	for _, route := range d.Get("additional_routes").([]interface{}) {
+		route := route.(map[string]interface{})
+		additionalRoutes = append(additionalRoutes, waitingRoomRoute{
+			Host: route["host"].(string),
+			Path: route["path"].(string),
+		})
+	}
+
+	var cookieAttributes *waitingRoomCookieAttributes
+	if attributes, ok := d.GetOk("cookie_attributes"); ok && attributes.([]interface{})[0] != nil {
+		attributes := attributes.([]interface{})[0].(map[string]interface{})
+		cookieAttributes = &waitingRoomCookieAttributes{
+			SameSite: attributes["samesite"].(string),
+			Secure:   attributes["secure"].(string),
+		}
+	}
+
+	return waitingRoom{
+		WaitingRoom:      buildCloudflareWaitingRoom(d),
+		QueueingMethod:   d.Get("queueing_method").(string),
+		CookieAttributes: cookieAttributes,
+		AdditionalRoutes: additionalRoutes,
+	}
+}
+
+func buildCloudflareWaitingRoom(d *schema.ResourceData) cloudflare.WaitingRoom {
 	return cloudflare.WaitingRoom{
 		Name:                    d.Get("name").(string),
 		Description:             d.Get("description").(string),
@@ -49,12 +108,15 @@ func buildWaitingRoom(d *schema.ResourceData) cloudflare.WaitingRoom {
 }
 
 func resourceCloudflareWaitingRoomCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	newWaitingRoom := buildWaitingRoom(d)
 
-	waitingRoom, err := client.CreateWaitingRoom(ctx, zoneID, newWaitingRoom)
+	waitingRoom, err := createWaitingRoom(client, zoneID, newWaitingRoom)
 
 	if err != nil {
 		name := d.Get("name").(string)
@@ -67,13 +129,17 @@ func resourceCloudflareWaitingRoomCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareWaitingRoomRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	waitingRoomID := d.Id()
 	zoneID := d.Get("zone_id").(string)
 
-	waitingRoom, err := client.WaitingRoom(ctx, zoneID, waitingRoomID)
+	waitingRoom, err := waitingRoomOf(client, zoneID, waitingRoomID)
 	if err != nil {
-		if strings.Contains(err.Error(), "HTTP status 404") {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
 			tflog.Warn(ctx, fmt.Sprintf("Removing waiting room from state because it's not found in API"))
 			d.SetId("")
 			return nil
@@ -95,17 +161,44 @@ func resourceCloudflareWaitingRoomRead(ctx context.Context, d *schema.ResourceDa
 	d.Set("custom_page_html", waitingRoom.CustomPageHTML)
 	d.Set("default_template_language", waitingRoom.DefaultTemplateLanguage)
 	d.Set("json_response_enabled", waitingRoom.JsonResponseEnabled)
+	d.Set("queueing_method", waitingRoom.QueueingMethod)
+
+	var cookieAttributes []map[string]interface{}
+	if waitingRoom.CookieAttributes != nil {
+		cookieAttributes = append(cookieAttributes, map[string]interface{}{
+			"samesite": waitingRoom.CookieAttributes.SameSite,
+			"secure":   waitingRoom.CookieAttributes.Secure,
+		})
+	}
+	if err := d.Set("cookie_attributes", cookieAttributes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var additionalRoutes []map[string]interface{}
+	for _, route := range waitingRoom.AdditionalRoutes {
+		additionalRoutes = append(additionalRoutes, map[string]interface{}{
+			"host": route.Host,
+			"path": route.Path,
+		})
+	}
+	if err := d.Set("additional_routes", additionalRoutes); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
 func resourceCloudflareWaitingRoomUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	waitingRoomID := d.Id()
 	zoneID := d.Get("zone_id").(string)
 
 	waitingRoom := buildWaitingRoom(d)
 
-	_, err := client.ChangeWaitingRoom(ctx, zoneID, waitingRoomID, waitingRoom)
+	_, err := updateWaitingRoom(client, zoneID, waitingRoomID, waitingRoom)
 
 	if err != nil {
 		name := d.Get("name").(string)
@@ -116,7 +209,10 @@ func resourceCloudflareWaitingRoomUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareWaitingRoomDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	waitingRoomID := d.Id()
 	zoneID := d.Get("zone_id").(string)
 
@@ -131,7 +227,10 @@ func resourceCloudflareWaitingRoomDelete(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareWaitingRoomImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return nil, clientErr
+	}
 	idAttr := strings.SplitN(d.Id(), "/", 2)
 	var zoneID string
 	var waitingRoomID string
@@ -153,3 +252,25 @@ func resourceCloudflareWaitingRoomImport(ctx context.Context, d *schema.Resource
 	resourceCloudflareWaitingRoomRead(ctx, d, meta)
 	return []*schema.ResourceData{d}, nil
 }
+
+func createWaitingRoom(client *cloudflare.API, zoneID string, wr waitingRoom) (waitingRoom, error) {
+	return waitingRoomRequest(client, http.MethodPost, fmt.Sprintf("/zones/%s/waiting_rooms", zoneID), wr)
+}
+
+func waitingRoomOf(client *cloudflare.API, zoneID, waitingRoomID string) (waitingRoom, error) {
+	return waitingRoomRequest(client, http.MethodGet, fmt.Sprintf("/zones/%s/waiting_rooms/%s", zoneID, waitingRoomID), nil)
+}
+
+func updateWaitingRoom(client *cloudflare.API, zoneID, waitingRoomID string, wr waitingRoom) (waitingRoom, error) {
+	return waitingRoomRequest(client, http.MethodPut, fmt.Sprintf("/zones/%s/waiting_rooms/%s", zoneID, waitingRoomID), wr)
+}
+
+func waitingRoomRequest(client *cloudflare.API, method, uri string, body interface{}) (waitingRoom, error) {
+	var wr waitingRoom
+	result, err := client.Raw(method, uri, body)
+	if err != nil {
+		return wr, err
+	}
+	err = json.Unmarshal(result, &wr)
+	return wr, err
+}
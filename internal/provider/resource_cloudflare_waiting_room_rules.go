@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// waitingRoomRuleActions are the actions of waiting room rules.
+var waitingRoomRuleActions = []string{"bypass_waiting_room"}
+
+// cloudflare-go doesn't support waiting room rules, so they're managed with
+// raw requests.
+type waitingRoomRule struct {
+	ID          string `json:"id,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Action      string `json:"action"`
+	Expression  string `json:"expression"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func resourceCloudflareWaitingRoomRules() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareWaitingRoomRulesSchema(),
+		CreateContext: resourceCloudflareWaitingRoomRulesCreate,
+		ReadContext:   resourceCloudflareWaitingRoomRulesRead,
+		UpdateContext: resourceCloudflareWaitingRoomRulesUpdate,
+		DeleteContext: resourceCloudflareWaitingRoomRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareWaitingRoomRulesImport,
+		},
+		Description: "Provides a Cloudflare resource to manage the rules of a waiting room, such as letting some requests bypass it. The rules of a waiting room are managed as a whole, so a waiting room should only have a single `cloudflare_waiting_room_rules`.",
+	}
+}
+
+func resourceCloudflareWaitingRoomRulesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(d.Get("waiting_room_id").(string))
+
+	return resourceCloudflareWaitingRoomRulesUpdate(ctx, d, meta)
+}
+
+func resourceCloudflareWaitingRoomRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	rules, err := waitingRoomRulesOf(client, zoneID, d.Id())
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Waiting room %s no longer exists", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading rules of waiting room %q: %w", d.Id(), err))
+	}
+
+	d.Set("waiting_room_id", d.Id())
+	if err := d.Set("rules", flattenWaitingRoomRules(rules)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCloudflareWaitingRoomRulesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	rules := expandWaitingRoomRules(d.Get("rules").([]interface{}))
+
+	tflog.Debug(ctx, fmt.Sprintf("Setting %d rules of waiting room %s", len(rules), d.Id()))
+
+	if _, err := updateWaitingRoomRules(client, zoneID, d.Id(), rules); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating rules of waiting room %q: %w", d.Id(), err))
+	}
+
+	return resourceCloudflareWaitingRoomRulesRead(ctx, d, meta)
+}
+
+func resourceCloudflareWaitingRoomRulesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	if _, err := updateWaitingRoomRules(client, zoneID, d.Id(), []waitingRoomRule{}); err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error deleting rules of waiting room %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareWaitingRoomRulesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idAttr := strings.SplitN(d.Id(), "/", 2)
+	if len(idAttr) != 2 {
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"zoneID/waitingRoomID\"", d.Id())
+	}
+	zoneID, waitingRoomID := idAttr[0], idAttr[1]
+
+	d.SetId(waitingRoomID)
+	d.Set("zone_id", zoneID)
+
+	resourceCloudflareWaitingRoomRulesRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandWaitingRoomRules(rules []interface{}) []waitingRoomRule {
+	expanded := []waitingRoomRule{}
+	for _, rule := range rules {
+		rule := rule.(map[string]interface{})
+		expanded = append(expanded, waitingRoomRule{
+			Action:      rule["action"].(string),
+			Expression:  rule["expression"].(string),
+			Description: rule["description"].(string),
+			Enabled:     rule["enabled"].(bool),
+		})
+	}
+	return expanded
+}
+
+func flattenWaitingRoomRules(rules []waitingRoomRule) []map[string]interface{} {
+	var flattened []map[string]interface{}
+	for _, rule := range rules {
+		flattened = append(flattened, map[string]interface{}{
+			"id":          rule.ID,
+			"version":     rule.Version,
+			"action":      rule.Action,
+			"expression":  rule.Expression,
+			"description": rule.Description,
+			"enabled":     rule.Enabled,
+		})
+	}
+	return flattened
+}
+
+func waitingRoomRulesOf(client *cloudflare.API, zoneID, waitingRoomID string) ([]waitingRoomRule, error) {
+	return waitingRoomRulesRequest(client, http.MethodGet, zoneID, waitingRoomID, nil)
+}
+
+func updateWaitingRoomRules(client *cloudflare.API, zoneID, waitingRoomID string, rules []waitingRoomRule) ([]waitingRoomRule, error) {
+	return waitingRoomRulesRequest(client, http.MethodPut, zoneID, waitingRoomID, rules)
+}
+
+func waitingRoomRulesRequest(client *cloudflare.API, method, zoneID, waitingRoomID string, body interface{}) ([]waitingRoomRule, error) {
+	var rules []waitingRoomRule
+	result, err := client.Raw(method, fmt.Sprintf("/zones/%s/waiting_rooms/%s/rules", zoneID, waitingRoomID), body)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(result, &rules)
+	return rules, err
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareWaitingRoomRules_Basic(t *testing.T) {
+	t.Parallel()
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	domain := os.Getenv("CLOUDFLARE_DOMAIN")
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_waiting_room_rules.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareWaitingRoomRules(rnd, zoneID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "zone_id", zoneID),
+					resource.TestCheckResourceAttrPair(name, "waiting_room_id", "cloudflare_waiting_room."+rnd, "id"),
+					resource.TestCheckResourceAttr(name, "rules.#", "2"),
+					resource.TestCheckResourceAttr(name, "rules.0.action", "bypass_waiting_room"),
+					resource.TestCheckResourceAttr(name, "rules.0.expression", "ip.src in {192.0.2.0/24}"),
+					resource.TestCheckResourceAttr(name, "rules.0.description", "office"),
+					resource.TestCheckResourceAttr(name, "rules.0.enabled", "true"),
+					resource.TestCheckResourceAttrSet(name, "rules.0.id"),
+					resource.TestCheckResourceAttr(name, "rules.1.enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateIdFunc: testAccCloudflareWaitingRoomRulesImportStateIdFunc(name),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCloudflareWaitingRoomRulesImportStateIdFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", name)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["zone_id"], rs.Primary.ID), nil
+	}
+}
+
+func testAccCloudflareWaitingRoomRules(resourceName, zoneID, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_waiting_room" "%[1]s" {
+  name                 = "waiting_room_%[1]s"
+  zone_id              = "%[2]s"
+  host                 = "www.%[3]s"
+  path                 = "/%[1]s"
+  new_users_per_minute = 400
+  total_active_users   = 405
+}
+
+resource "cloudflare_waiting_room_rules" "%[1]s" {
+  zone_id         = "%[2]s"
+  waiting_room_id = cloudflare_waiting_room.%[1]s.id
+
+  rules {
+    action      = "bypass_waiting_room"
+    expression  = "ip.src in {192.0.2.0/24}"
+    description = "office"
+  }
+
+  rules {
+    action     = "bypass_waiting_room"
+    expression = "http.request.uri.path contains \"/status\""
+    enabled    = false
+  }
+}
+`, resourceName, zoneID, domain)
+}
+
+func TestWaitingRoomRulesApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	wr, err := createWaitingRoom(m.defaultClient(), testAccCloudflareZoneID, waitingRoom{
+		WaitingRoom: cloudflare.WaitingRoom{Name: "launch", Host: "www." + testAccCloudflareZoneName},
+	})
+	require.NoError(t, err)
+
+	r := p.ResourcesMap["cloudflare_waiting_room_rules"]
+	config := func(rules ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"zone_id":         testAccCloudflareZoneID,
+			"waiting_room_id": wr.ID,
+			"rules":           rules,
+		}
+	}
+	office := map[string]interface{}{
+		"action":      "bypass_waiting_room",
+		"expression":  "ip.src in {192.0.2.0/24}",
+		"description": "office",
+	}
+	status := map[string]interface{}{
+		"action":     "bypass_waiting_room",
+		"expression": `http.request.uri.path contains "/status"`,
+		"enabled":    false,
+	}
+
+	state := testMockAPIApply(t, r, m, nil, config(office, status))
+	assert.Equal(t, wr.ID, state.ID)
+	assert.Equal(t, "2", state.Attributes["rules.#"])
+	assert.Equal(t, "true", state.Attributes["rules.0.enabled"])
+	assert.Equal(t, "false", state.Attributes["rules.1.enabled"])
+	assert.NotEmpty(t, state.Attributes["rules.0.id"])
+
+	// Rules are replaced as a whole.
+	state = testMockAPIApply(t, r, m, state, config(status))
+	rules, err := waitingRoomRulesOf(m.defaultClient(), testAccCloudflareZoneID, wr.ID)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, `http.request.uri.path contains "/status"`, rules[0].Expression)
+	assert.False(t, rules[0].Enabled)
+
+	_, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	rules, err = waitingRoomRulesOf(m.defaultClient(), testAccCloudflareZoneID, wr.ID)
+	require.NoError(t, err)
+	assert.Empty(t, rules, "destroying the resource removes the rules")
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// cloudflare-go doesn't support the waiting room settings of zones, so
+// they're managed with raw requests.
+type waitingRoomSettings struct {
+	SearchEngineCrawlerBypass bool `json:"search_engine_crawler_bypass"`
+}
+
+func resourceCloudflareWaitingRoomSettings() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceCloudflareWaitingRoomSettingsSchema(),
+		CreateContext: resourceCloudflareWaitingRoomSettingsCreate,
+		ReadContext:   resourceCloudflareWaitingRoomSettingsRead,
+		UpdateContext: resourceCloudflareWaitingRoomSettingsUpdate,
+		DeleteContext: resourceCloudflareWaitingRoomSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCloudflareWaitingRoomSettingsImport,
+		},
+		Description: "Provides a Cloudflare resource to manage the waiting room settings of a zone, which apply to all of its waiting rooms. Settings are reset to their defaults when the resource is destroyed.",
+	}
+}
+
+func resourceCloudflareWaitingRoomSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(d.Get("zone_id").(string))
+
+	return resourceCloudflareWaitingRoomSettingsUpdate(ctx, d, meta)
+}
+
+func resourceCloudflareWaitingRoomSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	settings, err := waitingRoomSettingsOf(client, zoneID)
+	if err != nil {
+		var notFoundError *cloudflare.NotFoundError
+		if errors.As(err, &notFoundError) {
+			tflog.Info(ctx, fmt.Sprintf("Zone %s no longer exists", zoneID))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading waiting room settings of zone %q: %w", zoneID, err))
+	}
+
+	d.Set("search_engine_crawler_bypass", settings.SearchEngineCrawlerBypass)
+
+	return nil
+}
+
+func resourceCloudflareWaitingRoomSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	settings := waitingRoomSettings{
+		SearchEngineCrawlerBypass: d.Get("search_engine_crawler_bypass").(bool),
+	}
+	if err := updateWaitingRoomSettings(client, zoneID, settings); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating waiting room settings of zone %q: %w", zoneID, err))
+	}
+
+	return resourceCloudflareWaitingRoomSettingsRead(ctx, d, meta)
+}
+
+func resourceCloudflareWaitingRoomSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
+	zoneID := d.Get("zone_id").(string)
+
+	if err := updateWaitingRoomSettings(client, zoneID, waitingRoomSettings{}); err != nil {
+		return diag.FromErr(fmt.Errorf("error resetting waiting room settings of zone %q: %w", zoneID, err))
+	}
+
+	return nil
+}
+
+func resourceCloudflareWaitingRoomSettingsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("zone_id", d.Id())
+
+	resourceCloudflareWaitingRoomSettingsRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func waitingRoomSettingsOf(client *cloudflare.API, zoneID string) (waitingRoomSettings, error) {
+	var settings waitingRoomSettings
+	result, err := client.Raw(http.MethodGet, fmt.Sprintf("/zones/%s/waiting_rooms/settings", zoneID), nil)
+	if err != nil {
+		return settings, err
+	}
+	err = json.Unmarshal(result, &settings)
+	return settings, err
+}
+
+func updateWaitingRoomSettings(client *cloudflare.API, zoneID string, settings waitingRoomSettings) error {
+	_, err := client.Raw(http.MethodPut, fmt.Sprintf("/zones/%s/waiting_rooms/settings", zoneID), settings)
+	return err
+}
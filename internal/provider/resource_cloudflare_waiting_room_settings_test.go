@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCloudflareWaitingRoomSettings_Basic(t *testing.T) {
+	zoneID := os.Getenv("CLOUDFLARE_ZONE_ID")
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_waiting_room_settings.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareWaitingRoomSettings(rnd, zoneID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "zone_id", zoneID),
+					resource.TestCheckResourceAttr(name, "search_engine_crawler_bypass", "true"),
+				),
+			},
+			{
+				Config: testAccCloudflareWaitingRoomSettings(rnd, zoneID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "search_engine_crawler_bypass", "false"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCloudflareWaitingRoomSettings(resourceName, zoneID string, bypass bool) string {
+	return fmt.Sprintf(`
+resource "cloudflare_waiting_room_settings" "%[1]s" {
+  zone_id                      = "%[2]s"
+  search_engine_crawler_bypass = %[3]t
+}
+`, resourceName, zoneID, bypass)
+}
+
+func TestWaitingRoomSettingsApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_waiting_room_settings"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"zone_id":                      testAccCloudflareZoneID,
+		"search_engine_crawler_bypass": true,
+	})
+	diff, err := r.Diff(ctx, nil, config, m)
+	require.NoError(t, err)
+	state, diags := r.Apply(ctx, nil, diff, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, testAccCloudflareZoneID, state.ID)
+	assert.Equal(t, "true", state.Attributes["search_engine_crawler_bypass"])
+
+	diff, err = r.Diff(ctx, state, config, m)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "%v", diff)
+
+	_, diags = r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	settings, err := waitingRoomSettingsOf(m.defaultClient(), testAccCloudflareZoneID)
+	require.NoError(t, err)
+	assert.False(t, settings.SearchEngineCrawlerBypass, "destroying the resource resets the settings")
+}
@@ -6,9 +6,11 @@ import (
 	"os"
 	"testing"
 
-	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccCloudflareWaitingRoom_Create(t *testing.T) {
@@ -39,6 +41,12 @@ func TestAccCloudflareWaitingRoom_Create(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "total_active_users", "405"),
 					resource.TestCheckResourceAttr(name, "session_duration", "10"),
 					resource.TestCheckResourceAttr(name, "json_response_enabled", "true"),
+					resource.TestCheckResourceAttr(name, "queueing_method", "random"),
+					resource.TestCheckResourceAttr(name, "cookie_attributes.0.samesite", "strict"),
+					resource.TestCheckResourceAttr(name, "cookie_attributes.0.secure", "always"),
+					resource.TestCheckResourceAttr(name, "additional_routes.#", "1"),
+					resource.TestCheckResourceAttr(name, "additional_routes.0.host", "shop."+domain),
+					resource.TestCheckResourceAttr(name, "additional_routes.0.path", "/"),
 				),
 			},
 		},
@@ -46,7 +54,7 @@ func TestAccCloudflareWaitingRoom_Create(t *testing.T) {
 }
 
 func testAccCheckCloudflareWaitingRoomDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*cloudflare.API)
+	client := testAccProvider.Meta().(*providerMeta).defaultClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "cloudflare_waiting_room" {
@@ -79,6 +87,66 @@ resource "cloudflare_waiting_room" "%[1]s" {
   suspended                 = true
   queue_all                 = false
   json_response_enabled     = true
+  queueing_method           = "random"
+
+  cookie_attributes {
+    samesite = "strict"
+    secure   = "always"
+  }
+
+  additional_routes {
+    host = "shop.%[4]s"
+  }
 }
 `, resourceName, waitingRoomName, zoneID, domain, path)
 }
+
+func TestWaitingRoomApply(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+	p, m := testMockAPIProvider(t, server)
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_waiting_room"]
+	config := map[string]interface{}{
+		"zone_id":              testAccCloudflareZoneID,
+		"name":                 "launch",
+		"host":                 "www." + testAccCloudflareZoneName,
+		"path":                 "/",
+		"session_duration":     5,
+		"total_active_users":   200,
+		"new_users_per_minute": 200,
+	}
+
+	// Cookie attributes default to those of the API.
+	state := testMockAPIApply(t, r, m, nil, config)
+	assert.Equal(t, "fifo", state.Attributes["queueing_method"])
+	assert.Equal(t, "auto", state.Attributes["cookie_attributes.0.samesite"])
+
+	state = testMockAPIApply(t, r, m, state, testMockAPIConfig(config, map[string]interface{}{
+		"queueing_method": "reject",
+		"cookie_attributes": []interface{}{map[string]interface{}{
+			"secure": "always",
+		}},
+		"additional_routes": []interface{}{
+			map[string]interface{}{"host": "shop." + testAccCloudflareZoneName, "path": "/checkout"},
+		},
+	}))
+	wr, err := waitingRoomOf(m.defaultClient(), testAccCloudflareZoneID, state.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "reject", wr.QueueingMethod)
+	assert.Equal(t, &waitingRoomCookieAttributes{SameSite: "auto", Secure: "always"}, wr.CookieAttributes)
+	assert.Equal(t, []waitingRoomRoute{{Host: "shop." + testAccCloudflareZoneName, Path: "/checkout"}}, wr.AdditionalRoutes)
+
+	state = testMockAPIApply(t, r, m, state, config)
+	wr, err = waitingRoomOf(m.defaultClient(), testAccCloudflareZoneID, state.ID)
+	require.NoError(t, err)
+	assert.Empty(t, wr.AdditionalRoutes)
+
+	_, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Nil(t, state, "deleted waiting rooms are removed from state")
+}
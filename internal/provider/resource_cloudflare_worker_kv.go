@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"strings"
 
-	cloudflare "github.com/cloudflare/cloudflare-go"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -26,7 +25,10 @@ func resourceCloudflareWorkerKV() *schema.Resource {
 }
 
 func resourceCloudflareWorkersKVRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	namespaceID, key, err := parseId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -47,7 +49,10 @@ func resourceCloudflareWorkersKVRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceCloudflareWorkersKVUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	namespaceID := d.Get("namespace_id").(string)
 	key := d.Get("key").(string)
 	value := d.Get("value").(string)
@@ -65,7 +70,10 @@ func resourceCloudflareWorkersKVUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCloudflareWorkersKVDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	namespaceID, key, err := parseId(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -36,7 +36,10 @@ func getRouteFromResource(d *schema.ResourceData) cloudflare.WorkerRoute {
 }
 
 func resourceCloudflareWorkerRouteCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	route := getRouteFromResource(d)
 	zoneID := d.Get("zone_id").(string)
 
@@ -59,7 +62,10 @@ func resourceCloudflareWorkerRouteCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareWorkerRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	routeID := d.Id()
 
@@ -93,7 +99,10 @@ func resourceCloudflareWorkerRouteRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCloudflareWorkerRouteUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	route := getRouteFromResource(d)
 
@@ -108,7 +117,10 @@ func resourceCloudflareWorkerRouteUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceCloudflareWorkerRouteDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 	route := getRouteFromResource(d)
 
@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"io/ioutil"
+	"sort"
 	"strings"
 
 	cloudflare "github.com/cloudflare/cloudflare-go"
@@ -24,6 +24,7 @@ func resourceCloudflareWorkerScript() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceCloudflareWorkerScriptImport,
 		},
+		CustomizeDiff: resourceCloudflareWorkerScriptValidateModules,
 	}
 }
 
@@ -47,56 +48,271 @@ func getScriptData(d *schema.ResourceData, client *cloudflare.API) (ScriptData,
 	}, nil
 }
 
-type ScriptBindings map[string]cloudflare.WorkerBinding
+// expandWorkerScript returns the script to upload, along with its bindings
+// and settings.
+func expandWorkerScript(d *schema.ResourceData) (workerScript, error) {
+	script := workerScript{
+		Script:             d.Get("content").(string),
+		MainModule:         d.Get("main_module").(string),
+		CompatibilityDate:  d.Get("compatibility_date").(string),
+		CompatibilityFlags: expandInterfaceToStringList(d.Get("compatibility_flags").(*schema.Set).List()),
+	}
 
-func getWorkerScriptBindings(ctx context.Context, scriptName string, client *cloudflare.API) (ScriptBindings, error) {
-	resp, err := client.ListWorkerBindings(ctx, &cloudflare.WorkerRequestParams{ScriptName: scriptName})
-	if err != nil {
-		return nil, fmt.Errorf("cannot list script bindings: %w", err)
+	for _, rawData := range d.Get("module").([]interface{}) {
+		data := rawData.(map[string]interface{})
+		module := workerModule{
+			Name:    data["name"].(string),
+			Type:    data["type"].(string),
+			Content: []byte(data["content"].(string)),
+		}
+		if module.binary() {
+			content, err := base64.StdEncoding.DecodeString(data["content"].(string))
+			if err != nil {
+				return workerScript{}, fmt.Errorf("content of %s module %q must be base64 encoded: %w", module.Type, module.Name, err)
+			}
+			module.Content = content
+		}
+		script.Modules = append(script.Modules, module)
 	}
 
-	bindings := make(ScriptBindings, len(resp.BindingList))
+	for _, rawData := range d.Get("kv_namespace_binding").(*schema.Set).List() {
+		data := rawData.(map[string]interface{})
+		script.Bindings = append(script.Bindings, workerBinding{Meta: map[string]interface{}{
+			"name":         data["name"],
+			"type":         cloudflare.WorkerKvNamespaceBindingType,
+			"namespace_id": data["namespace_id"],
+		}})
+	}
 
-	for _, b := range resp.BindingList {
-		bindings[b.Name] = b.Binding
+	for _, rawData := range d.Get("r2_bucket_binding").(*schema.Set).List() {
+		data := rawData.(map[string]interface{})
+		script.Bindings = append(script.Bindings, workerBinding{Meta: map[string]interface{}{
+			"name":        data["name"],
+			"type":        workerR2BucketBindingType,
+			"bucket_name": data["bucket_name"],
+		}})
 	}
 
-	return bindings, nil
-}
+	for _, rawData := range d.Get("service_binding").(*schema.Set).List() {
+		data := rawData.(map[string]interface{})
+		meta := map[string]interface{}{
+			"name":    data["name"],
+			"type":    workerServiceBindingType,
+			"service": data["service"],
+		}
+		// Bindings without an environment bind to the production one.
+		if environment := data["environment"].(string); environment != "" {
+			meta["environment"] = environment
+		}
+		script.Bindings = append(script.Bindings, workerBinding{Meta: meta})
+	}
 
-func parseWorkerBindings(d *schema.ResourceData, bindings ScriptBindings) {
-	for _, rawData := range d.Get("kv_namespace_binding").(*schema.Set).List() {
+	for _, rawData := range d.Get("durable_object_namespace_binding").(*schema.Set).List() {
 		data := rawData.(map[string]interface{})
-		bindings[data["name"].(string)] = cloudflare.WorkerKvNamespaceBinding{
-			NamespaceID: data["namespace_id"].(string),
+		meta := map[string]interface{}{
+			"name":       data["name"],
+			"type":       workerDurableObjectNamespaceBindingType,
+			"class_name": data["class_name"],
 		}
+		// Classes of the script itself are bound without a script name.
+		if scriptName := data["script_name"].(string); scriptName != "" {
+			meta["script_name"] = scriptName
+		}
+		script.Bindings = append(script.Bindings, workerBinding{Meta: meta})
+	}
+
+	for _, rawData := range d.Get("queue_binding").(*schema.Set).List() {
+		data := rawData.(map[string]interface{})
+		script.Bindings = append(script.Bindings, workerBinding{Meta: map[string]interface{}{
+			"name":       data["name"],
+			"type":       workerQueueBindingType,
+			"queue_name": data["queue_name"],
+		}})
+	}
+
+	for _, rawData := range d.Get("analytics_engine_binding").(*schema.Set).List() {
+		data := rawData.(map[string]interface{})
+		script.Bindings = append(script.Bindings, workerBinding{Meta: map[string]interface{}{
+			"name":    data["name"],
+			"type":    workerAnalyticsEngineBindingType,
+			"dataset": data["dataset"],
+		}})
 	}
 
 	for _, rawData := range d.Get("plain_text_binding").(*schema.Set).List() {
 		data := rawData.(map[string]interface{})
-		bindings[data["name"].(string)] = cloudflare.WorkerPlainTextBinding{
-			Text: data["text"].(string),
-		}
+		script.Bindings = append(script.Bindings, workerBinding{Meta: map[string]interface{}{
+			"name": data["name"],
+			"type": cloudflare.WorkerPlainTextBindingType,
+			"text": data["text"],
+		}})
 	}
 
 	for _, rawData := range d.Get("secret_text_binding").(*schema.Set).List() {
 		data := rawData.(map[string]interface{})
-		bindings[data["name"].(string)] = cloudflare.WorkerSecretTextBinding{
-			Text: data["text"].(string),
-		}
+		script.Bindings = append(script.Bindings, workerBinding{Meta: map[string]interface{}{
+			"name": data["name"],
+			"type": cloudflare.WorkerSecretTextBindingType,
+			"text": data["text"],
+		}})
 	}
 
 	for _, rawData := range d.Get("webassembly_binding").(*schema.Set).List() {
 		data := rawData.(map[string]interface{})
-		module := base64.NewDecoder(base64.StdEncoding, strings.NewReader(data["module"].(string)))
-		bindings[data["name"].(string)] = cloudflare.WorkerWebAssemblyBinding{
-			Module: module,
+		name := data["name"].(string)
+		module, err := base64.StdEncoding.DecodeString(data["module"].(string))
+		if err != nil {
+			return workerScript{}, fmt.Errorf("module of webassembly binding %q must be base64 encoded: %w", name, err)
+		}
+		script.Bindings = append(script.Bindings, workerBinding{
+			Meta: map[string]interface{}{
+				"name": name,
+				"type": cloudflare.WorkerWebAssemblyBindingType,
+				"part": name,
+			},
+			Part: &workerModule{Name: name, Type: "compiled_wasm", Content: module},
+		})
+	}
+
+	sort.Slice(script.Bindings, func(i, j int) bool {
+		return script.Bindings[i].Meta["name"].(string) < script.Bindings[j].Meta["name"].(string)
+	})
+
+	return script, nil
+}
+
+// pendingWorkerMigrations returns the configured migrations that follow the
+// one tagged appliedTag, or nil if there are none.
+func pendingWorkerMigrations(migrations []interface{}, appliedTag string) (*workerMigrations, error) {
+	pending := migrations
+	if appliedTag != "" {
+		i := workerMigrationIndex(migrations, appliedTag)
+		if i < 0 {
+			return nil, fmt.Errorf("migration %q applied to the script isn't one of the configured migrations", appliedTag)
+		}
+		pending = migrations[i+1:]
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	out := &workerMigrations{OldTag: appliedTag}
+	for _, rawData := range pending {
+		data := rawData.(map[string]interface{})
+		step := workerMigrationStep{
+			NewClasses:     expandInterfaceToStringList(data["new_classes"]),
+			DeletedClasses: expandInterfaceToStringList(data["deleted_classes"]),
+		}
+		for _, rawRename := range data["renamed_classes"].([]interface{}) {
+			rename := rawRename.(map[string]interface{})
+			step.RenamedClasses = append(step.RenamedClasses, workerMigrationRenamedClass{
+				From: rename["from"].(string),
+				To:   rename["to"].(string),
+			})
+		}
+		out.NewTag = data["tag"].(string)
+		out.Steps = append(out.Steps, step)
+	}
+
+	return out, nil
+}
+
+// appliedWorkerMigrations returns the migrations up to the one tagged
+// appliedTag. Migrations are left as they are if the tag isn't one of them.
+func appliedWorkerMigrations(migrations []interface{}, appliedTag string) []interface{} {
+	if appliedTag == "" {
+		return []interface{}{}
+	}
+	i := workerMigrationIndex(migrations, appliedTag)
+	if i < 0 {
+		return migrations
+	}
+	return migrations[:i+1]
+}
+
+func workerMigrationIndex(migrations []interface{}, tag string) int {
+	for i, rawData := range migrations {
+		if rawData.(map[string]interface{})["tag"] == tag {
+			return i
+		}
+	}
+	return -1
+}
+
+func workerModuleExists(modules []workerModule, name string) bool {
+	for _, m := range modules {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenWorkerModules returns the modules of a Worker with the hash of their
+// content, in the order they are configured in.
+func flattenWorkerModules(configured []interface{}, modules []workerModule) []interface{} {
+	order := make(map[string]int, len(configured))
+	for i, rawData := range configured {
+		order[rawData.(map[string]interface{})["name"].(string)] = i
+	}
+	sorted := make([]workerModule, len(modules))
+	copy(sorted, modules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		oi, ok := order[sorted[i].Name]
+		if !ok {
+			return false
+		}
+		oj, ok := order[sorted[j].Name]
+		return !ok || oi < oj
+	})
+
+	out := make([]interface{}, 0, len(sorted))
+	for _, m := range sorted {
+		content := string(m.Content)
+		if m.binary() {
+			content = base64.StdEncoding.EncodeToString(m.Content)
+		}
+		out = append(out, map[string]interface{}{
+			"name":    m.Name,
+			"type":    m.Type,
+			"content": hashWorkerModuleContent(content),
+		})
+	}
+
+	return out
+}
+
+func resourceCloudflareWorkerScriptValidateModules(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	mainModule := d.Get("main_module").(string)
+	if mainModule == "" || !d.NewValueKnown("main_module") {
+		return nil
+	}
+
+	names := make(map[string]bool)
+	for i := range d.Get("module").([]interface{}) {
+		key := fmt.Sprintf("module.%d.name", i)
+		if !d.NewValueKnown(key) {
+			return nil
 		}
+		name := d.Get(key).(string)
+		if names[name] {
+			return fmt.Errorf("module names must be unique, %q is used more than once", name)
+		}
+		names[name] = true
+	}
+
+	if !names[mainModule] {
+		return fmt.Errorf("main_module %q must be the name of one of the modules", mainModule)
 	}
+
+	return nil
 }
 
 func resourceCloudflareWorkerScriptCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	scriptData, err := getScriptData(d, client)
 	if err != nil {
@@ -109,23 +325,22 @@ func resourceCloudflareWorkerScriptCreate(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(fmt.Errorf("script already exists"))
 	}
 
-	scriptBody := d.Get("content").(string)
-	if scriptBody == "" {
+	script, err := expandWorkerScript(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if script.MainModule == "" && script.Script == "" {
 		return diag.FromErr(fmt.Errorf("script content cannot be empty"))
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Creating Cloudflare Worker Script from struct: %+v", &scriptData.Params))
-
-	bindings := make(ScriptBindings)
-
-	parseWorkerBindings(d, bindings)
-
-	scriptParams := cloudflare.WorkerScriptParams{
-		Script:   scriptBody,
-		Bindings: bindings,
+	script.Migrations, err = pendingWorkerMigrations(d.Get("migration").([]interface{}), "")
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	_, err = client.UploadWorkerWithBindings(ctx, &scriptData.Params, &scriptParams)
+	tflog.Info(ctx, fmt.Sprintf("Creating Cloudflare Worker Script from struct: %+v", &scriptData.Params))
+
+	err = uploadWorkerScript(ctx, meta, client, scriptData.Params.ScriptName, script)
 	if err != nil {
 		return diag.FromErr(errors.Wrap(err, "error creating worker script"))
 	}
@@ -136,14 +351,17 @@ func resourceCloudflareWorkerScriptCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceCloudflareWorkerScriptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	scriptData, err := getScriptData(d, client)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	r, err := client.DownloadWorker(ctx, &scriptData.Params)
+	script, err := downloadWorkerScript(ctx, meta, client, scriptData.Params.ScriptName)
 	if err != nil {
 		// If the resource is deleted, we should set the ID to "" and not
 		// return an error according to the terraform spec
@@ -156,44 +374,76 @@ func resourceCloudflareWorkerScriptRead(ctx context.Context, d *schema.ResourceD
 			fmt.Sprintf("Error reading worker script from API for resource %+v", &scriptData.Params)))
 	}
 
-	existingBindings := make(ScriptBindings)
-
-	parseWorkerBindings(d, existingBindings)
-
-	bindings, err := getWorkerScriptBindings(ctx, d.Get("name").(string), client)
+	settings, err := getWorkerScriptSettings(ctx, meta, client, scriptData.Params.ScriptName)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(fmt.Errorf("cannot read script settings: %w", err))
+	}
+
+	// Secret values are write only, so the configured ones are kept.
+	secretTexts := make(map[string]interface{})
+	for _, rawData := range d.Get("secret_text_binding").(*schema.Set).List() {
+		data := rawData.(map[string]interface{})
+		secretTexts[data["name"].(string)] = data["text"]
 	}
 
 	kvNamespaceBindings := &schema.Set{F: schema.HashResource(kvNamespaceBindingResource)}
+	r2BucketBindings := &schema.Set{F: schema.HashResource(r2BucketBindingResource)}
+	serviceBindings := &schema.Set{F: hashWorkerServiceBinding}
+	durableObjectNamespaceBindings := &schema.Set{F: schema.HashResource(durableObjectNamespaceBindingResource)}
+	queueBindings := &schema.Set{F: schema.HashResource(queueBindingResource)}
+	analyticsEngineBindings := &schema.Set{F: schema.HashResource(analyticsEngineBindingResource)}
 	plainTextBindings := &schema.Set{F: schema.HashResource(plainTextBindingResource)}
 	secretTextBindings := &schema.Set{F: schema.HashResource(secretTextBindingResource)}
 	webAssemblyBindings := &schema.Set{F: schema.HashResource(webAssemblyBindingResource)}
 
-	for name, binding := range bindings {
-		switch v := binding.(type) {
-		case cloudflare.WorkerKvNamespaceBinding:
+	for _, b := range settings.Bindings {
+		name, _ := b["name"].(string)
+		switch b["type"] {
+		case string(cloudflare.WorkerKvNamespaceBindingType):
 			kvNamespaceBindings.Add(map[string]interface{}{
 				"name":         name,
-				"namespace_id": v.NamespaceID,
+				"namespace_id": b["namespace_id"],
+			})
+		case workerR2BucketBindingType:
+			r2BucketBindings.Add(map[string]interface{}{
+				"name":        name,
+				"bucket_name": b["bucket_name"],
 			})
-		case cloudflare.WorkerPlainTextBinding:
+		case workerServiceBindingType:
+			serviceBindings.Add(map[string]interface{}{
+				"name":        name,
+				"service":     b["service"],
+				"environment": b["environment"],
+			})
+		case workerDurableObjectNamespaceBindingType:
+			scriptName, _ := b["script_name"].(string)
+			durableObjectNamespaceBindings.Add(map[string]interface{}{
+				"name":        name,
+				"class_name":  b["class_name"],
+				"script_name": scriptName,
+			})
+		case workerQueueBindingType:
+			queueBindings.Add(map[string]interface{}{
+				"name":       name,
+				"queue_name": b["queue_name"],
+			})
+		case workerAnalyticsEngineBindingType:
+			analyticsEngineBindings.Add(map[string]interface{}{
+				"name":    name,
+				"dataset": b["dataset"],
+			})
+		case string(cloudflare.WorkerPlainTextBindingType):
 			plainTextBindings.Add(map[string]interface{}{
 				"name": name,
-				"text": v.Text,
+				"text": b["text"],
 			})
-		case cloudflare.WorkerSecretTextBinding:
-			value := v.Text
-			switch v := existingBindings[name].(type) {
-			case cloudflare.WorkerSecretTextBinding:
-				value = v.Text
-			}
+		case string(cloudflare.WorkerSecretTextBindingType):
 			secretTextBindings.Add(map[string]interface{}{
 				"name": name,
-				"text": value,
+				"text": secretTexts[name],
 			})
-		case cloudflare.WorkerWebAssemblyBinding:
-			module, err := ioutil.ReadAll(v.Module)
+		case string(cloudflare.WorkerWebAssemblyBindingType):
+			module, err := downloadWorkerBindingContent(ctx, meta, client, scriptData.Params.ScriptName, name)
 			if err != nil {
 				return diag.FromErr(errors.Wrap(err, fmt.Sprintf("cannot read contents of wasm bindings (%s)", name)))
 			}
@@ -204,14 +454,60 @@ func resourceCloudflareWorkerScriptRead(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
-	if err := d.Set("content", r.Script); err != nil {
+	if err := d.Set("content", script.Script); err != nil {
 		return diag.FromErr(fmt.Errorf("cannot set content: %w", err))
 	}
 
+	// The main module isn't part of the download, other than being its first
+	// part, so the configured one is kept while it still exists.
+	mainModule := d.Get("main_module").(string)
+	if !workerModuleExists(script.Modules, mainModule) {
+		mainModule = script.MainModule
+	}
+	if err := d.Set("main_module", mainModule); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot set main module: %w", err))
+	}
+
+	if err := d.Set("module", flattenWorkerModules(d.Get("module").([]interface{}), script.Modules)); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot set modules (%s): %w", d.Id(), err))
+	}
+
+	if err := d.Set("compatibility_date", settings.CompatibilityDate); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot set compatibility date (%s): %w", d.Id(), err))
+	}
+
+	if err := d.Set("compatibility_flags", settings.CompatibilityFlags); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot set compatibility flags (%s): %w", d.Id(), err))
+	}
+
 	if err := d.Set("kv_namespace_binding", kvNamespaceBindings); err != nil {
 		return diag.FromErr(fmt.Errorf("cannot set kv namespace bindings (%s): %w", d.Id(), err))
 	}
 
+	if err := d.Set("r2_bucket_binding", r2BucketBindings); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot set r2 bucket bindings (%s): %w", d.Id(), err))
+	}
+
+	if err := d.Set("service_binding", serviceBindings); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot set service bindings (%s): %w", d.Id(), err))
+	}
+
+	if err := d.Set("durable_object_namespace_binding", durableObjectNamespaceBindings); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot set durable object namespace bindings (%s): %w", d.Id(), err))
+	}
+
+	if err := d.Set("queue_binding", queueBindings); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot set queue bindings (%s): %w", d.Id(), err))
+	}
+
+	if err := d.Set("analytics_engine_binding", analyticsEngineBindings); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot set analytics engine bindings (%s): %w", d.Id(), err))
+	}
+
+	if err := d.Set("migration", appliedWorkerMigrations(d.Get("migration").([]interface{}), settings.MigrationTag)); err != nil {
+		return diag.FromErr(fmt.Errorf("cannot set migrations (%s): %w", d.Id(), err))
+	}
+
 	if err := d.Set("plain_text_binding", plainTextBindings); err != nil {
 		return diag.FromErr(fmt.Errorf("cannot set plain text bindings (%s): %w", d.Id(), err))
 	}
@@ -228,30 +524,36 @@ func resourceCloudflareWorkerScriptRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareWorkerScriptUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	scriptData, err := getScriptData(d, client)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	scriptBody := d.Get("content").(string)
-	if scriptBody == "" {
+	script, err := expandWorkerScript(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if script.MainModule == "" && script.Script == "" {
 		return diag.FromErr(fmt.Errorf("script content cannot be empty"))
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("Updating Cloudflare Worker Script from struct: %+v", &scriptData.Params))
-
-	bindings := make(ScriptBindings)
-
-	parseWorkerBindings(d, bindings)
-
-	scriptParams := cloudflare.WorkerScriptParams{
-		Script:   scriptBody,
-		Bindings: bindings,
+	settings, err := getWorkerScriptSettings(ctx, meta, client, scriptData.Params.ScriptName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading migrations of worker script: %w", err))
+	}
+	script.Migrations, err = pendingWorkerMigrations(d.Get("migration").([]interface{}), settings.MigrationTag)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	_, err = client.UploadWorkerWithBindings(ctx, &scriptData.Params, &scriptParams)
+	tflog.Info(ctx, fmt.Sprintf("Updating Cloudflare Worker Script from struct: %+v", &scriptData.Params))
+
+	err = uploadWorkerScript(ctx, meta, client, scriptData.Params.ScriptName, script)
 	if err != nil {
 		return diag.FromErr(errors.Wrap(err, "error updating worker script"))
 	}
@@ -260,7 +562,10 @@ func resourceCloudflareWorkerScriptUpdate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceCloudflareWorkerScriptDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	scriptData, err := getScriptData(d, client)
 	if err != nil {
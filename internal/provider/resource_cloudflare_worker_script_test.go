@@ -1,14 +1,23 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"mime"
+	"mime/multipart"
+	"os"
 	"strings"
 	"testing"
 
 	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -60,6 +69,106 @@ func TestAccCloudflareWorkerScript_MultiScriptEnt(t *testing.T) {
 	})
 }
 
+func TestAccCloudflareWorkerScript_R2Binding(t *testing.T) {
+	t.Parallel()
+
+	var script cloudflare.WorkerScript
+	rnd := generateRandomResourceName()
+	name := "cloudflare_worker_script." + rnd
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareWorkerScriptDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareWorkerScriptConfigR2Binding(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudflareWorkerScriptExists(name, &script, []string{"MY_BUCKET"}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "r2_bucket_binding.*", map[string]string{
+						"name":        "MY_BUCKET",
+						"bucket_name": rnd,
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareWorkerScript_ModuleFormat(t *testing.T) {
+	t.Parallel()
+
+	var script cloudflare.WorkerScript
+	rnd := generateRandomResourceName()
+	name := "cloudflare_worker_script." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareWorkerScriptDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareWorkerScriptConfigModuleFormat(rnd, "test 1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudflareWorkerScriptExists(name, &script, []string{"MY_PLAIN_TEXT"}),
+					resource.TestCheckResourceAttr(name, "main_module", "index.mjs"),
+					resource.TestCheckResourceAttr(name, "module.#", "3"),
+					resource.TestCheckResourceAttr(name, "module.1.content", hashWorkerModuleContent("test 1")),
+					resource.TestCheckResourceAttr(name, "content", ""),
+				),
+			},
+			{
+				Config: testAccCheckCloudflareWorkerScriptConfigModuleFormat(rnd, "test 2"),
+				Check:  resource.TestCheckResourceAttr(name, "module.1.content", hashWorkerModuleContent("test 2")),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareWorkerScriptConfigModuleFormat(rnd, greeting string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_worker_script" "%[1]s" {
+  name                = "%[1]s"
+  main_module         = "index.mjs"
+  compatibility_date  = "2022-07-12"
+  compatibility_flags = ["nodejs_compat"]
+
+  module {
+    name    = "index.mjs"
+    content = "import greeting from './greeting.txt'; import wasm from './empty.wasm'; export default { fetch() { return new Response(greeting) } }"
+  }
+
+  module {
+    name    = "greeting.txt"
+    type    = "text"
+    content = "%[2]s"
+  }
+
+  module {
+    name    = "empty.wasm"
+    type    = "compiled_wasm"
+    content = "%[3]s"
+  }
+
+  plain_text_binding {
+    name = "MY_PLAIN_TEXT"
+    text = "%[1]s"
+  }
+}`, rnd, greeting, encodedWasm)
+}
+
 func testAccCheckCloudflareWorkerScriptConfigMultiScriptInitial(rnd string) string {
 	return fmt.Sprintf(`
 resource "cloudflare_worker_script" "%[1]s" {
@@ -108,6 +217,24 @@ resource "cloudflare_worker_script" "%[1]s" {
 }`, rnd, scriptContent2, encodedWasm)
 }
 
+func testAccCheckCloudflareWorkerScriptConfigR2Binding(rnd, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_r2_bucket" "%[1]s" {
+  account_id = "%[3]s"
+  name       = "%[1]s"
+}
+
+resource "cloudflare_worker_script" "%[1]s" {
+  name    = "%[1]s"
+  content = "%[2]s"
+
+  r2_bucket_binding {
+    name        = "MY_BUCKET"
+    bucket_name = cloudflare_r2_bucket.%[1]s.name
+  }
+}`, rnd, scriptContent2, accountID)
+}
+
 func getRequestParamsFromResource(rs *terraform.ResourceState) cloudflare.WorkerRequestParams {
 	params := cloudflare.WorkerRequestParams{
 		ScriptName: rs.Primary.Attributes["name"],
@@ -127,7 +254,7 @@ func testAccCheckCloudflareWorkerScriptExists(n string, script *cloudflare.Worke
 			return fmt.Errorf("No Worker Script ID is set")
 		}
 
-		client := testAccProvider.Meta().(*cloudflare.API)
+		client := testAccProvider.Meta().(*providerMeta).defaultClient()
 		params := getRequestParamsFromResource(rs)
 		r, err := client.DownloadWorker(context.Background(), &params)
 		if err != nil {
@@ -139,13 +266,17 @@ func testAccCheckCloudflareWorkerScriptExists(n string, script *cloudflare.Worke
 		}
 
 		name := strings.Replace(n, "cloudflare_worker_script.", "", -1)
-		foundBindings, err := getWorkerScriptBindings(context.Background(), name, client)
+		settings, err := getWorkerScriptSettings(context.Background(), testAccProvider.Meta(), client, name)
 		if err != nil {
-			return fmt.Errorf("cannot list script bindings: %w", err)
+			return fmt.Errorf("cannot read script settings: %w", err)
 		}
 
+		foundBindings := make(map[interface{}]bool)
+		for _, binding := range settings.Bindings {
+			foundBindings[binding["name"]] = true
+		}
 		for _, binding := range bindings {
-			if _, ok := foundBindings[binding]; !ok {
+			if !foundBindings[binding] {
 				return fmt.Errorf("cannot find binding with name %s", binding)
 			}
 		}
@@ -161,7 +292,7 @@ func testAccCheckCloudflareWorkerScriptDestroy(s *terraform.State) error {
 			continue
 		}
 
-		client := testAccProvider.Meta().(*cloudflare.API)
+		client := testAccProvider.Meta().(*providerMeta).defaultClient()
 		params := getRequestParamsFromResource(rs)
 		r, _ := client.DownloadWorker(context.Background(), &params)
 
@@ -172,3 +303,204 @@ func testAccCheckCloudflareWorkerScriptDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestWorkerScriptModuleFormat(t *testing.T) {
+	for _, env := range []string{"CLOUDFLARE_API_TOKEN", "CLOUDFLARE_API_KEY", "CLOUDFLARE_EMAIL", "CLOUDFLARE_ACCOUNT_ID", "CLOUDFLARE_PROFILE", "CLOUDFLARE_REQUEST_RECORDING_PATH"} {
+		t.Setenv(env, "")
+	}
+
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	p := New("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_token":    "0123456789abcdefghijklmnopqrstuvwxyzABCD",
+		"api_hostname": server.Hostname(),
+		"account_id":   testAccCloudflareAccountID,
+		"rps":          1000,
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+	m := p.Meta().(*providerMeta)
+	client := m.defaultClient()
+	client.BaseURL = strings.Replace(client.BaseURL, "https://", "http://", 1)
+
+	modules := []interface{}{
+		map[string]interface{}{"name": "index.mjs", "content": "import greeting from './greeting.txt'"},
+		map[string]interface{}{"name": "greeting.txt", "type": "text", "content": "hello"},
+		map[string]interface{}{"name": "empty.wasm", "type": "compiled_wasm", "content": encodedWasm},
+	}
+	r := p.ResourcesMap["cloudflare_worker_script"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                "modules",
+		"main_module":         "index.mjs",
+		"module":              modules,
+		"compatibility_date":  "2022-07-12",
+		"compatibility_flags": []interface{}{"nodejs_compat"},
+		"plain_text_binding":  []interface{}{map[string]interface{}{"name": "GREETING", "text": "hello"}},
+		"r2_bucket_binding":   []interface{}{map[string]interface{}{"name": "ASSETS", "bucket_name": "assets"}},
+	})
+
+	ctx := context.Background()
+	diags = r.CreateContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	diags = r.ReadContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	assert.Equal(t, "modules", d.Id())
+	assert.Equal(t, "", d.Get("content"))
+	assert.Equal(t, "index.mjs", d.Get("main_module"))
+	for i, rawData := range modules {
+		module := rawData.(map[string]interface{})
+		assert.Equal(t, module["name"], d.Get(fmt.Sprintf("module.%d.name", i)))
+		assert.Equal(t, hashWorkerModuleContent(module["content"]), d.Get(fmt.Sprintf("module.%d.content", i)),
+			"the hash of the uploaded module matches the hash of its configured content")
+	}
+	assert.Equal(t, "compiled_wasm", d.Get("module.2.type"))
+	assert.Equal(t, 1, d.Get("plain_text_binding.#"))
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "ASSETS", "bucket_name": "assets"}}, d.Get("r2_bucket_binding").(*schema.Set).List(),
+		"bindings cloudflare-go doesn't know are read back")
+
+	script, err := downloadWorkerScript(ctx, m, client, "modules")
+	require.NoError(t, err)
+	require.Len(t, script.Modules, 3)
+	wasm, _ := base64.StdEncoding.DecodeString(encodedWasm)
+	assert.Equal(t, workerModule{Name: "empty.wasm", Type: "compiled_wasm", Content: wasm}, script.Modules[2])
+
+	assert.Equal(t, "2022-07-12", d.Get("compatibility_date"))
+	assert.Equal(t, []interface{}{"nodejs_compat"}, d.Get("compatibility_flags").(*schema.Set).List())
+
+	// Compatibility settings changed outside of Terraform are read back.
+	script, err = expandWorkerScript(d)
+	require.NoError(t, err)
+	script.CompatibilityDate = "2022-09-01"
+	script.CompatibilityFlags = []string{"streams_enable_constructors"}
+	require.NoError(t, uploadWorkerScript(ctx, m, client, "modules", script))
+	diags = r.ReadContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "2022-09-01", d.Get("compatibility_date"))
+	assert.Equal(t, []interface{}{"streams_enable_constructors"}, d.Get("compatibility_flags").(*schema.Set).List())
+
+	diags = r.DeleteContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	diags = r.ReadContext(ctx, d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestWorkerScriptDurableObjects(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+	p, m := testMockAPIProvider(t, server)
+	m.defaultClient().AccountID = testAccCloudflareAccountID
+	ctx := context.Background()
+
+	r := p.ResourcesMap["cloudflare_worker_script"]
+	migrations := []interface{}{
+		map[string]interface{}{"tag": "v1", "new_classes": []interface{}{"Counter", "Lock"}},
+		map[string]interface{}{"tag": "v2", "renamed_classes": []interface{}{map[string]interface{}{"from": "Counter", "to": "Tally"}}},
+		map[string]interface{}{"tag": "v3", "deleted_classes": []interface{}{"Lock"}},
+	}
+	config := func(migrations []interface{}, className string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":      "durable",
+			"content":   scriptContent1,
+			"migration": migrations,
+			"durable_object_namespace_binding": []interface{}{
+				map[string]interface{}{"name": "COUNTER", "class_name": className},
+				map[string]interface{}{"name": "ROOMS", "class_name": "Room", "script_name": "chat"},
+			},
+			"service_binding":          []interface{}{map[string]interface{}{"name": "AUTH", "service": "auth"}},
+			"queue_binding":            []interface{}{map[string]interface{}{"name": "JOBS", "queue_name": "jobs"}},
+			"analytics_engine_binding": []interface{}{map[string]interface{}{"name": "EVENTS", "dataset": "events"}},
+		}
+	}
+
+	state := testMockAPIApply(t, r, m, nil, config(migrations[:1], "Counter"))
+	assert.Equal(t, "1", state.Attributes["migration.#"])
+	// Services are bound to the environment the API defaults to.
+	state, diags := r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	serviceBinding := fmt.Sprintf("service_binding.%d.environment", hashWorkerServiceBinding(map[string]interface{}{"name": "AUTH", "service": "auth"}))
+	assert.Equal(t, "production", state.Attributes[serviceBinding])
+	diff, err := testMockAPIDiff(t, r, m, state, config(migrations[:1], "Counter"))
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "%v", diff)
+	for _, attr := range []string{"durable_object_namespace_binding.#", "queue_binding.#", "analytics_engine_binding.#"} {
+		assert.NotEqual(t, "0", state.Attributes[attr], attr)
+	}
+
+	// Only the migrations following the applied one are sent, all at once.
+	state = testMockAPIApply(t, r, m, state, config(migrations, "Tally"))
+	assert.Equal(t, "3", state.Attributes["migration.#"])
+	assert.Equal(t, "v3", state.Attributes["migration.2.tag"])
+
+	// Migrations the state doesn't know were applied aren't applied again.
+	delete(state.Attributes, "migration.2.tag")
+	delete(state.Attributes, "migration.2.deleted_classes.#")
+	delete(state.Attributes, "migration.2.deleted_classes.0")
+	state.Attributes["migration.#"] = "2"
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "2", state.Attributes["migration.#"])
+	state = testMockAPIApply(t, r, m, state, config(migrations, "Tally"))
+	assert.Equal(t, "3", state.Attributes["migration.#"])
+}
+
+func TestPendingWorkerMigrations(t *testing.T) {
+	migrations := []interface{}{
+		map[string]interface{}{"tag": "v1", "new_classes": []interface{}{"Counter"}, "renamed_classes": []interface{}{}, "deleted_classes": []interface{}{}},
+		map[string]interface{}{"tag": "v2", "new_classes": []interface{}{}, "renamed_classes": []interface{}{}, "deleted_classes": []interface{}{"Counter"}},
+	}
+
+	pending, err := pendingWorkerMigrations(migrations, "")
+	require.NoError(t, err)
+	assert.Equal(t, &workerMigrations{NewTag: "v2", Steps: []workerMigrationStep{
+		{NewClasses: []string{"Counter"}, DeletedClasses: []string{}},
+		{NewClasses: []string{}, DeletedClasses: []string{"Counter"}},
+	}}, pending)
+
+	pending, err = pendingWorkerMigrations(migrations, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", pending.OldTag)
+	assert.Len(t, pending.Steps, 1)
+
+	pending, err = pendingWorkerMigrations(migrations, "v2")
+	require.NoError(t, err)
+	assert.Nil(t, pending)
+
+	_, err = pendingWorkerMigrations(migrations, "v0")
+	assert.EqualError(t, err, `migration "v0" applied to the script isn't one of the configured migrations`)
+}
+
+func TestWorkerScriptMultipartBody(t *testing.T) {
+	script := workerScript{
+		MainModule:         "index.mjs",
+		Modules:            []workerModule{{Name: "index.mjs", Type: "esm", Content: []byte("export default {}")}},
+		Bindings:           []workerBinding{{Meta: map[string]interface{}{"name": "KV", "type": "kv_namespace", "namespace_id": "abc"}}},
+		CompatibilityDate:  "2022-07-12",
+		CompatibilityFlags: []string{"nodejs_compat"},
+	}
+	contentType, body, err := script.multipartBody()
+	require.NoError(t, err)
+
+	_, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+	form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(1 << 20)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"main_module": "index.mjs",
+		"bindings": [{"name": "KV", "type": "kv_namespace", "namespace_id": "abc"}],
+		"compatibility_date": "2022-07-12",
+		"compatibility_flags": ["nodejs_compat"]
+	}`, form.Value["metadata"][0])
+	require.Len(t, form.File["index.mjs"], 1)
+	assert.Equal(t, "application/javascript+module", form.File["index.mjs"][0].Header.Get("Content-Type"))
+
+	script.MainModule = ""
+	script.Script = "addEventListener('fetch', () => {})"
+	_, body, err = script.multipartBody()
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"body_part":"script"`)
+	assert.Contains(t, string(body), `name="script"; filename="script"`)
+}
@@ -25,7 +25,10 @@ func resourceCloudflareWorkersKVNamespace() *schema.Resource {
 }
 
 func resourceCloudflareWorkersKVNamespaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	req := &cloudflare.WorkersKVNamespaceRequest{
 		Title: d.Get("title").(string),
@@ -50,7 +53,10 @@ func resourceCloudflareWorkersKVNamespaceCreate(ctx context.Context, d *schema.R
 }
 
 func resourceCloudflareWorkersKVNamespaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	namespaceID := d.Id()
 
 	resp, err := client.ListWorkersKVNamespaces(ctx)
@@ -75,7 +81,10 @@ func resourceCloudflareWorkersKVNamespaceRead(ctx context.Context, d *schema.Res
 }
 
 func resourceCloudflareWorkersKVNamespaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	namespace := &cloudflare.WorkersKVNamespaceRequest{
 		Title: d.Get("title").(string),
@@ -92,7 +101,10 @@ func resourceCloudflareWorkersKVNamespaceUpdate(ctx context.Context, d *schema.R
 }
 
 func resourceCloudflareWorkersKVNamespaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	tflog.Info(ctx, fmt.Sprintf("Deleting Cloudflare Workers KV Namespace with id: %+v", d.Id()))
 
@@ -105,7 +117,10 @@ func resourceCloudflareWorkersKVNamespaceDelete(ctx context.Context, d *schema.R
 }
 
 func resourceCloudflareWorkersKVNamespaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return nil, clientErr
+	}
 
 	namespaces, err := client.ListWorkersKVNamespaces(ctx)
 	var title string
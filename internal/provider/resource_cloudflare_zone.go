@@ -89,7 +89,10 @@ func resourceCloudflareZone() *schema.Resource {
 }
 
 func resourceCloudflareZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	zoneName := d.Get("zone").(string)
 	jumpstart := d.Get("jump_start").(bool)
@@ -135,7 +138,10 @@ func resourceCloudflareZoneCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceCloudflareZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Id()
 
 	zone, err := client.ZoneDetails(ctx, zoneID)
@@ -176,7 +182,10 @@ func resourceCloudflareZoneRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceCloudflareZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Id()
 	zone, _ := client.ZoneDetails(ctx, zoneID)
 
@@ -224,7 +233,10 @@ func resourceCloudflareZoneUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceCloudflareZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Id()
 
 	log.Printf("[INFO] Deleting Cloudflare Zone: id %s", zoneID)
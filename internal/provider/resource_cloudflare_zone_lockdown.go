@@ -25,7 +25,10 @@ func resourceCloudflareZoneLockdown() *schema.Resource {
 }
 
 func resourceCloudflareZoneLockdownCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	var err error
@@ -74,7 +77,10 @@ func resourceCloudflareZoneLockdownCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceCloudflareZoneLockdownRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	zoneLockdownResponse, err := client.ZoneLockdown(ctx, zoneID, d.Id())
@@ -117,7 +123,10 @@ func resourceCloudflareZoneLockdownRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceCloudflareZoneLockdownUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	var newZoneLockdown cloudflare.ZoneLockdown
@@ -162,7 +171,10 @@ func resourceCloudflareZoneLockdownUpdate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceCloudflareZoneLockdownDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 	zoneID := d.Get("zone_id").(string)
 
 	tflog.Info(ctx, fmt.Sprintf("Deleting Cloudflare Zone Lockdown: id %s for zone %s", d.Id(), zoneID))
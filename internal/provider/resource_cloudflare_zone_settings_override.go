@@ -36,7 +36,10 @@ var fetchAsSingleSetting = []string{
 }
 
 func resourceCloudflareZoneSettingsOverrideCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	zoneID := d.Get("zone_id").(string)
 	d.SetId(zoneID)
@@ -107,7 +110,10 @@ func updateZoneSettingsResponseWithUniversalSSLSettings(ctx context.Context, zon
 }
 
 func resourceCloudflareZoneSettingsOverrideRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	zone, err := client.ZoneDetails(ctx, d.Id())
 	if err != nil {
@@ -259,7 +265,10 @@ func updateUniversalSSLSetting(ctx context.Context, zoneSettings []cloudflare.Zo
 }
 
 func resourceCloudflareZoneSettingsOverrideUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	if cfg, ok := d.GetOkExists("settings"); ok && cfg != nil && len(cfg.([]interface{})) > 0 {
 		readOnlySettings := expandInterfaceToStringList(d.Get("readonly_settings"))
@@ -364,7 +373,10 @@ func expandZoneSetting(d *schema.ResourceData, keyFormatString, k string, settin
 }
 
 func resourceCloudflareZoneSettingsOverrideDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*cloudflare.API)
+	client, clientErr := apiClient(meta, d)
+	if clientErr != nil {
+		return diag.FromErr(clientErr)
+	}
 
 	if cfg, ok := d.GetOkExists("settings"); ok && cfg != nil && len(cfg.([]interface{})) > 0 {
 		readOnlySettings := expandInterfaceToStringList(d.Get("readonly_settings"))
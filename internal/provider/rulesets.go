@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// cloudflare-go doesn't support the cache settings and redirect phases nor
+// their action parameters, so rulesets are sent and read with raw requests
+// using the types below. They embed the cloudflare-go types, shadowing the
+// fields that hold what cloudflare-go doesn't know about.
+const (
+	rulesetPhaseHTTPRequestCacheSettings   = "http_request_cache_settings"
+	rulesetPhaseHTTPRequestDynamicRedirect = "http_request_dynamic_redirect"
+	rulesetPhaseHTTPRequestRedirect        = "http_request_redirect"
+	rulesetRuleActionSetCacheSettings      = "set_cache_settings"
+	rulesetRuleActionRedirect              = "redirect"
+	rulesetCacheKeyQueryStringAllWildcard  = "*"
+)
+
+// rulesetRedirectStatusCodes are the status codes of redirects.
+var rulesetRedirectStatusCodes = []int{301, 302, 303, 307, 308}
+
+// rulesetEdgeTTLModes are the modes of the edge TTL of cache settings.
+var rulesetEdgeTTLModes = []string{"respect_origin", "bypass_by_default", "override_origin"}
+
+// rulesetBrowserTTLModes are the modes of the browser TTL of cache settings.
+var rulesetBrowserTTLModes = []string{"respect_origin", "bypass", "override_origin"}
+
+type ruleset struct {
+	cloudflare.Ruleset
+	Rules []rulesetRule `json:"rules"`
+}
+
+type rulesetRule struct {
+	cloudflare.RulesetRule
+	ActionParameters *rulesetRuleActionParameters `json:"action_parameters,omitempty"`
+}
+
+type rulesetRuleActionParameters struct {
+	cloudflare.RulesetRuleActionParameters
+	Cache                   *bool                                  `json:"cache,omitempty"`
+	EdgeTTL                 *rulesetRuleActionParametersEdgeTTL    `json:"edge_ttl,omitempty"`
+	BrowserTTL              *rulesetRuleActionParametersBrowserTTL `json:"browser_ttl,omitempty"`
+	ServeStale              *rulesetRuleActionParametersServeStale `json:"serve_stale,omitempty"`
+	CacheKey                *rulesetRuleActionParametersCacheKey   `json:"cache_key,omitempty"`
+	RespectStrongETags      *bool                                  `json:"respect_strong_etags,omitempty"`
+	OriginErrorPagePassthru *bool                                  `json:"origin_error_page_passthru,omitempty"`
+	FromValue               *rulesetRuleActionParametersFromValue  `json:"from_value,omitempty"`
+	FromList                *rulesetRuleActionParametersFromList   `json:"from_list,omitempty"`
+}
+
+type rulesetRuleActionParametersFromValue struct {
+	StatusCode          int                                  `json:"status_code,omitempty"`
+	TargetURL           rulesetRuleActionParametersTargetURL `json:"target_url"`
+	PreserveQueryString bool                                 `json:"preserve_query_string"`
+}
+
+// rulesetRuleActionParametersTargetURL is either a static URL or an
+// expression evaluating to the URL.
+type rulesetRuleActionParametersTargetURL struct {
+	Value      string `json:"value,omitempty"`
+	Expression string `json:"expression,omitempty"`
+}
+
+// rulesetRuleActionParametersFromList looks up the redirect in the list with
+// the given name, by the value of the key expression.
+type rulesetRuleActionParametersFromList struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+type rulesetRuleActionParametersEdgeTTL struct {
+	Mode          string                                     `json:"mode,omitempty"`
+	Default       int                                        `json:"default,omitempty"`
+	StatusCodeTTL []rulesetRuleActionParametersStatusCodeTTL `json:"status_code_ttl,omitempty"`
+}
+
+type rulesetRuleActionParametersStatusCodeTTL struct {
+	StatusCode      int                                         `json:"status_code,omitempty"`
+	StatusCodeRange *rulesetRuleActionParametersStatusCodeRange `json:"status_code_range,omitempty"`
+	Value           int                                         `json:"value"`
+}
+
+type rulesetRuleActionParametersStatusCodeRange struct {
+	From int `json:"from,omitempty"`
+	To   int `json:"to,omitempty"`
+}
+
+type rulesetRuleActionParametersBrowserTTL struct {
+	Mode    string `json:"mode"`
+	Default int    `json:"default,omitempty"`
+}
+
+type rulesetRuleActionParametersServeStale struct {
+	DisableStaleWhileUpdating bool `json:"disable_stale_while_updating"`
+}
+
+type rulesetRuleActionParametersCacheKey struct {
+	CacheByDeviceType       bool                                  `json:"cache_by_device_type"`
+	IgnoreQueryStringsOrder bool                                  `json:"ignore_query_strings_order"`
+	CacheDeceptionArmor     bool                                  `json:"cache_deception_armor"`
+	CustomKey               *rulesetRuleActionParametersCustomKey `json:"custom_key,omitempty"`
+}
+
+type rulesetRuleActionParametersCustomKey struct {
+	Query  *rulesetRuleActionParametersCustomKeyQuery  `json:"query_string,omitempty"`
+	Header *rulesetRuleActionParametersCustomKeyHeader `json:"header,omitempty"`
+	Cookie *rulesetRuleActionParametersCustomKeyCookie `json:"cookie,omitempty"`
+	User   *rulesetRuleActionParametersCustomKeyUser   `json:"user,omitempty"`
+	Host   *rulesetRuleActionParametersCustomKeyHost   `json:"host,omitempty"`
+}
+
+type rulesetRuleActionParametersCustomKeyQuery struct {
+	Include *rulesetRuleActionParametersCustomKeyList `json:"include,omitempty"`
+	Exclude *rulesetRuleActionParametersCustomKeyList `json:"exclude,omitempty"`
+}
+
+// rulesetRuleActionParametersCustomKeyList is either a list of query string
+// parameters or all of them.
+type rulesetRuleActionParametersCustomKeyList struct {
+	List []string `json:"list,omitempty"`
+	All  bool     `json:"all,omitempty"`
+}
+
+type rulesetRuleActionParametersCustomKeyHeader struct {
+	Include       []string `json:"include,omitempty"`
+	CheckPresence []string `json:"check_presence,omitempty"`
+	ExcludeOrigin bool     `json:"exclude_origin"`
+}
+
+type rulesetRuleActionParametersCustomKeyCookie struct {
+	Include       []string `json:"include,omitempty"`
+	CheckPresence []string `json:"check_presence,omitempty"`
+}
+
+type rulesetRuleActionParametersCustomKeyUser struct {
+	DeviceType bool `json:"device_type"`
+	Geo        bool `json:"geo"`
+	Lang       bool `json:"lang"`
+}
+
+type rulesetRuleActionParametersCustomKeyHost struct {
+	Resolved bool `json:"resolved"`
+}
+
+// rulesetPhaseValues are the phases known to cloudflare-go along with the
+// cache settings and redirect phases.
+func rulesetPhaseValues() []string {
+	return append(cloudflare.RulesetPhaseValues(),
+		rulesetPhaseHTTPRequestCacheSettings,
+		rulesetPhaseHTTPRequestDynamicRedirect,
+		rulesetPhaseHTTPRequestRedirect,
+	)
+}
+
+// rulesetRuleActionValues are the rule actions known to cloudflare-go along
+// with the cache settings and redirect actions.
+func rulesetRuleActionValues() []string {
+	return append(cloudflare.RulesetRuleActionValues(), rulesetRuleActionSetCacheSettings, rulesetRuleActionRedirect)
+}
+
+// rulesetsURI is the URI of the rulesets of the account or, when there is no
+// account, of the zone.
+func rulesetsURI(accountID, zoneID string, path ...string) string {
+	uri := fmt.Sprintf("/zones/%s/rulesets", zoneID)
+	if accountID != "" {
+		uri = fmt.Sprintf("/accounts/%s/rulesets", accountID)
+	}
+	for _, p := range path {
+		uri += "/" + p
+	}
+	return uri
+}
+
+func createRuleset(client *cloudflare.API, accountID, zoneID string, rs ruleset) (ruleset, error) {
+	return rulesetRequest(client, http.MethodPost, rulesetsURI(accountID, zoneID), rs)
+}
+
+func rulesetOf(client *cloudflare.API, accountID, zoneID, rulesetID string) (ruleset, error) {
+	return rulesetRequest(client, http.MethodGet, rulesetsURI(accountID, zoneID, rulesetID), nil)
+}
+
+func updateRuleset(client *cloudflare.API, accountID, zoneID, rulesetID, description string, rules []rulesetRule) (ruleset, error) {
+	rs := ruleset{Ruleset: cloudflare.Ruleset{Description: description}, Rules: rules}
+	return rulesetRequest(client, http.MethodPut, rulesetsURI(accountID, zoneID, rulesetID), rs)
+}
+
+func updateRulesetPhase(client *cloudflare.API, accountID, zoneID, phase string, rs ruleset) (ruleset, error) {
+	return rulesetRequest(client, http.MethodPut, rulesetsURI(accountID, zoneID, "phases", phase, "entrypoint"), rs)
+}
+
+func rulesetRequest(client *cloudflare.API, method, uri string, body interface{}) (ruleset, error) {
+	var rs ruleset
+	result, err := client.Raw(method, uri, body)
+	if err != nil {
+		return rs, err
+	}
+	err = json.Unmarshal(result, &rs)
+	return rs, err
+}
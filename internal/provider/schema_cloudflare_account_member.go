@@ -1,18 +1,61 @@
 package provider
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
 
 func resourceCloudflareAccountMemberSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		"account_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
+		},
+
 		"email_address": {
 			Type:     schema.TypeString,
 			Required: true,
 		},
 
 		"role_ids": {
+			Type:         schema.TypeSet,
+			Optional:     true,
+			Elem:         &schema.Schema{Type: schema.TypeString},
+			ExactlyOneOf: []string{"role_ids", "policy"},
+		},
+
+		"policy": {
 			Type:     schema.TypeSet,
-			Required: true,
-			Elem:     &schema.Schema{Type: schema.TypeString},
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"permission_groups": {
+						Type:     schema.TypeSet,
+						Required: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"resource_groups": {
+						Type:     schema.TypeSet,
+						Required: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"access": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "allow",
+						ValidateFunc: validation.StringInSlice(apiTokenPolicyEffects, false),
+					},
+				},
+			},
+		},
+
+		"status": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice(accountMemberStatuses, false),
 		},
 	}
 }
@@ -5,6 +5,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// apiTokenPolicyEffects are the effects of the policies of API tokens and
+// account members.
+var apiTokenPolicyEffects = []string{"allow", "deny"}
+
 func resourceCloudflareApiTokenSchema() map[string]*schema.Schema {
 	p := schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -22,12 +26,18 @@ func resourceCloudflareApiTokenSchema() map[string]*schema.Schema {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Default:      "allow",
-				ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+				ValidateFunc: validation.StringInSlice(apiTokenPolicyEffects, false),
 			},
 		},
 	}
 
 	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to own the token. Tokens owned by an account aren't tied to a user, so they keep working after the user who created them leaves the account. Tokens without an account are owned by the user of the provider credentials.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+		},
 		"name": {
 			Type:     schema.TypeString,
 			Required: true,
@@ -66,6 +76,40 @@ func resourceCloudflareApiTokenSchema() map[string]*schema.Schema {
 				},
 			},
 		},
+		"not_before": {
+			Description:      "The time, in RFC 3339 format, before which the token can't be used.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.IsRFC3339Time,
+			DiffSuppressFunc: apiTokenTimeDiffSuppress,
+		},
+		"expires_on": {
+			Description:      "The time, in RFC 3339 format, after which the token can't be used.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.IsRFC3339Time,
+			DiffSuppressFunc: apiTokenTimeDiffSuppress,
+		},
+		"rotate_when_changed": {
+			Description: "Arbitrary map of values that, when changed, rolls the value of the token without replacing it.",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"expiry_warning_days": {
+			Description:  "Number of days before `expires_on` from which `expiring_soon` is set.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      30,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"expiring_soon": {
+			Description: "Whether the token expires within `expiry_warning_days`, as of the last plan or refresh.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
 		"value": {
 			Type:      schema.TypeString,
 			Computed:  true,
@@ -14,6 +14,7 @@ func resourceCloudflareArgoSchema() map[string]*schema.Schema {
 		},
 		"tiered_caching": {
 			Type:         schema.TypeString,
+			Deprecated:   "Use `cloudflare_tiered_cache` instead.",
 			ValidateFunc: validation.StringInSlice([]string{"on", "off"}, false),
 			Optional:     true,
 		},
@@ -121,6 +121,18 @@ func resourceCloudflareCustomHostnameSchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
+		"wait_for_ssl_pending_validation": {
+			Description:   "Whether to wait for the certificate of the hostname to be pending validation, when its `validation_records` are set, before completing.",
+			Type:          schema.TypeBool,
+			Optional:      true,
+			ConflictsWith: []string{"wait_for_active_status"},
+		},
+		"wait_for_active_status": {
+			Description:   "Whether to wait for the hostname and its certificate to be active before completing.",
+			Type:          schema.TypeBool,
+			Optional:      true,
+			ConflictsWith: []string{"wait_for_ssl_pending_validation"},
+		},
 		"ownership_verification": {
 			Type:     schema.TypeMap,
 			Computed: true,
@@ -0,0 +1,30 @@
+package provider
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+func resourceCloudflareEmailRoutingAddressSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"email": {
+			Description: "The destination address. A message asking its owner to verify it is sent to it when it's added.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"verified": {
+			Description: "When the owner of the address verified it. Email is only forwarded to verified addresses.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"created": {
+			Description: "When the address was added.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflareEmailRoutingRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"zone_id": {
+			Description: "The zone identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description: "Name of the rule.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"enabled": {
+			Description: "Whether the rule routes email.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+		"priority": {
+			Description:  "The priority of the rule. Rules with lower priorities are evaluated first.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"matcher": {
+			Description: "The conditions email must meet for the rule to route it.",
+			Type:        schema.TypeList,
+			Required:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Description:  fmt.Sprintf("The type of the matcher. %s", renderAvailableDocumentationValuesStringSlice(emailRoutingRuleMatcherTypes)),
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(emailRoutingRuleMatcherTypes, false),
+					},
+					"field": {
+						Description:  fmt.Sprintf("The field of the email the matcher compares. %s", renderAvailableDocumentationValuesStringSlice(emailRoutingRuleMatcherFields)),
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(emailRoutingRuleMatcherFields, false),
+					},
+					"value": {
+						Description: "The value the field must be equal to, e.g. an address of the zone.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+				},
+			},
+		},
+		"action": {
+			Description: "What is done with email the rule matches.",
+			Type:        schema.TypeList,
+			Required:    true,
+			Elem:        emailRoutingRuleActionResource(),
+		},
+	}
+}
+
+func emailRoutingRuleActionResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Description:  fmt.Sprintf("The type of the action. %s", renderAvailableDocumentationValuesStringSlice(emailRoutingRuleActionTypes)),
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(emailRoutingRuleActionTypes, false),
+			},
+			"value": {
+				Description: "The destination addresses email is forwarded to, or the name of the Worker script processing it. Required by the `forward` and `worker` actions.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+func resourceCloudflareEmailRoutingSettingsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"zone_id": {
+			Description: "The zone identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"enabled": {
+			Description: "Whether the email of the zone is routed. Enabling routing adds the DNS records it needs that the zone doesn't already have, and fails if the zone has MX or SPF records routing its email elsewhere.",
+			Type:        schema.TypeBool,
+			Required:    true,
+		},
+		"status": {
+			Description: "The status of Email Routing on the zone, e.g. `ready` or `misconfigured` when records it needs are missing.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"dns_records": {
+			Description: "The DNS records the zone needs for its email to be routed.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Description: "The type of the record.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"name": {
+						Description: "The name of the record.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"content": {
+						Description: "The content of the record.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"priority": {
+						Description: "The priority of MX records.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					"ttl": {
+						Description: "The TTL of the record.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"html"
 	"strings"
 
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/wirefilter"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -21,8 +22,9 @@ func resourceCloudflareFilterSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"expression": {
-			Type:     schema.TypeString,
-			Required: true,
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validateExpression(wirefilter.ScopeRequest),
 			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 				return strings.TrimSpace(new) == old
 			},
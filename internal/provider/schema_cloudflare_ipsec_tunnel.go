@@ -41,30 +41,86 @@ func resourceCloudflareIPsecTunnelSchema() map[string]*schema.Schema {
 			Description: "An optional description of the IPsec tunnel.",
 		},
 		"health_check_enabled": {
-			Type:        schema.TypeBool,
-			Optional:    true,
-			Computed:    true,
-			Description: "Specifies if ICMP tunnel health checks are enabled. Default: `true`.",
+			Type:          schema.TypeBool,
+			Optional:      true,
+			Computed:      true,
+			Deprecated:    "Use `health_check` instead.",
+			ConflictsWith: []string{"health_check"},
+			Description:   "Specifies if ICMP tunnel health checks are enabled. Default: `true`.",
 		},
 		"health_check_target": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Computed:    true,
-			Description: "The IP address of the customer endpoint that will receive tunnel health checks. Default: `<customer_gre_endpoint>`.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			Deprecated:    "Use `health_check` instead.",
+			ConflictsWith: []string{"health_check"},
+			Description:   "The IP address of the customer endpoint that will receive tunnel health checks. Default: `<customer_gre_endpoint>`.",
 		},
 		"health_check_type": {
-			Type:         schema.TypeString,
-			Optional:     true,
-			Computed:     true,
-			ValidateFunc: validation.StringInSlice([]string{"request", "reply"}, false),
-			Description:  fmt.Sprintf("Specifies the ICMP echo type for the health check (`request` or `reply`). %s Default: `reply`.", renderAvailableDocumentationValuesStringSlice([]string{"request", "reply"})),
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			Deprecated:    "Use `health_check` instead.",
+			ConflictsWith: []string{"health_check"},
+			ValidateFunc:  validation.StringInSlice([]string{"request", "reply"}, false),
+			Description:   fmt.Sprintf("Specifies the ICMP echo type for the health check (`request` or `reply`). %s Default: `reply`.", renderAvailableDocumentationValuesStringSlice([]string{"request", "reply"})),
+		},
+		"health_check": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Description: "Configuration of the ICMP tunnel health checks.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"enabled": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+						Description: "Specifies if ICMP tunnel health checks are enabled.",
+					},
+					"target": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.IsIPAddress,
+						Description:  "The IP address of the customer endpoint that will receive tunnel health checks. Default: `<customer_endpoint>`.",
+					},
+					"type": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.StringInSlice([]string{"request", "reply"}, false),
+						Description:  fmt.Sprintf("Specifies the ICMP echo type for the health check. %s Default: `reply`.", renderAvailableDocumentationValuesStringSlice([]string{"request", "reply"})),
+					},
+					"direction": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.StringInSlice(magicTransitTunnelHealthCheckDirections, false),
+						Description:  fmt.Sprintf("Whether health checks are only sent by Cloudflare or by both sides of the tunnel. %s Default: `unidirectional`.", renderAvailableDocumentationValuesStringSlice(magicTransitTunnelHealthCheckDirections)),
+					},
+					"rate": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.StringInSlice(magicTransitTunnelHealthCheckRates, false),
+						Description:  fmt.Sprintf("How often health checks are sent. %s Default: `mid`.", renderAvailableDocumentationValuesStringSlice(magicTransitTunnelHealthCheckRates)),
+					},
+				},
+			},
 		},
 		"psk": {
 			Type:        schema.TypeString,
 			Optional:    true,
 			Computed:    true,
 			Sensitive:   true,
-			Description: "Pre shared key to be used with the IPsec tunnel. If left unset, it will be autogenerated.",
+			Description: "Pre shared key to be used with the IPsec tunnel. If left unset, it will be autogenerated once and kept in the state, as the API never returns it.",
+		},
+		"psk_generated_on": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The RFC3339 timestamp of when the pre shared key was last generated.",
 		},
 		"hex_id": {
 			Type:        schema.TypeString,
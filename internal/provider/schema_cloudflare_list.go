@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// listKinds are the kinds of lists, each named after the attribute its items
+// set.
+var listKinds = []string{"ip", "redirect", "hostname", "asn"}
+
+var listRedirectStatusCodes = []int{301, 302, 307, 308}
+
+func resourceCloudflareListSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description:  "The name of the list, which rules reference it with.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile("^[0-9a-z_]+$"), "List name must only contain lowercase letters, numbers and underscores"),
+		},
+		"description": {
+			Description: "An informative summary of the list.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"kind": {
+			Description:  fmt.Sprintf("The type of items the list contains. %s", renderAvailableDocumentationValuesStringSlice(listKinds)),
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice(listKinds, false),
+		},
+		"item": {
+			Description:   "The items of the list. Each item sets the attribute named after the `kind` of the list. When no items were ever set, the items of the list are left as they are, such as for `cloudflare_list_item` resources to manage, while removing every item empties the list.",
+			Type:          schema.TypeSet,
+			Optional:      true,
+			Elem:          listItemResource(),
+			ConflictsWith: []string{"items_file"},
+		},
+		"items_file": {
+			Description:   "Path to a file of the items of the list, with an item per line made of its value and an optional comment, separated by a comma. Lines starting with `#` are ignored and values must not be repeated. Only a hash of the items is kept in state. Not supported by `redirect` lists, nor by lists with `cloudflare_list_item` resources, as every other item is removed.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"item"},
+		},
+		"items_file_hash": {
+			Description: "Hash of the items of the list, when they are read from `items_file`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func listItemResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"ip": {
+				Description: "IP address or network in CIDR notation, for `ip` lists.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"asn": {
+				Description:  "Autonomous system number, for `asn` lists.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"hostname": {
+				Description: "Hostname, for `hostname` lists.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"redirect": {
+				Description: "Redirect, for `redirect` lists.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_url": {
+							Description: "URL the redirect matches, without a scheme, such as `example.com/path`.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"target_url": {
+							Description: "URL requests are redirected to.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"status_code": {
+							Description:  fmt.Sprintf("Status code of the redirect response. %s", renderAvailableDocumentationValuesIntSlice(listRedirectStatusCodes)),
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      301,
+							ValidateFunc: validation.IntInSlice(listRedirectStatusCodes),
+						},
+						"include_subdomains": {
+							Description: "Whether the redirect also matches subdomains of the source URL.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"subpath_matching": {
+							Description: "Whether the redirect also matches paths below the source URL.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"preserve_query_string": {
+							Description: "Whether the query string of requests is kept in the target URL.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"preserve_path_suffix": {
+							Description: "Whether the part of the path below the source URL is appended to the target URL, when `subpath_matching` is enabled.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"comment": {
+				Description: "An informative summary of the item.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var listItemValueAttributes = []string{"ip", "asn", "hostname", "redirect"}
+
+func resourceCloudflareListItemSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"list_id": {
+			Description: "The identifier of the list the item belongs to.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+	}
+
+	// Items have the attributes of the items of `cloudflare_list`, and are
+	// replaced whenever those change.
+	for k, v := range listItemResource().Schema {
+		setListItemSchemaForceNew(v)
+		s[k] = v
+	}
+	for _, k := range listItemValueAttributes {
+		s[k].ExactlyOneOf = listItemValueAttributes
+	}
+
+	return s
+}
+
+func setListItemSchemaForceNew(s *schema.Schema) {
+	s.ForceNew = true
+	if elem, ok := s.Elem.(*schema.Resource); ok {
+		for _, v := range elem.Schema {
+			setListItemSchemaForceNew(v)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflareMagicWANSiteSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the site.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "An optional description of the site.",
+		},
+		"connector_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Identifier of the Magic WAN Connector installed at the site.",
+		},
+		"secondary_connector_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			RequiredWith: []string{"ha_mode"},
+			Description:  "Identifier of the Magic WAN Connector taking over when the primary one fails. Requires `ha_mode`.",
+		},
+		"ha_mode": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Whether the site runs a pair of Magic WAN Connectors in high availability mode.",
+		},
+		"location": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Location of the site.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"lat": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Latitude of the site.",
+					},
+					"lon": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Longitude of the site.",
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflareMagicWANSiteACLSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"site_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Identifier of the site of the ACL.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the ACL.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "An optional description of the ACL.",
+		},
+		"lan_1": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "First LAN the ACL allows traffic between.",
+			Elem:        magicWANSiteACLConfigurationResource(),
+		},
+		"lan_2": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "Second LAN the ACL allows traffic between.",
+			Elem:        magicWANSiteACLConfigurationResource(),
+		},
+		"protocols": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: fmt.Sprintf("Protocols allowed by the ACL, all of them when unset. %s", renderAvailableDocumentationValuesStringSlice(magicWANSiteACLProtocols)),
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(magicWANSiteACLProtocols, false),
+			},
+		},
+		"forward_locally": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether the traffic is forwarded between the LANs by the Magic WAN Connector rather than through Cloudflare.",
+		},
+	}
+}
+
+func magicWANSiteACLConfigurationResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"lan_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Identifier of the LAN.",
+			},
+			"lan_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the LAN.",
+			},
+			"ports": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Ports of the LAN the traffic is allowed on, all of them when unset.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IsPortNumber,
+				},
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Subnets of the LAN the traffic is allowed from and to, in CIDR notation, all of them when unset.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflareMagicWANSiteLANSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"site_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Identifier of the site of the LAN.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the LAN.",
+		},
+		"physport": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Number of the port of the Magic WAN Connector the LAN is plugged into.",
+		},
+		"vlan_tag": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(0, 4094),
+			Description:  "VLAN tag of the LAN, `0` for untagged traffic.",
+		},
+		"ha_link": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether the LAN links the Magic WAN Connectors of a site in high availability mode.",
+		},
+		"nat": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Network address translation of the LAN.",
+			Elem:        magicWANSiteNatResource(),
+		},
+		"static_addressing": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Static addressing of the LAN, with either a DHCP relay or a DHCP server.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"address": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.IsCIDR,
+						Description:  "Address of the Magic WAN Connector on the LAN, in CIDR notation.",
+					},
+					"secondary_address": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.IsCIDR,
+						Description:  "Address of the secondary Magic WAN Connector on the LAN in high availability mode, in CIDR notation.",
+					},
+					"virtual_address": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.IsCIDR,
+						Description:  "Address shared by the Magic WAN Connectors on the LAN in high availability mode, in CIDR notation.",
+					},
+					"dhcp_relay": {
+						Type:          schema.TypeList,
+						Optional:      true,
+						MaxItems:      1,
+						ConflictsWith: []string{"static_addressing.0.dhcp_server"},
+						Description:   "Relays the DHCP requests of the LAN to other servers.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"server_addresses": {
+									Type:        schema.TypeList,
+									Required:    true,
+									MinItems:    1,
+									Description: "Addresses of the DHCP servers.",
+									Elem: &schema.Schema{
+										Type:         schema.TypeString,
+										ValidateFunc: validation.IsIPAddress,
+									},
+								},
+							},
+						},
+					},
+					"dhcp_server": {
+						Type:          schema.TypeList,
+						Optional:      true,
+						MaxItems:      1,
+						ConflictsWith: []string{"static_addressing.0.dhcp_relay"},
+						Description:   "Leases addresses of the LAN with the DHCP server of the Magic WAN Connector.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"dhcp_pool_start": {
+									Type:         schema.TypeString,
+									Required:     true,
+									ValidateFunc: validation.IsIPAddress,
+									Description:  "First address of the pool of leased addresses.",
+								},
+								"dhcp_pool_end": {
+									Type:         schema.TypeString,
+									Required:     true,
+									ValidateFunc: validation.IsIPAddress,
+									Description:  "Last address of the pool of leased addresses.",
+								},
+								"dns_server": {
+									Type:         schema.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.IsIPAddress,
+									Description:  "Address of the DNS server handed out with the leases.",
+								},
+								"reservations": {
+									Type:        schema.TypeMap,
+									Optional:    true,
+									Description: "Addresses reserved for MAC addresses, keyed by MAC address.",
+									Elem: &schema.Schema{
+										Type:         schema.TypeString,
+										ValidateFunc: validation.IsIPAddress,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"routed_subnet": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Subnets reachable through a router on the LAN.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"prefix": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.IsCIDR,
+						Description:  "Prefix of the subnet, in CIDR notation.",
+					},
+					"next_hop": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.IsIPAddress,
+						Description:  "Address of the router the subnet is reachable through.",
+					},
+					"nat": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Network address translation of the subnet.",
+						Elem:        magicWANSiteNatResource(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func magicWANSiteNatResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"static_prefix": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsCIDR,
+				Description:  "Prefix the addresses are translated to, in CIDR notation.",
+			},
+		},
+	}
+}
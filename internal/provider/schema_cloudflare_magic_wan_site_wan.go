@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflareMagicWANSiteWANSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+		},
+		"site_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Identifier of the site of the WAN.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the WAN.",
+		},
+		"physport": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Number of the port of the Magic WAN Connector the WAN is plugged into.",
+		},
+		"vlan_tag": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(0, 4094),
+			Description:  "VLAN tag of the WAN, `0` for untagged traffic.",
+		},
+		"priority": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "Priority of the WAN when the site has several of them, lower values being preferred.",
+		},
+		"static_addressing": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Static addressing of the WAN. The WAN gets its address with DHCP without it.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"address": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.IsCIDR,
+						Description:  "Address of the Magic WAN Connector on the WAN, in CIDR notation.",
+					},
+					"gateway_address": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.IsIPAddress,
+						Description:  "Address of the gateway of the WAN.",
+					},
+					"secondary_address": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.IsCIDR,
+						Description:  "Address of the secondary Magic WAN Connector on the WAN in high availability mode, in CIDR notation.",
+					},
+				},
+			},
+		},
+	}
+}
@@ -1,6 +1,16 @@
 package provider
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	notificationPolicyFilterEventSources      = []string{"pool", "origin"}
+	notificationPolicyFilterHealthStates      = []string{"Healthy", "Unhealthy"}
+	notificationPolicyFilterProtocols         = []string{"tcp", "udp", "icmp", "gre", "ipv4", "ipv6"}
+	notificationPolicyFilterTrafficExclusions = []string{"security_events"}
+)
 
 func resourceCloudflareNotificationPolicySchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
@@ -22,8 +32,9 @@ func resourceCloudflareNotificationPolicySchema() map[string]*schema.Schema {
 			Required: true,
 		},
 		"alert_type": {
-			Type:     schema.TypeString,
-			Required: true,
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(notificationPolicyAlertTypes(), false),
 		},
 		"filters": notificationPolicyFilterSchema(),
 		"created": {
@@ -123,8 +134,7 @@ func notificationPolicyFilterSchema() *schema.Schema {
 					Elem: &schema.Schema{
 						Type: schema.TypeString,
 					},
-					Optional:     true,
-					RequiredWith: []string{"filters.0.enabled"},
+					Optional: true,
 				},
 				"slo": {
 					Type: schema.TypeSet,
@@ -133,6 +143,87 @@ func notificationPolicyFilterSchema() *schema.Schema {
 					},
 					Optional: true,
 				},
+				"event_source": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(notificationPolicyFilterEventSources, false),
+					},
+					Optional: true,
+				},
+				"new_health": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(notificationPolicyFilterHealthStates, false),
+					},
+					Optional: true,
+				},
+				"input_id": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"event_type": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"traffic_exclusions": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(notificationPolicyFilterTrafficExclusions, false),
+					},
+					Optional: true,
+				},
+				"megabits_per_second": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"packets_per_second": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"requests_per_second": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"protocol": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(notificationPolicyFilterProtocols, false),
+					},
+					Optional: true,
+				},
+				"target_hostname": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
+				"target_zone_name": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Optional: true,
+				},
 			},
 		},
 	}
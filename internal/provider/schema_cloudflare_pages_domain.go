@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudflarePagesDomainSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"project_name": {
+			Description: "Name of the Pages project the domain serves.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"domain": {
+			Description: "The custom domain serving the project.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"wait_for_active_status": {
+			Description: "Whether to wait for the domain to be verified and active before completing, bounded by the `create` and `update` timeouts of the resource, which default to 10 minutes.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+		"status": {
+			Description: "Status of the domain.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
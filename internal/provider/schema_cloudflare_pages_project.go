@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflarePagesProjectSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description:  "Name of the project.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,56}[a-z0-9]$`), "Project name must be up to 58 lowercase letters, numbers and hyphens, starting and ending with a letter or number"),
+		},
+		"production_branch": {
+			Description: "The branch deployed to the production environment.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"source": {
+			Description: "The repository the project is built from. Projects without a source are deployed by uploading their assets.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Description:  fmt.Sprintf("The type of the repository. %s", renderAvailableDocumentationValuesStringSlice(pagesSourceTypes)),
+						Type:         schema.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringInSlice(pagesSourceTypes, false),
+					},
+					"config": {
+						Description: "The configuration of the repository.",
+						Type:        schema.TypeList,
+						Required:    true,
+						MaxItems:    1,
+						Elem:        pagesProjectSourceConfigResource(),
+					},
+				},
+			},
+		},
+		"build_config": {
+			Description: "The configuration of the builds of the project.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"build_command": {
+						Description: "The command building the project.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"destination_dir": {
+						Description: "The directory of the assets built by the build command.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"root_dir": {
+						Description: "The directory of the repository the build command runs in.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"web_analytics_tag": {
+						Description: "The tag of the Web Analytics site of the project.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"web_analytics_token": {
+						Description: "The token of the Web Analytics site of the project.",
+						Type:        schema.TypeString,
+						Optional:    true,
+						Sensitive:   true,
+					},
+				},
+			},
+		},
+		"deployment_configs": {
+			Description: "The configuration of the deployments of each environment.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"preview": {
+						Description: "The configuration of preview deployments.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						Computed:    true,
+						MaxItems:    1,
+						Elem:        pagesDeploymentConfigResource(),
+					},
+					"production": {
+						Description: "The configuration of production deployments.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						Computed:    true,
+						MaxItems:    1,
+						Elem:        pagesDeploymentConfigResource(),
+					},
+				},
+			},
+		},
+		"subdomain": {
+			Description: "The `pages.dev` subdomain the project is served from.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"domains": {
+			Description: "The domains the project is served from.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"created_on": {
+			Description: "When the project was created.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+func pagesProjectSourceConfigResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Description: "The owner of the repository.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"repo_name": {
+				Description: "The name of the repository.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pr_comments_enabled": {
+				Description: "Whether deployments of pull requests are commented on them.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"deployments_enabled": {
+				Description: "Whether pushes to the repository are deployed.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"production_deployments_enabled": {
+				Description: "Whether pushes to the production branch are deployed.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"preview_deployment_setting": {
+				Description:  fmt.Sprintf("Which branches are deployed to the preview environment. %s", renderAvailableDocumentationValuesStringSlice(pagesPreviewDeploymentSetting)),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ValidateFunc: validation.StringInSlice(pagesPreviewDeploymentSetting, false),
+			},
+			"preview_branch_includes": {
+				Description: "Patterns of the branches deployed to the preview environment, when `preview_deployment_setting` is `custom`.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"preview_branch_excludes": {
+				Description: "Patterns of the branches not deployed to the preview environment, when `preview_deployment_setting` is `custom`.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func pagesDeploymentConfigResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"environment_variables": {
+				Description: "Plain text environment variables of the deployments.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"secrets": {
+				Description: "Encrypted environment variables of the deployments. Their values can't be read back, so changes made outside of Terraform are only detected when secrets are added or removed.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"compatibility_date": {
+				Description: "The date of the Workers runtime behaviour Functions of the deployments run with, e.g. `2022-08-15`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"compatibility_flags": {
+				Description: "Flags enabling or disabling individual changes to the Workers runtime behaviour.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"kv_namespaces": {
+				Description: "KV namespaces bound to Functions of the deployments, keyed by the name of their binding.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"durable_object_namespaces": {
+				Description: "Durable Object namespaces bound to Functions of the deployments, keyed by the name of their binding.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"d1_databases": {
+				Description: "D1 databases bound to Functions of the deployments, keyed by the name of their binding.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"r2_buckets": {
+				Description: "Names of the R2 buckets bound to Functions of the deployments, keyed by the name of their binding.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflareR2BucketSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description:  "The name of the bucket.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,61}[a-z0-9]$`), "Bucket name must be 3 to 63 lowercase letters, numbers and hyphens, starting and ending with a letter or number"),
+		},
+		"location": {
+			Description:  fmt.Sprintf("The location hint of the bucket, which it's created close to. Defaults to a location close to where the bucket is created from. %s", renderAvailableDocumentationValuesStringSlice(r2BucketLocationHints)),
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice(r2BucketLocationHints, true),
+			// Location hints are case insensitive and read back in upper case,
+			// so they are stored in lower case and compared regardless of case
+			// to not replace buckets.
+			StateFunc: func(v interface{}) string {
+				return strings.ToLower(v.(string))
+			},
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return strings.EqualFold(old, new)
+			},
+		},
+		"creation_date": {
+			Description: "When the bucket was created.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
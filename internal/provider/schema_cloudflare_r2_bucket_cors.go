@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var r2CORSMethods = []string{"GET", "PUT", "POST", "DELETE", "HEAD"}
+
+func resourceCloudflareR2BucketCORSSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"bucket_name": {
+			Description: "The name of the bucket the rules apply to.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"rule": {
+			Description: "The CORS rules of the bucket.",
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Description: "Identifier of the rule.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"allowed_origins": {
+						Description: "Origins allowed to make cross-origin requests.",
+						Type:        schema.TypeList,
+						Required:    true,
+						MinItems:    1,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"allowed_methods": {
+						Description: fmt.Sprintf("HTTP methods allowed in cross-origin requests. %s", renderAvailableDocumentationValuesStringSlice(r2CORSMethods)),
+						Type:        schema.TypeList,
+						Required:    true,
+						MinItems:    1,
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringInSlice(r2CORSMethods, false),
+						},
+					},
+					"allowed_headers": {
+						Description: "Headers allowed in cross-origin requests.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"expose_headers": {
+						Description: "Headers of responses that are exposed to cross-origin requests.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"max_age_seconds": {
+						Description:  "Number of seconds browsers may cache the response to a preflight request.",
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflareR2BucketLifecycleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"bucket_name": {
+			Description: "The name of the bucket the rules apply to.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"rule": {
+			Description: "The lifecycle rules of the bucket.",
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Description: "Unique identifier of the rule.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"enabled": {
+						Description: "Whether the rule is applied.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+					},
+					"prefix": {
+						Description: "Prefix of the keys of the objects the rule applies to. Defaults to every object.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"delete_objects_after_days": {
+						Description:  "Number of days after their creation that objects are deleted.",
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"delete_objects_on_date": {
+						Description:  "Date, in RFC 3339 format, after which objects are deleted.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.IsRFC3339Time,
+					},
+					"abort_multipart_uploads_after_days": {
+						Description:  "Number of days after their start that incomplete multipart uploads are aborted.",
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var r2CustomDomainMinTLSVersions = []string{"1.0", "1.1", "1.2", "1.3"}
+
+func resourceCloudflareR2CustomDomainSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"bucket_name": {
+			Description: "The name of the bucket the domain serves.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"domain": {
+			Description: "The domain serving the bucket.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"zone_id": {
+			Description: "The zone identifier of the zone the domain belongs to.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"enabled": {
+			Description: "Whether the bucket is served from the domain.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+		"min_tls": {
+			Description:  fmt.Sprintf("The minimum TLS version clients must use to connect to the domain. %s", renderAvailableDocumentationValuesStringSlice(r2CustomDomainMinTLSVersions)),
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice(r2CustomDomainMinTLSVersions, false),
+		},
+		"ownership_status": {
+			Description: "Status of the verification of the ownership of the domain.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"ssl_status": {
+			Description: "Status of the certificate of the domain.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var recordSetRecordTypes = []string{"A", "AAAA", "CAA", "CNAME", "TXT", "SRV", "LOC", "MX", "NS", "SPF", "CERT", "DNSKEY", "DS", "NAPTR", "SMIMEA", "SSHFP", "TLSA", "URI", "PTR"}
+
+func resourceCloudflareRecordSetSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"zone_id": {
+			Description: "The zone identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+
+		"filter": {
+			Description: "Restricts the records managed by the resource, which otherwise manages every record in the zone of a type `record` supports. Records outside of the filter are left untouched.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Only manage records with this name, relative to the zone or fully qualified.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"types": {
+						Description: "Only manage records of these types.",
+						Type:        schema.TypeSet,
+						Optional:    true,
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringInSlice(recordSetRecordTypes, false),
+						},
+					},
+					"tag": {
+						Description: "Only manage records with this tag, such as `env:production`. Records created by the resource are given the tag.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+				},
+			},
+		},
+
+		"record": {
+			Description: "The records of the zone, or of the records matching `filter`. Records that exist but aren't listed are deleted.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        resourceCloudflareRecordSetRecord(),
+			Set:         hashRecordSetRecord,
+		},
+
+		"max_concurrency": {
+			Description:  "The maximum number of records to create, update or delete at once.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      4,
+			ValidateFunc: validation.IntBetween(1, 32),
+		},
+	}
+}
+
+// resourceCloudflareRecordSetRecord describes a record of a record set. It
+// is derived from the schema of `cloudflare_record`, keeping the fields that
+// are known before the record exists.
+func resourceCloudflareRecordSetRecord() *schema.Resource {
+	descriptions := map[string]string{
+		"name":     "The name of the record, relative to the zone or fully qualified.",
+		"type":     "The type of the record.",
+		"value":    "The value of the record. Conflicts with `data`.",
+		"data":     "Structured data of the record, for record types such as SRV, CAA and LOC. Conflicts with `value`.",
+		"ttl":      "The TTL of the record, `1` being automatic.",
+		"priority": "The priority of MX and URI records.",
+		"proxied":  "Whether the record gets Cloudflare's origin protection.",
+	}
+
+	recordSchema := resourceCloudflareRecordSchema()
+	fields := make(map[string]*schema.Schema, len(descriptions))
+	for key, description := range descriptions {
+		field := recordSchema[key]
+		// Fields of set elements are part of their hash, so they can't be
+		// computed or conflict with each other, and changing them updates
+		// the record set in place. Names and values are normalised by
+		// hashRecordSetRecord instead, as changing them in state would
+		// change the hash.
+		field.ForceNew = false
+		field.Computed = false
+		field.ConflictsWith = nil
+		field.StateFunc = nil
+		field.DiffSuppressFunc = nil
+		field.Description = description
+		fields[key] = field
+	}
+	fields["ttl"].Default = 1
+	fields["proxied"].Default = false
+
+	return &schema.Resource{Schema: fields}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/wirefilter"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -42,8 +43,8 @@ func resourceCloudflareRulesetSchema() map[string]*schema.Schema {
 		"phase": {
 			Type:         schema.TypeString,
 			Required:     true,
-			ValidateFunc: validation.StringInSlice(cloudflare.RulesetPhaseValues(), false),
-			Description:  fmt.Sprintf("Point in the request/response lifecycle where the ruleset will be created. %s", renderAvailableDocumentationValuesStringSlice(cloudflare.RulesetPhaseValues())),
+			ValidateFunc: validation.StringInSlice(rulesetPhaseValues(), false),
+			Description:  fmt.Sprintf("Point in the request/response lifecycle where the ruleset will be created. %s", renderAvailableDocumentationValuesStringSlice(rulesetPhaseValues())),
 		},
 		"shareable_entitlement_name": {
 			Type:        schema.TypeString,
@@ -79,13 +80,14 @@ func resourceCloudflareRulesetSchema() map[string]*schema.Schema {
 					"action": {
 						Type:         schema.TypeString,
 						Optional:     true,
-						ValidateFunc: validation.StringInSlice(cloudflare.RulesetRuleActionValues(), false),
-						Description:  fmt.Sprintf("Action to perform in the ruleset rule. %s", renderAvailableDocumentationValuesStringSlice(cloudflare.RulesetRuleActionValues())),
+						ValidateFunc: validation.StringInSlice(rulesetRuleActionValues(), false),
+						Description:  fmt.Sprintf("Action to perform in the ruleset rule. %s", renderAvailableDocumentationValuesStringSlice(rulesetRuleActionValues())),
 					},
 					"expression": {
-						Description: "Criteria for an HTTP request to trigger the ruleset rule action. Uses the Firewall Rules expression language based on Wireshark display filters. Refer to the [Firewall Rules language](https://developers.cloudflare.com/firewall/cf-firewall-language) documentation for all available fields, operators, and functions",
-						Type:        schema.TypeString,
-						Required:    true,
+						Description:      "Criteria for an HTTP request to trigger the ruleset rule action. Uses the Firewall Rules expression language based on Wireshark display filters. Refer to the [Firewall Rules language](https://developers.cloudflare.com/firewall/cf-firewall-language) documentation for all available fields, operators, and functions. Expressions are validated during plan, including whether the fields used are available in the ruleset phase, while fields unknown to the provider are only warned about.",
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: validateExpression(wirefilter.ScopeAny),
 					},
 					"description": {
 						Type:        schema.TypeString,
@@ -115,7 +117,7 @@ func resourceCloudflareRulesetSchema() map[string]*schema.Schema {
 								"phases": {
 									Type:        schema.TypeSet,
 									Optional:    true,
-									Description: fmt.Sprintf("Point in the request/response lifecycle where the ruleset will be created. %s", renderAvailableDocumentationValuesStringSlice(cloudflare.RulesetPhaseValues())),
+									Description: fmt.Sprintf("Point in the request/response lifecycle where the ruleset will be created. %s", renderAvailableDocumentationValuesStringSlice(rulesetPhaseValues())),
 									Elem: &schema.Schema{
 										Type: schema.TypeString,
 									},
@@ -403,6 +405,343 @@ func resourceCloudflareRulesetSchema() map[string]*schema.Schema {
 										Type: schema.TypeString,
 									},
 								},
+								"cache": {
+									Type:        schema.TypeBool,
+									Optional:    true,
+									Description: "Whether to cache if expression matches.",
+								},
+								"edge_ttl": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "List of edge TTL parameters to apply to the request.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"mode": {
+												Type:         schema.TypeString,
+												Required:     true,
+												ValidateFunc: validation.StringInSlice(rulesetEdgeTTLModes, false),
+												Description:  fmt.Sprintf("Mode of the edge TTL. %s", renderAvailableDocumentationValuesStringSlice(rulesetEdgeTTLModes)),
+											},
+											"default": {
+												Type:         schema.TypeInt,
+												Optional:     true,
+												ValidateFunc: validation.IntAtLeast(1),
+												Description:  "Default edge TTL, in seconds, when `mode` is `override_origin`.",
+											},
+											"status_code_ttl": {
+												Type:        schema.TypeList,
+												Optional:    true,
+												Description: "Edge TTL for the status codes of origin responses.",
+												Elem: &schema.Resource{
+													Schema: map[string]*schema.Schema{
+														"status_code": {
+															Type:         schema.TypeInt,
+															Optional:     true,
+															ValidateFunc: validation.IntBetween(100, 999),
+															Description:  "Status code for which the edge TTL is applied. Conflicts with `status_code_range`.",
+														},
+														"status_code_range": {
+															Type:        schema.TypeList,
+															Optional:    true,
+															MaxItems:    1,
+															Description: "Range of status codes for which the edge TTL is applied. Conflicts with `status_code`.",
+															Elem: &schema.Resource{
+																Schema: map[string]*schema.Schema{
+																	"from": {
+																		Type:         schema.TypeInt,
+																		Optional:     true,
+																		ValidateFunc: validation.IntBetween(100, 999),
+																		Description:  "First status code of the range.",
+																	},
+																	"to": {
+																		Type:         schema.TypeInt,
+																		Optional:     true,
+																		ValidateFunc: validation.IntBetween(100, 999),
+																		Description:  "Last status code of the range.",
+																	},
+																},
+															},
+														},
+														"value": {
+															Type:        schema.TypeInt,
+															Required:    true,
+															Description: "Edge TTL in seconds. `0` caches without storing and `-1` doesn't cache.",
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								"browser_ttl": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "List of browser TTL parameters to apply to the request.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"mode": {
+												Type:         schema.TypeString,
+												Required:     true,
+												ValidateFunc: validation.StringInSlice(rulesetBrowserTTLModes, false),
+												Description:  fmt.Sprintf("Mode of the browser TTL. %s", renderAvailableDocumentationValuesStringSlice(rulesetBrowserTTLModes)),
+											},
+											"default": {
+												Type:         schema.TypeInt,
+												Optional:     true,
+												ValidateFunc: validation.IntAtLeast(1),
+												Description:  "Default browser TTL, in seconds, when `mode` is `override_origin`.",
+											},
+										},
+									},
+								},
+								"serve_stale": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "List of serve stale parameters to apply to the request.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"disable_stale_while_updating": {
+												Type:        schema.TypeBool,
+												Optional:    true,
+												Description: "Whether stale content is not served while the cache is being updated.",
+											},
+										},
+									},
+								},
+								"cache_key": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "List of cache key parameters to apply to the request.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"cache_by_device_type": {
+												Type:        schema.TypeBool,
+												Optional:    true,
+												Description: "Whether the cache is separated by the device type of the visitor.",
+											},
+											"ignore_query_strings_order": {
+												Type:        schema.TypeBool,
+												Optional:    true,
+												Description: "Whether the order of the query string parameters is ignored in the cache key.",
+											},
+											"cache_deception_armor": {
+												Type:        schema.TypeBool,
+												Optional:    true,
+												Description: "Whether Cache Deception Armor, which checks the extension of the URL matches the content type of the response, is enabled.",
+											},
+											"custom_key": {
+												Type:        schema.TypeList,
+												Optional:    true,
+												MaxItems:    1,
+												Description: "Custom parts of the request that make up the cache key.",
+												Elem: &schema.Resource{
+													Schema: map[string]*schema.Schema{
+														"query_string": {
+															Type:        schema.TypeList,
+															Optional:    true,
+															MaxItems:    1,
+															Description: "Query string parameters of the cache key.",
+															Elem: &schema.Resource{
+																Schema: map[string]*schema.Schema{
+																	"include": {
+																		Type:        schema.TypeSet,
+																		Optional:    true,
+																		Description: "Query string parameters to include in the cache key, `*` for all of them.",
+																		Elem: &schema.Schema{
+																			Type: schema.TypeString,
+																		},
+																	},
+																	"exclude": {
+																		Type:        schema.TypeSet,
+																		Optional:    true,
+																		Description: "Query string parameters to exclude from the cache key, `*` for all of them.",
+																		Elem: &schema.Schema{
+																			Type: schema.TypeString,
+																		},
+																	},
+																},
+															},
+														},
+														"header": {
+															Type:        schema.TypeList,
+															Optional:    true,
+															MaxItems:    1,
+															Description: "Request headers of the cache key.",
+															Elem: &schema.Resource{
+																Schema: map[string]*schema.Schema{
+																	"include": {
+																		Type:        schema.TypeSet,
+																		Optional:    true,
+																		Description: "Headers whose values are included in the cache key.",
+																		Elem: &schema.Schema{
+																			Type: schema.TypeString,
+																		},
+																	},
+																	"check_presence": {
+																		Type:        schema.TypeSet,
+																		Optional:    true,
+																		Description: "Headers whose presence is included in the cache key.",
+																		Elem: &schema.Schema{
+																			Type: schema.TypeString,
+																		},
+																	},
+																	"exclude_origin": {
+																		Type:        schema.TypeBool,
+																		Optional:    true,
+																		Description: "Whether the `Origin` header is excluded from the cache key.",
+																	},
+																},
+															},
+														},
+														"cookie": {
+															Type:        schema.TypeList,
+															Optional:    true,
+															MaxItems:    1,
+															Description: "Cookies of the cache key.",
+															Elem: &schema.Resource{
+																Schema: map[string]*schema.Schema{
+																	"include": {
+																		Type:        schema.TypeSet,
+																		Optional:    true,
+																		Description: "Cookies whose values are included in the cache key.",
+																		Elem: &schema.Schema{
+																			Type: schema.TypeString,
+																		},
+																	},
+																	"check_presence": {
+																		Type:        schema.TypeSet,
+																		Optional:    true,
+																		Description: "Cookies whose presence is included in the cache key.",
+																		Elem: &schema.Schema{
+																			Type: schema.TypeString,
+																		},
+																	},
+																},
+															},
+														},
+														"user": {
+															Type:        schema.TypeList,
+															Optional:    true,
+															MaxItems:    1,
+															Description: "Characteristics of the visitor included in the cache key.",
+															Elem: &schema.Resource{
+																Schema: map[string]*schema.Schema{
+																	"device_type": {
+																		Type:        schema.TypeBool,
+																		Optional:    true,
+																		Description: "Whether the device type of the visitor is included in the cache key.",
+																	},
+																	"geo": {
+																		Type:        schema.TypeBool,
+																		Optional:    true,
+																		Description: "Whether the country of the visitor is included in the cache key.",
+																	},
+																	"lang": {
+																		Type:        schema.TypeBool,
+																		Optional:    true,
+																		Description: "Whether the first language of the `Accept-Language` header is included in the cache key.",
+																	},
+																},
+															},
+														},
+														"host": {
+															Type:        schema.TypeList,
+															Optional:    true,
+															MaxItems:    1,
+															Description: "Host of the cache key.",
+															Elem: &schema.Resource{
+																Schema: map[string]*schema.Schema{
+																	"resolved": {
+																		Type:        schema.TypeBool,
+																		Optional:    true,
+																		Description: "Whether the resolved host, rather than the `Host` header, is used in the cache key.",
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								"respect_strong_etags": {
+									Type:        schema.TypeBool,
+									Optional:    true,
+									Description: "Whether strong ETag headers are kept as is, rather than being turned into weak ones.",
+								},
+								"origin_error_page_passthru": {
+									Type:        schema.TypeBool,
+									Optional:    true,
+									Description: "Whether the error pages of the origin are served, rather than Cloudflare error pages.",
+								},
+								"from_value": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "Redirect to a URL, static or built from the request, when the rule matches. Conflicts with `from_list`.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"status_code": {
+												Type:         schema.TypeInt,
+												Optional:     true,
+												ValidateFunc: validation.IntInSlice(rulesetRedirectStatusCodes),
+												Description:  fmt.Sprintf("Status code of the redirect. %s", renderAvailableDocumentationValuesIntSlice(rulesetRedirectStatusCodes)),
+											},
+											"target_url": {
+												Type:        schema.TypeList,
+												Required:    true,
+												MaxItems:    1,
+												Description: "URL to redirect to.",
+												Elem: &schema.Resource{
+													Schema: map[string]*schema.Schema{
+														"value": {
+															Type:        schema.TypeString,
+															Optional:    true,
+															Description: "Static URL to redirect to. Conflicts with `expression`.",
+														},
+														"expression": {
+															Type:             schema.TypeString,
+															Optional:         true,
+															ValidateDiagFunc: validateExpressionValue(wirefilter.ScopeRequest),
+															Description:      "Expression evaluating to the URL to redirect to, such as `concat(\"https://example.com\", http.request.uri.path)`. Conflicts with `value`.",
+														},
+													},
+												},
+											},
+											"preserve_query_string": {
+												Type:        schema.TypeBool,
+												Optional:    true,
+												Description: "Whether the query string of the request is kept in the redirect.",
+											},
+										},
+									},
+								},
+								"from_list": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "Redirect according to a list of redirects, for Bulk Redirects. Conflicts with `from_value`.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"name": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Description: "Name of the list of redirects.",
+											},
+											"key": {
+												Type:             schema.TypeString,
+												Required:         true,
+												ValidateDiagFunc: validateExpressionValue(wirefilter.ScopeRequest),
+												Description:      "Expression evaluating to the source URL looked up in the list, such as `http.request.full_uri`.",
+											},
+										},
+									},
+								},
 							},
 						},
 					},
@@ -437,9 +776,10 @@ func resourceCloudflareRulesetSchema() map[string]*schema.Schema {
 									Description: "Once the request rate is reached, the Rate Limiting rule blocks further requests for the period of time defined in this field.",
 								},
 								"counting_expression": {
-									Type:        schema.TypeString,
-									Optional:    true,
-									Description: "Criteria for counting HTTP requests to trigger the Rate Limiting action. Uses the Firewall Rules expression language based on Wireshark display filters. Refer to the [Firewall Rules language](https://developers.cloudflare.com/firewall/cf-firewall-language) documentation for all available fields, operators, and functions.",
+									Type:             schema.TypeString,
+									Optional:         true,
+									ValidateDiagFunc: validateExpression(wirefilter.ScopeRequest | wirefilter.ScopeResponse),
+									Description:      "Criteria for counting HTTP requests to trigger the Rate Limiting action. Uses the Firewall Rules expression language based on Wireshark display filters. Refer to the [Firewall Rules language](https://developers.cloudflare.com/firewall/cf-firewall-language) documentation for all available fields, operators, and functions.",
 								},
 								"requests_to_origin": {
 									Type:        schema.TypeBool,
@@ -457,14 +797,16 @@ func resourceCloudflareRulesetSchema() map[string]*schema.Schema {
 						Elem: &schema.Resource{
 							Schema: map[string]*schema.Schema{
 								"username_expression": {
-									Type:        schema.TypeString,
-									Optional:    true,
-									Description: "Firewall Rules expression language based on Wireshark display filters for where to check for the \"username\" value. Refer to the [Firewall Rules language](https://developers.cloudflare.com/firewall/cf-firewall-language).",
+									Type:             schema.TypeString,
+									Optional:         true,
+									ValidateDiagFunc: validateExpressionValue(wirefilter.ScopeRequest),
+									Description:      "Firewall Rules expression language based on Wireshark display filters for where to check for the \"username\" value. Refer to the [Firewall Rules language](https://developers.cloudflare.com/firewall/cf-firewall-language).",
 								},
 								"password_expression": {
-									Type:        schema.TypeString,
-									Optional:    true,
-									Description: "Firewall Rules expression language based on Wireshark display filters for where to check for the \"password\" value. Refer to the [Firewall Rules language](https://developers.cloudflare.com/firewall/cf-firewall-language).",
+									Type:             schema.TypeString,
+									Optional:         true,
+									ValidateDiagFunc: validateExpressionValue(wirefilter.ScopeRequest),
+									Description:      "Firewall Rules expression language based on Wireshark display filters for where to check for the \"password\" value. Refer to the [Firewall Rules language](https://developers.cloudflare.com/firewall/cf-firewall-language).",
 								},
 							},
 						},
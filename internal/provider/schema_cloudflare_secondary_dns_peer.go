@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflareSecondaryDNSPeerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description: "The name of the peer.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"ip": {
+			Description:  "The IPv4 or IPv6 address of the peer.",
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPAddress,
+		},
+		"port": {
+			Description:  "The port the peer serves DNS on.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      53,
+			ValidateFunc: validation.IsPortNumber,
+		},
+		"ixfr_enable": {
+			Description: "Whether to use incremental zone transfers (IXFR) instead of full transfers (AXFR) with the peer.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"tsig_id": {
+			Description: "The identifier of the TSIG key used to authenticate transfers from the peer.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var secondaryDNSTSIGAlgorithms = []string{
+	"hmac-md5.sig-alg.reg.int.",
+	"hmac-sha1.",
+	"hmac-sha256.",
+	"hmac-sha512.",
+}
+
+func resourceCloudflareSecondaryDNSTSIGSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description: "The name of the TSIG key as known by the peers.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"algo": {
+			Description:  fmt.Sprintf("The TSIG algorithm. %s", renderAvailableDocumentationValuesStringSlice(secondaryDNSTSIGAlgorithms)),
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(secondaryDNSTSIGAlgorithms, true),
+		},
+		"secret": {
+			Description: "The base64 encoded TSIG secret shared with the peers.",
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+		},
+	}
+}
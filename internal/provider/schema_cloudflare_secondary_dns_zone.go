@@ -1 +1,62 @@
 package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflareSecondaryDNSZoneSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"zone_id": {
+			Description: "The zone identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description: "The name of the zone being transferred in from the peers.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"peers": {
+			Description: "The identifiers of the peers (primary nameservers) to transfer the zone from.",
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"auto_refresh_seconds": {
+			Description:  "How often, in seconds, to check the peers for a new SOA serial when no NOTIFY has been received.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      86400,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"force_axfr": {
+			Description: "Whether to request an immediate full zone transfer (AXFR) from the peers after the configuration is created or changed.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"soa_serial": {
+			Description: "The SOA serial of the most recently transferred version of the zone.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"created_time": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"checked_time": {
+			Description: "When the peers were last checked for a new SOA serial.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"modified_time": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
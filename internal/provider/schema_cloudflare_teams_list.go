@@ -31,6 +31,18 @@ func resourceCloudflareTeamsListSchema() map[string]*schema.Schema {
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
+			ConflictsWith: []string{"items_file"},
+		},
+		"items_file": {
+			Description:   "Path to a file of the items of the list, with a value per line. Lines starting with `#` are ignored and values must not be repeated. Only a hash of the items is kept in state.",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"items"},
+		},
+		"items_file_hash": {
+			Description: "Hash of the items of the list, when they are read from `items_file`.",
+			Type:        schema.TypeString,
+			Computed:    true,
 		},
 	}
 }
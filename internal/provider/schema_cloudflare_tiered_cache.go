@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflareTieredCacheSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"zone_id": {
+			Description: "The zone identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"cache_type": {
+			Description:  fmt.Sprintf("The topology of the tiered cache. `smart` picks the upper tier closest to the origin, `generic` uses every upper tier and `off` disables tiered caching. %s", renderAvailableDocumentationValuesStringSlice(tieredCacheTypes)),
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(tieredCacheTypes, false),
+		},
+		"regional_tiered_cache": {
+			Description:  fmt.Sprintf("Whether a regional tier is added between the lower tiers and the upper tier, which requires `cache_type` to be `smart` or `generic`. Left as is when not set, or when the resource is destroyed. %s", renderAvailableDocumentationValuesStringSlice([]string{"on", "off"})),
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"on", "off"}, false),
+		},
+	}
+}
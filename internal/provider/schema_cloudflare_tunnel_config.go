@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflareTunnelConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"account_id": {
+			Description: "The account identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"tunnel_id": {
+			Description: "Identifier of the tunnel to configure.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"config": {
+			Description: "Configuration of the tunnel, which `cloudflared` fetches when it's run with the tunnel token.",
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"warp_routing": {
+						Description: "Configuration of routing private network traffic from WARP clients through the tunnel.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"enabled": {
+									Description: "Whether WARP routing is enabled.",
+									Type:        schema.TypeBool,
+									Optional:    true,
+								},
+							},
+						},
+					},
+					"origin_request": {
+						Description: "Default settings of the requests made to origins, which ingress rules may override.",
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Elem:        tunnelConfigOriginRequestResource(),
+					},
+					"ingress_rule": {
+						Description: "Rules routing requests to origins, evaluated in order. The last rule must be a catch-all rule matching every request, without a `hostname` or `path`.",
+						Type:        schema.TypeList,
+						Required:    true,
+						MinItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"hostname": {
+									Description:  "Hostname the rule matches, which may start with a `*` wildcard.",
+									Type:         schema.TypeString,
+									Optional:     true,
+									ValidateFunc: validateTunnelIngressHostname,
+								},
+								"path": {
+									Description:  "Regular expression matching the path of requests the rule matches.",
+									Type:         schema.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.StringIsValidRegExp,
+								},
+								"service": {
+									Description:  "Origin requests are routed to, such as `http://localhost:8080`, `unix:/var/run/app.sock`, `hello_world`, `bastion` or `http_status:404`.",
+									Type:         schema.TypeString,
+									Required:     true,
+									ValidateFunc: validateTunnelIngressService,
+								},
+								"origin_request": {
+									Description: "Settings of the requests made to the origin, overriding those of `config.origin_request`.",
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Elem:        tunnelConfigOriginRequestResource(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"version": {
+			Description: "Version of the tunnel configuration, incremented on every change.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+}
+
+// tunnelConfigDurationSchema describes durations, which the API stores as a
+// number of seconds.
+func tunnelConfigDurationSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description:      fmt.Sprintf("%s, as a duration such as `30s` or `1m30s`.", description),
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validateTunnelConfigDuration,
+		DiffSuppressFunc: suppressEquivalentTunnelConfigDuration,
+	}
+}
+
+// tunnelConfigOriginRequestResource describes the `originRequest` settings of
+// cloudflared. As every setting is an attribute, unknown settings are
+// rejected during plan.
+func tunnelConfigOriginRequestResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"connect_timeout":    tunnelConfigDurationSchema("Timeout for establishing a new TCP connection to the origin"),
+			"tls_timeout":        tunnelConfigDurationSchema("Timeout for completing a TLS handshake with the origin"),
+			"tcp_keep_alive":     tunnelConfigDurationSchema("Interval of TCP keep-alive packets sent to the origin"),
+			"keep_alive_timeout": tunnelConfigDurationSchema("Timeout after which an idle keep-alive connection to the origin is closed"),
+			"no_happy_eyeballs": {
+				Description: "Whether to disable the \"happy eyeballs\" algorithm for IPv4 and IPv6 fallback.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"keep_alive_connections": {
+				Description:  "Maximum number of idle keep-alive connections to the origin.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"http_host_header": {
+				Description: "Host header sent to the origin.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"origin_server_name": {
+				Description: "Hostname expected in the certificate of the origin.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"ca_pool": {
+				Description: "Path to the certificate authority used to verify the certificate of the origin, on the host running `cloudflared`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"no_tls_verify": {
+				Description: "Whether to skip verifying the certificate of the origin.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"disable_chunked_encoding": {
+				Description: "Whether to disable chunked transfer encoding, for origins such as WSGI servers that don't support it.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"bastion_mode": {
+				Description: "Whether `cloudflared` acts as a jump host, connecting to the destination requested by clients.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"http2_origin": {
+				Description: "Whether to connect to the origin with HTTP/2.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"proxy_address": {
+				Description:  "Address the proxy `cloudflared` runs for non-HTTP origins listens on.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"proxy_port": {
+				Description:  "Port the proxy `cloudflared` runs for non-HTTP origins listens on.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"proxy_type": {
+				Description:  fmt.Sprintf("Type of the proxy `cloudflared` runs for non-HTTP origins. %s", renderAvailableDocumentationValuesStringSlice([]string{"socks"})),
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"socks"}, false),
+			},
+			"ip_rule": {
+				Description: "Rules allowing or denying the destinations of the SOCKS proxy, evaluated in order.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prefix": {
+							Description:  "Destination network of the rule, in CIDR notation.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsCIDR,
+						},
+						"ports": {
+							Description: "Destination ports of the rule. All ports match if none are given.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(1, 65535),
+							},
+						},
+						"allow": {
+							Description: "Whether the destinations are allowed.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"access": {
+				Description: "Requires requests to the origin to carry a valid Access JWT.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"required": {
+							Description: "Whether requests without a valid Access JWT are rejected.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"team_name": {
+							Description: "Name of the Zero Trust organization issuing the JWT.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"aud_tag": {
+							Description: "Audience tags of the Access applications the JWT may be issued for.",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -104,5 +104,56 @@ func resourceCloudflareWaitingRoomSchema() map[string]*schema.Schema {
 			Type:     schema.TypeBool,
 			Optional: true,
 		},
+
+		"queueing_method": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "fifo",
+			ValidateFunc: validation.StringInSlice(waitingRoomQueueingMethods, false),
+		},
+
+		"cookie_attributes": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"samesite": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "auto",
+						ValidateFunc: validation.StringInSlice(waitingRoomCookieSameSiteValues, false),
+					},
+					"secure": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "auto",
+						ValidateFunc: validation.StringInSlice(waitingRoomCookieSecureValues, false),
+					},
+				},
+			},
+		},
+
+		"additional_routes": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"host": {
+						Type:     schema.TypeString,
+						Required: true,
+						StateFunc: func(i interface{}) string {
+							return strings.ToLower(i.(string))
+						},
+					},
+					"path": {
+						Type:     schema.TypeString,
+						Optional: true,
+						Default:  "/",
+					},
+				},
+			},
+		},
 	}
 }
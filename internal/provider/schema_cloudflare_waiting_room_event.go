@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCloudflareWaitingRoomEventSchema() map[string]*schema.Schema {
@@ -58,8 +59,9 @@ func resourceCloudflareWaitingRoomEventSchema() map[string]*schema.Schema {
 		},
 
 		"queueing_method": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(waitingRoomQueueingMethods, false),
 		},
 
 		"shuffle_at_event_start": {
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/wirefilter"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCloudflareWaitingRoomRulesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"zone_id": {
+			Description: "The zone identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"waiting_room_id": {
+			Description: "The waiting room identifier to target for the resource.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"rules": {
+			Description: "List of rules of the waiting room, evaluated in order.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Description: "Unique rule identifier.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"version": {
+						Description: "Version of the rule.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"action": {
+						Description:  fmt.Sprintf("Action to perform when the rule matches. %s", renderAvailableDocumentationValuesStringSlice(waitingRoomRuleActions)),
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(waitingRoomRuleActions, false),
+					},
+					"expression": {
+						Description:      "Criteria for a request to match the rule. Uses the Firewall Rules expression language based on Wireshark display filters. Refer to the [Firewall Rules language](https://developers.cloudflare.com/firewall/cf-firewall-language) documentation for all available fields, operators, and functions.",
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: validateExpression(wirefilter.ScopeRequest),
+					},
+					"description": {
+						Description: "Brief summary of the rule and its intended use.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"enabled": {
+						Description: "Whether the rule is active.",
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+					},
+				},
+			},
+		},
+	}
+}
@@ -1,6 +1,14 @@
 package provider
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
 
 var kvNamespaceBindingResource = &schema.Resource{
 	Schema: map[string]*schema.Schema{
@@ -15,6 +23,115 @@ var kvNamespaceBindingResource = &schema.Resource{
 	},
 }
 
+var r2BucketBindingResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"bucket_name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	},
+}
+
+var serviceBindingResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"service": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"environment": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+	},
+}
+
+var durableObjectNamespaceBindingResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"class_name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"script_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	},
+}
+
+var queueBindingResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"queue_name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	},
+}
+
+var analyticsEngineBindingResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"dataset": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	},
+}
+
+var workerMigrationResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"tag": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"new_classes": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"renamed_classes": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"from": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"to": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+				},
+			},
+		},
+		"deleted_classes": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	},
+}
+
 var plainTextBindingResource = &schema.Resource{
 	Schema: map[string]*schema.Schema{
 		"name": {
@@ -55,6 +172,41 @@ var webAssemblyBindingResource = &schema.Resource{
 	},
 }
 
+var workerModuleResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "esm",
+			ValidateFunc: validation.StringInSlice(workerModuleTypes(), false),
+		},
+		"content": {
+			Type:     schema.TypeString,
+			Required: true,
+			// Only a hash of the content is stored, which is compared with a
+			// hash of the module downloaded from the API to detect drift.
+			StateFunc: hashWorkerModuleContent,
+		},
+	},
+}
+
+func hashWorkerModuleContent(v interface{}) string {
+	sum := sha256.Sum256([]byte(v.(string)))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashWorkerServiceBinding identifies service bindings by their name and
+// service only, so that bindings that don't set an environment match the
+// environment read back from the API.
+func hashWorkerServiceBinding(v interface{}) int {
+	m := v.(map[string]interface{})
+	return schema.HashString(fmt.Sprintf("%s-%s", m["name"], m["service"]))
+}
+
 func resourceCloudflareWorkerScriptSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"name": {
@@ -63,8 +215,30 @@ func resourceCloudflareWorkerScriptSchema() map[string]*schema.Schema {
 			ForceNew: true,
 		},
 		"content": {
-			Type:     schema.TypeString,
-			Required: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"content", "main_module"},
+		},
+		"main_module": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			RequiredWith: []string{"module"},
+		},
+		"module": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			RequiredWith: []string{"main_module"},
+			Elem:         workerModuleResource,
+		},
+		"compatibility_date": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "must be a date in the format YYYY-MM-DD"),
+		},
+		"compatibility_flags": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
 		"plain_text_binding": {
 			Type:     schema.TypeSet,
@@ -81,10 +255,46 @@ func resourceCloudflareWorkerScriptSchema() map[string]*schema.Schema {
 			Optional: true,
 			Elem:     kvNamespaceBindingResource,
 		},
+		"r2_bucket_binding": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     r2BucketBindingResource,
+		},
+		"service_binding": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     serviceBindingResource,
+			Set:      hashWorkerServiceBinding,
+		},
+		"durable_object_namespace_binding": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     durableObjectNamespaceBindingResource,
+		},
+		"queue_binding": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     queueBindingResource,
+		},
+		"analytics_engine_binding": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     analyticsEngineBindingResource,
+		},
+		"migration": {
+			// Only the migrations applied to the script are kept in state,
+			// so that pending ones show up as changes.
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     workerMigrationResource,
+		},
 		"webassembly_binding": {
 			Type:     schema.TypeSet,
 			Optional: true,
 			Elem:     webAssemblyBindingResource,
+			// Workers in the ES module format import WebAssembly modules
+			// instead.
+			ConflictsWith: []string{"main_module"},
 		},
 	}
 }
@@ -251,3 +251,19 @@ func renderAvailableDocumentationValuesStringSlice(s []string) string {
 	}
 	return output
 }
+
+// renderAvailableDocumentationValuesIntSlice takes a slice of ints and
+// formats it for documentation output use.
+//
+// Example: [301, 302] -> `301`, `302`.
+func renderAvailableDocumentationValuesIntSlice(s []int) string {
+	output := ""
+	if len(s) > 0 {
+		values := make([]string, len(s))
+		for i, c := range s {
+			values[i] = fmt.Sprintf("`%d`", c)
+		}
+		output = fmt.Sprintf("Available values: %s", strings.Join(values, ", "))
+	}
+	return output
+}
@@ -5,6 +5,11 @@ import (
 	"net"
 	"net/url"
 	"strings"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/wirefilter"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var allowedHTTPMethods = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "_ALL_"}
@@ -74,3 +79,51 @@ func validateURL(v interface{}, k string) (s []string, errors []error) {
 	}
 	return
 }
+
+// validateExpression returns a validator ensuring the value is a Rules
+// language expression evaluating to a boolean that only uses fields
+// available in scope. Unknown fields are only warned about.
+func validateExpression(scope wirefilter.Scope) schema.SchemaValidateDiagFunc {
+	return expressionValidator(func(expr string) ([]*wirefilter.Error, error) {
+		return wirefilter.Validate(expr, scope)
+	})
+}
+
+// validateExpressionValue returns a validator ensuring the value is a Rules
+// language expression evaluating to a string, such as a field or a function
+// transforming one.
+func validateExpressionValue(scope wirefilter.Scope) schema.SchemaValidateDiagFunc {
+	return expressionValidator(func(expr string) ([]*wirefilter.Error, error) {
+		return wirefilter.ValidateValue(expr, scope)
+	})
+}
+
+func expressionValidator(validate func(string) ([]*wirefilter.Error, error)) schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		expr, ok := v.(string)
+		if !ok {
+			return diag.Errorf("expected expression to be a string")
+		}
+
+		var diags diag.Diagnostics
+		warnings, err := validate(expr)
+		for _, warning := range warnings {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "Unknown field in expression",
+				Detail:        fmt.Sprintf("%s. The field may be newer than this provider, in which case its use isn't validated until apply.", warning),
+				AttributePath: path,
+			})
+		}
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid expression",
+				Detail:        err.Error(),
+				AttributePath: path,
+			})
+		}
+
+		return diags
+	}
+}
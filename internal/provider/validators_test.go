@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/wirefilter"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestValidateRecordType(t *testing.T) {
@@ -67,3 +71,33 @@ func TestValidateRecordName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateExpression(t *testing.T) {
+	path := cty.GetAttrPath("rules").IndexInt(0).GetAttr("expression")
+
+	diags := validateExpression(wirefilter.ScopeRequest)(`http.request.uri.path eq "/"`, path)
+	assert.False(t, diags.HasError())
+
+	// Unknown fields are only warned about, as they may be newer than the
+	// provider.
+	diags = validateExpression(wirefilter.ScopeRequest)(`http.request.uri.pth eq "/"`, path)
+	assert.False(t, diags.HasError())
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "Unknown field in expression", diags[0].Summary)
+		assert.Equal(t, `unknown field "http.request.uri.pth", did you mean "http.request.uri.path"? (at character 0). The field may be newer than this provider, in which case its use isn't validated until apply.`, diags[0].Detail)
+		assert.Equal(t, path, diags[0].AttributePath)
+	}
+
+	diags = validateExpression(wirefilter.ScopeRequest)(`http.host eq 1`, path)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Invalid expression", diags[0].Summary)
+		assert.Equal(t, `expected a string after "eq", got "1" (at character 13)`, diags[0].Detail)
+	}
+
+	diags = validateExpression(wirefilter.ScopeRequest)(`http.response.code eq 404`, path)
+	assert.True(t, diags.HasError())
+
+	diags = validateExpressionValue(wirefilter.ScopeRequest)(`url_decode(http.request.body.form["username"][0])`, path)
+	assert.False(t, diags.HasError())
+}
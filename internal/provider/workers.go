@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strings"
+
+	cloudflare "github.com/cloudflare/cloudflare-go"
+)
+
+// Workers are uploaded as multipart forms, which cloudflare-go can only build
+// for scripts in the service worker format with a fixed set of bindings. The
+// forms are therefore built here and sent with the HTTP client the provider
+// configures cloudflare-go with.
+
+// workerModuleContentTypes are the content types of the parts of each type of
+// module, which is how the API tells the types apart.
+var workerModuleContentTypes = map[string]string{
+	"esm":           "application/javascript+module",
+	"commonjs":      "application/javascript",
+	"text":          "text/plain",
+	"data":          "application/octet-stream",
+	"compiled_wasm": "application/wasm",
+}
+
+func workerModuleTypes() []string {
+	types := make([]string, 0, len(workerModuleContentTypes))
+	for t := range workerModuleContentTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// workerModule is a part of a Worker in the ES module format.
+type workerModule struct {
+	Name    string
+	Type    string
+	Content []byte
+}
+
+// binary reports whether the content of m is configured base64 encoded.
+func (m workerModule) binary() bool {
+	return m.Type == "data" || m.Type == "compiled_wasm"
+}
+
+// Types of bindings cloudflare-go doesn't know.
+const (
+	workerR2BucketBindingType               = "r2_bucket"
+	workerServiceBindingType                = "service"
+	workerDurableObjectNamespaceBindingType = "durable_object_namespace"
+	workerQueueBindingType                  = "queue"
+	workerAnalyticsEngineBindingType        = "analytics_engine"
+)
+
+// workerBinding is a binding of a Worker, described by its metadata. Some
+// bindings, such as WebAssembly modules, also upload a part of their own.
+type workerBinding struct {
+	Meta map[string]interface{}
+	Part *workerModule
+}
+
+// workerScript is a Worker in either the service worker format, where
+// Script holds the script, or in the ES module format, where MainModule names
+// the module of Modules the Worker runs.
+type workerScript struct {
+	Script             string
+	MainModule         string
+	Modules            []workerModule
+	Bindings           []workerBinding
+	CompatibilityDate  string
+	CompatibilityFlags []string
+	Migrations         *workerMigrations
+}
+
+// workerMigrations are the Durable Object migrations applied by an upload,
+// moving the script from the migration tagged OldTag to the one tagged NewTag.
+type workerMigrations struct {
+	OldTag string                `json:"old_tag,omitempty"`
+	NewTag string                `json:"new_tag"`
+	Steps  []workerMigrationStep `json:"steps"`
+}
+
+type workerMigrationStep struct {
+	NewClasses     []string                      `json:"new_classes,omitempty"`
+	RenamedClasses []workerMigrationRenamedClass `json:"renamed_classes,omitempty"`
+	DeletedClasses []string                      `json:"deleted_classes,omitempty"`
+}
+
+type workerMigrationRenamedClass struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// multipartBody returns the multipart form uploading s, along with its
+// content type.
+func (s workerScript) multipartBody() (string, []byte, error) {
+	bindings := make([]map[string]interface{}, 0, len(s.Bindings))
+	for _, b := range s.Bindings {
+		bindings = append(bindings, b.Meta)
+	}
+
+	metadata := map[string]interface{}{"bindings": bindings}
+	if s.MainModule != "" {
+		metadata["main_module"] = s.MainModule
+	} else {
+		metadata["body_part"] = "script"
+	}
+	if s.CompatibilityDate != "" {
+		metadata["compatibility_date"] = s.CompatibilityDate
+	}
+	if len(s.CompatibilityFlags) > 0 {
+		metadata["compatibility_flags"] = s.CompatibilityFlags
+	}
+	if s.Migrations != nil {
+		metadata["migrations"] = s.Migrations
+	}
+
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return "", nil, fmt.Errorf("error marshalling worker metadata: %w", err)
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	if err := writeWorkerPart(w, "metadata", "application/json", metadataJSON, false); err != nil {
+		return "", nil, err
+	}
+
+	if s.MainModule != "" {
+		for _, m := range s.Modules {
+			if err := writeWorkerPart(w, m.Name, workerModuleContentTypes[m.Type], m.Content, true); err != nil {
+				return "", nil, err
+			}
+		}
+	} else if err := writeWorkerPart(w, "script", "application/javascript", []byte(s.Script), true); err != nil {
+		return "", nil, err
+	}
+
+	for _, b := range s.Bindings {
+		if b.Part == nil {
+			continue
+		}
+		if err := writeWorkerPart(w, b.Part.Name, workerModuleContentTypes[b.Part.Type], b.Part.Content, true); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return "", nil, fmt.Errorf("error writing worker upload: %w", err)
+	}
+
+	return w.FormDataContentType(), body.Bytes(), nil
+}
+
+var workerPartQuoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func writeWorkerPart(w *multipart.Writer, name, contentType string, content []byte, file bool) error {
+	disposition := fmt.Sprintf(`form-data; name="%s"`, workerPartQuoteEscaper.Replace(name))
+	if file {
+		disposition += fmt.Sprintf(`; filename="%s"`, workerPartQuoteEscaper.Replace(name))
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", disposition)
+	h.Set("Content-Type", contentType)
+
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return fmt.Errorf("error writing part %q of worker upload: %w", name, err)
+	}
+	if _, err := part.Write(content); err != nil {
+		return fmt.Errorf("error writing part %q of worker upload: %w", name, err)
+	}
+
+	return nil
+}
+
+// workerScriptURI returns the endpoint of the named script in the account of
+// client.
+func workerScriptURI(client *cloudflare.API, name string) (string, error) {
+	if client.AccountID == "" {
+		return "", fmt.Errorf("an account ID is required to manage Workers, set `account_id` on the provider")
+	}
+	return fmt.Sprintf("/accounts/%s/workers/scripts/%s", client.AccountID, url.PathEscape(name)), nil
+}
+
+// uploadWorkerScript creates or replaces the named script.
+func uploadWorkerScript(ctx context.Context, meta interface{}, client *cloudflare.API, name string, script workerScript) error {
+	uri, err := workerScriptURI(client, name)
+	if err != nil {
+		return err
+	}
+
+	contentType, body, err := script.multipartBody()
+	if err != nil {
+		return err
+	}
+
+	_, _, err = apiRequest(ctx, meta, client, http.MethodPut, uri, contentType, body)
+	return err
+}
+
+// downloadWorkerScript returns the content of the named script. Scripts in
+// the ES module format are downloaded as a multipart form of their modules,
+// starting with the main module. Bindings and settings aren't part of the
+// download.
+func downloadWorkerScript(ctx context.Context, meta interface{}, client *cloudflare.API, name string) (workerScript, error) {
+	uri, err := workerScriptURI(client, name)
+	if err != nil {
+		return workerScript{}, err
+	}
+
+	body, header, err := apiRequest(ctx, meta, client, http.MethodGet, uri, "", nil)
+	if err != nil {
+		return workerScript{}, err
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return workerScript{Script: string(body)}, nil
+	}
+
+	var script workerScript
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return workerScript{}, fmt.Errorf("error reading modules of worker script %q: %w", name, err)
+		}
+
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return workerScript{}, fmt.Errorf("error reading modules of worker script %q: %w", name, err)
+		}
+
+		module := workerModule{Name: part.FormName(), Type: "data", Content: content}
+		if partType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type")); err == nil {
+			for t, contentType := range workerModuleContentTypes {
+				if partType == contentType {
+					module.Type = t
+				}
+			}
+		}
+		if script.MainModule == "" {
+			script.MainModule = module.Name
+		}
+		script.Modules = append(script.Modules, module)
+	}
+
+	return script, nil
+}
+
+// workerScriptSettings are the settings of a script, which aren't part of its
+// download.
+type workerScriptSettings struct {
+	// Bindings are the metadata of the bindings of the script, read as they
+	// are as cloudflare-go reads bindings of types it doesn't know as
+	// inherited bindings, losing their settings.
+	Bindings []map[string]interface{} `json:"bindings"`
+	// MigrationTag is the tag of the last Durable Object migration applied
+	// to the script.
+	MigrationTag       string   `json:"migration_tag"`
+	CompatibilityDate  string   `json:"compatibility_date"`
+	CompatibilityFlags []string `json:"compatibility_flags"`
+}
+
+// getWorkerScriptSettings returns the settings of the named script.
+func getWorkerScriptSettings(ctx context.Context, meta interface{}, client *cloudflare.API, name string) (workerScriptSettings, error) {
+	uri, err := workerScriptURI(client, name)
+	if err != nil {
+		return workerScriptSettings{}, err
+	}
+
+	body, _, err := apiRequest(ctx, meta, client, http.MethodGet, uri+"/settings", "", nil)
+	if err != nil {
+		return workerScriptSettings{}, err
+	}
+
+	var resp struct {
+		Result workerScriptSettings `json:"result"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return workerScriptSettings{}, fmt.Errorf("error reading settings of worker script %q: %w", name, err)
+	}
+
+	return resp.Result, nil
+}
+
+// downloadWorkerBindingContent returns the content of a binding of the named
+// script that uploads a part of its own, such as a WebAssembly module.
+func downloadWorkerBindingContent(ctx context.Context, meta interface{}, client *cloudflare.API, name, binding string) ([]byte, error) {
+	uri, err := workerScriptURI(client, name)
+	if err != nil {
+		return nil, err
+	}
+
+	body, _, err := apiRequest(ctx, meta, client, http.MethodGet, uri+"/bindings/"+url.PathEscape(binding)+"/content", "", nil)
+	return body, err
+}
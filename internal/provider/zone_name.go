@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// addZoneNameSchema adds a `zone_name` alternative to the `zone_id` of
+// resources and data sources that require one. The name is resolved to the
+// zone identifier before anything else uses it: during plan for resources,
+// so that the identifier is known and only changing zones replaces the
+// resource, and before reading for data sources.
+func addZoneNameSchema(r *schema.Resource) {
+	zoneID, ok := r.Schema["zone_id"]
+	if !ok || zoneID.Type != schema.TypeString || !zoneID.Required {
+		return
+	}
+
+	zoneID.Required = false
+	zoneID.Optional = true
+	zoneID.Computed = true
+	zoneID.ExactlyOneOf = []string{"zone_id", "zone_name"}
+
+	r.Schema["zone_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ExactlyOneOf: []string{"zone_id", "zone_name"},
+		Description:  "The name of the zone to target for the resource, as an alternative to `zone_id`.",
+	}
+
+	if r.CreateContext == nil {
+		read := r.ReadContext
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if name, ok := d.GetOk("zone_name"); ok {
+				zoneID, err := zoneIDByName(meta, d, name.(string))
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				d.Set("zone_id", zoneID)
+			}
+			return read(ctx, d, meta)
+		}
+		return
+	}
+
+	// Switching between `zone_id` and `zone_name` of the same zone, or setting
+	// `zone_name` after an import, only changes `zone_name`. Resources that
+	// can't otherwise be updated in place then only need to store it.
+	if r.UpdateContext == nil {
+		r.UpdateContext = schema.UpdateContextFunc(r.ReadContext)
+	}
+
+	if r.CustomizeDiff == nil {
+		r.CustomizeDiff = resolveZoneName
+	} else {
+		r.CustomizeDiff = customdiff.All(resolveZoneName, r.CustomizeDiff)
+	}
+}
+
+func resolveZoneName(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("zone_name") {
+		return d.SetNewComputed("zone_id")
+	}
+
+	name, ok := d.GetOk("zone_name")
+	if !ok {
+		return nil
+	}
+
+	zoneID, err := zoneIDByName(meta, d, name.(string))
+	if err != nil {
+		return err
+	}
+
+	if d.Get("zone_id").(string) != zoneID {
+		return d.SetNew("zone_id", zoneID)
+	}
+
+	return nil
+}
+
+func zoneIDByName(meta interface{}, d resourceAttributeGetter, name string) (string, error) {
+	client, err := apiClient(meta, d)
+	if err != nil {
+		return "", err
+	}
+
+	zoneID, err := meta.(*providerMeta).zoneIDByName(client, name)
+	if err != nil {
+		return "", fmt.Errorf("error finding zone %q: %w", name, err)
+	}
+
+	return zoneID, nil
+}
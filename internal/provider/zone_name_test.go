@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/terraform-provider-cloudflare/internal/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneNameResolution(t *testing.T) {
+	for _, env := range []string{"CLOUDFLARE_API_TOKEN", "CLOUDFLARE_API_KEY", "CLOUDFLARE_EMAIL", "CLOUDFLARE_ACCOUNT_ID", "CLOUDFLARE_PROFILE", "CLOUDFLARE_REQUEST_RECORDING_PATH"} {
+		t.Setenv(env, "")
+	}
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.AddZone(testAccCloudflareZoneID, testAccCloudflareZoneName, testAccCloudflareAccountID)
+
+	p := New("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_token":    "0123456789abcdefghijklmnopqrstuvwxyzABCD",
+		"api_hostname": server.Hostname(),
+	}))
+	require.False(t, diags.HasError(), "%v", diags)
+	m := p.Meta().(*providerMeta)
+	client := m.defaultClient()
+	client.BaseURL = strings.Replace(client.BaseURL, "https://", "http://", 1)
+
+	record := p.ResourcesMap["cloudflare_record"]
+	assert.True(t, record.Schema["zone_id"].Optional)
+	assert.Contains(t, record.Schema, "zone_name")
+	assert.NotContains(t, p.ResourcesMap["cloudflare_ruleset"].Schema, "zone_name", "zone_id isn't required by account and zone level resources")
+
+	diff, err := record.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"zone_name": strings.ToUpper(testAccCloudflareZoneName),
+		"name":      "www",
+		"type":      "A",
+		"value":     "192.0.2.1",
+	}), m)
+	require.NoError(t, err)
+	assert.Equal(t, testAccCloudflareZoneID, diff.Attributes["zone_id"].New)
+
+	// Moving resources that can't be updated in place to the name of the same
+	// zone, as after an import, only stores the name, while moving them to
+	// another zone replaces them.
+	server.AddZone("1e6a8a2d6b5c4d6b8c1e7a9b0f2d3c4e", "other.example.com", testAccCloudflareAccountID)
+	certificatePack := p.ResourcesMap["cloudflare_certificate_pack"]
+	assert.NotNil(t, certificatePack.UpdateContext)
+	assert.True(t, certificatePack.Schema["profile"].ForceNew, "changing profiles still replaces resources that can't be updated in place")
+	assert.False(t, record.Schema["profile"].ForceNew)
+	state := &terraform.InstanceState{ID: "1", Attributes: map[string]string{
+		"id":      "1",
+		"zone_id": testAccCloudflareZoneID,
+		"type":    "advanced",
+		"hosts.#": "1",
+		fmt.Sprintf("hosts.%d", schema.HashString(testAccCloudflareZoneName)): testAccCloudflareZoneName,
+		"wait_for_active_status": "false",
+	}}
+	for name, requiresNew := range map[string]bool{testAccCloudflareZoneName: false, "other.example.com": true} {
+		diff, err = certificatePack.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"zone_name": name,
+			"type":      "advanced",
+			"hosts":     []interface{}{testAccCloudflareZoneName},
+		}), m)
+		require.NoError(t, err)
+		assert.Equal(t, requiresNew, diff.RequiresNew(), name)
+		assert.Equal(t, name, diff.Attributes["zone_name"].New)
+	}
+
+	dataSource := p.DataSourcesMap["cloudflare_dns_zone_file_export"]
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{"zone_name": testAccCloudflareZoneName})
+	diags = dataSource.ReadContext(context.Background(), d, m)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, testAccCloudflareZoneID, d.Get("zone_id"))
+
+	_, err = zoneIDByName(m, d, "missing.example.com")
+	assert.EqualError(t, err, `error finding zone "missing.example.com": zone could not be found`)
+
+	// Zones are only looked up once per provider.
+	server.Close()
+	zoneID, err := m.zoneIDByName(client, testAccCloudflareZoneName+".")
+	assert.NoError(t, err)
+	assert.Equal(t, testAccCloudflareZoneID, zoneID)
+}
@@ -0,0 +1,191 @@
+package recorder
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// The subset of the HAR 1.2 format that recordings use.
+//
+// Reference: http://www.softwareishard.com/blog/har-12-spec/
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            int64       `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    int64 `json:"send"`
+	Wait    int64 `json:"wait"`
+	Receive int64 `json:"receive"`
+}
+
+func marshalHAR(interactions []Interaction) ([]byte, error) {
+	file := harFile{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "terraform-provider-cloudflare", Version: "1.0"},
+		Entries: make([]harEntry, 0, len(interactions)),
+	}}
+
+	for _, i := range interactions {
+		entry := harEntry{
+			StartedDateTime: i.StartedAt.Format(time.RFC3339Nano),
+			Time:            i.Duration,
+			Request: harRequest{
+				Method:      i.Request.Method,
+				URL:         i.Request.URL,
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     []harNameValue{},
+				Headers:     toNameValues(i.Request.Headers),
+				QueryString: []harNameValue{},
+				HeadersSize: -1,
+				BodySize:    len(i.Request.Body),
+			},
+			Response: harResponse{
+				Status:      i.Response.StatusCode,
+				StatusText:  http.StatusText(i.Response.StatusCode),
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     []harNameValue{},
+				Headers:     toNameValues(i.Response.Headers),
+				Content: harContent{
+					Size:     len(i.Response.Body),
+					MimeType: i.Response.Headers.Get("Content-Type"),
+					Text:     i.Response.Body,
+				},
+				HeadersSize: -1,
+				BodySize:    len(i.Response.Body),
+			},
+			Timings: harTimings{Wait: i.Duration},
+		}
+
+		if u, err := url.Parse(i.Request.URL); err == nil {
+			entry.Request.QueryString = toNameValues(u.Query())
+		}
+		if i.Request.Body != "" {
+			entry.Request.PostData = &harPostData{MimeType: i.Request.Headers.Get("Content-Type"), Text: i.Request.Body}
+		}
+
+		file.Log.Entries = append(file.Log.Entries, entry)
+	}
+
+	return json.MarshalIndent(file, "", "  ")
+}
+
+func unmarshalHAR(content []byte) ([]Interaction, error) {
+	var file harFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+
+	interactions := make([]Interaction, 0, len(file.Log.Entries))
+	for _, entry := range file.Log.Entries {
+		started, _ := time.Parse(time.RFC3339Nano, entry.StartedDateTime)
+		interaction := Interaction{
+			StartedAt: started,
+			Duration:  entry.Time,
+			Request: Request{
+				Method:  entry.Request.Method,
+				URL:     entry.Request.URL,
+				Headers: fromNameValues(entry.Request.Headers),
+			},
+			Response: Response{
+				StatusCode: entry.Response.Status,
+				Headers:    fromNameValues(entry.Response.Headers),
+				Body:       entry.Response.Content.Text,
+			},
+		}
+		if entry.Request.PostData != nil {
+			interaction.Request.Body = entry.Request.PostData.Text
+		}
+		interactions = append(interactions, interaction)
+	}
+
+	return interactions, nil
+}
+
+// toNameValues converts headers or query parameters to name and value pairs,
+// sorted by name so that recordings are stable.
+func toNameValues(headers map[string][]string) []harNameValue {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := []harNameValue{}
+	for _, name := range names {
+		for _, v := range headers[name] {
+			values = append(values, harNameValue{name, v})
+		}
+	}
+	return values
+}
+
+func fromNameValues(values []harNameValue) http.Header {
+	headers := make(http.Header, len(values))
+	for _, v := range values {
+		headers.Add(v.Name, v.Value)
+	}
+	return headers
+}
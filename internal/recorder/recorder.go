@@ -0,0 +1,228 @@
+// Package recorder provides HTTP transports that record the requests the
+// provider makes to the Cloudflare API to a file, and replay them back from
+// one.
+//
+// Recordings are written either as an HTTP Archive (HAR) when the file name
+// ends in `.har`, or as JSON Lines with one interaction per line otherwise.
+// Credentials are redacted before anything is written so that recordings can
+// be shared when reporting issues and committed as test fixtures.
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// ModeRecord forwards requests to the API and records them.
+	ModeRecord = "record"
+	// ModeReplay serves responses from a recording without contacting the
+	// API.
+	ModeReplay = "replay"
+
+	// Redacted replaces credentials in recordings.
+	Redacted = "REDACTED"
+)
+
+// Modes lists the supported recording modes.
+var Modes = []string{ModeRecord, ModeReplay}
+
+// Format is the file format of a recording.
+type Format int
+
+const (
+	FormatJSONL Format = iota
+	FormatHAR
+)
+
+// FormatForPath returns the format used for recordings at path.
+func FormatForPath(path string) Format {
+	if strings.HasSuffix(strings.ToLower(path), ".har") {
+		return FormatHAR
+	}
+	return FormatJSONL
+}
+
+// Interaction is a single recorded request along with its response.
+type Interaction struct {
+	StartedAt time.Time `json:"started_at"`
+	Duration  int64     `json:"duration_ms"`
+	Request   Request   `json:"request"`
+	Response  Response  `json:"response"`
+}
+
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that forwards requests to another
+// transport and records them along with their responses.
+//
+// Interactions are appended to existing recordings so that the plan and apply
+// of a single Terraform run, which use separate provider processes, end up in
+// the same file.
+type Recorder struct {
+	next   http.RoundTripper
+	path   string
+	format Format
+
+	mu      sync.Mutex
+	entries []Interaction
+}
+
+// NewRecorder returns a Recorder writing to path that sends requests using
+// next.
+func NewRecorder(path string, next http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{next: next, path: path, format: FormatForPath(path)}
+
+	if r.format == FormatHAR {
+		entries, err := Load(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		r.entries = entries
+	}
+
+	// Fail early rather than on the first request if the file can't be
+	// written to.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening request recording %q: %w", path, err)
+	}
+
+	return r, f.Close()
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		if requestBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := Interaction{
+		StartedAt: started.UTC(),
+		Duration:  time.Since(started).Milliseconds(),
+		Request: Request{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: redactHeaders(req.Header),
+			Body:    redactBody(req.URL.Path, req.Header.Get("Content-Type"), requestBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    redactHeaders(resp.Header),
+			Body:       redactBody(req.URL.Path, resp.Header.Get("Content-Type"), responseBody),
+		},
+	}
+
+	// A failure to record shouldn't fail the operation being performed.
+	if err := r.record(interaction); err != nil {
+		tflog.Warn(req.Context(), fmt.Sprintf("failed to record request to %s: %s", r.path, err))
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) record(interaction Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.format == FormatJSONL {
+		line, err := json.Marshal(interaction)
+		if err != nil {
+			return err
+		}
+		f, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+
+	// HAR files are a single JSON document so they are rewritten in full
+	// each time, which keeps them valid should the provider be interrupted.
+	r.entries = append(r.entries, interaction)
+	content, err := marshalHAR(r.entries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, content, 0o600)
+}
+
+// Load reads the interactions of the recording at path.
+func Load(path string) ([]Interaction, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, nil
+	}
+
+	if FormatForPath(path) == FormatHAR {
+		interactions, err := unmarshalHAR(content)
+		if err != nil {
+			return nil, fmt.Errorf("error reading request recording %q: %w", path, err)
+		}
+		return interactions, nil
+	}
+
+	var interactions []Interaction
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("error reading request recording %q: line %d: %w", path, line, err)
+		}
+		interactions = append(interactions, interaction)
+	}
+
+	return interactions, scanner.Err()
+}
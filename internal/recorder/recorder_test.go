@@ -0,0 +1,158 @@
+package recorder
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAPI(t *testing.T) *httptest.Server {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "__cfruid=abc")
+		switch r.URL.Path {
+		case "/client/v4/user/tokens":
+			fmt.Fprint(w, `{"success":true,"result":{"id":"1","value":"token-secret"}}`)
+		case "/client/v4/missing":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"success":false}`)
+		default:
+			fmt.Fprintf(w, `{"result":{"call":%d,"name":"www","secret":"s3cr3t"},"success":true}`, calls)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func do(t *testing.T, client *http.Client, method, url, body string) (int, string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer api-token")
+	req.Header.Set("X-Auth-Key", "api-key")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(content)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	for _, name := range []string{"session.jsonl", "session.har"} {
+		t.Run(name, func(t *testing.T) {
+			server := newTestAPI(t)
+			path := filepath.Join(t.TempDir(), name)
+
+			rec, err := NewRecorder(path, http.DefaultTransport)
+			require.NoError(t, err)
+			client := &http.Client{Transport: rec}
+
+			_, first := do(t, client, http.MethodGet, server.URL+"/client/v4/zones/1?page=1", "")
+			_, second := do(t, client, http.MethodGet, server.URL+"/client/v4/zones/1?page=1", "")
+			do(t, client, http.MethodPost, server.URL+"/client/v4/user/tokens", `{"name":"t","password":"hunter2"}`)
+			do(t, client, http.MethodGet, server.URL+"/client/v4/missing", "")
+
+			// The response seen by the client isn't redacted.
+			assert.Contains(t, first, `"secret":"s3cr3t"`)
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			for _, secret := range []string{"api-token", "api-key", "s3cr3t", "token-secret", "hunter2", "__cfruid"} {
+				assert.NotContains(t, string(content), secret)
+			}
+
+			// Recordings are appended to by later provider processes.
+			rec, err = NewRecorder(path, http.DefaultTransport)
+			require.NoError(t, err)
+			do(t, &http.Client{Transport: rec}, http.MethodDelete, server.URL+"/client/v4/zones/1", "")
+
+			interactions, err := Load(path)
+			require.NoError(t, err)
+			require.Len(t, interactions, 5)
+			assert.Equal(t, `{"name":"t","password":"REDACTED"}`, interactions[2].Request.Body)
+			assert.Equal(t, []string{Redacted}, interactions[2].Request.Headers.Values("Authorization"))
+
+			replayer, err := NewReplayer(path)
+			require.NoError(t, err)
+			client = &http.Client{Transport: replayer}
+
+			// The host is ignored so that the API doesn't need to be running.
+			status, body := do(t, client, http.MethodGet, "https://api.cloudflare.com/client/v4/zones/1?page=1", "")
+			assert.Equal(t, http.StatusOK, status)
+			assert.Equal(t, strings.Replace(first, "s3cr3t", Redacted, 1), body)
+
+			_, body = do(t, client, http.MethodGet, "https://api.cloudflare.com/client/v4/zones/1?page=1", "")
+			assert.Equal(t, strings.Replace(second, "s3cr3t", Redacted, 1), body)
+
+			_, body = do(t, client, http.MethodGet, "https://api.cloudflare.com/client/v4/zones/1?page=1", "")
+			assert.Equal(t, strings.Replace(second, "s3cr3t", Redacted, 1), body, "the last interaction is replayed once exhausted")
+
+			status, _ = do(t, client, http.MethodGet, "https://api.cloudflare.com/client/v4/missing", "")
+			assert.Equal(t, http.StatusNotFound, status)
+
+			_, err = client.Get("https://api.cloudflare.com/client/v4/zones/2")
+			assert.ErrorContains(t, err, "no recorded interaction matches GET /client/v4/zones/2")
+		})
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	assert.Equal(t, "not json", redactBody("/zones", "text/plain", []byte("not json")))
+	assert.Equal(t, `{"value":"x"}`, redactBody("/zones/1/dns_records", "application/json", []byte(`{"value":"x"}`)))
+	assert.Equal(t, `{"result":[{"psk":"REDACTED","value":1.0}]}`, redactBody("/accounts/1/magic/ipsec_tunnels", "application/json", []byte(`{"result":[{"psk":"k","value":1.0}]}`)))
+	assert.Equal(t, `{"result":{"id":"1","value":"REDACTED"}}`, redactBody("/accounts/1/tokens", "application/json", []byte(`{"result":{"id":"1","value":"t"}}`)))
+}
+
+func TestRedactBodyWorkerUpload(t *testing.T) {
+	var body strings.Builder
+	w := multipart.NewWriter(&body)
+	metadata, err := w.CreateFormField("metadata")
+	require.NoError(t, err)
+	fmt.Fprint(metadata, `{"bindings":[{"name":"API_KEY","text":"s3cr3t","type":"secret_text"},{"name":"MODE","text":"debug","type":"plain_text"}]}`)
+	script, err := w.CreateFormFile("script", "script")
+	require.NoError(t, err)
+	fmt.Fprint(script, `addEventListener("fetch", () => {})`)
+	require.NoError(t, w.Close())
+
+	redacted := redactBody("/accounts/1/workers/scripts/s", w.FormDataContentType(), []byte(body.String()))
+	assert.NotContains(t, redacted, "s3cr3t")
+
+	// The upload is recorded as a valid form, only without its secrets.
+	form, err := multipart.NewReader(strings.NewReader(redacted), w.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"bindings":[{"name":"API_KEY","text":"REDACTED","type":"secret_text"},{"name":"MODE","text":"debug","type":"plain_text"}]}`}, form.Value["metadata"])
+	require.Len(t, form.File["script"], 1)
+
+	assert.Equal(t, Redacted, redactBody("/accounts/1/workers/scripts/s", "multipart/form-data; boundary=x", []byte("--x\r\nmalformed")))
+}
+
+func TestRedactBodyAPITokenRoll(t *testing.T) {
+	assert.Equal(t, `{"result":"REDACTED","success":true}`, redactBody("/user/tokens/1/value", "application/json", []byte(`{"result":"t0k3n","success":true}`)))
+	assert.Equal(t, `{"result":"REDACTED","success":true}`, redactBody("/accounts/1/tokens/2/value", "application/json", []byte(`{"result":"t0k3n","success":true}`)))
+}
+
+func TestRedactBodyTunnelToken(t *testing.T) {
+	assert.Equal(t, `{"result":"REDACTED","success":true}`, redactBody("/accounts/1/cfd_tunnel/2/token", "application/json", []byte(`{"result":"t0k3n","success":true}`)))
+	assert.Equal(t, `{"result":"REDACTED","success":true}`, redactBody("/accounts/1/tunnels/2/token", "application/json", []byte(`{"result":"t0k3n","success":true}`)))
+	assert.Equal(t, `{"result":{"id":"2","token":"x"}}`, redactBody("/accounts/1/cfd_tunnel/2", "application/json", []byte(`{"result":{"id":"2","token":"x"}}`)))
+}
+
+func TestRedactBodyPagesSecrets(t *testing.T) {
+	body := `{"deployment_configs":{"production":{"env_vars":{"API_KEY":{"type":"secret_text","value":"s3cr3t"},"MODE":{"type":"plain_text","value":"debug"}}}}}`
+	assert.Equal(t,
+		`{"deployment_configs":{"production":{"env_vars":{"API_KEY":{"type":"secret_text","value":"REDACTED"},"MODE":{"type":"plain_text","value":"debug"}}}}}`,
+		redactBody("/accounts/1/pages/projects/p", "application/json", []byte(body)))
+}
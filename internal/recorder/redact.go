@@ -0,0 +1,178 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// sensitiveHeaders are the headers carrying credentials, in canonical form.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":           true,
+	"Cookie":                  true,
+	"Set-Cookie":              true,
+	"X-Auth-Email":            true,
+	"X-Auth-Key":              true,
+	"X-Auth-User-Service-Key": true,
+	"Cf-Access-Client-Secret": true,
+}
+
+// sensitiveFields are the keys of JSON request and response bodies holding
+// secrets, such as Access service token secrets or IPsec pre-shared keys.
+var sensitiveFields = map[string]bool{
+	"client_secret": true,
+	"password":      true,
+	"private_key":   true,
+	"psk":           true,
+	"secret":        true,
+	"tunnel_secret": true,
+}
+
+// tokenPaths matches the API token endpoints, which return the token itself
+// in the `value` field.
+var tokenPaths = regexp.MustCompile(`/(user|accounts/[^/]+)/tokens(/|$)`)
+
+// secretResultPaths matches the endpoints whose whole result is a secret:
+// rolled API token values and tunnel tokens.
+var secretResultPaths = regexp.MustCompile(`/((user|accounts/[^/]+)/tokens/[^/]+/value|accounts/[^/]+/(cfd_tunnel|tunnels)/[^/]+/token)$`)
+
+// secretTextFields are the fields holding the secret of objects whose type
+// is `secret_text`, such as Worker bindings and Pages environment variables.
+var secretTextFields = map[string]bool{
+	"text":  true,
+	"value": true,
+}
+
+func redactHeaders(headers http.Header) http.Header {
+	redacted := make(http.Header, len(headers))
+	for name, values := range headers {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			values = []string{Redacted}
+		}
+		redacted[name] = values
+	}
+	return redacted
+}
+
+// redactBody replaces the values of sensitive fields of JSON bodies, and of
+// the JSON parts of multipart bodies such as Worker script uploads. Other
+// bodies are recorded as they are.
+func redactBody(path, contentType string, body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	if mediaType, params, err := mime.ParseMediaType(contentType); err == nil && strings.HasPrefix(mediaType, "multipart/") {
+		redacted, err := redactMultipart(path, params["boundary"], body)
+		if err != nil {
+			// Parts that can't be read may hold secrets all the same.
+			return Redacted
+		}
+		return redacted
+	}
+
+	return redactJSON(path, body)
+}
+
+// redactJSON replaces the values of sensitive fields of body if it's JSON,
+// and returns it unchanged otherwise.
+func redactJSON(path string, body []byte) string {
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return string(body)
+	}
+
+	fields := sensitiveFields
+	if tokenPaths.MatchString(path) {
+		fields = map[string]bool{"value": true}
+		for k := range sensitiveFields {
+			fields[k] = true
+		}
+	}
+
+	changed := redactValue(decoded, fields)
+	if envelope, ok := decoded.(map[string]interface{}); ok && secretResultPaths.MatchString(path) {
+		if _, ok := envelope["result"]; ok {
+			envelope["result"] = Redacted
+			changed = true
+		}
+	}
+	if !changed {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(decoded)
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+// redactMultipart redacts the JSON parts of a multipart body, keeping its
+// boundary and the headers of its parts.
+func redactMultipart(path, boundary string, body []byte) (string, error) {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+
+	var redacted bytes.Buffer
+	writer := multipart.NewWriter(&redacted)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return "", err
+	}
+
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return "", err
+		}
+
+		w, err := writer.CreatePart(part.Header)
+		if err != nil {
+			return "", err
+		}
+		if _, err := io.WriteString(w, redactJSON(path, content)); err != nil {
+			return "", err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return redacted.String(), nil
+}
+
+// redactValue redacts sensitive fields of v in place and reports whether
+// anything was changed.
+func redactValue(v interface{}, fields map[string]bool) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		secretText := v["type"] == "secret_text"
+		for key, value := range v {
+			if _, isString := value.(string); isString && (fields[strings.ToLower(key)] || secretText && secretTextFields[key]) {
+				v[key] = Redacted
+				changed = true
+				continue
+			}
+			changed = redactValue(value, fields) || changed
+		}
+	case []interface{}:
+		for _, value := range v {
+			changed = redactValue(value, fields) || changed
+		}
+	}
+	return changed
+}
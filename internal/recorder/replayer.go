@@ -0,0 +1,97 @@
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Replayer is an http.RoundTripper serving responses from a recording
+// instead of sending requests to the API.
+//
+// Requests are matched to recorded interactions by method, path and query,
+// ignoring the host so that recordings made against a different API hostname
+// can be replayed. Interactions are replayed in the order they were recorded
+// so that reading an object before and after it is changed returns the
+// respective responses. Once every matching interaction has been replayed
+// the last one is served again, as Terraform commonly refreshes objects more
+// times than it did during the recording.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+	last         map[string]int
+}
+
+// NewReplayer returns a Replayer serving the interactions recorded at path.
+func NewReplayer(path string) (*Replayer, error) {
+	interactions, err := Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("error loading request recording %q: %w", path, err)
+	}
+
+	return &Replayer{
+		interactions: interactions,
+		replayed:     make([]bool, len(interactions)),
+		last:         make(map[string]int),
+	}, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := matchKey(req.Method, req.URL)
+
+	r.mu.Lock()
+	index, ok := -1, false
+	for i, interaction := range r.interactions {
+		if r.replayed[i] {
+			continue
+		}
+		u, err := url.Parse(interaction.Request.URL)
+		if err != nil || matchKey(interaction.Request.Method, u) != key {
+			continue
+		}
+		index, ok = i, true
+		break
+	}
+	if ok {
+		r.replayed[index] = true
+		r.last[key] = index
+	} else {
+		index, ok = r.last[key]
+	}
+	r.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no recorded interaction matches %s", key)
+	}
+
+	recorded := r.interactions[index].Response
+	headers := recorded.Headers.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+func matchKey(method string, u *url.URL) string {
+	return strings.ToUpper(method) + " " + u.RequestURI()
+}
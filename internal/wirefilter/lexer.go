@@ -0,0 +1,190 @@
+package wirefilter
+
+import (
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenList
+	tokenPunct
+)
+
+// token is a lexical token. offset is the byte offset of the token in the
+// expression, text is the raw token and value the decoded value of strings.
+type token struct {
+	kind   tokenKind
+	offset int
+	text   string
+	value  string
+}
+
+// punctuation lists the operators and delimiters, longest first so that
+// `==` isn't lexed as two `=`.
+var punctuation = []string{"==", "!=", "<=", ">=", "&&", "||", "^^", "<", ">", "~", "!", "(", ")", "{", "}", "[", "]", ",", "*", "&"}
+
+type lexer struct {
+	input  string
+	pos    int
+	tokens []token
+}
+
+func lex(input string) ([]token, error) {
+	l := &lexer{input: input}
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		l.tokens = append(l.tokens, tok)
+		if tok.kind == tokenEOF {
+			return l.tokens, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && strings.IndexByte(" \t\r\n", l.input[l.pos]) != -1 {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, offset: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.input[l.pos]
+
+	switch {
+	case c == '"':
+		return l.string(start, 1, `"`)
+	case c == 'r' && l.pos+1 < len(l.input) && (l.input[l.pos+1] == '"' || l.input[l.pos+1] == '#'):
+		hashes := 0
+		for l.pos+1+hashes < len(l.input) && l.input[l.pos+1+hashes] == '#' {
+			hashes++
+		}
+		if l.pos+1+hashes >= len(l.input) || l.input[l.pos+1+hashes] != '"' {
+			break
+		}
+		return l.rawString(start, hashes)
+	case c == '$':
+		l.pos++
+		for l.pos < len(l.input) && isIdentByte(l.input[l.pos]) {
+			l.pos++
+		}
+		if l.pos == start+1 {
+			return token{}, errorf(start, "expected a list name after $")
+		}
+		return token{kind: tokenList, offset: start, text: l.input[start:l.pos], value: l.input[start+1 : l.pos]}, nil
+	case isDigit(c) || c == ':' || c == '-' && l.pos+1 < len(l.input) && isDigit(l.input[l.pos+1]):
+		return l.number(start), nil
+	case isIdentStart(c):
+		for l.pos < len(l.input) && isIdentByte(l.input[l.pos]) {
+			l.pos++
+		}
+		// IPv6 addresses may start with letters, such as fe80::1.
+		if l.pos < len(l.input) && l.input[l.pos] == ':' && isHex(l.input[start:l.pos]) {
+			l.pos = start
+			return l.number(start), nil
+		}
+		return token{kind: tokenIdent, offset: start, text: l.input[start:l.pos]}, nil
+	}
+
+	for _, p := range punctuation {
+		if strings.HasPrefix(l.input[l.pos:], p) {
+			l.pos += len(p)
+			return token{kind: tokenPunct, offset: start, text: p}, nil
+		}
+	}
+
+	return token{}, errorf(start, "unexpected character %q", c)
+}
+
+func (l *lexer) string(start, skip int, terminator string) (token, error) {
+	l.pos += skip
+	var b strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case strings.HasPrefix(l.input[l.pos:], terminator):
+			l.pos += len(terminator)
+			return token{kind: tokenString, offset: start, text: l.input[start:l.pos], value: b.String()}, nil
+		case c == '\\':
+			if l.pos+1 >= len(l.input) {
+				return token{}, errorf(l.pos, "unterminated string")
+			}
+			switch e := l.input[l.pos+1]; e {
+			case '"', '\\':
+				b.WriteByte(e)
+				l.pos += 2
+			case 'x':
+				if l.pos+3 >= len(l.input) || !isHex(l.input[l.pos+2:l.pos+4]) {
+					return token{}, errorf(l.pos, "invalid hex escape")
+				}
+				b.WriteString(l.input[l.pos : l.pos+4])
+				l.pos += 4
+			default:
+				return token{}, errorf(l.pos, "invalid escape sequence \\%c", e)
+			}
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return token{}, errorf(start, "unterminated string")
+}
+
+func (l *lexer) rawString(start, hashes int) (token, error) {
+	l.pos += 2 + hashes
+	terminator := `"` + strings.Repeat("#", hashes)
+	end := strings.Index(l.input[l.pos:], terminator)
+	if end == -1 {
+		return token{}, errorf(start, "unterminated raw string")
+	}
+	value := l.input[l.pos : l.pos+end]
+	l.pos += end + len(terminator)
+	return token{kind: tokenString, offset: start, text: l.input[start:l.pos], value: value}, nil
+}
+
+// number lexes integers, IP addresses, CIDRs and ranges of either. They
+// are told apart by the parser.
+func (l *lexer) number(start int) token {
+	if l.input[l.pos] == '-' {
+		l.pos++
+	}
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		if isHex(string(c)) || c == '.' || c == ':' || c == '/' || c == 'x' || c == 'X' {
+			l.pos++
+			continue
+		}
+		break
+	}
+	return token{kind: tokenNumber, offset: start, text: l.input[start:l.pos]}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentByte(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.'
+}
+
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !isDigit(c) && !(c >= 'a' && c <= 'f') && !(c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,651 @@
+// Package wirefilter implements a validator for expressions written in the
+// Cloudflare Rules language, the wirefilter syntax used by firewall rules,
+// filters and rulesets.
+//
+// Expressions are parsed and type checked against the known fields and
+// functions without evaluating them, so that mistakes are reported at plan
+// time instead of being rejected by the API during apply.
+//
+// Reference: https://developers.cloudflare.com/ruleset-engine/rules-language/
+package wirefilter
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error is returned for invalid expressions. Offset is the position of the
+// offending character in the expression, counted in characters from 0.
+type Error struct {
+	Offset  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (at character %d)", e.Message, e.Offset)
+}
+
+func errorf(offset int, format string, args ...interface{}) *Error {
+	return &Error{Offset: offset, Message: fmt.Sprintf(format, args...)}
+}
+
+// Validate parses and type checks expr, which must evaluate to a boolean.
+// Fields that aren't available in scope are rejected. Fields that aren't
+// known at all are only returned as warnings, as fields are added to the
+// Rules language over time, and their values aren't type checked.
+func Validate(expr string, scope Scope) (warnings []*Error, err error) {
+	return validate(expr, scope, Bool)
+}
+
+// ValidateValue parses and type checks expr, which must evaluate to a string,
+// such as the username and password expressions of exposed credential
+// checks. Unknown fields are returned as warnings, as with Validate.
+func ValidateValue(expr string, scope Scope) (warnings []*Error, err error) {
+	return validate(expr, scope, Bytes)
+}
+
+func validate(expr string, scope Scope, want Type) ([]*Error, error) {
+	// Offsets are tracked in bytes while parsing but reported in characters
+	// so that they line up with what users see in their configuration.
+	warnings, err := check(expr, scope, want)
+	for _, warning := range warnings {
+		warning.Offset = utf8.RuneCountInString(expr[:warning.Offset])
+	}
+	if err == nil {
+		return warnings, nil
+	}
+	err.Offset = utf8.RuneCountInString(expr[:err.Offset])
+	return warnings, err
+}
+
+func check(expr string, scope Scope, want Type) ([]*Error, *Error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, errorf(0, "expression is empty")
+	}
+
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err.(*Error)
+	}
+
+	p := &parser{tokens: tokens, scope: scope}
+	result, perr := p.parseOr()
+	if perr != nil {
+		return p.warnings, perr
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return p.warnings, errorf(tok.offset, "unexpected %s", describe(tok))
+	}
+	if result.v.mapped {
+		return p.warnings, errorf(result.offset, "expression evaluates to %s, wrap it in any() or all()", result.v)
+	}
+	if !result.v.typ.Equal(want) && result.v.typ.Kind != KindUnknown {
+		return p.warnings, errorf(result.offset, "expression must evaluate to a %s, got %s", want, result.v)
+	}
+
+	return p.warnings, nil
+}
+
+// operand is a type checked (sub)expression.
+type operand struct {
+	v       value
+	literal bool
+	// value holds the decoded value of string literals and the text of
+	// other literals.
+	value  string
+	offset int
+}
+
+type parser struct {
+	tokens   []token
+	pos      int
+	scope    Scope
+	warnings []*Error
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of the given keywords or
+// punctuation.
+func (p *parser) accept(texts ...string) (token, bool) {
+	tok := p.peek()
+	if tok.kind != tokenIdent && tok.kind != tokenPunct {
+		return tok, false
+	}
+	for _, text := range texts {
+		if tok.text == text {
+			return p.advance(), true
+		}
+	}
+	return tok, false
+}
+
+func (p *parser) expect(text string) (token, *Error) {
+	if tok, ok := p.accept(text); ok {
+		return tok, nil
+	}
+	tok := p.peek()
+	return tok, errorf(tok.offset, "expected %q, got %s", text, describe(tok))
+}
+
+func describe(tok token) string {
+	if tok.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", tok.text)
+}
+
+// Logical operators from the lowest to the highest precedence.
+func (p *parser) parseOr() (operand, *Error) {
+	return p.parseLogical(p.parseXor, "or", "||")
+}
+
+func (p *parser) parseXor() (operand, *Error) {
+	return p.parseLogical(p.parseAnd, "xor", "^^")
+}
+
+func (p *parser) parseAnd() (operand, *Error) {
+	return p.parseLogical(p.parseNot, "and", "&&")
+}
+
+func (p *parser) parseLogical(next func() (operand, *Error), operators ...string) (operand, *Error) {
+	left, err := next()
+	if err != nil {
+		return left, err
+	}
+	for {
+		op, ok := p.accept(operators...)
+		if !ok {
+			return left, nil
+		}
+		right, err := next()
+		if err != nil {
+			return right, err
+		}
+		for _, side := range []operand{left, right} {
+			if !containsKind([]Kind{KindBool}, side.v.typ.Kind) {
+				return side, errorf(side.offset, "operands of %q must be Boolean, got %s", op.text, side.v)
+			}
+		}
+		left = operand{v: value{typ: Bool, mapped: left.v.mapped || right.v.mapped}, offset: left.offset}
+	}
+}
+
+func (p *parser) parseNot() (operand, *Error) {
+	op, ok := p.accept("not", "!")
+	if !ok {
+		return p.parseComparison()
+	}
+	inner, err := p.parseNot()
+	if err != nil {
+		return inner, err
+	}
+	if !containsKind([]Kind{KindBool}, inner.v.typ.Kind) {
+		return inner, errorf(inner.offset, "operand of %q must be Boolean, got %s", op.text, inner.v)
+	}
+	inner.offset = op.offset
+	inner.literal = false
+	return inner, nil
+}
+
+// comparisons maps each comparison operator to the kinds of values it
+// applies to.
+var comparisons = map[string][]Kind{
+	"eq":          {KindBytes, KindInt, KindIP},
+	"==":          {KindBytes, KindInt, KindIP},
+	"ne":          {KindBytes, KindInt, KindIP},
+	"!=":          {KindBytes, KindInt, KindIP},
+	"lt":          {KindBytes, KindInt},
+	"<":           {KindBytes, KindInt},
+	"le":          {KindBytes, KindInt},
+	"<=":          {KindBytes, KindInt},
+	"gt":          {KindBytes, KindInt},
+	">":           {KindBytes, KindInt},
+	"ge":          {KindBytes, KindInt},
+	">=":          {KindBytes, KindInt},
+	"contains":    {KindBytes},
+	"matches":     {KindBytes},
+	"~":           {KindBytes},
+	"wildcard":    {KindBytes},
+	"in":          {KindBytes, KindInt, KindIP},
+	"bitwise_and": {KindInt},
+	"&":           {KindInt},
+}
+
+func (p *parser) parseComparison() (operand, *Error) {
+	left, err := p.parseValue()
+	if err != nil {
+		return left, err
+	}
+
+	op := p.peek()
+	if op.kind != tokenIdent && op.kind != tokenPunct {
+		return left, nil
+	}
+	name := op.text
+	allowed, ok := comparisons[name]
+	if op.text == "strict" {
+		p.advance()
+		if _, err := p.expect("wildcard"); err != nil {
+			return left, err
+		}
+		name, allowed, ok = "strict wildcard", comparisons["wildcard"], true
+	} else if ok {
+		p.advance()
+	} else {
+		return left, nil
+	}
+
+	if left.literal {
+		return left, errorf(left.offset, "the left-hand side of %q must be a field or function, not a literal", name)
+	}
+	if !containsKind(allowed, left.v.typ.Kind) {
+		if left.v.typ.Kind == KindArray || left.v.typ.Kind == KindMap {
+			return left, errorf(op.offset, "%q can't be applied to %s, use [*] or an index to access its elements", name, left.v.typ)
+		}
+		return left, errorf(op.offset, "%q can't be applied to %s", name, left.v.typ)
+	}
+
+	if name == "in" {
+		err = p.parseSet(left.v.typ)
+	} else {
+		err = p.parseLiteral(left.v.typ, name)
+	}
+	if err != nil {
+		return left, err
+	}
+
+	return operand{v: value{typ: Bool, mapped: left.v.mapped}, offset: left.offset}, nil
+}
+
+// parseLiteral parses the right-hand side of a comparison against a value of
+// type typ.
+func (p *parser) parseLiteral(typ Type, op string) *Error {
+	tok := p.advance()
+	switch typ.Kind {
+	case KindBytes:
+		if tok.kind != tokenString {
+			return errorf(tok.offset, "expected a string after %q, got %s", op, describe(tok))
+		}
+		if op == "matches" || op == "~" {
+			if _, err := regexp.Compile(tok.value); err != nil {
+				return errorf(tok.offset, "invalid regular expression: %s", strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+			}
+		}
+	case KindInt:
+		if _, ok := parseInt(tok); !ok {
+			return errorf(tok.offset, "expected an integer after %q, got %s", op, describe(tok))
+		}
+	case KindIP:
+		if !isIPOrCIDR(tok) {
+			return errorf(tok.offset, "expected an IP address after %q, got %s", op, describe(tok))
+		}
+	}
+	return nil
+}
+
+// parseSet parses the right-hand side of the `in` operator, either an inline
+// set of values or a reference to a list.
+func (p *parser) parseSet(typ Type) *Error {
+	tok := p.advance()
+	if tok.kind == tokenList {
+		if typ.Kind == KindInt {
+			return errorf(tok.offset, "lists can't be used with Integer values")
+		}
+		return nil
+	}
+	if tok.kind != tokenPunct || tok.text != "{" {
+		return errorf(tok.offset, "expected \"{\" or a list reference after \"in\", got %s", describe(tok))
+	}
+
+	count := 0
+	for {
+		tok := p.peek()
+		if tok.kind == tokenPunct && tok.text == "}" {
+			p.advance()
+			break
+		}
+		if tok.kind == tokenEOF {
+			return errorf(tok.offset, "unterminated set, expected \"}\"")
+		}
+		p.advance()
+		count++
+
+		switch typ.Kind {
+		case KindBytes:
+			if tok.kind != tokenString {
+				return errorf(tok.offset, "expected a string in set, got %s", describe(tok))
+			}
+		case KindInt:
+			if !isRange(tok, func(t token) bool { _, ok := parseInt(t); return ok }) {
+				return errorf(tok.offset, "expected an integer or range in set, got %s", describe(tok))
+			}
+		case KindIP:
+			if !isRange(tok, isIPOrCIDR) {
+				return errorf(tok.offset, "expected an IP address, CIDR or range in set, got %s", describe(tok))
+			}
+		}
+	}
+	if count == 0 {
+		return errorf(tok.offset, "set must not be empty")
+	}
+	return nil
+}
+
+// parseValue parses a field, function call or literal along with any
+// indexing applied to it.
+func (p *parser) parseValue() (operand, *Error) {
+	tok := p.advance()
+
+	var result operand
+	switch tok.kind {
+	case tokenString:
+		result = operand{v: value{typ: Bytes}, literal: true, value: tok.value, offset: tok.offset}
+	case tokenNumber:
+		if _, ok := parseInt(tok); !ok {
+			return result, errorf(tok.offset, "unexpected %s", describe(tok))
+		}
+		result = operand{v: value{typ: Int}, literal: true, value: tok.text, offset: tok.offset}
+	case tokenPunct:
+		if tok.text != "(" {
+			return result, errorf(tok.offset, "unexpected %s", describe(tok))
+		}
+		inner, err := p.parseOr()
+		if err != nil {
+			return inner, err
+		}
+		if _, err := p.expect(")"); err != nil {
+			return inner, err
+		}
+		inner.offset = tok.offset
+		inner.literal = false
+		return inner, nil
+	case tokenIdent:
+		if tok.text == "true" || tok.text == "false" {
+			return operand{v: value{typ: Bool}, literal: true, value: tok.text, offset: tok.offset}, nil
+		}
+		if next := p.peek(); next.kind == tokenPunct && next.text == "(" {
+			p.advance()
+			var err *Error
+			if result, err = p.parseCall(tok); err != nil {
+				return result, err
+			}
+		} else {
+			f, ok := fields[tok.text]
+			switch {
+			case !ok:
+				p.warnings = append(p.warnings, errorf(tok.offset, "unknown field %q%s", tok.text, suggest(tok.text, fieldNames)))
+				f = field{typ: unknown, scope: ScopeAny}
+			case f.scope&p.scope == 0:
+				return result, errorf(tok.offset, "field %q is only available in %s expressions", tok.text, f.scope)
+			}
+			result = operand{v: value{typ: f.typ}, offset: tok.offset}
+		}
+	default:
+		if tok.kind == tokenList {
+			return result, errorf(tok.offset, "list %s can only be used with the \"in\" operator", tok.text)
+		}
+		return result, errorf(tok.offset, "unexpected %s", describe(tok))
+	}
+
+	return p.parseIndexes(result)
+}
+
+func (p *parser) parseIndexes(result operand) (operand, *Error) {
+	for {
+		open, ok := p.accept("[")
+		if !ok {
+			return result, nil
+		}
+
+		typ := result.v.typ
+		if typ.Kind == KindUnknown {
+			// Values of unknown fields may be indexed in any way.
+			if index := p.advance(); index.kind == tokenPunct && index.text == "*" {
+				result.v.mapped = true
+			}
+			if _, err := p.expect("]"); err != nil {
+				return result, err
+			}
+			continue
+		}
+		if typ.Kind != KindArray && typ.Kind != KindMap {
+			return result, errorf(open.offset, "%s can't be indexed", typ)
+		}
+
+		index := p.advance()
+		switch {
+		case index.kind == tokenPunct && index.text == "*":
+			result.v.mapped = true
+		case typ.Kind == KindMap && index.kind != tokenString:
+			return result, errorf(index.offset, "%s must be indexed with a string, got %s", typ, describe(index))
+		case typ.Kind == KindArray:
+			if n, ok := parseInt(index); !ok || n < 0 {
+				return result, errorf(index.offset, "%s must be indexed with a non-negative integer, got %s", typ, describe(index))
+			}
+		}
+
+		if _, err := p.expect("]"); err != nil {
+			return result, err
+		}
+		result.v.typ = *typ.Elem
+		result.literal = false
+	}
+}
+
+func (p *parser) parseCall(name token) (operand, *Error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return operand{}, errorf(name.offset, "unknown function %q%s", name.text, suggest(name.text, functionNames))
+	}
+
+	var args []operand
+	if _, ok := p.accept(")"); !ok {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return arg, err
+			}
+			args = append(args, arg)
+			if _, ok := p.accept(","); ok {
+				continue
+			}
+			if _, err := p.expect(")"); err != nil {
+				return arg, err
+			}
+			break
+		}
+	}
+
+	min := len(fn.params) - fn.optional
+	switch {
+	case len(args) < min:
+		return operand{}, errorf(name.offset, "%s() expects at least %d arguments, got %d", name.text, min, len(args))
+	case len(args) > len(fn.params) && !fn.variadic:
+		return operand{}, errorf(name.offset, "%s() expects at most %d arguments, got %d", name.text, len(fn.params), len(args))
+	}
+
+	// any() and all() reduce the result of a comparison applied to each
+	// element of an array with [*].
+	if name.text == "any" || name.text == "all" {
+		arg := args[0]
+		isBoolArray := arg.v.typ.Kind == KindArray && arg.v.typ.Elem.Kind == KindBool
+		if !(arg.v.mapped && arg.v.typ.Kind == KindBool) && !(!arg.v.mapped && isBoolArray) && arg.v.typ.Kind != KindUnknown {
+			return arg, errorf(arg.offset, "%s() expects an array of Boolean values, such as a comparison using [*], got %s", name.text, arg.v)
+		}
+		return operand{v: value{typ: Bool}, offset: name.offset}, nil
+	}
+
+	mapped := false
+	values := make([]value, len(args))
+	for i, arg := range args {
+		param := fn.params[len(fn.params)-1]
+		if i < len(fn.params) {
+			param = fn.params[i]
+		}
+		if param.literal && !arg.literal {
+			return arg, errorf(arg.offset, "argument %d of %s() must be a literal", i+1, name.text)
+		}
+		if len(param.accepts) > 0 && !containsKind(param.accepts, arg.v.typ.Kind) {
+			return arg, errorf(arg.offset, "argument %d of %s() must be %s, got %s", i+1, name.text, describeKinds(param.accepts), arg.v)
+		}
+		mapped = mapped || arg.v.mapped
+		values[i] = arg.v
+	}
+
+	if name.text == "regex_replace" {
+		if _, err := regexp.Compile(args[1].value); err != nil {
+			return args[1], errorf(args[1].offset, "invalid regular expression: %s", strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+		}
+	}
+
+	return operand{v: value{typ: fn.returns(values), mapped: mapped}, offset: name.offset}, nil
+}
+
+// containsKind reports whether kind is one of kinds. Values of unknown kind
+// are accepted as any kind.
+func containsKind(kinds []Kind, kind Kind) bool {
+	if kind == KindUnknown {
+		return true
+	}
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func describeKinds(kinds []Kind) string {
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		switch k {
+		case KindArray:
+			names[i] = "an Array"
+		case KindMap:
+			names[i] = "a Map"
+		case KindIP:
+			names[i] = "an IP address"
+		case KindInt:
+			names[i] = "an Integer"
+		default:
+			names[i] = "a " + Type{Kind: k}.String()
+		}
+	}
+	return strings.Join(names, " or ")
+}
+
+func parseInt(tok token) (int64, bool) {
+	if tok.kind != tokenNumber {
+		return 0, false
+	}
+	text := tok.text
+	base := 10
+	if strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X") {
+		text, base = text[2:], 16
+	}
+	n, err := strconv.ParseInt(text, base, 64)
+	return n, err == nil
+}
+
+func isIPOrCIDR(tok token) bool {
+	if tok.kind != tokenNumber {
+		return false
+	}
+	if strings.Contains(tok.text, "/") {
+		_, _, err := net.ParseCIDR(tok.text)
+		return err == nil
+	}
+	return net.ParseIP(tok.text) != nil
+}
+
+// isRange reports whether tok is a single value or a `start..end` range of
+// values accepted by valid.
+func isRange(tok token, valid func(token) bool) bool {
+	parts := strings.Split(tok.text, "..")
+	if len(parts) > 2 {
+		return false
+	}
+	for _, part := range parts {
+		if !valid(token{kind: tok.kind, text: part}) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	fieldNames    []string
+	functionNames []string
+)
+
+func init() {
+	for name := range fields {
+		fieldNames = append(fieldNames, name)
+	}
+	for name := range functions {
+		functionNames = append(functionNames, name)
+	}
+	sort.Strings(fieldNames)
+	sort.Strings(functionNames)
+}
+
+// suggest returns a hint naming the closest known name to name, if any is
+// close enough to likely be what was meant.
+func suggest(name string, known []string) string {
+	best, bestDistance := "", len(name)/3+1
+	for _, candidate := range known {
+		if d := levenshtein(name, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %q?", best)
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
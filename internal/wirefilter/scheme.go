@@ -0,0 +1,307 @@
+package wirefilter
+
+import "strings"
+
+// Scope is a set of places an expression can be evaluated in. Each field is
+// only populated in some of them, for example response fields are only known
+// once the origin has responded.
+type Scope uint
+
+const (
+	// ScopeRequest covers expressions evaluated against HTTP requests.
+	ScopeRequest Scope = 1 << iota
+	// ScopeResponse covers expressions evaluated against HTTP responses.
+	ScopeResponse
+	// ScopeNetwork covers expressions evaluated against IP packets, such as
+	// Magic Firewall and network-layer DDoS rules.
+	ScopeNetwork
+
+	// ScopeAny allows every field and is used when the place an expression
+	// is evaluated in isn't known.
+	ScopeAny = ScopeRequest | ScopeResponse | ScopeNetwork
+)
+
+func (s Scope) String() string {
+	var names []string
+	if s&ScopeRequest != 0 {
+		names = append(names, "HTTP request")
+	}
+	if s&ScopeResponse != 0 {
+		names = append(names, "HTTP response")
+	}
+	if s&ScopeNetwork != 0 {
+		names = append(names, "network")
+	}
+	return strings.Join(names, " and ")
+}
+
+// ScopeForPhase returns the scope that expressions of rules in the given
+// ruleset phase are evaluated in. Unknown phases allow every field so that
+// new phases aren't rejected before they are added here.
+func ScopeForPhase(phase string) Scope {
+	switch {
+	case phase == "ddos_l4" || strings.HasPrefix(phase, "magic_transit"):
+		return ScopeNetwork
+	case strings.HasPrefix(phase, "http_response_"),
+		phase == "http_log_custom_fields",
+		phase == "http_custom_errors":
+		return ScopeRequest | ScopeResponse
+	case strings.HasPrefix(phase, "http_"), phase == "ddos_l7":
+		return ScopeRequest
+	default:
+		return ScopeAny
+	}
+}
+
+type field struct {
+	typ   Type
+	scope Scope
+}
+
+// Shorthands for the scopes of the field table below. Request fields remain
+// available once a response has been received.
+const (
+	scopeHTTP    = ScopeRequest | ScopeResponse
+	scopeNetwork = ScopeNetwork
+	scopeAll     = ScopeAny
+)
+
+// fields lists the fields of the Rules language along with the scopes they
+// are available in.
+//
+// Reference: https://developers.cloudflare.com/ruleset-engine/rules-language/fields/
+var fields = map[string]field{
+	// Standard fields.
+	"cf.bot_management.corporate_proxy":                    {Bool, scopeHTTP},
+	"cf.bot_management.detection_ids":                      {ArrayOf(Int), scopeHTTP},
+	"cf.bot_management.ja3_hash":                           {Bytes, scopeHTTP},
+	"cf.bot_management.js_detection.passed":                {Bool, scopeHTTP},
+	"cf.bot_management.score":                              {Int, scopeHTTP},
+	"cf.bot_management.static_resource":                    {Bool, scopeHTTP},
+	"cf.bot_management.verified_bot":                       {Bool, scopeHTTP},
+	"cf.client.bot":                                        {Bool, scopeHTTP},
+	"cf.colo.name":                                         {Bytes, scopeAll},
+	"cf.colo.region":                                       {Bytes, scopeAll},
+	"cf.edge.server_ip":                                    {IP, scopeHTTP},
+	"cf.edge.server_port":                                  {Int, scopeHTTP},
+	"cf.hostname.metadata":                                 {Bytes, scopeHTTP},
+	"cf.metal.id":                                          {Bytes, scopeHTTP},
+	"cf.random_seed":                                       {Bytes, scopeHTTP},
+	"cf.ray_id":                                            {Bytes, scopeHTTP},
+	"cf.threat_score":                                      {Int, scopeHTTP},
+	"cf.tls_cipher":                                        {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_fingerprint_sha1":             {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_fingerprint_sha256":           {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_issuer_dn":                    {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_issuer_dn_legacy":             {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_issuer_dn_rfc2253":            {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_issuer_serial":                {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_issuer_ski":                   {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_not_after":                    {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_not_before":                   {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_presented":                    {Bool, scopeHTTP},
+	"cf.tls_client_auth.cert_revoked":                      {Bool, scopeHTTP},
+	"cf.tls_client_auth.cert_serial":                       {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_ski":                          {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_subject_dn":                   {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_subject_dn_legacy":            {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_subject_dn_rfc2253":           {Bytes, scopeHTTP},
+	"cf.tls_client_auth.cert_verified":                     {Bool, scopeHTTP},
+	"cf.tls_version":                                       {Bytes, scopeHTTP},
+	"cf.verified_bot_category":                             {Bytes, scopeHTTP},
+	"cf.waf.auth_detected":                                 {Bool, scopeHTTP},
+	"cf.waf.content_scan.has_failed":                       {Bool, scopeHTTP},
+	"cf.waf.content_scan.has_malicious_obj":                {Bool, scopeHTTP},
+	"cf.waf.content_scan.has_obj":                          {Bool, scopeHTTP},
+	"cf.waf.content_scan.num_malicious_obj":                {Int, scopeHTTP},
+	"cf.waf.content_scan.num_obj":                          {Int, scopeHTTP},
+	"cf.waf.content_scan.obj_results":                      {ArrayOf(Bytes), scopeHTTP},
+	"cf.waf.content_scan.obj_sizes":                        {ArrayOf(Int), scopeHTTP},
+	"cf.waf.content_scan.obj_types":                        {ArrayOf(Bytes), scopeHTTP},
+	"cf.waf.credential_check.password_leaked":              {Bool, scopeHTTP},
+	"cf.waf.credential_check.username_and_password_leaked": {Bool, scopeHTTP},
+	"cf.waf.credential_check.username_leaked":              {Bool, scopeHTTP},
+	"cf.waf.credential_check.username_password_similar":    {Bool, scopeHTTP},
+	"cf.waf.score":                                         {Int, scopeHTTP},
+	"cf.waf.score.class":                                   {Bytes, scopeHTTP},
+	"cf.waf.score.rce":                                     {Int, scopeHTTP},
+	"cf.waf.score.sqli":                                    {Int, scopeHTTP},
+	"cf.waf.score.xss":                                     {Int, scopeHTTP},
+	"cf.worker.upstream_zone":                              {Bytes, scopeHTTP},
+	"cf.zone.name":                                         {Bytes, scopeHTTP},
+	"cf.zone.plan":                                         {Bytes, scopeHTTP},
+	"http.cookie":                                          {Bytes, scopeHTTP},
+	"http.host":                                            {Bytes, scopeHTTP},
+	"http.referer":                                         {Bytes, scopeHTTP},
+	"http.request.full_uri":                                {Bytes, scopeHTTP},
+	"http.request.method":                                  {Bytes, scopeHTTP},
+	"http.request.timestamp.msec":                          {Int, scopeHTTP},
+	"http.request.timestamp.sec":                           {Int, scopeHTTP},
+	"http.request.uri":                                     {Bytes, scopeHTTP},
+	"http.request.uri.path":                                {Bytes, scopeHTTP},
+	"http.request.uri.path.extension":                      {Bytes, scopeHTTP},
+	"http.request.uri.query":                               {Bytes, scopeHTTP},
+	"http.request.version":                                 {Bytes, scopeHTTP},
+	"http.user_agent":                                      {Bytes, scopeHTTP},
+	"http.x_forwarded_for":                                 {Bytes, scopeHTTP},
+	"ip.src":                                               {IP, scopeAll},
+	"ip.src.asnum":                                         {Int, scopeAll},
+	"ip.src.city":                                          {Bytes, scopeHTTP},
+	"ip.src.continent":                                     {Bytes, scopeHTTP},
+	"ip.src.country":                                       {Bytes, scopeAll},
+	"ip.src.is_in_european_union":                          {Bool, scopeHTTP},
+	"ip.src.lat":                                           {Bytes, scopeHTTP},
+	"ip.src.lon":                                           {Bytes, scopeHTTP},
+	"ip.src.metro_code":                                    {Bytes, scopeHTTP},
+	"ip.src.postal_code":                                   {Bytes, scopeHTTP},
+	"ip.src.region":                                        {Bytes, scopeHTTP},
+	"ip.src.region_code":                                   {Bytes, scopeHTTP},
+	"ip.src.subdivision_1_iso_code":                        {Bytes, scopeHTTP},
+	"ip.src.subdivision_2_iso_code":                        {Bytes, scopeHTTP},
+	"ip.src.timezone.name":                                 {Bytes, scopeHTTP},
+	"ip.geoip.asnum":                                       {Int, scopeAll},
+	"ip.geoip.continent":                                   {Bytes, scopeHTTP},
+	"ip.geoip.country":                                     {Bytes, scopeAll},
+	"ip.geoip.is_in_european_union":                        {Bool, scopeHTTP},
+	"ip.geoip.subdivision_1_iso_code":                      {Bytes, scopeHTTP},
+	"ip.geoip.subdivision_2_iso_code":                      {Bytes, scopeHTTP},
+	"raw.http.request.full_uri":                            {Bytes, scopeHTTP},
+	"raw.http.request.uri":                                 {Bytes, scopeHTTP},
+	"raw.http.request.uri.path":                            {Bytes, scopeHTTP},
+	"raw.http.request.uri.path.extension":                  {Bytes, scopeHTTP},
+	"raw.http.request.uri.query":                           {Bytes, scopeHTTP},
+	"ssl":                                                  {Bool, scopeHTTP},
+
+	// URI argument and value fields.
+	"http.request.uri.args":            {MapOf(ArrayOf(Bytes)), scopeHTTP},
+	"http.request.uri.args.names":      {ArrayOf(Bytes), scopeHTTP},
+	"http.request.uri.args.values":     {ArrayOf(Bytes), scopeHTTP},
+	"raw.http.request.uri.args":        {MapOf(ArrayOf(Bytes)), scopeHTTP},
+	"raw.http.request.uri.args.names":  {ArrayOf(Bytes), scopeHTTP},
+	"raw.http.request.uri.args.values": {ArrayOf(Bytes), scopeHTTP},
+
+	// Request header fields.
+	"http.request.accepted_languages": {ArrayOf(Bytes), scopeHTTP},
+	"http.request.cookies":            {MapOf(ArrayOf(Bytes)), scopeHTTP},
+	"http.request.headers":            {MapOf(ArrayOf(Bytes)), scopeHTTP},
+	"http.request.headers.names":      {ArrayOf(Bytes), scopeHTTP},
+	"http.request.headers.truncated":  {Bool, scopeHTTP},
+	"http.request.headers.values":     {ArrayOf(Bytes), scopeHTTP},
+
+	// Request body fields.
+	"http.request.body.form":                                 {MapOf(ArrayOf(Bytes)), scopeHTTP},
+	"http.request.body.form.names":                           {ArrayOf(Bytes), scopeHTTP},
+	"http.request.body.form.values":                          {ArrayOf(Bytes), scopeHTTP},
+	"http.request.body.mime":                                 {Bytes, scopeHTTP},
+	"http.request.body.multipart":                            {MapOf(ArrayOf(Bytes)), scopeHTTP},
+	"http.request.body.multipart.content_dispositions":       {ArrayOf(ArrayOf(Bytes)), scopeHTTP},
+	"http.request.body.multipart.content_transfer_encodings": {ArrayOf(ArrayOf(Bytes)), scopeHTTP},
+	"http.request.body.multipart.content_types":              {ArrayOf(ArrayOf(Bytes)), scopeHTTP},
+	"http.request.body.multipart.filenames":                  {ArrayOf(ArrayOf(Bytes)), scopeHTTP},
+	"http.request.body.multipart.names":                      {ArrayOf(ArrayOf(Bytes)), scopeHTTP},
+	"http.request.body.multipart.values":                     {ArrayOf(Bytes), scopeHTTP},
+	"http.request.body.raw":                                  {Bytes, scopeHTTP},
+	"http.request.body.size":                                 {Int, scopeHTTP},
+	"http.request.body.truncated":                            {Bool, scopeHTTP},
+
+	// Response fields.
+	"cf.response.1xxx_code":                 {Int, ScopeResponse},
+	"cf.response.error_type":                {Bytes, ScopeResponse},
+	"http.response.code":                    {Int, ScopeResponse},
+	"http.response.content_type.media_type": {Bytes, ScopeResponse},
+	"http.response.headers":                 {MapOf(ArrayOf(Bytes)), ScopeResponse},
+	"http.response.headers.names":           {ArrayOf(Bytes), ScopeResponse},
+	"http.response.headers.values":          {ArrayOf(Bytes), ScopeResponse},
+
+	// Network fields used by Magic Firewall and network-layer DDoS rules.
+	"icmp":            {Bool, scopeNetwork},
+	"icmp.code":       {Int, scopeNetwork},
+	"icmp.type":       {Int, scopeNetwork},
+	"ip":              {Bool, scopeNetwork},
+	"ip.dst":          {IP, scopeNetwork},
+	"ip.dst.asnum":    {Int, scopeNetwork},
+	"ip.dst.country":  {Bytes, scopeNetwork},
+	"ip.flags.df":     {Bool, scopeNetwork},
+	"ip.flags.mf":     {Bool, scopeNetwork},
+	"ip.hdr_len":      {Int, scopeNetwork},
+	"ip.len":          {Int, scopeNetwork},
+	"ip.opt.type":     {Int, scopeNetwork},
+	"ip.proto":        {Bytes, scopeNetwork},
+	"ip.ttl":          {Int, scopeNetwork},
+	"sip":             {Bool, scopeNetwork},
+	"tcp":             {Bool, scopeNetwork},
+	"tcp.dstport":     {Int, scopeNetwork},
+	"tcp.flags":       {Int, scopeNetwork},
+	"tcp.flags.ack":   {Bool, scopeNetwork},
+	"tcp.flags.cwr":   {Bool, scopeNetwork},
+	"tcp.flags.ecn":   {Bool, scopeNetwork},
+	"tcp.flags.fin":   {Bool, scopeNetwork},
+	"tcp.flags.push":  {Bool, scopeNetwork},
+	"tcp.flags.reset": {Bool, scopeNetwork},
+	"tcp.flags.syn":   {Bool, scopeNetwork},
+	"tcp.flags.urg":   {Bool, scopeNetwork},
+	"tcp.srcport":     {Int, scopeNetwork},
+	"udp":             {Bool, scopeNetwork},
+	"udp.dstport":     {Int, scopeNetwork},
+	"udp.srcport":     {Int, scopeNetwork},
+}
+
+// function describes a function of the Rules language. params holds the
+// types of the required parameters followed by optional ones; variadic
+// functions accept any number of trailing arguments of the last type.
+//
+// Functions whose first parameter is a scalar may also be applied to an array
+// using `[*]`, in which case they return an array of results.
+type function struct {
+	params   []param
+	optional int
+	variadic bool
+	returns  func(args []value) Type
+}
+
+// param matches the type of an argument. A nil accepts list means any type.
+type param struct {
+	accepts []Kind
+	literal bool
+}
+
+func kinds(k ...Kind) param             { return param{accepts: k} }
+func literalKinds(k ...Kind) param      { return param{accepts: k, literal: true} }
+func returns(t Type) func([]value) Type { return func([]value) Type { return t } }
+
+var anyKind = param{}
+
+// functions lists the functions of the Rules language.
+//
+// Reference: https://developers.cloudflare.com/ruleset-engine/rules-language/functions/
+var functions = map[string]function{
+	"any":                    {params: []param{kinds(KindBool)}, returns: returns(Bool)},
+	"all":                    {params: []param{kinds(KindBool)}, returns: returns(Bool)},
+	"bit_slice":              {params: []param{kinds(KindBytes), literalKinds(KindInt), literalKinds(KindInt)}, returns: returns(Int)},
+	"cidr":                   {params: []param{kinds(KindIP), literalKinds(KindInt), literalKinds(KindInt)}, returns: returns(IP)},
+	"cidr6":                  {params: []param{kinds(KindIP), literalKinds(KindInt)}, returns: returns(IP)},
+	"concat":                 {params: []param{kinds(KindBytes, KindArray), kinds(KindBytes, KindArray)}, variadic: true, returns: func(args []value) Type { return args[0].typ }},
+	"decode_base64":          {params: []param{kinds(KindBytes)}, returns: returns(Bytes)},
+	"ends_with":              {params: []param{kinds(KindBytes), literalKinds(KindBytes)}, returns: returns(Bool)},
+	"encode_base64":          {params: []param{kinds(KindBytes), literalKinds(KindBytes)}, optional: 1, returns: returns(Bytes)},
+	"has_key":                {params: []param{kinds(KindMap), kinds(KindBytes)}, returns: returns(Bool)},
+	"has_value":              {params: []param{kinds(KindArray), anyKind}, returns: returns(Bool)},
+	"is_timed_hmac_valid_v0": {params: []param{literalKinds(KindBytes), kinds(KindBytes), literalKinds(KindInt), kinds(KindInt), literalKinds(KindInt), literalKinds(KindBytes)}, optional: 2, returns: returns(Bool)},
+	"join":                   {params: []param{kinds(KindArray), literalKinds(KindBytes)}, returns: returns(Bytes)},
+	"len":                    {params: []param{kinds(KindBytes, KindArray)}, returns: returns(Int)},
+	"lookup_json_integer":    {params: []param{kinds(KindBytes), literalKinds(KindBytes, KindInt)}, variadic: true, returns: returns(Int)},
+	"lookup_json_string":     {params: []param{kinds(KindBytes), literalKinds(KindBytes, KindInt)}, variadic: true, returns: returns(Bytes)},
+	"lower":                  {params: []param{kinds(KindBytes)}, returns: returns(Bytes)},
+	"regex_replace":          {params: []param{kinds(KindBytes), literalKinds(KindBytes), literalKinds(KindBytes)}, returns: returns(Bytes)},
+	"remove_bytes":           {params: []param{kinds(KindBytes), literalKinds(KindBytes)}, returns: returns(Bytes)},
+	"remove_query_args":      {params: []param{kinds(KindBytes), literalKinds(KindBytes)}, variadic: true, returns: returns(Bytes)},
+	"sha256":                 {params: []param{kinds(KindBytes)}, returns: returns(Bytes)},
+	"split":                  {params: []param{kinds(KindBytes), literalKinds(KindBytes), literalKinds(KindInt)}, returns: returns(ArrayOf(Bytes))},
+	"starts_with":            {params: []param{kinds(KindBytes), literalKinds(KindBytes)}, returns: returns(Bool)},
+	"substring":              {params: []param{kinds(KindBytes), literalKinds(KindInt), literalKinds(KindInt)}, optional: 1, returns: returns(Bytes)},
+	"to_string":              {params: []param{kinds(KindInt, KindBool, KindIP)}, returns: returns(Bytes)},
+	"upper":                  {params: []param{kinds(KindBytes)}, returns: returns(Bytes)},
+	"url_decode":             {params: []param{kinds(KindBytes), literalKinds(KindBytes)}, optional: 1, returns: returns(Bytes)},
+	"uuidv4":                 {params: []param{kinds(KindBytes)}, returns: returns(Bytes)},
+	"wildcard_replace":       {params: []param{kinds(KindBytes), literalKinds(KindBytes), literalKinds(KindBytes), literalKinds(KindBytes)}, optional: 1, returns: returns(Bytes)},
+}
@@ -0,0 +1,89 @@
+package wirefilter
+
+import "fmt"
+
+// Kind is the kind of value a field, function or literal produces.
+type Kind int
+
+const (
+	KindBytes Kind = iota
+	KindInt
+	KindBool
+	KindIP
+	KindArray
+	KindMap
+	// KindUnknown is the kind of fields that aren't known, whose values are
+	// accepted wherever a value is expected.
+	KindUnknown
+)
+
+// Type describes the type of a value. Arrays and maps carry the type of their
+// elements.
+type Type struct {
+	Kind Kind
+	Elem *Type
+}
+
+var (
+	Bytes = Type{Kind: KindBytes}
+	Int   = Type{Kind: KindInt}
+	Bool  = Type{Kind: KindBool}
+	IP    = Type{Kind: KindIP}
+
+	unknown = Type{Kind: KindUnknown}
+)
+
+// ArrayOf returns the type of an array holding elem values.
+func ArrayOf(elem Type) Type {
+	return Type{Kind: KindArray, Elem: &elem}
+}
+
+// MapOf returns the type of a map from strings to elem values.
+func MapOf(elem Type) Type {
+	return Type{Kind: KindMap, Elem: &elem}
+}
+
+func (t Type) String() string {
+	switch t.Kind {
+	case KindBytes:
+		return "String"
+	case KindInt:
+		return "Integer"
+	case KindBool:
+		return "Boolean"
+	case KindIP:
+		return "IP address"
+	case KindArray:
+		return fmt.Sprintf("Array<%s>", t.Elem)
+	case KindMap:
+		return fmt.Sprintf("Map<%s>", t.Elem)
+	default:
+		return "unknown"
+	}
+}
+
+// Equal reports whether t and other are the same type.
+func (t Type) Equal(other Type) bool {
+	if t.Kind != other.Kind {
+		return false
+	}
+	if t.Elem == nil || other.Elem == nil {
+		return t.Elem == other.Elem
+	}
+	return t.Elem.Equal(*other.Elem)
+}
+
+// value is the type of a (sub)expression during type checking. mapped is set
+// once the `[*]` operator has been applied to an array, after which functions
+// and comparisons apply to each element and produce an array in turn.
+type value struct {
+	typ    Type
+	mapped bool
+}
+
+func (v value) String() string {
+	if v.mapped {
+		return fmt.Sprintf("Array<%s> (from [*])", v.typ)
+	}
+	return v.typ.String()
+}
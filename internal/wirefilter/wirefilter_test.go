@@ -0,0 +1,121 @@
+package wirefilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateValidExpressions(t *testing.T) {
+	expressions := []string{
+		`true`,
+		`http.host eq "example.com"`,
+		`(http.host == "example.com" and not ssl) or cf.threat_score gt 10`,
+		`http.request.method in {"PUT" "DELETE"} and http.request.uri.path eq "/"`,
+		`ip.src in {192.0.2.0/24 2001:db8::/32 198.51.100.1..198.51.100.9} && !cf.client.bot`,
+		`ip.src in $office_ips`,
+		`ip.geoip.asnum in {13335 64512..65534}`,
+		`http.request.uri.path matches "^/api/v[0-9]+/"`,
+		`http.request.uri.path ~ r#"^/a"b$"#`,
+		`http.user_agent contains "curl" xor http.user_agent contains "wget"`,
+		`http.request.full_uri strict wildcard "https://*.example.com/*"`,
+		`any(http.request.headers.names[*] == "x-custom")`,
+		`all(lower(http.request.headers["accept"][*]) contains "json")`,
+		`len(http.request.body.raw) > 0x10`,
+		`starts_with(http.request.uri.path, "/static/") and ends_with(http.request.uri.path, ".css")`,
+		`lookup_json_string(http.request.body.raw, "user", 0) eq "admin"`,
+		`http.request.headers["x-forwarded-for"][0] eq "192.0.2.1"`,
+		`is_timed_hmac_valid_v0("secret", http.request.uri, 10800, http.request.timestamp.sec, 8)`,
+	}
+
+	for _, expr := range expressions {
+		warnings, err := Validate(expr, ScopeRequest)
+		assert.NoError(t, err, expr)
+		assert.Empty(t, warnings, expr)
+	}
+}
+
+func TestValidateInvalidExpressions(t *testing.T) {
+	cases := map[string]Error{
+		``:                                        {0, "expression is empty"},
+		`http.host eq "example.com" and`:          {30, "unexpected end of expression"},
+		`http.host eq 10`:                         {13, `expected a string after "eq", got "10"`},
+		`cf.threat_score eq "10"`:                 {19, `expected an integer after "eq", got "\"10\""`},
+		`cf.threat_score contains "1"`:            {16, `"contains" can't be applied to Integer`},
+		`http.host`:                               {0, "expression must evaluate to a Boolean, got String"},
+		`http.request.uri.path matches "(["`:      {30, "invalid regular expression: missing closing ]: `[`"},
+		`http.request.headers.names[*] eq "a"`:    {0, "expression evaluates to Array<Boolean> (from [*]), wrap it in any() or all()"},
+		`http.request.headers eq "a"`:             {21, `"eq" can't be applied to Map<Array<String>>, use [*] or an index to access its elements`},
+		`lowr(http.host) eq "a"`:                  {0, `unknown function "lowr", did you mean "lower"?`},
+		`starts_with(http.host, http.referer)`:    {23, "argument 2 of starts_with() must be a literal"},
+		`len(cf.threat_score) eq 1`:               {4, "argument 1 of len() must be a String or an Array, got Integer"},
+		`ip.src in {192.0.2.300}`:                 {11, `expected an IP address, CIDR or range in set, got "192.0.2.300"`},
+		`http.host in {}`:                         {13, "set must not be empty"},
+		`"a" eq "b"`:                              {0, `the left-hand side of "eq" must be a field or function, not a literal`},
+		`http.host eq "é" and http.response.code`: {21, `field "http.response.code" is only available in HTTP response expressions`},
+		`http.host eq "example.com`:               {13, "unterminated string"},
+		`(http.host eq "a"`:                       {17, `expected ")", got end of expression`},
+	}
+
+	for expr, expected := range cases {
+		_, err := Validate(expr, ScopeRequest)
+		if assert.Error(t, err, expr) {
+			assert.Equal(t, &expected, err, expr)
+		}
+	}
+}
+
+func TestValidateUnknownFields(t *testing.T) {
+	warnings, err := Validate(`http.hots eq "example.com"`, ScopeRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Error{{0, `unknown field "http.hots", did you mean "http.host"?`}}, warnings)
+
+	// Values of unknown fields are accepted as any type, while the rest of
+	// the expression is still checked.
+	warnings, err = Validate(`http.host ne "é" and any(http.request.jwt.claims["aud"][*] eq "a") and cf.colo.id in {1 2} and cf.api_gateway.auth_id_present`, ScopeRequest)
+	assert.NoError(t, err)
+	if assert.Len(t, warnings, 3) {
+		assert.Equal(t, 25, warnings[0].Offset)
+		assert.Equal(t, `unknown field "cf.api_gateway.auth_id_present"`, warnings[2].Message)
+	}
+
+	warnings, err = Validate(`cf.bot_management.ja4 eq "a" and http.host eq 1`, ScopeRequest)
+	assert.EqualError(t, err, `expected a string after "eq", got "1" (at character 46)`)
+	assert.Len(t, warnings, 1)
+}
+
+func TestValidateScopes(t *testing.T) {
+	for phase, expr := range map[string]string{
+		"http_response_headers_transform": `http.response.code eq 404 and http.host eq "a"`,
+		"magic_transit":                   `tcp.dstport eq 22`,
+		"some_new_phase":                  `tcp.dstport eq 22 or http.response.code eq 200`,
+	} {
+		_, err := Validate(expr, ScopeForPhase(phase))
+		assert.NoError(t, err, expr)
+	}
+	for phase, expr := range map[string]string{
+		"magic_transit":                `http.host eq "a"`,
+		"http_request_firewall_custom": `tcp.dstport eq 22`,
+	} {
+		_, err := Validate(expr, ScopeForPhase(phase))
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	_, err := ValidateValue(`url_decode(http.request.body.form["username"][0])`, ScopeRequest)
+	assert.NoError(t, err)
+	_, err = ValidateValue(`concat("requestUrl=", http.request.full_uri)`, ScopeRequest)
+	assert.NoError(t, err)
+	_, err = ValidateValue(`http.host eq "a"`, ScopeRequest)
+	assert.EqualError(t, err, "expression must evaluate to a String, got Boolean (at character 0)")
+}
+
+func TestScopeForPhase(t *testing.T) {
+	assert.Equal(t, ScopeNetwork, ScopeForPhase("ddos_l4"))
+	assert.Equal(t, ScopeRequest, ScopeForPhase("http_request_firewall_managed"))
+	assert.Equal(t, ScopeRequest, ScopeForPhase("ddos_l7"))
+	assert.Equal(t, ScopeRequest|ScopeResponse, ScopeForPhase("http_response_firewall_managed"))
+	assert.Equal(t, ScopeAny, ScopeForPhase("unknown"))
+	assert.Equal(t, "HTTP request and HTTP response", (ScopeRequest | ScopeResponse).String())
+}